@@ -0,0 +1,65 @@
+// Garbage collection file media koleksi: mencari (dan menghapus) file di storage
+// yang tidak lagi dipakai koleksi mana pun.
+//
+// MONGOSTRING dan konfigurasi storage (STORAGE_DRIVER, dll) dibaca dari environment.
+//
+// Contoh:
+//
+//	go run ./cmd/gc-media              # hanya menampilkan daftar (dry run)
+//	go run ./cmd/gc-media -hapus       # menghapus file yatim
+//	go run ./cmd/gc-media -umur 1h -hapus  # -umur harus > 0 untuk -hapus
+package main
+
+import (
+	"be-internship/controller"
+	"be-internship/storage"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+func main() {
+	hapus := flag.Bool("hapus", false, "hapus file yatim (default hanya menampilkan daftar)")
+	umur := flag.Duration("umur", 24*time.Hour, "hanya file yang sudah yatim minimal selama ini")
+	asJSON := flag.Bool("json", false, "tampilkan laporan lengkap dalam format JSON")
+	flag.Parse()
+
+	if err := storage.Init(); err != nil {
+		log.Fatalf("❌ Gagal menyiapkan storage: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	laporan, err := controller.GarbageCollectMedia(ctx, *hapus, *umur)
+	if err != nil {
+		log.Fatalf("❌ Garbage collection gagal: %v", err)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(laporan)
+		return
+	}
+
+	fmt.Printf("Storage      : %s\n", storage.Aktif().Nama())
+	fmt.Printf("File di storage: %d, key dipakai koleksi: %d\n", laporan.JumlahFile, laporan.JumlahDipakai)
+	fmt.Printf("Media yatim  : %d (%.2f MB tercatat)\n", len(laporan.Yatim), float64(laporan.TotalUkuran)/(1<<20))
+	for _, y := range laporan.Yatim {
+		fmt.Printf("  - %s\n", y.Key)
+	}
+
+	if !*hapus {
+		fmt.Println("Dry run: jalankan dengan -hapus untuk menghapus file di atas.")
+		return
+	}
+	fmt.Printf("Dihapus      : %d, gagal: %d\n", len(laporan.Dihapus), len(laporan.Gagal))
+	if len(laporan.Gagal) > 0 {
+		os.Exit(1)
+	}
+}
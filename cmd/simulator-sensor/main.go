@@ -0,0 +1,93 @@
+// Simulator sensor suhu & kelembapan untuk menguji endpoint monitoring lingkungan.
+//
+// Contoh:
+//
+//	go run ./cmd/simulator-sensor -key rahasia -gudang 693a3a7a416cd8d592b5058e -interval 5s
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"time"
+)
+
+type pembacaan struct {
+	GudangID   string  `json:"gudang_id"`
+	RakID      string  `json:"rak_id,omitempty"`
+	SensorID   string  `json:"sensor_id,omitempty"`
+	Waktu      string  `json:"waktu"`
+	Suhu       float64 `json:"suhu"`
+	Kelembapan float64 `json:"kelembapan"`
+}
+
+func main() {
+	url := flag.String("url", "http://localhost:3000/api/lingkungan/pembacaan", "URL endpoint ingest")
+	key := flag.String("key", os.Getenv("SENSOR_API_KEY"), "API key sensor (default: env SENSOR_API_KEY)")
+	gudang := flag.String("gudang", "", "ID gudang (wajib)")
+	rak := flag.String("rak", "", "ID rak (opsional)")
+	sensor := flag.String("sensor", "simulator-01", "ID sensor")
+	interval := flag.Duration("interval", 10*time.Second, "jeda antar pembacaan")
+	jumlah := flag.Int("n", 0, "jumlah pembacaan (0 = terus-menerus)")
+	suhu := flag.Float64("suhu", 22, "suhu dasar (°C)")
+	kelembapan := flag.Float64("kelembapan", 55, "kelembapan dasar (%)")
+	lonjakan := flag.Float64("lonjakan", 0.05, "peluang pembacaan ekstrem untuk memicu peringatan (0-1)")
+	flag.Parse()
+
+	if *gudang == "" || *key == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	for i := 0; *jumlah == 0 || i < *jumlah; i++ {
+		p := pembacaan{
+			GudangID:   *gudang,
+			RakID:      *rak,
+			SensorID:   *sensor,
+			Waktu:      time.Now().UTC().Format(time.RFC3339),
+			Suhu:       *suhu + rand.NormFloat64()*0.5,
+			Kelembapan: *kelembapan + rand.NormFloat64()*2,
+		}
+		if rand.Float64() < *lonjakan {
+			p.Suhu += 10
+			p.Kelembapan += 25
+		}
+
+		if err := kirim(*url, *key, p); err != nil {
+			log.Printf("❌ gagal mengirim: %v", err)
+		} else {
+			log.Printf("✅ suhu=%.1f°C kelembapan=%.1f%%", p.Suhu, p.Kelembapan)
+		}
+
+		time.Sleep(*interval)
+	}
+}
+
+func kirim(url, key string, p pembacaan) error {
+	body, _ := json.Marshal(p)
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", key)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status %d: %s", resp.StatusCode, respBody)
+	}
+	return nil
+}
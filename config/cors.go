@@ -21,8 +21,16 @@ var Internalhost string = os.Getenv("INTERNALHOST") + ":" + os.Getenv("PORT")
 
 var Cors = cors.Config{
 	AllowOrigins:     strings.Join(origins[:], ","),
-	AllowMethods:     "GET,HEAD,OPTIONS,POST,PUT,DELETE",
-	AllowHeaders:     "Origin,Login,Content-Type,Authorization",
-	ExposeHeaders:    "Content-Length",
+	AllowMethods:     "GET,HEAD,OPTIONS,POST,PUT,PATCH,DELETE",
+	AllowHeaders:     "Origin,Login,Content-Type,Authorization,X-API-Key,If-Match,If-None-Match",
+	ExposeHeaders:    "Content-Length,ETag",
 	AllowCredentials: true,
 }
+
+// CorsPublik untuk endpoint yang boleh diakses dari domain mana pun tanpa kredensial
+// (misalnya IIIF yang dibuka lewat Mirador / Universal Viewer milik mitra)
+var CorsPublik = cors.Config{
+	AllowOrigins:  "*",
+	AllowMethods:  "GET,HEAD,OPTIONS",
+	ExposeHeaders: "Content-Length,Link",
+}
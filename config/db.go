@@ -9,7 +9,16 @@ import (
 
 var IteungIPAddress string = os.Getenv("ITEUNGBEV1")
 
-var MongoString string = os.Getenv("MONGOSTRING")
+var MongoString string = mongoString()
+
+// mongoString membaca env MONGOSTRING. Jika kosong dipakai MongoDB lokal, supaya package
+// yang memakai koneksi ini tetap bisa di-load (misalnya saat go test) tanpa .env.
+func mongoString() string {
+	if s := os.Getenv("MONGOSTRING"); s != "" {
+		return s
+	}
+	return "mongodb://localhost:27017"
+}
 
 var DBUlbimongoinfo = atdb.DBInfo{
 	DBString: MongoString,
@@ -0,0 +1,479 @@
+package config
+
+import (
+	"be-internship/model"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Batas waktu setup database. Index dan setiap migrasi data lama punya batas waktu sendiri,
+// supaya migrasi yang lambat tidak membuat index berikutnya gagal karena context habis.
+const (
+	batasWaktuIndex   = 30 * time.Second
+	batasWaktuMigrasi = 2 * time.Minute
+)
+
+// SetupDatabase menyiapkan collection khusus dan index yang dibutuhkan aplikasi, lalu memigrasi data lama.
+// Kegagalan hanya dicatat di log supaya server tetap bisa berjalan.
+func SetupDatabase() {
+	denganBatasWaktu(batasWaktuIndex, buatIndexAplikasi)
+
+	// Migrasi data lama, berurutan (ukuran normal dihitung ulang setelah ukuran lama diubah):
+	// foto tunggal → daftar media, tanggal perolehan teks → perolehan terstruktur,
+	// ukuran teks bebas → angka dengan satuan baku
+	for _, migrasi := range []func(context.Context){
+		pindahkanFotoKeMedia,
+		isiPerolehanLama,
+		ubahUkuranLama,
+		hitungUlangUkuranNormal,
+	} {
+		denganBatasWaktu(batasWaktuMigrasi, migrasi)
+	}
+
+	// Nama master data unik tanpa membedakan huruf besar/kecil & spasi.
+	// Jika masih ada duplikat lama, index gagal dibuat dan dicoba lagi setelah data digabung lewat endpoint /gabung.
+	for collection, field := range map[string]string{
+		"kategori": "nama_kategori",
+		"gudang":   "nama_gudang",
+		"rak":      "nama_rak",
+		"tahap":    "nama_tahap",
+	} {
+		denganBatasWaktu(batasWaktuMigrasi, func(ctx context.Context) {
+			isiNamaNormal(ctx, collection, field)
+		})
+		denganBatasWaktu(batasWaktuIndex, func(ctx context.Context) {
+			if err := BuatIndexNamaMasterData(ctx, collection); err != nil {
+				log.Printf("‼️  Index nama_normal_unik pada %s belum aktif, gabungkan duplikat lewat /duplikat & /gabung: %v", collection, err)
+			}
+		})
+	}
+}
+
+// denganBatasWaktu menjalankan satu langkah setup dengan context yang batas waktunya terpisah
+func denganBatasWaktu(batas time.Duration, langkah func(ctx context.Context)) {
+	ctx, cancel := context.WithTimeout(context.Background(), batas)
+	defer cancel()
+	langkah(ctx)
+}
+
+// buatIndexAplikasi membuat collection khusus dan index yang dibutuhkan aplikasi
+func buatIndexAplikasi(ctx context.Context) {
+	// Data sensor suhu & kelembapan disimpan di collection time-series (MongoDB 5.0+)
+	tsOpts := options.CreateCollection().SetTimeSeriesOptions(
+		options.TimeSeries().
+			SetTimeField("waktu").
+			SetMetaField("lokasi").
+			SetGranularity("minutes"),
+	)
+	buatCollection(ctx, "pembacaan_sensor", tsOpts)
+
+	// Peringatan lingkungan yang belum selesai dicari per lokasi & parameter saat pembacaan baru masuk
+	buatIndex(ctx, "peringatan_lingkungan", mongo.IndexModel{Keys: bson.D{{Key: "lokasi.gudang_id", Value: 1}, {Key: "parameter", Value: 1}, {Key: "selesai", Value: 1}}})
+
+	// Index materialized path & induk untuk pohon kategori
+	buatIndex(ctx, "kategori", mongo.IndexModel{Keys: bson.D{{Key: "path", Value: 1}}})
+	buatIndex(ctx, "kategori", mongo.IndexModel{Keys: bson.D{{Key: "parent_id", Value: 1}}})
+	// Dokumen kunci pemindahan kategori ditulis di dalam transaksi; collection disiapkan di awal supaya tidak dibuat di tengah transaksi
+	buatCollection(ctx, "kunci_kategori", nil)
+
+	// Deduplikasi upload berdasarkan hash isi file
+	buatIndex(ctx, "objek_media", mongo.IndexModel{Keys: bson.D{{Key: "sha256", Value: 1}}})
+
+	// Pencarian media berdasarkan ID untuk IIIF Image API
+	buatIndex(ctx, "koleksi", mongo.IndexModel{Keys: bson.D{{Key: "media._id", Value: 1}}})
+
+	// Filter perolehan per metode & tahun
+	buatIndex(ctx, "koleksi", mongo.IndexModel{Keys: bson.D{{Key: "perolehan.metode", Value: 1}, {Key: "perolehan.tahun", Value: 1}}})
+
+	// Filter & urut berdasarkan ukuran dalam satuan SI
+	for _, field := range []string{"tinggi", "berat"} {
+		buatIndex(ctx, "koleksi", mongo.IndexModel{Keys: bson.D{{Key: "ukuran.normal." + field, Value: 1}}})
+	}
+
+	// Riwayat laporan kondisi & perawatan per koleksi, serta daftar koleksi yang perlu perawatan
+	buatIndex(ctx, "laporan_kondisi", mongo.IndexModel{Keys: bson.D{{Key: "koleksi_id", Value: 1}, {Key: "tanggal", Value: -1}}})
+	buatIndex(ctx, "perawatan_konservasi", mongo.IndexModel{Keys: bson.D{{Key: "koleksi_id", Value: 1}, {Key: "tanggal_mulai", Value: -1}}})
+	buatIndex(ctx, "koleksi", mongo.IndexModel{Keys: bson.D{{Key: "kondisi_terakhir.perlu_perawatan", Value: 1}, {Key: "kondisi_terakhir.tingkat", Value: -1}}})
+
+	// Peminjaman: cek bentrok jadwal per koleksi, daftar terlambat, dan nomor perjanjian unik
+	buatIndex(ctx, "peminjaman", mongo.IndexModel{Keys: bson.D{{Key: "koleksi.koleksi_id", Value: 1}, {Key: "status", Value: 1}}})
+	buatIndex(ctx, "peminjaman", mongo.IndexModel{Keys: bson.D{{Key: "status", Value: 1}, {Key: "tanggal_selesai", Value: 1}}})
+	buatIndex(ctx, "peminjaman", mongo.IndexModel{
+		Keys: bson.D{{Key: "no_perjanjian", Value: 1}},
+		Options: options.Index().
+			SetName("no_perjanjian_unik").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"no_perjanjian": bson.M{"$gt": ""}}),
+	})
+	buatIndex(ctx, "koleksi", mongo.IndexModel{Keys: bson.D{{Key: "status_lokasi", Value: 1}}})
+
+	// Kunci jadwal koleksi yang tertinggal (request mati sebelum melepas kunci) dihapus otomatis
+	buatIndex(ctx, "kunci_jadwal", mongo.IndexModel{
+		Keys:    bson.D{{Key: "kedaluwarsa", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+
+	// Pameran: cek bentrok jadwal & riwayat pameran per koleksi
+	buatIndex(ctx, "pameran", mongo.IndexModel{Keys: bson.D{{Key: "koleksi.koleksi_id", Value: 1}, {Key: "tanggal_mulai", Value: -1}}})
+
+	// Review perubahan koleksi & riwayat koleksi
+	buatIndex(ctx, "perubahan_koleksi", mongo.IndexModel{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}})
+	buatIndex(ctx, "perubahan_koleksi", mongo.IndexModel{Keys: bson.D{{Key: "diajukan_oleh", Value: 1}, {Key: "created_at", Value: -1}}})
+	buatIndex(ctx, "perubahan_koleksi", mongo.IndexModel{Keys: bson.D{{Key: "koleksi_id", Value: 1}}})
+	buatIndex(ctx, "riwayat_koleksi", mongo.IndexModel{Keys: bson.D{{Key: "koleksi_id", Value: 1}, {Key: "tanggal", Value: -1}}})
+
+	// Penilaian koleksi: riwayat per koleksi & penilaian terakhir untuk laporan nilai
+	buatIndex(ctx, "penilaian_koleksi", mongo.IndexModel{Keys: bson.D{{Key: "koleksi_id", Value: 1}, {Key: "tanggal", Value: -1}, {Key: "created_at", Value: -1}}})
+
+	// Deaksesi: daftar usulan per status / koleksi, dan filter inventaris aktif
+	buatIndex(ctx, "deaksesi", mongo.IndexModel{Keys: bson.D{{Key: "koleksi_id", Value: 1}, {Key: "created_at", Value: -1}}})
+	buatIndex(ctx, "deaksesi", mongo.IndexModel{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}})
+	buatIndex(ctx, "koleksi", mongo.IndexModel{Keys: bson.D{{Key: "deaksesi.status", Value: 1}}})
+
+	// Nomor registrasi & inventaris unik, dipakai juga untuk lookup hasil scan label.
+	// Jika gagal (masih ada nomor ganda), status index bisa dilihat di GET /koleksi/nomor-ganda.
+	for nama, pesan := range BuatIndexNomorKoleksi(ctx) {
+		log.Printf("‼️  Index %s belum aktif, nomor ganda dicek manual sebelum simpan. Bereskan lewat /koleksi/nomor-ganda: %s", nama, pesan)
+	}
+}
+
+// statusIndexNama menyimpan status index nama_normal_unik per collection master data
+var statusIndexNama = struct {
+	sync.RWMutex
+	siap  map[string]bool
+	gagal map[string]string
+}{siap: map[string]bool{}, gagal: map[string]string{}}
+
+// BuatIndexNamaMasterData membuat unique index nama_normal_unik pada collection master data dan mencatat statusnya.
+// Gagal jika masih ada nama ganda; dipanggil ulang setelah penggabungan berhasil.
+func BuatIndexNamaMasterData(ctx context.Context, collection string) error {
+	_, err := Ulbimongoconn.Collection(collection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "nama_normal", Value: 1}},
+		Options: options.Index().
+			SetName("nama_normal_unik").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"nama_normal": bson.M{"$exists": true}}),
+	})
+
+	statusIndexNama.Lock()
+	defer statusIndexNama.Unlock()
+	statusIndexNama.siap[collection] = err == nil
+	delete(statusIndexNama.gagal, collection)
+	if err != nil {
+		statusIndexNama.gagal[collection] = err.Error()
+	}
+	return err
+}
+
+// IndexNamaSiap bernilai true jika index nama_normal_unik pada collection master data sudah aktif
+func IndexNamaSiap(collection string) bool {
+	statusIndexNama.RLock()
+	defer statusIndexNama.RUnlock()
+	return statusIndexNama.siap[collection]
+}
+
+// IndexNamaGagal mengembalikan collection master data yang index nama_normal_unik-nya gagal dibuat beserta pesan error-nya
+func IndexNamaGagal() map[string]string {
+	statusIndexNama.RLock()
+	defer statusIndexNama.RUnlock()
+	hasil := map[string]string{}
+	for collection, pesan := range statusIndexNama.gagal {
+		hasil[collection] = pesan
+	}
+	return hasil
+}
+
+// statusIndexNomor menyimpan index unik nomor koleksi yang gagal dibuat (nama index → pesan error)
+var statusIndexNomor = struct {
+	sync.RWMutex
+	siap  map[string]bool
+	gagal map[string]string
+}{siap: map[string]bool{}, gagal: map[string]string{}}
+
+// BuatIndexNomorKoleksi membuat unique index no_reg_unik & no_inv_unik dan mencatat statusnya.
+// String kosong tidak ikut dicek supaya koleksi tanpa nomor tetap bisa disimpan.
+// Mengembalikan index yang gagal dibuat, misalnya karena masih ada nomor ganda.
+func BuatIndexNomorKoleksi(ctx context.Context) map[string]string {
+	gagal := map[string]string{}
+	for _, field := range []string{"no_reg", "no_inv"} {
+		nama := field + "_unik"
+		_, err := Ulbimongoconn.Collection("koleksi").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: field, Value: 1}},
+			Options: options.Index().
+				SetName(nama).
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{field: bson.M{"$gt": ""}}),
+		})
+
+		statusIndexNomor.Lock()
+		statusIndexNomor.siap[nama] = err == nil
+		delete(statusIndexNomor.gagal, nama)
+		if err != nil {
+			statusIndexNomor.gagal[nama] = err.Error()
+			gagal[nama] = err.Error()
+		}
+		statusIndexNomor.Unlock()
+	}
+	return gagal
+}
+
+// IndexNomorSiap bernilai true jika unique index nomor koleksi (no_reg_unik / no_inv_unik) sudah aktif.
+// Selama belum aktif, keunikan nomor harus dicek manual sebelum data disimpan.
+func IndexNomorSiap(nama string) bool {
+	statusIndexNomor.RLock()
+	defer statusIndexNomor.RUnlock()
+	return statusIndexNomor.siap[nama]
+}
+
+// IndexNomorGagal mengembalikan index nomor koleksi yang gagal dibuat beserta pesan error-nya
+func IndexNomorGagal() map[string]string {
+	statusIndexNomor.RLock()
+	defer statusIndexNomor.RUnlock()
+	hasil := map[string]string{}
+	for nama, pesan := range statusIndexNomor.gagal {
+		hasil[nama] = pesan
+	}
+	return hasil
+}
+
+// isiNamaNormal mengisi nama_normal untuk data lama yang dibuat sebelum field ini ada
+func isiNamaNormal(ctx context.Context, collection, field string) {
+	col := Ulbimongoconn.Collection(collection)
+
+	cursor, err := col.Find(ctx, bson.M{"nama_normal": bson.M{"$exists": false}},
+		options.Find().SetProjection(bson.M{field: 1}))
+	if err != nil {
+		log.Printf("⚠️  Gagal membaca %s untuk nama_normal: %v", collection, err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := cursor.Decode(&doc); err != nil {
+			continue
+		}
+		nama, _ := doc[field].(string)
+		col.UpdateOne(ctx, bson.M{"_id": doc["_id"]}, bson.M{"$set": bson.M{"nama_normal": model.NormalisasiNama(nama)}})
+	}
+}
+
+// pindahkanFotoKeMedia mengubah field foto (string) pada koleksi lama menjadi media utama
+func pindahkanFotoKeMedia(ctx context.Context) {
+	col := Ulbimongoconn.Collection("koleksi")
+
+	cursor, err := col.Find(ctx, bson.M{"foto": bson.M{"$exists": true}},
+		options.Find().SetProjection(bson.M{"foto": 1, "media": 1, "created_at": 1}))
+	if err != nil {
+		log.Printf("⚠️  Gagal membaca koleksi untuk migrasi foto: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID        primitive.ObjectID `bson:"_id"`
+			Foto      string             `bson:"foto"`
+			Media     []model.Media      `bson:"media"`
+			CreatedAt time.Time          `bson:"created_at"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			continue
+		}
+
+		update := bson.M{"$unset": bson.M{"foto": ""}}
+		if doc.Foto != "" && len(doc.Media) == 0 {
+			update["$set"] = bson.M{"media": []model.Media{{
+				ID:        primitive.NewObjectID(),
+				URL:       doc.Foto,
+				Tipe:      model.TipeMediaFoto,
+				Utama:     true,
+				CreatedAt: doc.CreatedAt,
+			}}}
+		}
+		col.UpdateOne(ctx, bson.M{"_id": doc.ID}, update)
+	}
+}
+
+// buatCollection membuat collection jika belum ada
+func buatCollection(ctx context.Context, nama string, opts *options.CreateCollectionOptions) {
+	err := Ulbimongoconn.CreateCollection(ctx, nama, opts)
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Name == "NamespaceExists" {
+		return
+	}
+	if err != nil {
+		log.Printf("⚠️  Gagal membuat collection %s: %v", nama, err)
+	}
+}
+
+// buatIndex membuat index pada collection (tidak melakukan apa-apa jika index sudah ada)
+func buatIndex(ctx context.Context, collection string, index mongo.IndexModel) {
+	if _, err := Ulbimongoconn.Collection(collection).Indexes().CreateOne(ctx, index); err != nil {
+		log.Printf("⚠️  Gagal membuat index pada %s: %v", collection, err)
+	}
+}
+
+// isiPerolehanLama mengisi perolehan.tanggal & tahun dari field tanggal_perolehan lama yang bisa dibaca,
+// supaya koleksi lama ikut terfilter per tahun. Metode perolehan tetap harus dilengkapi manual.
+func isiPerolehanLama(ctx context.Context) {
+	col := Ulbimongoconn.Collection("koleksi")
+
+	cursor, err := col.Find(ctx,
+		bson.M{"perolehan": bson.M{"$exists": false}, "tanggal_perolehan": bson.M{"$gt": ""}},
+		options.Find().SetProjection(bson.M{"tanggal_perolehan": 1, "tempat_perolehan": 1}))
+	if err != nil {
+		log.Printf("⚠️  Gagal membaca koleksi untuk migrasi perolehan: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc model.Koleksi
+		if err := cursor.Decode(&doc); err != nil {
+			continue
+		}
+		mulai, selesai, err := model.ParseTanggalPerolehan(doc.TanggalPerolehan)
+		if err != nil {
+			continue
+		}
+		col.UpdateOne(ctx, bson.M{"_id": doc.ID}, bson.M{"$set": bson.M{"perolehan": model.Perolehan{
+			Tanggal:        doc.TanggalPerolehan,
+			TanggalMulai:   &mulai,
+			TanggalSelesai: &selesai,
+			Tahun:          mulai.Year(),
+			Tempat:         doc.TempatPerolehan,
+		}}})
+	}
+}
+
+// ubahUkuranLama mengubah ukuran versi lama (angka & satuan berupa teks bebas) menjadi angka desimal
+// dengan satuan baku. Nilai yang tidak bisa dibaca disimpan apa adanya di ukuran.catatan.
+func ubahUkuranLama(ctx context.Context) {
+	col := Ulbimongoconn.Collection("koleksi")
+
+	cursor, err := col.Find(ctx,
+		bson.M{"ukuran": bson.M{"$type": "object"}, "ukuran.normal": bson.M{"$exists": false}},
+		options.Find().SetProjection(bson.M{"ukuran": 1}))
+	if err != nil {
+		log.Printf("⚠️  Gagal membaca koleksi untuk migrasi ukuran: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID     primitive.ObjectID `bson:"_id"`
+			Ukuran bson.M             `bson:"ukuran"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			continue
+		}
+		ukuran := ukuranDariDataLama(doc.Ukuran)
+		if ukuran.Kosong() {
+			col.UpdateOne(ctx, bson.M{"_id": doc.ID}, bson.M{"$unset": bson.M{"ukuran": ""}})
+			continue
+		}
+		col.UpdateOne(ctx, bson.M{"_id": doc.ID}, bson.M{"$set": bson.M{"ukuran": ukuran}})
+	}
+}
+
+// hitungUlangUkuranNormal menghitung ulang ukuran.normal yang dibuat dengan faktor desimal (nilai * 0.01),
+// supaya nilainya sama persis dengan batas filter yang dikonversi dengan model.KonversiUkuran
+func hitungUlangUkuranNormal(ctx context.Context) {
+	col := Ulbimongoconn.Collection("koleksi")
+
+	cursor, err := col.Find(ctx,
+		bson.M{"ukuran.normal": bson.M{"$exists": true}, "ukuran.normal.bulat": bson.M{"$ne": true}},
+		options.Find().SetProjection(bson.M{"ukuran": 1}))
+	if err != nil {
+		log.Printf("⚠️  Gagal membaca koleksi untuk menghitung ulang ukuran: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID     primitive.ObjectID `bson:"_id"`
+			Ukuran model.Ukuran       `bson:"ukuran"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			continue
+		}
+		doc.Ukuran.HitungNormal()
+		col.UpdateOne(ctx, bson.M{"_id": doc.ID}, bson.M{"$set": bson.M{"ukuran.normal": doc.Ukuran.Normal}})
+	}
+}
+
+// ukuranDariDataLama mengonversi satu dokumen ukuran lama
+func ukuranDariDataLama(lama bson.M) model.Ukuran {
+	ukuran := model.Ukuran{}
+	if id, ok := lama["_id"].(primitive.ObjectID); ok {
+		ukuran.ID = id
+	}
+	var catatan []string
+
+	teks := func(field string) string {
+		v, ok := lama[field]
+		if !ok || v == nil {
+			return ""
+		}
+		return strings.TrimSpace(fmt.Sprint(v))
+	}
+
+	// satuan lama dikonversi ke satuan baku, nilainya ikut dikalikan (mis. 2 ons → 200 g)
+	satuan, pengali, satuanOK := model.KonversiSatuanLama(teks("satuan"))
+	satuanBerat, pengaliBerat, satuanBeratOK := model.KonversiSatuanLama(teks("satuan_berat"))
+	if _, ok := model.SatuanPanjang[satuan]; !ok {
+		satuanOK = false
+	}
+	if _, ok := model.SatuanBerat[satuanBerat]; !ok {
+		satuanBeratOK = false
+	}
+
+	for _, d := range model.DimensiUkuran {
+		nilaiTeks := teks(d)
+		if nilaiTeks == "" {
+			continue
+		}
+		nilai, err := model.ParseAngkaUkuran(nilaiTeks)
+		if err != nil || !satuanOK {
+			catatan = append(catatan, strings.TrimSpace(d+" "+nilaiTeks+" "+teks("satuan")))
+			continue
+		}
+		n := model.KaliSatuanLama(*nilai, pengali)
+		*ukuran.Dimensi(d) = &n
+	}
+	if nilaiTeks := teks("berat"); nilaiTeks != "" {
+		nilai, err := model.ParseAngkaUkuran(nilaiTeks)
+		if err != nil || !satuanBeratOK {
+			catatan = append(catatan, strings.TrimSpace("berat "+nilaiTeks+" "+teks("satuan_berat")))
+		} else {
+			n := model.KaliSatuanLama(*nilai, pengaliBerat)
+			ukuran.Berat = &n
+		}
+	}
+
+	if ukuran.AdaDimensi() {
+		ukuran.Satuan = satuan
+	}
+	if ukuran.Berat != nil {
+		ukuran.SatuanBerat = satuanBerat
+	}
+	if c := teks("catatan"); c != "" {
+		catatan = append([]string{c}, catatan...)
+	}
+	ukuran.Catatan = strings.Join(catatan, "; ")
+	ukuran.HitungNormal()
+	return ukuran
+}
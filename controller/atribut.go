@@ -0,0 +1,340 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var namaAtributRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// snapshotKategori adalah salinan kategori yang disimpan di dalam koleksi (tanpa skema atribut)
+func snapshotKategori(k model.Kategori) model.Kategori {
+	k.Atribut = nil
+	k.NamaNormal = ""
+	k.Versi = 0
+	return k
+}
+
+// skemaAtributKategori mengembalikan skema atribut efektif sebuah kategori:
+// atribut milik kategori induk diwariskan, lalu ditimpa oleh atribut dengan nama sama di kategori anak.
+func skemaAtributKategori(ctx context.Context, k model.Kategori) ([]model.AtributKategori, error) {
+	// 🔹 Ambil leluhur berdasarkan materialized path
+	var leluhurIDs []primitive.ObjectID
+	for _, hex := range strings.Split(k.Path, "/") {
+		if hex == "" {
+			continue
+		}
+		if id, err := primitive.ObjectIDFromHex(hex); err == nil {
+			leluhurIDs = append(leluhurIDs, id)
+		}
+	}
+
+	urutan := []model.Kategori{}
+	if len(leluhurIDs) > 0 {
+		cursor, err := config.Ulbimongoconn.Collection("kategori").Find(ctx, bson.M{"_id": bson.M{"$in": leluhurIDs}})
+		if err != nil {
+			return nil, err
+		}
+		var leluhur []model.Kategori
+		if err := cursor.All(ctx, &leluhur); err != nil {
+			return nil, err
+		}
+
+		byID := map[primitive.ObjectID]model.Kategori{}
+		for _, l := range leluhur {
+			byID[l.ID] = l
+		}
+		// urutkan dari kategori paling atas
+		for _, id := range leluhurIDs {
+			if l, ok := byID[id]; ok {
+				urutan = append(urutan, l)
+			}
+		}
+	}
+	urutan = append(urutan, k)
+
+	// 🔹 Gabungkan skema, atribut anak menimpa atribut induk
+	skema := []model.AtributKategori{}
+	posisi := map[string]int{}
+	for _, kat := range urutan {
+		for _, a := range kat.Atribut {
+			a.Sumber = kat.NamaKategori
+			if i, ok := posisi[a.Nama]; ok {
+				skema[i] = a
+				continue
+			}
+			posisi[a.Nama] = len(skema)
+			skema = append(skema, a)
+		}
+	}
+
+	return skema, nil
+}
+
+// validasiSkemaAtribut memeriksa definisi skema atribut yang dikirim admin
+func validasiSkemaAtribut(atribut []model.AtributKategori) string {
+	seen := map[string]bool{}
+	for i, a := range atribut {
+		if !namaAtributRegex.MatchString(a.Nama) {
+			return fmt.Sprintf("Atribut ke-%d: nama hanya boleh huruf kecil, angka, dan underscore (_), diawali huruf", i+1)
+		}
+		if seen[a.Nama] {
+			return fmt.Sprintf("Atribut '%s' didefinisikan lebih dari satu kali", a.Nama)
+		}
+		seen[a.Nama] = true
+
+		switch a.Tipe {
+		case model.TipeAtributTeks, model.TipeAtributAngka, model.TipeAtributTanggal, model.TipeAtributBoolean:
+			if len(a.Pilihan) > 0 {
+				return fmt.Sprintf("Atribut '%s': pilihan hanya boleh diisi untuk tipe pilihan", a.Nama)
+			}
+		case model.TipeAtributPilihan:
+			if len(a.Pilihan) == 0 {
+				return fmt.Sprintf("Atribut '%s': tipe pilihan wajib memiliki daftar pilihan", a.Nama)
+			}
+		default:
+			return fmt.Sprintf("Atribut '%s': tipe harus teks, angka, tanggal, boolean, atau pilihan", a.Nama)
+		}
+	}
+	return ""
+}
+
+// validasiAtributKoleksi membaca field atribut (JSON object) dari form koleksi lalu memvalidasinya
+// terhadap skema kategori. Nilai dikembalikan dalam bentuk yang sudah dinormalisasi.
+func validasiAtributKoleksi(skema []model.AtributKategori, raw string) (map[string]interface{}, string) {
+	input := map[string]interface{}{}
+	if strings.TrimSpace(raw) != "" {
+		if err := json.Unmarshal([]byte(raw), &input); err != nil {
+			return nil, "Atribut harus berupa JSON object, contoh: {\"nominal\": 100}"
+		}
+	}
+
+	definisi := map[string]model.AtributKategori{}
+	for _, a := range skema {
+		definisi[a.Nama] = a
+	}
+
+	hasil := map[string]interface{}{}
+	for key, value := range input {
+		a, ok := definisi[key]
+		if !ok {
+			return nil, fmt.Sprintf("Atribut '%s' tidak dikenal untuk kategori ini", key)
+		}
+		if value == nil || value == "" {
+			continue
+		}
+
+		nilai, errMsg := normalisasiNilaiAtribut(a, value)
+		if errMsg != "" {
+			return nil, errMsg
+		}
+		hasil[key] = nilai
+	}
+
+	for _, a := range skema {
+		if _, ok := hasil[a.Nama]; a.Wajib && !ok {
+			return nil, fmt.Sprintf("Atribut '%s' wajib diisi", labelAtribut(a))
+		}
+	}
+
+	return hasil, ""
+}
+
+// normalisasiNilaiAtribut mengubah nilai atribut ke tipe yang sesuai skema
+func normalisasiNilaiAtribut(a model.AtributKategori, value interface{}) (interface{}, string) {
+	label := labelAtribut(a)
+
+	switch a.Tipe {
+	case model.TipeAtributTeks:
+		if s, ok := value.(string); ok {
+			return s, ""
+		}
+		return nil, fmt.Sprintf("Atribut '%s' harus berupa teks", label)
+
+	case model.TipeAtributAngka:
+		switch v := value.(type) {
+		case float64:
+			return v, ""
+		case string:
+			if n, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(v), ",", "."), 64); err == nil {
+				return n, ""
+			}
+		}
+		return nil, fmt.Sprintf("Atribut '%s' harus berupa angka", label)
+
+	case model.TipeAtributTanggal:
+		if s, ok := value.(string); ok {
+			if _, err := time.Parse("2006-01-02", s); err == nil {
+				return s, ""
+			}
+		}
+		return nil, fmt.Sprintf("Atribut '%s' harus berupa tanggal dengan format YYYY-MM-DD", label)
+
+	case model.TipeAtributBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v, ""
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b, ""
+			}
+		}
+		return nil, fmt.Sprintf("Atribut '%s' harus bernilai true atau false", label)
+
+	case model.TipeAtributPilihan:
+		if s, ok := value.(string); ok {
+			for _, p := range a.Pilihan {
+				if p == s {
+					return s, ""
+				}
+			}
+		}
+		return nil, fmt.Sprintf("Atribut '%s' harus salah satu dari: %s", label, strings.Join(a.Pilihan, ", "))
+	}
+
+	return nil, fmt.Sprintf("Tipe atribut '%s' tidak dikenal", label)
+}
+
+func labelAtribut(a model.AtributKategori) string {
+	if a.Label != "" {
+		return a.Label
+	}
+	return a.Nama
+}
+
+// GetSkemaAtributKategori godoc
+// @Summary      Get Skema Atribut Kategori
+// @Description  Mengambil skema atribut tambahan yang berlaku untuk koleksi pada kategori ini (termasuk atribut warisan dari kategori induk). Dipakai front end untuk membuat form dinamis.
+// @Tags         Data Kategori
+// @Produce      json
+// @Param        id   path      string  true  "ID Kategori"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /kategori/{id}/atribut [get]
+func GetSkemaAtributKategori(c *fiber.Ctx) error {
+	objID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID kategori tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var kategori model.Kategori
+	if err := config.Ulbimongoconn.Collection("kategori").FindOne(ctx, bson.M{"_id": objID}).Decode(&kategori); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Kategori tidak ditemukan",
+		})
+	}
+
+	skema, err := skemaAtributKategori(ctx, kategori)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil skema atribut",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil skema atribut kategori",
+		"data":    skema,
+	})
+}
+
+// SetSkemaAtributKategori godoc
+// @Summary      Set Skema Atribut Kategori
+// @Description  Mengganti daftar atribut tambahan milik kategori ini. Tipe yang didukung: teks, angka, tanggal (YYYY-MM-DD), boolean, pilihan.
+// @Tags         Data Kategori
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                     true  "ID Kategori"
+// @Param        request  body  model.SkemaAtributRequest  true  "Skema atribut"
+// @Param        If-Match  header  string  true  "ETag dari GET terakhir"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      412  {object}  map[string]interface{}  "Data sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428  {object}  map[string]interface{}  "Header If-Match belum diisi"
+// @Router       /kategori/{id}/atribut [put]
+func SetSkemaAtributKategori(c *fiber.Ctx) error {
+	objID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID kategori tidak valid",
+		})
+	}
+
+	var req model.SkemaAtributRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	for i := range req.Atribut {
+		req.Atribut[i].Nama = strings.TrimSpace(req.Atribut[i].Nama)
+		req.Atribut[i].Tipe = strings.ToLower(strings.TrimSpace(req.Atribut[i].Tipe))
+		req.Atribut[i].Sumber = ""
+	}
+	if errMsg := validasiSkemaAtribut(req.Atribut); errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	kategoriCollection := config.Ulbimongoconn.Collection("kategori")
+
+	versi, err := versiDokumen(ctx, kategoriCollection, objID)
+	if err == mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Kategori tidak ditemukan",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil data kategori",
+		})
+	}
+	if ok, err := cekIfMatch(c, versi); !ok {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{"atribut": req.Atribut}}
+	if len(req.Atribut) == 0 {
+		update = bson.M{"$unset": bson.M{"atribut": ""}}
+	}
+
+	result, err := kategoriCollection.UpdateOne(ctx, filterVersi(objID, versi), tambahVersi(update))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan skema atribut",
+		})
+	}
+	if result.MatchedCount == 0 {
+		versi, _ := versiDokumen(ctx, kategoriCollection, objID)
+		return tolakVersiBerubah(c, versi)
+	}
+
+	var updated model.Kategori
+	kategoriCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&updated)
+	c.Set(fiber.HeaderETag, etagVersi(versi+1))
+
+	return c.JSON(fiber.Map{
+		"message": "Skema atribut kategori berhasil disimpan",
+		"data":    updated,
+	})
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"be-internship/model"
+	"reflect"
+	"testing"
+)
+
+func TestValidasiAtributKoleksi(t *testing.T) {
+	skema := []model.AtributKategori{
+		{Nama: "nominal", Label: "Nominal", Tipe: model.TipeAtributAngka, Wajib: true},
+		{Nama: "bahan", Tipe: model.TipeAtributTeks},
+		{Nama: "tanggal_cetak", Tipe: model.TipeAtributTanggal},
+		{Nama: "asli", Tipe: model.TipeAtributBoolean},
+		{Nama: "kondisi_uang", Tipe: model.TipeAtributPilihan, Pilihan: []string{"baru", "bekas"}},
+	}
+
+	tests := []struct {
+		raw   string
+		want  map[string]interface{}
+		gagal bool
+	}{
+		{raw: `{"nominal": 100}`, want: map[string]interface{}{"nominal": 100.0}},
+		// angka dari string, koma desimal diterima
+		{raw: `{"nominal": "2,5"}`, want: map[string]interface{}{"nominal": 2.5}},
+		{raw: `{"nominal": 1, "bahan": "kertas", "tanggal_cetak": "1945-08-17", "asli": "true", "kondisi_uang": "bekas"}`,
+			want: map[string]interface{}{"nominal": 1.0, "bahan": "kertas", "tanggal_cetak": "1945-08-17", "asli": true, "kondisi_uang": "bekas"}},
+		// nilai kosong / null dilewati
+		{raw: `{"nominal": 5, "bahan": "", "asli": null}`, want: map[string]interface{}{"nominal": 5.0}},
+		{raw: `{"nominal": 5, "asli": false}`, want: map[string]interface{}{"nominal": 5.0, "asli": false}},
+
+		{raw: ``, gagal: true},                                 // atribut wajib belum diisi
+		{raw: `{"nominal": ""}`, gagal: true},                  // kosong dianggap tidak diisi
+		{raw: `[1, 2]`, gagal: true},                           // bukan JSON object
+		{raw: `{"nominal": 1`, gagal: true},                    // JSON rusak
+		{raw: `{"nominal": 1, "warna": "merah"}`, gagal: true}, // atribut tidak dikenal
+		{raw: `{"nominal": "seratus"}`, gagal: true},           // bukan angka
+		{raw: `{"nominal": 1, "bahan": 12}`, gagal: true},      // teks harus string
+		{raw: `{"nominal": 1, "tanggal_cetak": "17-08-1945"}`, gagal: true},
+		{raw: `{"nominal": 1, "asli": "mungkin"}`, gagal: true},
+		{raw: `{"nominal": 1, "kondisi_uang": "Baru"}`, gagal: true}, // pilihan peka huruf besar
+	}
+
+	for _, tt := range tests {
+		got, errMsg := validasiAtributKoleksi(skema, tt.raw)
+		if tt.gagal {
+			if errMsg == "" {
+				t.Errorf("validasiAtributKoleksi(%q) = %v, want error", tt.raw, got)
+			}
+			continue
+		}
+		if errMsg != "" {
+			t.Errorf("validasiAtributKoleksi(%q) error: %s", tt.raw, errMsg)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("validasiAtributKoleksi(%q) = %v, want %v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestValidasiAtributKoleksiTanpaSkema(t *testing.T) {
+	got, errMsg := validasiAtributKoleksi(nil, "")
+	if errMsg != "" || len(got) != 0 {
+		t.Errorf("validasiAtributKoleksi(nil, \"\") = %v, %q, want kosong tanpa error", got, errMsg)
+	}
+	if _, errMsg := validasiAtributKoleksi(nil, `{"nominal": 1}`); errMsg == "" {
+		t.Errorf("validasiAtributKoleksi(nil, nominal) want error atribut tidak dikenal")
+	}
+}
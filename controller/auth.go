@@ -20,7 +20,7 @@ import (
 
 // Register godoc
 // @Summary Register
-// @Description Registrasi akun admin.
+// @Description Registrasi akun baru. Akun baru selalu ber-role contributor; role lain diberikan admin lewat PUT /users/{id}/role.
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -125,7 +125,7 @@ func Register(c *fiber.Ctx) error {
 	// SET DATA DEFAULT
 	// =========================
 	user.ID = primitive.NewObjectID()
-	user.Role = "admin"
+	user.Role = model.RoleContributor // role dari body diabaikan
 
 	// =========================
 	// INSERT DATA
@@ -399,6 +399,13 @@ func UpdateUserByID(c *fiber.Ctx) error {
 		})
 	}
 
+	// Role hanya boleh diubah admin lewat PUT /users/{id}/role
+	if c.FormValue("role") != "" {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Role tidak bisa diubah di sini, gunakan PUT /users/{id}/role (khusus admin)",
+		})
+	}
+
 	// Convert ID
 	userID, err := primitive.ObjectIDFromHex(idParam)
 	if err != nil {
@@ -433,7 +440,6 @@ func UpdateUserByID(c *fiber.Ctx) error {
 	username := c.FormValue("username")
 	phone := c.FormValue("phone_number")
 	password := c.FormValue("password")
-	role := c.FormValue("role")
 
 	update := bson.M{}
 
@@ -488,11 +494,6 @@ func UpdateUserByID(c *fiber.Ctx) error {
 		update["password"] = string(hashedPassword)
 	}
 
-	// ROLE (opsional)
-	if role != "" {
-		update["role"] = role
-	}
-
 	// Jika tidak ada yang dikirim
 	if len(update) == 0 {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -532,6 +533,71 @@ func UpdateUserByID(c *fiber.Ctx) error {
 	})
 }
 
+// roleDikenal memeriksa apakah role termasuk role yang didukung aplikasi
+func roleDikenal(role string) bool {
+	switch role {
+	case model.RoleAdmin, model.RoleContributor, model.RoleFinance:
+		return true
+	}
+	return false
+}
+
+// UbahRoleUser godoc
+// @Summary      Ubah Role User
+// @Description  Mengubah role user (khusus admin). Role yang didukung: admin, contributor, finance.
+// @Tags         Users
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string  true  "ID user"
+// @Param        role  formData  string  true  "Role baru (admin, contributor, finance)"
+// @Success      200   {object}  map[string]interface{}  "Role user berhasil diubah"
+// @Failure      400   {object}  map[string]interface{}  "Role tidak valid"
+// @Failure      403   {object}  map[string]interface{}  "Bukan admin"
+// @Failure      404   {object}  map[string]interface{}  "User tidak ditemukan"
+// @Router       /users/{id}/role [put]
+func UbahRoleUser(c *fiber.Ctx) error {
+	userID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "ID user tidak valid",
+		})
+	}
+
+	role := strings.ToLower(strings.TrimSpace(c.FormValue("role")))
+	if !roleDikenal(role) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Role tidak valid, gunakan: " + strings.Join([]string{model.RoleAdmin, model.RoleContributor, model.RoleFinance}, ", "),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	usersCollection := config.
+		Ulbimongoconn.Client().
+		Database(config.DBUlbimongoinfo.DBName).
+		Collection("users")
+
+	res, err := usersCollection.UpdateOne(ctx, bson.M{"_id": userID}, bson.M{"$set": bson.M{"role": role}})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal mengubah role user",
+		})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"message": "User tidak ditemukan",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Role user berhasil diubah",
+		"id":      userID.Hex(),
+		"role":    role,
+	})
+}
+
 // DeleteUserByID godoc
 // @Summary      Delete User
 // @Description  Menghapus data user berdasarkan ID (wajib autentikasi JWT Bearer)
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"be-internship/model"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// tokenUji membuat token JWT seperti hasil Login untuk role tertentu
+func tokenUji(t *testing.T, userID primitive.ObjectID, role string) string {
+	t.Helper()
+	claims := &Claims{
+		UserID:   userID.Hex(),
+		Username: "uji",
+		Role:     role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtKey)
+	if err != nil {
+		t.Fatalf("gagal membuat token: %v", err)
+	}
+	return token
+}
+
+func TestContributorTidakBisaUbahRoleSendiri(t *testing.T) {
+	// Route sama dengan routes.SetupRoutes
+	app := fiber.New()
+	app.Put("/users/:id", JWTAuth, UpdateUserByID)
+	app.Put("/users/:id/role", JWTAuth, RequireRole("admin"), UbahRoleUser)
+
+	id := primitive.NewObjectID()
+	token := tokenUji(t, id, model.RoleContributor)
+
+	for _, path := range []string{"/users/" + id.Hex(), "/users/" + id.Hex() + "/role"} {
+		form := url.Values{"role": {model.RoleAdmin}}
+		req := httptest.NewRequest(fiber.MethodPut, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", fiber.MIMEApplicationForm)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("PUT %s error: %v", path, err)
+		}
+		if resp.StatusCode != fiber.StatusForbidden {
+			t.Errorf("PUT %s sebagai contributor = %d, want %d", path, resp.StatusCode, fiber.StatusForbidden)
+		}
+	}
+}
+
+func TestRoleDikenal(t *testing.T) {
+	tests := []struct {
+		role string
+		want bool
+	}{
+		{model.RoleAdmin, true},
+		{model.RoleContributor, true},
+		{model.RoleFinance, true},
+		{"", false},
+		{"superadmin", false},
+		{"Admin", false}, // role disimpan huruf kecil
+	}
+	for _, tt := range tests {
+		if got := roleDikenal(tt.role); got != tt.want {
+			t.Errorf("roleDikenal(%q) = %v, want %v", tt.role, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,530 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// =============================================================
+// 📤 Deaksesi: pengeluaran koleksi secara permanen dengan persetujuan admin
+// =============================================================
+
+// minPersetujuanDeaksesi membaca jumlah persetujuan admin yang dibutuhkan (env DEAKSESI_MIN_PERSETUJUAN, default 2)
+func minPersetujuanDeaksesi() int {
+	n, err := strconv.Atoi(os.Getenv("DEAKSESI_MIN_PERSETUJUAN"))
+	if err != nil || n < 1 {
+		return 2
+	}
+	return n
+}
+
+// filterKoleksiAktif menambahkan syarat "belum dideaksesi" ke filter laporan inventaris aktif
+func filterKoleksiAktif(filter bson.M) bson.M {
+	filter["deaksesi.status"] = bson.M{"$ne": model.StatusDeaksesiSelesai}
+	return filter
+}
+
+// KoleksiBelumDideaksesi middleware menolak perubahan pada koleksi yang deaksesinya sudah selesai.
+// Dipasang pada route koleksi yang mengubah data (parameter :id = ID koleksi).
+func KoleksiBelumDideaksesi(c *fiber.Ctx) error {
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		// ID tidak valid ditangani handler berikutnya
+		return c.Next()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	n, err := config.Ulbimongoconn.Collection("koleksi").CountDocuments(ctx,
+		bson.M{"_id": id, "deaksesi.status": model.StatusDeaksesiSelesai})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memeriksa status deaksesi koleksi",
+		})
+	}
+	if n > 0 {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Koleksi sudah dideaksesi, data hanya bisa dibaca",
+		})
+	}
+	return c.Next()
+}
+
+// simpanRingkasanDeaksesi menyalin status usulan ke dokumen koleksi.
+// Usulan yang ditolak / dibatalkan menghapus ringkasan sehingga koleksi kembali aktif biasa.
+func simpanRingkasanDeaksesi(ctx context.Context, u model.UsulanDeaksesi) error {
+	update := bson.M{"$unset": bson.M{"deaksesi": ""}}
+	if u.Status != model.StatusDeaksesiDitolak && u.Status != model.StatusDeaksesiDibatalkan {
+		ringkasan := model.RingkasanDeaksesi{UsulanID: u.ID, Status: u.Status, Alasan: u.Alasan}
+		if u.Pelepasan != nil {
+			ringkasan.Metode = u.Pelepasan.Metode
+			ringkasan.Tanggal = &u.Pelepasan.Tanggal
+		}
+		update = bson.M{"$set": bson.M{"deaksesi": ringkasan}}
+	}
+	_, err := config.Ulbimongoconn.Collection("koleksi").UpdateOne(ctx,
+		bson.M{"_id": u.KoleksiID, "deaksesi.usulan_id": u.ID}, tambahVersi(update))
+	return err
+}
+
+// ambilUsulanDeaksesi membaca satu usulan deaksesi dari parameter :id
+func ambilUsulanDeaksesi(ctx context.Context, idParam string) (model.UsulanDeaksesi, int, string) {
+	var u model.UsulanDeaksesi
+	id, err := primitive.ObjectIDFromHex(idParam)
+	if err != nil {
+		return u, fiber.StatusBadRequest, "ID usulan deaksesi tidak valid"
+	}
+	if err := config.Ulbimongoconn.Collection("deaksesi").FindOne(ctx, bson.M{"_id": id}).Decode(&u); err != nil {
+		return u, fiber.StatusNotFound, "Usulan deaksesi tidak ditemukan"
+	}
+	return u, 0, ""
+}
+
+// ubahStatusDeaksesi memindahkan status usulan secara atomik dari statusLama, mencatat riwayat,
+// lalu menyalin ringkasannya ke koleksi
+func ubahStatusDeaksesi(ctx context.Context, u *model.UsulanDeaksesi, statusLama []string, setData bson.M, riwayat model.RiwayatDeaksesi) (int, string) {
+	setData["status"] = riwayat.Status
+	setData["updated_at"] = riwayat.Tanggal
+	err := config.Ulbimongoconn.Collection("deaksesi").FindOneAndUpdate(ctx,
+		bson.M{"_id": u.ID, "status": bson.M{"$in": statusLama}},
+		bson.M{"$set": setData, "$push": bson.M{"riwayat": riwayat}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(u)
+	if err == mongo.ErrNoDocuments {
+		return fiber.StatusConflict, "Status usulan deaksesi sudah berubah, muat ulang data"
+	}
+	if err != nil {
+		return fiber.StatusInternalServerError, "Gagal memperbarui usulan deaksesi"
+	}
+	if err := simpanRingkasanDeaksesi(ctx, *u); err != nil {
+		return fiber.StatusInternalServerError, "Usulan tersimpan, tetapi gagal memperbarui status deaksesi koleksi"
+	}
+	return 0, ""
+}
+
+// UsulkanDeaksesi godoc
+// @Summary      Usulkan Deaksesi Koleksi
+// @Description  Mengajukan deaksesi (pengeluaran permanen) koleksi dengan alasan dan dokumen pendukung. Alasan: rusak_total, duplikat, tidak_relevan, repatriasi, hilang, lainnya. Usulan membutuhkan persetujuan beberapa admin (DEAKSESI_MIN_PERSETUJUAN, default 2) selain pengusul.
+// @Tags         Deaksesi
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                       true  "ID koleksi"
+// @Param        request  body  model.UsulanDeaksesiRequest  true  "Usulan deaksesi"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]string
+// @Router       /koleksi/{id}/deaksesi [post]
+func UsulkanDeaksesi(c *fiber.Ctx) error {
+	var req model.UsulanDeaksesiRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Body request tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	koleksi, status, errMsg := ambilKoleksiMedia(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if koleksi.Deaksesi != nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Koleksi sudah memiliki usulan deaksesi berstatus " + koleksi.Deaksesi.Status,
+		})
+	}
+	if koleksi.StatusLokasi == model.StatusLokasiDipinjamkan {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Koleksi sedang dipinjamkan, deaksesi bisa diajukan setelah koleksi kembali",
+		})
+	}
+
+	req.Alasan = strings.ToLower(strings.TrimSpace(req.Alasan))
+	req.Keterangan = strings.TrimSpace(req.Keterangan)
+	if !model.AlasanDeaksesiValid[req.Alasan] {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Alasan harus salah satu dari: rusak_total, duplikat, tidak_relevan, repatriasi, hilang, lainnya",
+		})
+	}
+	if req.Keterangan == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Keterangan alasan deaksesi wajib diisi",
+		})
+	}
+	if errMsg := validasiDokumen(req.Dokumen, koleksi.Media); errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	now := time.Now()
+	pengusul := penggunaLogin(c)
+	u := model.UsulanDeaksesi{
+		ID:             primitive.NewObjectID(),
+		KoleksiID:      koleksi.ID,
+		NamaBenda:      koleksi.NamaBenda,
+		NoRegistrasi:   koleksi.NoRegistrasi,
+		NoInventaris:   koleksi.NoInventaris,
+		Alasan:         req.Alasan,
+		Keterangan:     req.Keterangan,
+		Dokumen:        req.Dokumen,
+		Status:         model.StatusDeaksesiDiajukan,
+		MinPersetujuan: minPersetujuanDeaksesi(),
+		Riwayat:        []model.RiwayatDeaksesi{{Status: model.StatusDeaksesiDiajukan, Tanggal: now, Oleh: pengusul}},
+		DiajukanOleh:   pengusul,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	col := config.Ulbimongoconn.Collection("deaksesi")
+	if _, err := col.InsertOne(ctx, u); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan usulan deaksesi",
+		})
+	}
+
+	// ringkasan hanya dipasang jika koleksi belum punya usulan lain (menghindari usulan ganda bersamaan)
+	res, err := config.Ulbimongoconn.Collection("koleksi").UpdateOne(ctx,
+		bson.M{"_id": koleksi.ID, "deaksesi": bson.M{"$exists": false}},
+		tambahVersi(bson.M{"$set": bson.M{"deaksesi": model.RingkasanDeaksesi{UsulanID: u.ID, Status: u.Status, Alasan: u.Alasan}}}))
+	if err != nil || res.MatchedCount == 0 {
+		col.DeleteOne(ctx, bson.M{"_id": u.ID})
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Koleksi baru saja diusulkan untuk deaksesi, muat ulang data",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Usulan deaksesi berhasil diajukan",
+		"data":    u,
+	})
+}
+
+// GetAllDeaksesi godoc
+// @Summary      Get All Usulan Deaksesi
+// @Description  Mengambil daftar usulan deaksesi, terbaru di depan
+// @Tags         Deaksesi
+// @Produce      json
+// @Param        status      query  string  false  "diajukan, disetujui, ditolak, dibatalkan, selesai"
+// @Param        koleksi_id  query  string  false  "Filter koleksi"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /deaksesi [get]
+func GetAllDeaksesi(c *fiber.Ctx) error {
+	filter := bson.M{}
+	if status := c.Query("status"); status != "" {
+		filter["status"] = status
+	}
+	if v := c.Query("koleksi_id"); v != "" {
+		id, err := primitive.ObjectIDFromHex(v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "ID koleksi tidak valid",
+			})
+		}
+		filter["koleksi_id"] = id
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.Ulbimongoconn.Collection("deaksesi").Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil usulan deaksesi",
+		})
+	}
+	list := []model.UsulanDeaksesi{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca usulan deaksesi",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil usulan deaksesi",
+		"total":   len(list),
+		"data":    list,
+	})
+}
+
+// GetDeaksesiByID godoc
+// @Summary      Get Usulan Deaksesi By ID
+// @Description  Mengambil satu usulan deaksesi beserta persetujuan dan riwayat statusnya
+// @Tags         Deaksesi
+// @Produce      json
+// @Param        id   path  string  true  "ID usulan deaksesi"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Router       /deaksesi/{id} [get]
+func GetDeaksesiByID(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	u, status, errMsg := ambilUsulanDeaksesi(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil usulan deaksesi",
+		"data":    u,
+	})
+}
+
+// PutusanDeaksesi godoc
+// @Summary      Persetujuan Deaksesi
+// @Description  Admin menyetujui atau menolak usulan deaksesi. Pengusul tidak bisa menyetujui usulannya sendiri dan setiap admin hanya bisa memberi satu keputusan. Satu penolakan membuat usulan ditolak; usulan disetujui setelah jumlah persetujuan mencapai min_persetujuan.
+// @Tags         Deaksesi
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                            true  "ID usulan deaksesi"
+// @Param        request  body  model.PersetujuanDeaksesiRequest  true  "Keputusan: setuju / tolak"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Router       /deaksesi/{id}/persetujuan [put]
+func PutusanDeaksesi(c *fiber.Ctx) error {
+	var req model.PersetujuanDeaksesiRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Body request tidak valid",
+		})
+	}
+	req.Keputusan = strings.ToLower(strings.TrimSpace(req.Keputusan))
+	if req.Keputusan != model.KeputusanSetuju && req.Keputusan != model.KeputusanTolak {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Keputusan harus setuju atau tolak",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	u, status, errMsg := ambilUsulanDeaksesi(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if u.Status != model.StatusDeaksesiDiajukan {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Usulan berstatus " + u.Status + " tidak bisa diputuskan lagi",
+		})
+	}
+	admin := penggunaLogin(c)
+	if admin == u.DiajukanOleh {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Pengusul tidak bisa memutuskan usulan deaksesinya sendiri",
+		})
+	}
+
+	now := time.Now()
+	putusan := model.PersetujuanDeaksesi{
+		Oleh:      admin,
+		Keputusan: req.Keputusan,
+		Catatan:   strings.TrimSpace(req.Catatan),
+		Tanggal:   now,
+	}
+	err := config.Ulbimongoconn.Collection("deaksesi").FindOneAndUpdate(ctx,
+		bson.M{"_id": u.ID, "status": model.StatusDeaksesiDiajukan, "persetujuan.oleh": bson.M{"$ne": admin}},
+		bson.M{"$push": bson.M{"persetujuan": putusan}, "$set": bson.M{"updated_at": now}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&u)
+	if err == mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Anda sudah memberi keputusan atau status usulan sudah berubah",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan keputusan",
+		})
+	}
+
+	// 🔹 Satu penolakan cukup untuk menolak usulan; persetujuan harus mencapai batas minimal
+	setuju := 0
+	for _, p := range u.Persetujuan {
+		if p.Keputusan == model.KeputusanSetuju {
+			setuju++
+		}
+	}
+	statusBaru := ""
+	if req.Keputusan == model.KeputusanTolak {
+		statusBaru = model.StatusDeaksesiDitolak
+	} else if setuju >= u.MinPersetujuan {
+		statusBaru = model.StatusDeaksesiDisetujui
+	}
+	if statusBaru != "" {
+		riwayat := model.RiwayatDeaksesi{Status: statusBaru, Tanggal: now, Oleh: admin, Catatan: putusan.Catatan}
+		if status, errMsg := ubahStatusDeaksesi(ctx, &u, []string{model.StatusDeaksesiDiajukan}, bson.M{}, riwayat); errMsg != "" {
+			return c.Status(status).JSON(fiber.Map{
+				"error": errMsg,
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Keputusan tersimpan, status usulan " + u.Status,
+		"data":    u,
+	})
+}
+
+// SelesaikanDeaksesi godoc
+// @Summary      Selesaikan Deaksesi
+// @Description  Mencatat pelepasan koleksi yang deaksesinya sudah disetujui (metode: hibah, pengembalian, pertukaran, penjualan, pemusnahan, dihapuskan). Setelah selesai, data koleksi menjadi hanya-baca dan tidak muncul di laporan inventaris aktif, tetapi riwayatnya tetap tersimpan.
+// @Tags         Deaksesi
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                          true  "ID usulan deaksesi"
+// @Param        request  body  model.PelepasanDeaksesiRequest  true  "Data pelepasan"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Router       /deaksesi/{id}/selesai [put]
+func SelesaikanDeaksesi(c *fiber.Ctx) error {
+	var req model.PelepasanDeaksesiRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Body request tidak valid",
+		})
+	}
+	req.Metode = strings.ToLower(strings.TrimSpace(req.Metode))
+	if !model.MetodePelepasanValid[req.Metode] {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Metode harus salah satu dari: hibah, pengembalian, pertukaran, penjualan, pemusnahan, dihapuskan",
+		})
+	}
+	tanggal, err := tanggalKondisi(req.Tanggal)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Format tanggal harus YYYY-MM-DD",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	u, status, errMsg := ambilUsulanDeaksesi(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if u.Status != model.StatusDeaksesiDisetujui {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Hanya usulan yang sudah disetujui yang bisa diselesaikan",
+		})
+	}
+
+	// 🔹 Koleksi tidak boleh sedang / akan dipamerkan atau dipinjamkan.
+	// Jadwal dikunci sampai status tersimpan supaya tidak ada jadwal baru di antara cek dan simpan.
+	lepas, err := kunciJadwalKoleksi(ctx, []primitive.ObjectID{u.KoleksiID})
+	if err != nil {
+		return gagalKunciJadwal(c, err)
+	}
+	defer lepas()
+	bentrok, err := jadwalBentrok(ctx, []primitive.ObjectID{u.KoleksiID}, awalHari(time.Now()), nil, primitive.NilObjectID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memeriksa jadwal koleksi",
+		})
+	}
+	if len(bentrok) > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   "Keluarkan koleksi dari jadwal berikut sebelum deaksesi diselesaikan. " + pesanBentrok(bentrok),
+			"bentrok": bentrok,
+		})
+	}
+
+	admin := penggunaLogin(c)
+	pelepasan := model.PelepasanDeaksesi{
+		Metode:   req.Metode,
+		Tanggal:  tanggal,
+		Penerima: strings.TrimSpace(req.Penerima),
+		Catatan:  strings.TrimSpace(req.Catatan),
+		Oleh:     admin,
+	}
+	riwayat := model.RiwayatDeaksesi{Status: model.StatusDeaksesiSelesai, Tanggal: time.Now(), Oleh: admin, Catatan: pelepasan.Catatan}
+	if status, errMsg := ubahStatusDeaksesi(ctx, &u, []string{model.StatusDeaksesiDisetujui}, bson.M{"pelepasan": pelepasan}, riwayat); errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Deaksesi selesai, data koleksi sekarang hanya-baca",
+		"data":    u,
+	})
+}
+
+// BatalkanDeaksesi godoc
+// @Summary      Batalkan Deaksesi
+// @Description  Membatalkan usulan deaksesi yang belum selesai. Hanya pengusul atau admin yang bisa membatalkan.
+// @Tags         Deaksesi
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                true   "ID usulan deaksesi"
+// @Param        request  body  model.CatatanRequest  false  "Alasan pembatalan"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]string
+// @Router       /deaksesi/{id}/batal [put]
+func BatalkanDeaksesi(c *fiber.Ctx) error {
+	var req model.CatatanRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Body request tidak valid",
+			})
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	u, status, errMsg := ambilUsulanDeaksesi(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	pengguna := penggunaLogin(c)
+	claims, _ := c.Locals("claims").(*Claims)
+	if pengguna != u.DiajukanOleh && (claims == nil || claims.Role != "admin") {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Hanya pengusul atau admin yang bisa membatalkan usulan deaksesi",
+		})
+	}
+
+	riwayat := model.RiwayatDeaksesi{Status: model.StatusDeaksesiDibatalkan, Tanggal: time.Now(), Oleh: pengguna, Catatan: strings.TrimSpace(req.Catatan)}
+	if status, errMsg := ubahStatusDeaksesi(ctx, &u, model.StatusDeaksesiAktif, bson.M{}, riwayat); errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Usulan deaksesi dibatalkan",
+		"data":    u,
+	})
+}
@@ -0,0 +1,624 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// masterData menjelaskan cara menggabungkan satu jenis master data (kategori / gudang / rak / tahap)
+type masterData struct {
+	collection   string // nama collection master data
+	label        string // dipakai di pesan response
+	namaField    string // field nama di collection master data
+	koleksiField string // field snapshot di collection koleksi
+	// snapshot membuat salinan data tujuan yang disimpan di koleksi
+	snapshot func(raw bson.Raw) (interface{}, error)
+	// validasi pengecekan tambahan sebelum penggabungan (opsional)
+	validasi func(duplikat, tujuan bson.Raw) string
+	// validasiKoleksi memeriksa koleksi yang akan dipindah terhadap data tujuan (opsional).
+	// Mengembalikan daftar koleksi / usulan yang tidak lolos dan update yang perlu diterapkan ke koleksi.
+	validasiKoleksi func(ctx context.Context, duplikatID primitive.ObjectID, tujuan bson.Raw) ([]fiber.Map, []mongo.WriteModel, error)
+	// referensiLain memindahkan referensi selain koleksi (opsional)
+	referensiLain func(ctx context.Context, duplikatID, tujuanID primitive.ObjectID, tujuan bson.Raw) error
+}
+
+var masterKategori = masterData{
+	collection:   "kategori",
+	label:        "kategori",
+	namaField:    "nama_kategori",
+	koleksiField: "kategori",
+	snapshot: func(raw bson.Raw) (interface{}, error) {
+		var k model.Kategori
+		err := bson.Unmarshal(raw, &k)
+		return snapshotKategori(k), err
+	},
+	validasi: func(duplikat, tujuan bson.Raw) string {
+		var dup, target model.Kategori
+		bson.Unmarshal(duplikat, &dup)
+		bson.Unmarshal(tujuan, &target)
+		if strings.Contains(target.Path, "/"+dup.ID.Hex()+"/") {
+			return "Kategori tujuan tidak boleh sub-kategori dari kategori yang digabung"
+		}
+		return ""
+	},
+	validasiKoleksi: validasiAtributGabung,
+	referensiLain: func(ctx context.Context, duplikatID, tujuanID primitive.ObjectID, tujuan bson.Raw) error {
+		var target model.Kategori
+		if err := bson.Unmarshal(tujuan, &target); err != nil {
+			return err
+		}
+		col := config.Ulbimongoconn.Collection("kategori")
+
+		// sub-kategori langsung pindah ke kategori tujuan
+		if _, err := col.UpdateMany(ctx, bson.M{"parent_id": duplikatID}, tambahVersi(bson.M{"$set": bson.M{"parent_id": tujuanID}})); err != nil {
+			return err
+		}
+
+		// perbarui materialized path seluruh turunan
+		turunan, err := turunanKategori(ctx, duplikatID)
+		if err != nil {
+			return err
+		}
+
+		segmen := "/" + duplikatID.Hex() + "/"
+		var models []mongo.WriteModel
+		for _, k := range turunan {
+			sisa := k.Path[strings.Index(k.Path, segmen)+len(segmen):]
+			models = append(models, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": k.ID}).
+				SetUpdate(tambahVersi(bson.M{"$set": bson.M{"path": pathAnak(target) + sisa}})))
+		}
+		if len(models) > 0 {
+			_, err = col.BulkWrite(ctx, models)
+		}
+		return err
+	},
+}
+
+var masterGudang = masterData{
+	collection:   "gudang",
+	label:        "gudang",
+	namaField:    "nama_gudang",
+	koleksiField: "tempat_penyimpanan.gudang",
+	snapshot: func(raw bson.Raw) (interface{}, error) {
+		var g model.Gudang
+		err := bson.Unmarshal(raw, &g)
+		return model.Gudang{ID: g.ID, NamaGudang: g.NamaGudang}, err
+	},
+	referensiLain: func(ctx context.Context, duplikatID, tujuanID primitive.ObjectID, _ bson.Raw) error {
+		return pindahkanReferensiLingkungan(ctx, "gudang_id", duplikatID, tujuanID)
+	},
+}
+
+var masterRak = masterData{
+	collection:   "rak",
+	label:        "rak",
+	namaField:    "nama_rak",
+	koleksiField: "tempat_penyimpanan.rak",
+	snapshot: func(raw bson.Raw) (interface{}, error) {
+		var r model.Rak
+		err := bson.Unmarshal(raw, &r)
+		return model.Rak{ID: r.ID, NamaRak: r.NamaRak}, err
+	},
+	referensiLain: func(ctx context.Context, duplikatID, tujuanID primitive.ObjectID, _ bson.Raw) error {
+		return pindahkanReferensiLingkungan(ctx, "rak_id", duplikatID, tujuanID)
+	},
+}
+
+var masterTahap = masterData{
+	collection:   "tahap",
+	label:        "tahap",
+	namaField:    "nama_tahap",
+	koleksiField: "tempat_penyimpanan.tahap",
+	snapshot: func(raw bson.Raw) (interface{}, error) {
+		var t model.Tahap
+		err := bson.Unmarshal(raw, &t)
+		return model.Tahap{ID: t.ID, NamaTahap: t.NamaTahap}, err
+	},
+}
+
+// validasiAtributGabung memeriksa ulang atribut koleksi di kategori duplikat beserta sub-kategorinya
+// terhadap skema atribut yang berlaku setelah kategori digabung. Usulan tambah / ubah yang masih menunggu
+// review ikut diperiksa. Atribut yang lolos dinormalisasi ulang (misalnya tipe atribut berbeda di kategori tujuan).
+func validasiAtributGabung(ctx context.Context, duplikatID primitive.ObjectID, tujuan bson.Raw) ([]fiber.Map, []mongo.WriteModel, error) {
+	var target model.Kategori
+	if err := bson.Unmarshal(tujuan, &target); err != nil {
+		return nil, nil, err
+	}
+
+	// 🔹 Skema setiap kategori terdampak setelah penggabungan
+	skemaTujuan, err := skemaAtributKategori(ctx, target)
+	if err != nil {
+		return nil, nil, err
+	}
+	skema := map[primitive.ObjectID][]model.AtributKategori{duplikatID: skemaTujuan}
+
+	turunan, err := turunanKategori(ctx, duplikatID)
+	if err != nil {
+		return nil, nil, err
+	}
+	segmen := "/" + duplikatID.Hex() + "/"
+	for _, k := range turunan {
+		k.Path = pathAnak(target) + k.Path[strings.Index(k.Path, segmen)+len(segmen):]
+		if skema[k.ID], err = skemaAtributKategori(ctx, k); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(skema))
+	for id := range skema {
+		ids = append(ids, id)
+	}
+
+	// cek mengembalikan atribut hasil normalisasi, atau pesan error jika tidak sesuai skema
+	cek := func(kategoriID primitive.ObjectID, atribut map[string]interface{}) (map[string]interface{}, bool, string) {
+		lama, err := json.Marshal(atribut)
+		if err != nil {
+			return nil, false, "Atribut koleksi tidak bisa dibaca"
+		}
+		hasil, errMsg := validasiAtributKoleksi(skema[kategoriID], string(lama))
+		if errMsg != "" {
+			return nil, false, errMsg
+		}
+		baru, _ := json.Marshal(hasil)
+		berubah := len(hasil)+len(atribut) > 0 && !bytes.Equal(lama, baru)
+		return hasil, berubah, ""
+	}
+
+	gagal := []fiber.Map{}
+	var perbaikan []mongo.WriteModel
+
+	// =========================
+	// KOLEKSI
+	// =========================
+	cursor, err := config.Ulbimongoconn.Collection("koleksi").Find(ctx,
+		filterKoleksiAktif(bson.M{"kategori._id": bson.M{"$in": ids}}),
+		options.Find().SetProjection(bson.M{"no_inv": 1, "nama_benda": 1, "kategori._id": 1, "atribut": 1, "versi": 1}))
+	if err != nil {
+		return nil, nil, err
+	}
+	var daftarKoleksi []model.Koleksi
+	if err := cursor.All(ctx, &daftarKoleksi); err != nil {
+		return nil, nil, err
+	}
+	for _, k := range daftarKoleksi {
+		hasil, berubah, errMsg := cek(k.Kategori.ID, k.Atribut)
+		if errMsg != "" {
+			gagal = append(gagal, fiber.Map{"koleksi_id": k.ID, "no_inv": k.NoInventaris, "nama_benda": k.NamaBenda, "error": errMsg})
+			continue
+		}
+		if berubah {
+			perbaikan = append(perbaikan, mongo.NewUpdateOneModel().
+				SetFilter(filterVersiKoleksi(k.ID, k.Versi)).
+				SetUpdate(tambahVersi(bson.M{"$set": bson.M{"atribut": hasil}})))
+		}
+	}
+
+	// =========================
+	// USULAN YANG MENUNGGU REVIEW
+	// =========================
+	cursor, err = config.Ulbimongoconn.Collection("perubahan_koleksi").Find(ctx,
+		bson.M{"status": model.StatusPerubahanMenunggu, "data.kategori._id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, nil, err
+	}
+	var daftarUsulan []model.PerubahanKoleksi
+	if err := cursor.All(ctx, &daftarUsulan); err != nil {
+		return nil, nil, err
+	}
+	for _, u := range daftarUsulan {
+		if _, _, errMsg := cek(u.Data.Kategori.ID, u.Data.Atribut); errMsg != "" {
+			gagal = append(gagal, fiber.Map{"perubahan_id": u.ID, "koleksi_id": u.KoleksiID, "nama_benda": u.NamaBenda, "error": errMsg})
+		}
+	}
+
+	return gagal, perbaikan, nil
+}
+
+// pindahkanReferensiLingkungan memindahkan data sensor, peringatan, dan ambang batas ke lokasi tujuan.
+// Ambang batas yang bentrok dengan ambang milik lokasi tujuan dihapus (ambang tujuan dipertahankan).
+func pindahkanReferensiLingkungan(ctx context.Context, field string, duplikatID, tujuanID primitive.ObjectID) error {
+	db := config.Ulbimongoconn
+
+	for _, collection := range []string{"pembacaan_sensor", "peringatan_lingkungan"} {
+		if _, err := db.Collection(collection).UpdateMany(ctx,
+			bson.M{"lokasi." + field: duplikatID},
+			bson.M{"$set": bson.M{"lokasi." + field: tujuanID}},
+		); err != nil {
+			return err
+		}
+	}
+
+	ambangCollection := db.Collection("ambang_lingkungan")
+	cursor, err := ambangCollection.Find(ctx, bson.M{field: duplikatID})
+	if err != nil {
+		return err
+	}
+	var daftarAmbang []model.AmbangLingkungan
+	if err := cursor.All(ctx, &daftarAmbang); err != nil {
+		return err
+	}
+
+	for _, a := range daftarAmbang {
+		// kunci ambang setelah dipindah
+		kunci := bson.M{"gudang_id": a.GudangID, "rak_id": bson.M{"$exists": false}}
+		if !a.RakID.IsZero() {
+			kunci["rak_id"] = a.RakID
+		}
+		kunci[field] = tujuanID
+
+		count, err := ambangCollection.CountDocuments(ctx, kunci)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			_, err = ambangCollection.DeleteOne(ctx, bson.M{"_id": a.ID})
+		} else {
+			_, err = ambangCollection.UpdateOne(ctx, bson.M{"_id": a.ID}, bson.M{"$set": bson.M{field: tujuanID}})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// gabungMasterData memindahkan seluruh referensi dari data duplikat (:id) ke data tujuan (target_id),
+// lalu menghapus data duplikat.
+// Data duplikat ditandai digabung_ke lebih dulu dan setiap langkah aman diulang, sehingga penggabungan
+// yang terhenti di tengah jalan dapat dilanjutkan dengan mengirim ulang permintaan yang sama.
+// If-Match wajib berisi ETag data duplikat; penggabungan yang dilanjutkan tidak memeriksanya lagi
+// karena versi duplikat sudah naik saat ditandai.
+func gabungMasterData(c *fiber.Ctx, m masterData) error {
+	duplikatID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID " + m.label + " tidak valid",
+		})
+	}
+
+	tujuanID, err := primitive.ObjectIDFromHex(c.FormValue("target_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID " + m.label + " tujuan tidak valid",
+		})
+	}
+
+	if duplikatID == tujuanID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Data " + m.label + " tidak bisa digabung dengan dirinya sendiri",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	col := config.Ulbimongoconn.Collection(m.collection)
+
+	// =========================
+	// AMBIL DATA DUPLIKAT & TUJUAN
+	// =========================
+	duplikat, err := col.FindOne(ctx, bson.M{"_id": duplikatID}).Raw()
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Data " + m.label + " yang akan digabung tidak ditemukan",
+		})
+	}
+
+	tujuan, err := col.FindOne(ctx, bson.M{"_id": tujuanID}).Raw()
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Data " + m.label + " tujuan tidak ditemukan",
+		})
+	}
+
+	// 🔹 Penggabungan yang sedang berjalan hanya boleh dilanjutkan ke tujuan yang sama
+	dilanjutkan := false
+	if v, err := duplikat.LookupErr("digabung_ke"); err == nil {
+		if id, ok := v.ObjectIDOK(); !ok || id != tujuanID {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Data " + m.label + " ini sedang digabung ke data lain",
+			})
+		}
+		dilanjutkan = true
+	}
+	var dup struct {
+		Versi int64 `bson:"versi"`
+	}
+	if err := bson.Unmarshal(duplikat, &dup); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca data " + m.label + " duplikat",
+		})
+	}
+	if !dilanjutkan {
+		if ok, err := cekIfMatch(c, dup.Versi); !ok {
+			return err
+		}
+	}
+	if _, err := tujuan.LookupErr("digabung_ke"); err == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Data " + m.label + " tujuan sedang digabung ke data lain",
+		})
+	}
+
+	if m.validasi != nil {
+		if errMsg := m.validasi(duplikat, tujuan); errMsg != "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": errMsg,
+			})
+		}
+	}
+
+	snapshot, err := m.snapshot(tujuan)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca data " + m.label + " tujuan",
+		})
+	}
+
+	var perbaikan []mongo.WriteModel
+	if m.validasiKoleksi != nil {
+		gagal, models, err := m.validasiKoleksi(ctx, duplikatID, tujuan)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Gagal memeriksa koleksi " + m.label + " duplikat",
+			})
+		}
+		if len(gagal) > 0 {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Sebagian koleksi atau usulan tidak sesuai dengan " + m.label + " tujuan. Perbaiki data berikut sebelum digabung.",
+				"gagal": gagal,
+			})
+		}
+		perbaikan = models
+	}
+
+	// =========================
+	// TANDAI DATA DUPLIKAT
+	// =========================
+	// Filter versi: duplikat yang diubah request lain sejak dibaca tidak ikut digabung
+	if !dilanjutkan {
+		filter := filterVersi(duplikatID, dup.Versi)
+		filter["digabung_ke"] = bson.M{"$exists": false}
+		tandai, err := col.UpdateOne(ctx, filter, tambahVersi(bson.M{"$set": bson.M{"digabung_ke": tujuanID}}))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Gagal memulai penggabungan " + m.label,
+			})
+		}
+		if tandai.MatchedCount == 0 {
+			versi, _ := versiDokumen(ctx, col, duplikatID)
+			return tolakVersiBerubah(c, versi)
+		}
+	}
+
+	lanjutkan := ". Kirim ulang permintaan yang sama untuk melanjutkan penggabungan."
+
+	// 🔹 Atribut disesuaikan sebelum koleksi dipindah (filter versi masih cocok)
+	if len(perbaikan) > 0 {
+		if _, err := config.Ulbimongoconn.Collection("koleksi").BulkWrite(ctx, perbaikan, options.BulkWrite().SetOrdered(false)); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Gagal menyesuaikan atribut koleksi" + lanjutkan,
+			})
+		}
+	}
+
+	// =========================
+	// PINDAHKAN REFERENSI KOLEKSI
+	// =========================
+	result, err := config.Ulbimongoconn.Collection("koleksi").UpdateMany(ctx,
+		bson.M{m.koleksiField + "._id": duplikatID},
+		tambahVersi(bson.M{"$set": bson.M{m.koleksiField: snapshot}}),
+	)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memindahkan koleksi ke " + m.label + " tujuan" + lanjutkan,
+		})
+	}
+
+	// 🔹 Usulan yang menunggu review ikut menunjuk ke data tujuan
+	colUsulan := config.Ulbimongoconn.Collection("perubahan_koleksi")
+	for _, field := range []string{"data", "sebelum"} {
+		if _, err := colUsulan.UpdateMany(ctx,
+			bson.M{"status": model.StatusPerubahanMenunggu, field + "." + m.koleksiField + "._id": duplikatID},
+			bson.M{"$set": bson.M{field + "." + m.koleksiField: snapshot}},
+		); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Gagal memperbarui usulan perubahan koleksi" + lanjutkan,
+			})
+		}
+	}
+
+	if m.referensiLain != nil {
+		if err := m.referensiLain(ctx, duplikatID, tujuanID, tujuan); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Gagal memindahkan referensi " + m.label + ": " + err.Error() + lanjutkan,
+			})
+		}
+	}
+
+	// =========================
+	// HAPUS DATA DUPLIKAT
+	// =========================
+	if _, err := col.DeleteOne(ctx, bson.M{"_id": duplikatID}); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menghapus data " + m.label + " duplikat" + lanjutkan,
+		})
+	}
+
+	// 🔹 Index nama unik yang gagal dibuat karena duplikat dicoba lagi setelah duplikat berkurang
+	if !config.IndexNamaSiap(m.collection) {
+		if err := config.BuatIndexNamaMasterData(ctx, m.collection); err != nil {
+			log.Printf("⚠️  Index nama_normal_unik pada %s masih belum bisa dibuat: %v", m.collection, err)
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message":            "Data " + m.label + " berhasil digabung",
+		"dihapus_id":         duplikatID,
+		"target_id":          tujuanID,
+		"koleksi_diperbarui": result.ModifiedCount,
+		"index_nama_aktif":   config.IndexNamaSiap(m.collection),
+	})
+}
+
+// GabungKategori godoc
+// @Summary      Gabung Kategori
+// @Description  Menggabungkan kategori duplikat ke kategori tujuan: semua koleksi dan sub-kategori dipindahkan ke kategori tujuan, lalu kategori duplikat dihapus.
+// @Description  Atribut koleksi dan usulan yang menunggu review diperiksa ulang terhadap skema kategori tujuan; jika ada yang tidak sesuai, penggabungan ditolak (409) beserta daftarnya.
+// @Description  Penggabungan yang gagal di tengah jalan dapat dilanjutkan dengan mengirim ulang permintaan yang sama.
+// @Tags         Data Kategori
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string  true  "ID kategori duplikat (akan dihapus)"
+// @Param        target_id  formData  string  true  "ID kategori tujuan (dipertahankan)"
+// @Param        If-Match   header    string  true  "ETag data duplikat dari GET terakhir (tidak diperiksa saat melanjutkan penggabungan)"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Failure      412  {object}  map[string]interface{}  "Data duplikat sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428  {object}  map[string]interface{}  "Header If-Match belum diisi"
+// @Router       /kategori/{id}/gabung [post]
+func GabungKategori(c *fiber.Ctx) error {
+	return gabungMasterData(c, masterKategori)
+}
+
+// GabungGudang godoc
+// @Summary      Gabung Gudang
+// @Description  Menggabungkan gudang duplikat ke gudang tujuan: semua koleksi, data sensor, dan ambang batas dipindahkan ke gudang tujuan, lalu gudang duplikat dihapus
+// @Tags         Data Tempat Penyimpanan (Gudang)
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string  true  "ID gudang duplikat (akan dihapus)"
+// @Param        target_id  formData  string  true  "ID gudang tujuan (dipertahankan)"
+// @Param        If-Match   header    string  true  "ETag data duplikat dari GET terakhir (tidak diperiksa saat melanjutkan penggabungan)"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      412  {object}  map[string]interface{}  "Data duplikat sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428  {object}  map[string]interface{}  "Header If-Match belum diisi"
+// @Router       /gudang/{id}/gabung [post]
+func GabungGudang(c *fiber.Ctx) error {
+	return gabungMasterData(c, masterGudang)
+}
+
+// GabungRak godoc
+// @Summary      Gabung Rak
+// @Description  Menggabungkan rak duplikat ke rak tujuan: semua koleksi, data sensor, dan ambang batas dipindahkan ke rak tujuan, lalu rak duplikat dihapus
+// @Tags         Data Tempat Penyimpanan (Rak)
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string  true  "ID rak duplikat (akan dihapus)"
+// @Param        target_id  formData  string  true  "ID rak tujuan (dipertahankan)"
+// @Param        If-Match   header    string  true  "ETag data duplikat dari GET terakhir (tidak diperiksa saat melanjutkan penggabungan)"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      412  {object}  map[string]interface{}  "Data duplikat sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428  {object}  map[string]interface{}  "Header If-Match belum diisi"
+// @Router       /rak/{id}/gabung [post]
+func GabungRak(c *fiber.Ctx) error {
+	return gabungMasterData(c, masterRak)
+}
+
+// GabungTahap godoc
+// @Summary      Gabung Tahap
+// @Description  Menggabungkan tahap duplikat ke tahap tujuan: semua koleksi dipindahkan ke tahap tujuan, lalu tahap duplikat dihapus
+// @Tags         Data Tempat Penyimpanan (Tahap)
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string  true  "ID tahap duplikat (akan dihapus)"
+// @Param        target_id  formData  string  true  "ID tahap tujuan (dipertahankan)"
+// @Param        If-Match   header    string  true  "ETag data duplikat dari GET terakhir (tidak diperiksa saat melanjutkan penggabungan)"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      412  {object}  map[string]interface{}  "Data duplikat sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428  {object}  map[string]interface{}  "Header If-Match belum diisi"
+// @Router       /tahap/{id}/gabung [post]
+func GabungTahap(c *fiber.Ctx) error {
+	return gabungMasterData(c, masterTahap)
+}
+
+// GetDuplikatMasterData godoc
+// @Summary      Get Duplikat Master Data
+// @Description  Mencari kategori, gudang, rak, dan tahap yang namanya sama jika huruf besar/kecil dan spasi diabaikan (kandidat untuk digabung)
+// @Tags         Master Data
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Router       /duplikat [get]
+func GetDuplikatMasterData(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	type item struct {
+		ID   primitive.ObjectID `json:"id"`
+		Nama string             `json:"nama"`
+	}
+	type grup struct {
+		NamaNormal string `json:"nama_normal"`
+		Data       []item `json:"data"`
+	}
+
+	hasil := fiber.Map{}
+	for _, m := range []masterData{masterKategori, masterGudang, masterRak, masterTahap} {
+		cursor, err := config.Ulbimongoconn.Collection(m.collection).Find(ctx, bson.M{},
+			options.Find().SetProjection(bson.M{m.namaField: 1}))
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"message": "Gagal mengambil data " + m.label,
+				"error":   err.Error(),
+			})
+		}
+
+		var docs []bson.M
+		if err := cursor.All(ctx, &docs); err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"message": "Gagal decode data " + m.label,
+				"error":   err.Error(),
+			})
+		}
+
+		kelompok := map[string][]item{}
+		for _, d := range docs {
+			id, _ := d["_id"].(primitive.ObjectID)
+			nama, _ := d[m.namaField].(string)
+			key := model.NormalisasiNama(nama)
+			kelompok[key] = append(kelompok[key], item{ID: id, Nama: nama})
+		}
+
+		duplikat := []grup{}
+		for key, items := range kelompok {
+			if len(items) > 1 {
+				duplikat = append(duplikat, grup{NamaNormal: key, Data: items})
+			}
+		}
+		sort.Slice(duplikat, func(i, j int) bool {
+			return duplikat[i].NamaNormal < duplikat[j].NamaNormal
+		})
+
+		hasil[m.label] = duplikat
+
+		// 🔹 Tidak ada duplikat lagi → index nama unik yang sempat gagal dicoba lagi
+		if len(duplikat) == 0 && !config.IndexNamaSiap(m.collection) {
+			config.BuatIndexNamaMasterData(ctx, m.collection)
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message":     "Berhasil mengambil data duplikat",
+		"data":        hasil,
+		"index_gagal": config.IndexNamaGagal(),
+	})
+}
@@ -0,0 +1,225 @@
+package controller
+
+import (
+	"be-internship/model"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/disintegration/imaging"
+	"github.com/gofiber/fiber/v2"
+	_ "golang.org/x/image/webp" // decoder WebP untuk image.Decode
+)
+
+// Batas default upload gambar; ukuran file bisa diubah lewat env MEDIA_MAX_MB
+const (
+	defaultMaksUkuranMB = 10
+	maksPikselGambar    = 60_000_000 // ±60 megapiksel, mencegah decompression bomb
+)
+
+// mimeGambarValid berisi tipe file yang diterima sebagai media koleksi
+var mimeGambarValid = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// mimeFileValid berisi tipe file selain gambar di atas yang diterima sebagai lampiran.
+// File ini disimpan apa adanya (tanpa encode ulang dan tanpa rendisi), beserta ekstensinya.
+var mimeFileValid = map[string]string{
+	"application/pdf": ".pdf",
+	"image/tiff":      ".tif",
+	"image/bmp":       ".bmp",
+	"text/plain":      ".txt",
+	"application/zip": ".zip", // termasuk dokumen office (docx / xlsx)
+}
+
+// ukuranRendisi adalah sisi terpanjang (px) untuk setiap rendisi
+var ukuranRendisi = []struct {
+	Nama string
+	Maks int
+}{
+	{model.RendisiThumbnail, 200},
+	{model.RendisiMedium, 800},
+	{model.RendisiLarge, 1600},
+}
+
+// errMedia adalah error validasi upload yang dikembalikan ke client dengan status tertentu
+type errMedia struct {
+	status int
+	pesan  string
+}
+
+func (e *errMedia) Error() string { return e.pesan }
+
+// statusErrorMedia menentukan status HTTP untuk error dari proses upload media
+func statusErrorMedia(err error) int {
+	var e *errMedia
+	if errors.As(err, &e) {
+		return e.status
+	}
+	return fiber.StatusInternalServerError
+}
+
+// maksUkuranMedia membaca batas ukuran file (byte) dari env MEDIA_MAX_MB
+func maksUkuranMedia() int64 {
+	mb, err := strconv.Atoi(os.Getenv("MEDIA_MAX_MB"))
+	if err != nil || mb <= 0 {
+		mb = defaultMaksUkuranMB
+	}
+	return int64(mb) << 20
+}
+
+// hasilGambar adalah gambar yang sudah divalidasi dan dibersihkan beserta rendisinya
+type hasilGambar struct {
+	Data     []byte // gambar asli tanpa metadata EXIF
+	MIME     string
+	Ekstensi string
+	Lebar    int
+	Tinggi   int
+	Rendisi  []rendisiGambar
+}
+
+type rendisiGambar struct {
+	Nama   string
+	Lebar  int
+	Tinggi int
+	JPEG   []byte
+	WebP   []byte
+}
+
+// deteksiMIME menentukan tipe file dari isinya, bukan dari nama atau header yang dikirim client.
+// TIFF dikenali dari signature-nya karena tidak dideteksi http.DetectContentType.
+func deteksiMIME(data []byte) string {
+	if bytes.HasPrefix(data, []byte("II*\x00")) || bytes.HasPrefix(data, []byte("MM\x00*")) {
+		return "image/tiff"
+	}
+	mime := http.DetectContentType(data)
+	if i := strings.Index(mime, ";"); i >= 0 {
+		mime = mime[:i]
+	}
+	return mime
+}
+
+// mediaGambar menandai media yang bisa ditampilkan sebagai gambar (IIIF / viewer).
+// Media lama tanpa MIME selalu berupa gambar.
+func mediaGambar(mime string) bool {
+	return mime == "" || strings.HasPrefix(mime, "image/")
+}
+
+// prosesMedia memvalidasi file upload. Gambar (JPEG / PNG / GIF / WebP) diproses lewat prosesGambar;
+// jika hanyaGambar false, lampiran lain (PDF, TIFF, dsb.) disimpan tanpa diubah.
+func prosesMedia(data []byte, hanyaGambar bool) (*hasilGambar, error) {
+	if int64(len(data)) > maksUkuranMedia() {
+		return nil, &errMedia{fiber.StatusRequestEntityTooLarge, fmt.Sprintf("Ukuran file melebihi batas %d MB", maksUkuranMedia()>>20)}
+	}
+
+	mime := deteksiMIME(data)
+	if mimeGambarValid[mime] {
+		return prosesGambar(data, mime)
+	}
+
+	ekstensi, ok := mimeFileValid[mime]
+	if hanyaGambar || !ok {
+		pesan := fmt.Sprintf("File bukan gambar yang didukung (%s). Gunakan JPEG, PNG, GIF, atau WebP.", mime)
+		if !hanyaGambar {
+			pesan = fmt.Sprintf("Tipe file tidak didukung (%s). Gunakan gambar (JPEG, PNG, GIF, WebP), PDF, TIFF, atau arsip ZIP.", mime)
+		}
+		return nil, &errMedia{fiber.StatusUnsupportedMediaType, pesan}
+	}
+
+	hasil := &hasilGambar{Data: data, MIME: mime, Ekstensi: ekstensi}
+	// dimensi dicatat jika file berupa gambar yang bisa dibaca (TIFF / BMP)
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		hasil.Lebar, hasil.Tinggi = cfg.Width, cfg.Height
+	}
+	return hasil, nil
+}
+
+// prosesGambar memvalidasi file gambar, membuang metadata EXIF (termasuk lokasi GPS)
+// dengan meng-encode ulang, lalu membuat rendisi thumbnail / medium / large dalam JPEG & WebP.
+// Orientasi dari EXIF diterapkan dulu supaya gambar tidak terbalik setelah metadata dibuang.
+// GIF disimpan apa adanya agar animasinya tidak hilang (GIF tidak membawa EXIF); rendisinya dari frame pertama.
+func prosesGambar(data []byte, mime string) (*hasilGambar, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, &errMedia{fiber.StatusBadRequest, "File gambar rusak atau tidak bisa dibaca"}
+	}
+	if cfg.Width*cfg.Height > maksPikselGambar {
+		return nil, &errMedia{fiber.StatusRequestEntityTooLarge, fmt.Sprintf("Resolusi gambar terlalu besar (%dx%d)", cfg.Width, cfg.Height)}
+	}
+
+	img, err := imaging.Decode(bytes.NewReader(data), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, &errMedia{fiber.StatusBadRequest, "File gambar rusak atau tidak bisa dibaca"}
+	}
+
+	hasil := &hasilGambar{
+		Lebar:  img.Bounds().Dx(),
+		Tinggi: img.Bounds().Dy(),
+	}
+
+	// JPEG tetap JPEG, GIF tidak diubah, format lain (PNG / WebP) disimpan sebagai PNG supaya transparansi tidak hilang
+	var buf bytes.Buffer
+	switch mime {
+	case "image/jpeg":
+		err = imaging.Encode(&buf, img, imaging.JPEG, imaging.JPEGQuality(92))
+		hasil.MIME, hasil.Ekstensi = "image/jpeg", ".jpg"
+	case "image/gif":
+		buf.Write(data)
+		hasil.MIME, hasil.Ekstensi = "image/gif", ".gif"
+	default:
+		err = imaging.Encode(&buf, img, imaging.PNG)
+		hasil.MIME, hasil.Ekstensi = "image/png", ".png"
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal encode gambar: %w", err)
+	}
+	hasil.Data = buf.Bytes()
+
+	for _, u := range ukuranRendisi {
+		r, err := buatRendisi(img, u.Nama, u.Maks)
+		if err != nil {
+			return nil, err
+		}
+		hasil.Rendisi = append(hasil.Rendisi, r)
+	}
+
+	return hasil, nil
+}
+
+// buatRendisi memperkecil gambar (tidak pernah memperbesar) lalu meng-encode ke JPEG dan WebP
+func buatRendisi(img image.Image, nama string, maks int) (rendisiGambar, error) {
+	kecil := imaging.Fit(img, maks, maks, imaging.Lanczos)
+	b := kecil.Bounds()
+
+	// JPEG tidak mendukung transparansi, jadi latar transparan dibuat putih
+	latar := imaging.New(b.Dx(), b.Dy(), color.White)
+	latar = imaging.Overlay(latar, kecil, image.Pt(0, 0), 1.0)
+
+	var jpegBuf bytes.Buffer
+	if err := imaging.Encode(&jpegBuf, latar, imaging.JPEG, imaging.JPEGQuality(82)); err != nil {
+		return rendisiGambar{}, fmt.Errorf("gagal membuat rendisi %s: %w", nama, err)
+	}
+
+	var webpBuf bytes.Buffer
+	if err := nativewebp.Encode(&webpBuf, kecil, nil); err != nil {
+		return rendisiGambar{}, fmt.Errorf("gagal membuat rendisi %s (webp): %w", nama, err)
+	}
+
+	return rendisiGambar{
+		Nama:   nama,
+		Lebar:  b.Dx(),
+		Tinggi: b.Dy(),
+		JPEG:   jpegBuf.Bytes(),
+		WebP:   webpBuf.Bytes(),
+	}, nil
+}
@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/png"
+	"testing"
+)
+
+func gambarUji(t *testing.T, w, h int) image.Image {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		img.Set(x, 0, color.RGBA{R: 200, A: 255})
+	}
+	return img
+}
+
+func TestDeteksiMIME(t *testing.T) {
+	tests := []struct {
+		nama string
+		data []byte
+		want string
+	}{
+		{"pdf", []byte("%PDF-1.7\n..."), "application/pdf"},
+		{"tiff little endian", []byte("II*\x00\x08\x00\x00\x00"), "image/tiff"},
+		{"tiff big endian", []byte("MM\x00*\x00\x00\x00\x08"), "image/tiff"},
+		{"teks tanpa parameter charset", []byte("catatan konservasi"), "text/plain"},
+		{"gif", []byte("GIF89a......"), "image/gif"},
+	}
+
+	for _, tt := range tests {
+		if got := deteksiMIME(tt.data); got != tt.want {
+			t.Errorf("%s: deteksiMIME() = %q, want %q", tt.nama, got, tt.want)
+		}
+	}
+}
+
+func TestProsesMediaLampiranTidakDiubah(t *testing.T) {
+	pdf := []byte("%PDF-1.4\n%âãÏÓ\n1 0 obj\n<<>>\nendobj\n")
+
+	hasil, err := prosesMedia(pdf, false)
+	if err != nil {
+		t.Fatalf("prosesMedia(pdf) error: %v", err)
+	}
+	if !bytes.Equal(hasil.Data, pdf) || hasil.MIME != "application/pdf" || hasil.Ekstensi != ".pdf" {
+		t.Errorf("pdf diubah: mime=%q ekstensi=%q sama=%v", hasil.MIME, hasil.Ekstensi, bytes.Equal(hasil.Data, pdf))
+	}
+	if len(hasil.Rendisi) != 0 {
+		t.Errorf("pdf tidak boleh punya rendisi, dapat %d", len(hasil.Rendisi))
+	}
+
+	// upload yang hanya menerima gambar menolak PDF
+	if _, err := prosesMedia(pdf, true); statusErrorMedia(err) != 415 {
+		t.Errorf("prosesMedia(pdf, hanyaGambar) status = %d, want 415", statusErrorMedia(err))
+	}
+}
+
+func TestProsesMediaGIFAnimasiDipertahankan(t *testing.T) {
+	anim := &gif.GIF{}
+	for i := 0; i < 3; i++ {
+		frame := image.NewPaletted(image.Rect(0, 0, 40, 30), palette.Plan9)
+		frame.SetColorIndex(i, i, uint8(i+1))
+		anim.Image = append(anim.Image, frame)
+		anim.Delay = append(anim.Delay, 10)
+	}
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, anim); err != nil {
+		t.Fatal(err)
+	}
+
+	hasil, err := prosesMedia(buf.Bytes(), true)
+	if err != nil {
+		t.Fatalf("prosesMedia(gif) error: %v", err)
+	}
+	if hasil.MIME != "image/gif" || !bytes.Equal(hasil.Data, buf.Bytes()) {
+		t.Fatalf("gif diubah menjadi %s", hasil.MIME)
+	}
+	g, err := gif.DecodeAll(bytes.NewReader(hasil.Data))
+	if err != nil || len(g.Image) != 3 {
+		t.Errorf("animasi gif hilang: frame=%d err=%v", len(g.Image), err)
+	}
+	if len(hasil.Rendisi) != len(ukuranRendisi) {
+		t.Errorf("rendisi gif = %d, want %d", len(hasil.Rendisi), len(ukuranRendisi))
+	}
+}
+
+func TestProsesMediaPNGDiencodeUlang(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, gambarUji(t, 50, 20)); err != nil {
+		t.Fatal(err)
+	}
+
+	hasil, err := prosesMedia(buf.Bytes(), false)
+	if err != nil {
+		t.Fatalf("prosesMedia(png) error: %v", err)
+	}
+	if hasil.MIME != "image/png" || hasil.Lebar != 50 || hasil.Tinggi != 20 {
+		t.Errorf("png: mime=%q ukuran=%dx%d", hasil.MIME, hasil.Lebar, hasil.Tinggi)
+	}
+	if len(hasil.Rendisi) != len(ukuranRendisi) {
+		t.Errorf("rendisi png = %d, want %d", len(hasil.Rendisi), len(ukuranRendisi))
+	}
+}
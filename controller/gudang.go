@@ -5,11 +5,13 @@ import (
 	"be-internship/model"
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // InsertGudang godoc
@@ -24,7 +26,7 @@ import (
 // @Router       /gudang [post]
 func InsertGudang(c *fiber.Ctx) error {
 	// 🔹 Ambil value dari form-data
-	namaGudang := c.FormValue("nama_gudang")
+	namaGudang := strings.TrimSpace(c.FormValue("nama_gudang"))
 	// 🔹 Validasi field wajib
 	if namaGudang == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -40,7 +42,7 @@ func InsertGudang(c *fiber.Ctx) error {
 	// 🔹 Cek apakah kategori sudah ada berdasarkan nama
 	var existing model.Gudang
 	err := gudangCollection.FindOne(ctx, bson.M{
-		"nama_gudang": namaGudang,
+		"nama_normal": model.NormalisasiNama(namaGudang),
 	}).Decode(&existing)
 
 	if err == nil {
@@ -53,11 +55,18 @@ func InsertGudang(c *fiber.Ctx) error {
 	newGudang := model.Gudang{
 		ID:         primitive.NewObjectID(),
 		NamaGudang: namaGudang,
+		NamaNormal: model.NormalisasiNama(namaGudang),
+		Versi:      1,
 	}
 
 	// 🔹 Insert ke database
 	_, err = gudangCollection.InsertOne(ctx, newGudang)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Data Gudang sudah terdaftar",
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Gagal menyimpan data gudang ke database",
 		})
@@ -80,6 +89,9 @@ func InsertGudang(c *fiber.Ctx) error {
 // @Param        id           path      string  true  "ID Gudang"
 // @Param        nama_gudang  formData  string  true  "Nama Gudang"
 // @Success      200 {object} map[string]interface{} "Data gudang berhasil diperbarui"
+// @Param        If-Match header string true "ETag dari GET terakhir"
+// @Failure      412 {object} map[string]interface{} "Data sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428 {object} map[string]interface{} "Header If-Match belum diisi"
 // @Router       /gudang/{id} [put]
 func UpdateGudangByID(c *fiber.Ctx) error {
 	// =========================
@@ -96,7 +108,7 @@ func UpdateGudangByID(c *fiber.Ctx) error {
 	// =========================
 	// AMBIL FORM DATA
 	// =========================
-	namaGudang := c.FormValue("nama_gudang")
+	namaGudang := strings.TrimSpace(c.FormValue("nama_gudang"))
 	if namaGudang == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Nama gudang tidak boleh kosong",
@@ -118,6 +130,23 @@ func UpdateGudangByID(c *fiber.Ctx) error {
 			"error": "Data gudang tidak ditemukan",
 		})
 	}
+	if ok, err := cekIfMatch(c, existing.Versi); !ok {
+		return err
+	}
+
+	// =========================
+	// CEK NAMA SUDAH DIPAKAI DATA LAIN
+	// =========================
+	var checkName model.Gudang
+	err = gudangCollection.FindOne(ctx, bson.M{
+		"nama_normal": model.NormalisasiNama(namaGudang),
+		"_id":         bson.M{"$ne": objID},
+	}).Decode(&checkName)
+	if err == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Nama gudang sudah digunakan data lain",
+		})
+	}
 
 	// =========================
 	// PROSES UPDATE
@@ -125,15 +154,26 @@ func UpdateGudangByID(c *fiber.Ctx) error {
 	update := bson.M{
 		"$set": bson.M{
 			"nama_gudang": namaGudang,
+			"nama_normal": model.NormalisasiNama(namaGudang),
 		},
 	}
 
-	_, err = gudangCollection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	res, err := gudangCollection.UpdateOne(ctx, filterVersi(objID, existing.Versi), tambahVersi(update))
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Nama gudang sudah digunakan data lain",
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Gagal memperbarui data gudang",
 		})
 	}
+	if res.MatchedCount == 0 {
+		versi, _ := versiDokumen(ctx, gudangCollection, objID)
+		return tolakVersiBerubah(c, versi)
+	}
+	c.Set(fiber.HeaderETag, etagVersi(existing.Versi+1))
 
 	// =========================
 	// RESPONSE
@@ -193,6 +233,9 @@ func GetAllGudang(c *fiber.Ctx) error {
 // @Produce      json
 // @Param        id   path      string  true  "ID Gudang"
 // @Success      200  {object}  model.Gudang  "Data gudang berhasil ditemukan"
+// @Param        If-None-Match header string false "ETag dari respons sebelumnya, 304 jika data belum berubah"
+// @Header       200 {string} ETag "Versi data saat ini"
+// @Success      304 {string} string "Data belum berubah"
 // @Router       /gudang/{id} [get]
 func GetGudangByID(c *fiber.Ctx) error {
 	// Ambil parameter ID dari URL
@@ -222,7 +265,7 @@ func GetGudangByID(c *fiber.Ctx) error {
 	}
 
 	// Return hasil
-	return c.Status(fiber.StatusOK).JSON(gudang)
+	return kirimDenganETag(c, gudang.Versi, gudang)
 }
 
 // DeleteGudangByID godoc
@@ -234,6 +277,9 @@ func GetGudangByID(c *fiber.Ctx) error {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "ID Gudang"
 // @Success      200  {object}  map[string]string "Data gudang berhasil dihapus"
+// @Param        If-Match header string true "ETag dari GET terakhir"
+// @Failure      412 {object} map[string]interface{} "Data sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428 {object} map[string]interface{} "Header If-Match belum diisi"
 // @Router       /gudang/{id} [delete]
 func DeleteGudangByID(c *fiber.Ctx) error {
 	// Ambil ID dari parameter
@@ -250,8 +296,18 @@ func DeleteGudangByID(c *fiber.Ctx) error {
 
 	gudangCollection := config.Ulbimongoconn.Collection("gudang")
 
-	// Hapus kategori
-	result, err := gudangCollection.DeleteOne(ctx, bson.M{"_id": objID})
+	// 🔹 Hanya hapus jika versi sesuai header If-Match
+	versi, err := versiDokumen(ctx, gudangCollection, objID)
+	if err == mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Data gudang tidak ditemukan",
+		})
+	}
+	if ok, err := cekIfMatch(c, versi); !ok {
+		return err
+	}
+
+	result, err := gudangCollection.DeleteOne(ctx, filterVersi(objID, versi))
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Gagal menghapus data gudang",
@@ -259,9 +315,8 @@ func DeleteGudangByID(c *fiber.Ctx) error {
 	}
 
 	if result.DeletedCount == 0 {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "Data gudang tidak ditemukan",
-		})
+		versi, _ := versiDokumen(ctx, gudangCollection, objID)
+		return tolakVersiBerubah(c, versi)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
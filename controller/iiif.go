@@ -0,0 +1,607 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"be-internship/storage"
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/disintegration/imaging"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// =============================================================
+// 🖼️ IIIF Image API 3.0 & Presentation API 3.0
+// Identifier gambar IIIF = ID media koleksi.
+// =============================================================
+
+const (
+	iiifUkuranTile   = 512
+	iiifMaksArea     = 25_000_000 // batas luas gambar hasil (px), mencegah permintaan upscale berlebihan
+	iiifMaksCache    = 4          // jumlah gambar terdekode yang disimpan di memori
+	iiifContextImage = "http://iiif.io/api/image/3/context.json"
+	iiifContextPres  = "http://iiif.io/api/presentation/3/context.json"
+)
+
+// formatIIIF berisi format output yang didukung beserta content type-nya
+var formatIIIF = map[string]string{
+	"jpg":  "image/jpeg",
+	"png":  "image/png",
+	"webp": "image/webp",
+}
+
+// cacheGambarIIIF menyimpan beberapa gambar terakhir yang sudah didekode,
+// karena viewer meminta banyak tile dari gambar yang sama secara berurutan.
+var cacheGambarIIIF = struct {
+	sync.Mutex
+	urutan []string
+	data   map[string]image.Image
+}{data: map[string]image.Image{}}
+
+// ambilMediaIIIF mencari media berdasarkan ID di seluruh koleksi
+func ambilMediaIIIF(ctx context.Context, idParam string) (model.Media, int, string) {
+	mediaID, err := primitive.ObjectIDFromHex(idParam)
+	if err != nil {
+		return model.Media{}, fiber.StatusBadRequest, "Identifier gambar tidak valid"
+	}
+
+	var koleksi model.Koleksi
+	err = config.Ulbimongoconn.Collection("koleksi").FindOne(ctx,
+		bson.M{"media._id": mediaID},
+		options.FindOne().SetProjection(bson.M{"media.$": 1}),
+	).Decode(&koleksi)
+	if err != nil || len(koleksi.Media) == 0 || !mediaGambar(koleksi.Media[0].MIME) {
+		return model.Media{}, fiber.StatusNotFound, "Gambar tidak ditemukan"
+	}
+
+	return koleksi.Media[0], 0, ""
+}
+
+// muatGambarIIIF membaca & mendekode gambar asli dari storage (atau dari URL untuk data lama)
+func muatGambarIIIF(ctx context.Context, media model.Media) (image.Image, error) {
+	cacheKey := media.ID.Hex() + media.SHA256
+
+	cacheGambarIIIF.Lock()
+	if img, ok := cacheGambarIIIF.data[cacheKey]; ok {
+		cacheGambarIIIF.Unlock()
+		return img, nil
+	}
+	cacheGambarIIIF.Unlock()
+
+	var data []byte
+	var err error
+	if media.Key != "" {
+		data, err = storage.Aktif().Get(ctx, media.Key)
+	} else {
+		data, err = unduhURL(ctx, media.URL)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := imaging.Decode(bytes.NewReader(data), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, fmt.Errorf("gagal mendekode gambar: %w", err)
+	}
+
+	cacheGambarIIIF.Lock()
+	defer cacheGambarIIIF.Unlock()
+	if _, ok := cacheGambarIIIF.data[cacheKey]; !ok {
+		cacheGambarIIIF.urutan = append(cacheGambarIIIF.urutan, cacheKey)
+		if len(cacheGambarIIIF.urutan) > iiifMaksCache {
+			delete(cacheGambarIIIF.data, cacheGambarIIIF.urutan[0])
+			cacheGambarIIIF.urutan = cacheGambarIIIF.urutan[1:]
+		}
+	}
+	cacheGambarIIIF.data[cacheKey] = img
+
+	return img, nil
+}
+
+// unduhURL mengunduh file dari URL publik (dipakai untuk foto lama yang tidak punya key storage)
+func unduhURL(ctx context.Context, alamat string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, alamat, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, storage.ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gagal mengunduh gambar (%d)", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maksUkuranMedia()+1))
+}
+
+// ukuranMediaIIIF mengembalikan lebar & tinggi gambar asli; data lama tanpa ukuran dibaca dari file
+func ukuranMediaIIIF(ctx context.Context, media model.Media) (int, int, error) {
+	if media.Lebar > 0 && media.Tinggi > 0 {
+		return media.Lebar, media.Tinggi, nil
+	}
+	img, err := muatGambarIIIF(ctx, media)
+	if err != nil {
+		return 0, 0, err
+	}
+	return img.Bounds().Dx(), img.Bounds().Dy(), nil
+}
+
+// parseAngkaIIIF membaca daftar angka yang dipisah koma
+func parseAngkaIIIF(s string, n int) ([]float64, bool) {
+	parts := strings.Split(s, ",")
+	if len(parts) != n {
+		return nil, false
+	}
+	hasil := make([]float64, n)
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(p, 64)
+		if err != nil || v < 0 {
+			return nil, false
+		}
+		hasil[i] = v
+	}
+	return hasil, true
+}
+
+// regionIIIF menerjemahkan parameter region (full, square, x,y,w,h, pct:x,y,w,h) menjadi kotak piksel
+func regionIIIF(param string, w, h int) (image.Rectangle, string) {
+	switch {
+	case param == "full":
+		return image.Rect(0, 0, w, h), ""
+	case param == "square":
+		sisi := min(w, h)
+		x, y := (w-sisi)/2, (h-sisi)/2
+		return image.Rect(x, y, x+sisi, y+sisi), ""
+	}
+
+	pct := strings.HasPrefix(param, "pct:")
+	v, ok := parseAngkaIIIF(strings.TrimPrefix(param, "pct:"), 4)
+	if !ok {
+		return image.Rectangle{}, "Parameter region tidak valid"
+	}
+	if pct {
+		v[0], v[2] = v[0]*float64(w)/100, v[2]*float64(w)/100
+		v[1], v[3] = v[1]*float64(h)/100, v[3]*float64(h)/100
+	}
+
+	r := image.Rect(int(v[0]), int(v[1]), int(math.Round(v[0]+v[2])), int(math.Round(v[1]+v[3])))
+	r = r.Intersect(image.Rect(0, 0, w, h))
+	if r.Empty() {
+		return image.Rectangle{}, "Region berada di luar gambar"
+	}
+	return r, ""
+}
+
+// sizeIIIF menghitung ukuran hasil dari parameter size (max, w,, ,h, pct:n, w,h, !w,h; awalan ^ untuk upscale)
+func sizeIIIF(param string, rw, rh int) (int, int, string) {
+	upscale := strings.HasPrefix(param, "^")
+	param = strings.TrimPrefix(param, "^")
+
+	var w, h float64
+	fw, fh := float64(rw), float64(rh)
+
+	switch {
+	case param == "max":
+		w, h = fw, fh
+		if area := w * h; area > iiifMaksArea {
+			// dibulatkan ke bawah agar hasilnya tidak melewati batas luas setelah pembulatan
+			skala := math.Sqrt(iiifMaksArea / area)
+			w, h = math.Floor(w*skala), math.Floor(h*skala)
+		}
+	case strings.HasPrefix(param, "pct:"):
+		n, err := strconv.ParseFloat(strings.TrimPrefix(param, "pct:"), 64)
+		if err != nil || n <= 0 {
+			return 0, 0, "Parameter size tidak valid"
+		}
+		w, h = fw*n/100, fh*n/100
+	case strings.HasPrefix(param, "!"):
+		v, ok := parseAngkaIIIF(strings.TrimPrefix(param, "!"), 2)
+		if !ok || v[0] == 0 || v[1] == 0 {
+			return 0, 0, "Parameter size tidak valid"
+		}
+		skala := math.Min(v[0]/fw, v[1]/fh)
+		w, h = fw*skala, fh*skala
+	default:
+		parts := strings.Split(param, ",")
+		if len(parts) != 2 || (parts[0] == "" && parts[1] == "") {
+			return 0, 0, "Parameter size tidak valid"
+		}
+		var err error
+		if parts[0] != "" {
+			if w, err = strconv.ParseFloat(parts[0], 64); err != nil || w <= 0 {
+				return 0, 0, "Parameter size tidak valid"
+			}
+		}
+		if parts[1] != "" {
+			if h, err = strconv.ParseFloat(parts[1], 64); err != nil || h <= 0 {
+				return 0, 0, "Parameter size tidak valid"
+			}
+		}
+		if parts[0] == "" {
+			w = fw * h / fh
+		}
+		if parts[1] == "" {
+			h = fh * w / fw
+		}
+	}
+
+	ow, oh := max(1, int(math.Round(w))), max(1, int(math.Round(h)))
+	if !upscale && (ow > rw || oh > rh) {
+		return 0, 0, "Ukuran melebihi region, gunakan awalan ^ untuk memperbesar"
+	}
+	if ow*oh > iiifMaksArea {
+		return 0, 0, "Ukuran hasil terlalu besar"
+	}
+	return ow, oh, ""
+}
+
+// rotasiIIIF menerapkan mirroring (awalan !) lalu rotasi searah jarum jam
+func rotasiIIIF(img image.Image, param string, latar color.Color) (image.Image, string) {
+	if strings.HasPrefix(param, "!") {
+		img = imaging.FlipH(img)
+		param = strings.TrimPrefix(param, "!")
+	}
+
+	derajat, err := strconv.ParseFloat(param, 64)
+	if err != nil || derajat < 0 || derajat > 360 {
+		return nil, "Parameter rotation tidak valid"
+	}
+	derajat = math.Mod(derajat, 360)
+
+	// imaging memutar berlawanan arah jarum jam, IIIF searah jarum jam
+	switch derajat {
+	case 0:
+		return img, ""
+	case 90:
+		return imaging.Rotate270(img), ""
+	case 180:
+		return imaging.Rotate180(img), ""
+	case 270:
+		return imaging.Rotate90(img), ""
+	}
+	return imaging.Rotate(img, 360-derajat, latar), ""
+}
+
+// kualitasIIIF menerapkan kualitas default / color / gray / bitonal
+func kualitasIIIF(img image.Image, kualitas string) (image.Image, string) {
+	switch kualitas {
+	case "default", "color":
+		return img, ""
+	case "gray":
+		return imaging.Grayscale(img), ""
+	case "bitonal":
+		abu := imaging.Grayscale(img)
+		return imaging.AdjustFunc(abu, func(c color.NRGBA) color.NRGBA {
+			v := uint8(0)
+			if c.R >= 128 {
+				v = 255
+			}
+			return color.NRGBA{v, v, v, c.A}
+		}), ""
+	}
+	return nil, "Parameter quality tidak valid"
+}
+
+// paramPath membaca parameter path yang bisa saja di-encode client (misalnya ^ menjadi %5E, / menjadi %2F)
+func paramPath(c *fiber.Ctx, nama string) string {
+	if v, err := url.PathUnescape(c.Params(nama)); err == nil {
+		return v
+	}
+	return c.Params(nama)
+}
+
+// GetIIIFInfo godoc
+// @Summary      IIIF Image Information
+// @Description  Dokumen info.json IIIF Image API 3.0 untuk satu media koleksi (dipakai Mirador / Universal Viewer)
+// @Tags         IIIF
+// @Produce      json
+// @Param        id   path  string  true  "ID media"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /iiif/image/{id}/info.json [get]
+func GetIIIFInfo(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	media, status, errMsg := ambilMediaIIIF(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": errMsg})
+	}
+
+	w, h, err := ukuranMediaIIIF(ctx, media)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca gambar: " + err.Error(),
+		})
+	}
+
+	scaleFactors := []int{1}
+	for f := 2; max(w, h)/f >= iiifUkuranTile/2; f *= 2 {
+		scaleFactors = append(scaleFactors, f)
+	}
+
+	sizes := []fiber.Map{}
+	for _, u := range ukuranRendisi {
+		if r, ok := media.Rendisi[u.Nama]; ok {
+			sizes = append(sizes, fiber.Map{"type": "Size", "width": r.Lebar, "height": r.Tinggi})
+		}
+	}
+
+	c.Set(fiber.HeaderCacheControl, "public, max-age=86400")
+	return c.JSON(fiber.Map{
+		"@context":       iiifContextImage,
+		"id":             c.BaseURL() + "/api/iiif/image/" + media.ID.Hex(),
+		"type":           "ImageService3",
+		"protocol":       "http://iiif.io/api/image",
+		"profile":        "level2",
+		"width":          w,
+		"height":         h,
+		"maxArea":        iiifMaksArea,
+		"sizes":          sizes,
+		"tiles":          []fiber.Map{{"width": iiifUkuranTile, "scaleFactors": scaleFactors}},
+		"extraFormats":   []string{"webp"},
+		"extraQualities": []string{"color", "gray", "bitonal"},
+		"extraFeatures":  []string{"mirroring", "rotationArbitrary", "sizeUpscaling"},
+	}, `application/ld+json;profile="`+iiifContextImage+`"`)
+}
+
+// RedirectIIIFInfo mengarahkan URI dasar gambar ke info.json sesuai spesifikasi IIIF
+func RedirectIIIFInfo(c *fiber.Ctx) error {
+	return c.Redirect(c.BaseURL()+"/api/iiif/image/"+c.Params("id")+"/info.json", fiber.StatusSeeOther)
+}
+
+// GetIIIFImage godoc
+// @Summary      IIIF Image Request
+// @Description  Mengambil potongan / ukuran / rotasi gambar sesuai IIIF Image API 3.0, termasuk tile untuk zoom
+// @Tags         IIIF
+// @Produce      image/jpeg,image/png,image/webp
+// @Param        id        path  string  true  "ID media"
+// @Param        region    path  string  true  "full | square | x,y,w,h | pct:x,y,w,h"
+// @Param        size      path  string  true  "max | w, | ,h | pct:n | w,h | !w,h (awalan ^ untuk upscale)"
+// @Param        rotation  path  string  true  "Derajat searah jarum jam, awalan ! untuk mirror"
+// @Param        quality   path  string  true  "default | color | gray | bitonal"
+// @Param        format    path  string  true  "jpg | png | webp"
+// @Success      200  {file}  file
+// @Router       /iiif/image/{id}/{region}/{size}/{rotation}/{quality}.{format} [get]
+func GetIIIFImage(c *fiber.Ctx) error {
+	contentType, ok := formatIIIF[paramPath(c, "format")]
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Format tidak didukung (jpg, png, webp)"})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	media, status, errMsg := ambilMediaIIIF(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": errMsg})
+	}
+
+	img, err := muatGambarIIIF(ctx, media)
+	if err == storage.ErrNotFound {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "File gambar tidak ditemukan di storage"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal membaca gambar: " + err.Error()})
+	}
+
+	b := img.Bounds()
+	region, errMsg := regionIIIF(paramPath(c, "region"), b.Dx(), b.Dy())
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": errMsg})
+	}
+
+	ow, oh, errMsg := sizeIIIF(paramPath(c, "size"), region.Dx(), region.Dy())
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": errMsg})
+	}
+
+	hasil := imaging.Crop(img, region.Add(b.Min))
+	if ow != region.Dx() || oh != region.Dy() {
+		hasil = imaging.Resize(hasil, ow, oh, imaging.Lanczos)
+	}
+
+	latar := color.Color(color.Transparent)
+	if contentType == "image/jpeg" {
+		latar = color.White
+	}
+	out, errMsg := rotasiIIIF(hasil, paramPath(c, "rotation"), latar)
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": errMsg})
+	}
+
+	out, errMsg = kualitasIIIF(out, paramPath(c, "quality"))
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": errMsg})
+	}
+
+	var buf bytes.Buffer
+	switch contentType {
+	case "image/jpeg":
+		ob := out.Bounds()
+		flat := imaging.Overlay(imaging.New(ob.Dx(), ob.Dy(), color.White), out, image.Pt(0, 0), 1.0)
+		err = imaging.Encode(&buf, flat, imaging.JPEG, imaging.JPEGQuality(85))
+	case "image/png":
+		err = imaging.Encode(&buf, out, imaging.PNG)
+	case "image/webp":
+		err = nativewebp.Encode(&buf, out, nil)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal membuat gambar"})
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderCacheControl, "public, max-age=86400")
+	c.Set("Link", `<http://iiif.io/api/image/3/level2.json>;rel="profile"`)
+	return c.Send(buf.Bytes())
+}
+
+// teksIIIF membuat language map IIIF (bahasa Indonesia)
+func teksIIIF(s string) fiber.Map {
+	return fiber.Map{"id": []string{s}}
+}
+
+// formatUkuranKoleksi menampilkan ukuran koleksi dalam satu baris teks
+func formatUkuranKoleksi(u *model.Ukuran) string {
+	if u == nil {
+		return ""
+	}
+	var bagian []string
+	for _, d := range []struct {
+		label string
+		nilai *float64
+	}{
+		{"Panjang", u.PanjangKeseluruhan},
+		{"Lebar", u.Lebar},
+		{"Tebal", u.Tebal},
+		{"Tinggi", u.Tinggi},
+		{"Diameter", u.Diameter},
+	} {
+		if d.nilai != nil {
+			bagian = append(bagian, d.label+" "+strconv.FormatFloat(*d.nilai, 'f', -1, 64)+" "+u.Satuan)
+		}
+	}
+	if u.Berat != nil {
+		bagian = append(bagian, "Berat "+strconv.FormatFloat(*u.Berat, 'f', -1, 64)+" "+u.SatuanBerat)
+	}
+	if u.Catatan != "" {
+		bagian = append(bagian, u.Catatan)
+	}
+	return strings.Join(bagian, "; ")
+}
+
+// GetIIIFManifest godoc
+// @Summary      IIIF Presentation Manifest
+// @Description  Manifest IIIF Presentation API 3.0 untuk satu koleksi; setiap media menjadi satu canvas dengan layanan IIIF Image API
+// @Tags         IIIF
+// @Produce      json
+// @Param        id   path  string  true  "ID koleksi"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /iiif/koleksi/{id}/manifest [get]
+func GetIIIFManifest(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	koleksi, status, errMsg := ambilKoleksiMedia(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": errMsg})
+	}
+
+	base := c.BaseURL() + "/api/iiif"
+	manifestID := fmt.Sprintf("%s/koleksi/%s/manifest", base, koleksi.ID.Hex())
+
+	metadata := []fiber.Map{}
+	for _, m := range []struct{ label, nilai string }{
+		{"Nama Benda", koleksi.NamaBenda},
+		{"No. Registrasi", koleksi.NoRegistrasi},
+		{"No. Inventaris", koleksi.NoInventaris},
+		{"Kategori", koleksi.Kategori.NamaKategori},
+		{"Bahan", koleksi.Bahan},
+		{"Ukuran", formatUkuranKoleksi(koleksi.Ukuran)},
+		{"Asal Koleksi", koleksi.AsalKoleksi},
+		{"Tempat Perolehan", koleksi.TempatPerolehan},
+		{"Tanggal Perolehan", koleksi.TanggalPerolehan},
+		{"Kondisi", koleksi.Kondisi},
+	} {
+		if m.nilai != "" {
+			metadata = append(metadata, fiber.Map{"label": teksIIIF(m.label), "value": teksIIIF(m.nilai)})
+		}
+	}
+
+	canvases := []fiber.Map{}
+	var thumbnail []fiber.Map
+	for i, media := range koleksi.Media {
+		// lampiran selain gambar (PDF, ZIP, dsb.) tidak punya canvas
+		if !mediaGambar(media.MIME) {
+			continue
+		}
+		w, h, err := ukuranMediaIIIF(ctx, media)
+		if err != nil {
+			// media yang filenya hilang dilewati supaya manifest tetap bisa dibuka
+			continue
+		}
+
+		imageID := base + "/image/" + media.ID.Hex()
+		canvasID := fmt.Sprintf("%s/koleksi/%s/canvas/%s", base, koleksi.ID.Hex(), media.ID.Hex())
+		label := media.Keterangan
+		if label == "" {
+			label = fmt.Sprintf("%s (%d)", media.Tipe, i+1)
+		}
+
+		canvas := fiber.Map{
+			"id":     canvasID,
+			"type":   "Canvas",
+			"label":  teksIIIF(label),
+			"width":  w,
+			"height": h,
+			"items": []fiber.Map{{
+				"id":   canvasID + "/page",
+				"type": "AnnotationPage",
+				"items": []fiber.Map{{
+					"id":         canvasID + "/annotation",
+					"type":       "Annotation",
+					"motivation": "painting",
+					"target":     canvasID,
+					"body": fiber.Map{
+						"id":     imageID + "/full/max/0/default.jpg",
+						"type":   "Image",
+						"format": "image/jpeg",
+						"width":  w,
+						"height": h,
+						"service": []fiber.Map{{
+							"id":      imageID,
+							"type":    "ImageService3",
+							"profile": "level2",
+						}},
+					},
+				}},
+			}},
+		}
+
+		if r, ok := media.Rendisi[model.RendisiThumbnail]; ok {
+			thumb := []fiber.Map{{"id": r.URL, "type": "Image", "format": "image/jpeg", "width": r.Lebar, "height": r.Tinggi}}
+			canvas["thumbnail"] = thumb
+			if media.Utama || thumbnail == nil {
+				thumbnail = thumb
+			}
+		}
+		canvases = append(canvases, canvas)
+	}
+
+	manifest := fiber.Map{
+		"@context": iiifContextPres,
+		"id":       manifestID,
+		"type":     "Manifest",
+		"label":    teksIIIF(koleksi.NamaBenda),
+		"metadata": metadata,
+		"items":    canvases,
+	}
+	if koleksi.Deskripsi != "" {
+		manifest["summary"] = teksIIIF(koleksi.Deskripsi)
+	}
+	if thumbnail != nil {
+		manifest["thumbnail"] = thumbnail
+	}
+
+	return c.JSON(manifest, `application/ld+json;profile="`+iiifContextPres+`"`)
+}
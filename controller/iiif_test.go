@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"image"
+	"testing"
+)
+
+func TestRegionIIIF(t *testing.T) {
+	const w, h = 1000, 600
+
+	tests := []struct {
+		param string
+		want  image.Rectangle
+		gagal bool
+	}{
+		{param: "full", want: image.Rect(0, 0, 1000, 600)},
+		{param: "square", want: image.Rect(200, 0, 800, 600)},
+		{param: "100,50,200,100", want: image.Rect(100, 50, 300, 150)},
+		{param: "pct:10,10,50,50", want: image.Rect(100, 60, 600, 360)},
+		// region yang melewati tepi gambar dipotong
+		{param: "900,500,300,300", want: image.Rect(900, 500, 1000, 600)},
+		{param: "1200,0,10,10", gagal: true},
+		{param: "0,0,0,10", gagal: true},
+		{param: "-1,0,10,10", gagal: true},
+		{param: "1,2,3", gagal: true},
+		{param: "pct:a,b,c,d", gagal: true},
+		{param: "abc", gagal: true},
+	}
+
+	for _, tt := range tests {
+		got, errMsg := regionIIIF(tt.param, w, h)
+		if tt.gagal {
+			if errMsg == "" {
+				t.Errorf("regionIIIF(%q) = %v, want error", tt.param, got)
+			}
+			continue
+		}
+		if errMsg != "" || got != tt.want {
+			t.Errorf("regionIIIF(%q) = %v, %q, want %v", tt.param, got, errMsg, tt.want)
+		}
+	}
+}
+
+func TestSizeIIIF(t *testing.T) {
+	tests := []struct {
+		param  string
+		rw, rh int
+		w, h   int
+		gagal  bool
+	}{
+		{param: "max", rw: 1000, rh: 600, w: 1000, h: 600},
+		{param: "500,", rw: 1000, rh: 600, w: 500, h: 300},
+		{param: ",300", rw: 1000, rh: 600, w: 500, h: 300},
+		{param: "pct:50", rw: 1000, rh: 600, w: 500, h: 300},
+		{param: "!400,400", rw: 1000, rh: 600, w: 400, h: 240},
+		{param: "200,100", rw: 1000, rh: 600, w: 200, h: 100},
+		{param: "^2000,", rw: 1000, rh: 600, w: 2000, h: 1200},
+		{param: "^pct:150", rw: 1000, rh: 600, w: 1500, h: 900},
+		// max pada gambar besar diperkecil sampai tepat di bawah batas luas
+		{param: "max", rw: 10000, rh: 5000, w: 7071, h: 3535},
+		// ukuran minimal 1 px
+		{param: "1,", rw: 1000, rh: 10, w: 1, h: 1},
+		{param: "2000,", rw: 1000, rh: 600, gagal: true},
+		{param: "^10000,10000", rw: 1000, rh: 600, gagal: true},
+		{param: ",", rw: 1000, rh: 600, gagal: true},
+		{param: "0,", rw: 1000, rh: 600, gagal: true},
+		{param: "pct:0", rw: 1000, rh: 600, gagal: true},
+		{param: "!0,10", rw: 1000, rh: 600, gagal: true},
+		{param: "abc", rw: 1000, rh: 600, gagal: true},
+	}
+
+	for _, tt := range tests {
+		w, h, errMsg := sizeIIIF(tt.param, tt.rw, tt.rh)
+		if tt.gagal {
+			if errMsg == "" {
+				t.Errorf("sizeIIIF(%q, %d, %d) = %dx%d, want error", tt.param, tt.rw, tt.rh, w, h)
+			}
+			continue
+		}
+		if errMsg != "" || w != tt.w || h != tt.h {
+			t.Errorf("sizeIIIF(%q, %d, %d) = %dx%d %q, want %dx%d", tt.param, tt.rw, tt.rh, w, h, errMsg, tt.w, tt.h)
+		}
+		if w*h > iiifMaksArea {
+			t.Errorf("sizeIIIF(%q) melewati batas luas: %d", tt.param, w*h)
+		}
+	}
+}
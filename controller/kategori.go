@@ -4,14 +4,75 @@ import (
 	"be-internship/config"
 	"be-internship/model"
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// pathAnak mengembalikan materialized path untuk anak-anak langsung dari kategori k
+func pathAnak(k model.Kategori) string {
+	path := k.Path
+	if path == "" {
+		path = "/"
+	}
+	return path + k.ID.Hex() + "/"
+}
+
+// filterTurunanKategori mencocokkan semua kategori di bawah kategori k.
+// Regex berawalan ^ supaya pencarian memakai index path.
+func filterTurunanKategori(k model.Kategori) bson.M {
+	return bson.M{"path": bson.M{"$regex": "^" + regexp.QuoteMeta(pathAnak(k))}}
+}
+
+// turunanKategori mengambil seluruh sub-kategori dari kategori dengan ID tertentu.
+// Kategori yang tidak ditemukan dianggap tidak punya turunan.
+func turunanKategori(ctx context.Context, id primitive.ObjectID) ([]model.Kategori, error) {
+	col := config.Ulbimongoconn.Collection("kategori")
+
+	var k model.Kategori
+	if err := col.FindOne(ctx, bson.M{"_id": id}).Decode(&k); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	cursor, err := col.Find(ctx, filterTurunanKategori(k))
+	if err != nil {
+		return nil, err
+	}
+
+	var turunan []model.Kategori
+	if err := cursor.All(ctx, &turunan); err != nil {
+		return nil, err
+	}
+	return turunan, nil
+}
+
+// idKategoriDanTurunan mengembalikan ID kategori beserta seluruh ID sub-kategorinya
+func idKategoriDanTurunan(ctx context.Context, id primitive.ObjectID) ([]primitive.ObjectID, error) {
+	ids := []primitive.ObjectID{id}
+
+	turunan, err := turunanKategori(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range turunan {
+		ids = append(ids, k.ID)
+	}
+
+	return ids, nil
+}
+
 // InsertKategori godoc
 // @Summary      Insert Kategori
 // @Description  Menambahkan data kategori museum menggunakan form-data (wajib token)
@@ -20,14 +81,18 @@ import (
 // @Produce      json
 // @Param        nama_kategori  formData  string  true   "Nama kategori"
 // @Param        deskripsi      formData  string  false  "Deskripsi kategori"
+// @Param        parent_id      formData  string  false  "ID kategori induk (kosong = kategori utama)"
+// @Param        kode           formData  string  false  "Kode singkat kategori untuk penomoran otomatis, contoh: NUM"
 // @Success      201  {object}  map[string]interface{}
 // @Router       /kategori [post]
 // @Security     BearerAuth
 func InsertKategori(c *fiber.Ctx) error {
 
 	// 🔹 Ambil value dari form-data
-	namaKategori := c.FormValue("nama_kategori")
+	namaKategori := strings.TrimSpace(c.FormValue("nama_kategori"))
 	deskripsi := c.FormValue("deskripsi")
+	parentID := c.FormValue("parent_id")
+	kode := strings.ToUpper(strings.TrimSpace(c.FormValue("kode")))
 
 	// 🔹 Validasi field wajib
 	if namaKategori == "" {
@@ -35,6 +100,11 @@ func InsertKategori(c *fiber.Ctx) error {
 			"error": "Nama kategori tidak boleh kosong",
 		})
 	}
+	if kode != "" && !polaKodeKategori.MatchString(kode) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Kode kategori maksimal 10 karakter berupa huruf, angka, atau tanda -",
+		})
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -44,7 +114,7 @@ func InsertKategori(c *fiber.Ctx) error {
 	// 🔹 Cek apakah kategori sudah ada berdasarkan nama
 	var existing model.Kategori
 	err := kategoriCollection.FindOne(ctx, bson.M{
-		"nama_kategori": namaKategori,
+		"nama_normal": model.NormalisasiNama(namaKategori),
 	}).Decode(&existing)
 
 	if err == nil {
@@ -57,12 +127,41 @@ func InsertKategori(c *fiber.Ctx) error {
 	newKategori := model.Kategori{
 		ID:           primitive.NewObjectID(),
 		NamaKategori: namaKategori,
+		NamaNormal:   model.NormalisasiNama(namaKategori),
 		Deskripsi:    deskripsi,
+		Kode:         kode,
+		Path:         "/",
+		Versi:        1,
+	}
+
+	// 🔹 Kategori induk opsional
+	if parentID != "" {
+		objParentID, err := primitive.ObjectIDFromHex(parentID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "ID kategori induk tidak valid",
+			})
+		}
+
+		var parent model.Kategori
+		if err := kategoriCollection.FindOne(ctx, bson.M{"_id": objParentID}).Decode(&parent); err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Kategori induk tidak ditemukan",
+			})
+		}
+
+		newKategori.ParentID = &parent.ID
+		newKategori.Path = pathAnak(parent)
 	}
 
 	// 🔹 Insert ke database
 	_, err = kategoriCollection.InsertOne(ctx, newKategori)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Kategori sudah terdaftar",
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Gagal menyimpan kategori ke database",
 		})
@@ -120,6 +219,9 @@ func GetAllCategory(c *fiber.Ctx) error {
 // @Produce      json
 // @Param        id   path      string  true  "ID Kategori"
 // @Success      200  {object}  map[string]interface{}
+// @Param        If-None-Match header string false "ETag dari respons sebelumnya, 304 jika data belum berubah"
+// @Header       200 {string} ETag "Versi data saat ini"
+// @Success      304 {string} string "Data belum berubah"
 // @Router       /kategori/{id} [get]
 func GetCategoryByID(c *fiber.Ctx) error {
 	// Ambil parameter ID dari URL
@@ -148,8 +250,8 @@ func GetCategoryByID(c *fiber.Ctx) error {
 		})
 	}
 
-	// Return hasil dengan pesan sukses
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+	// Return hasil dengan pesan sukses & ETag versi kategori
+	return kirimDenganETag(c, kategori.Versi, fiber.Map{
 		"message": "Kategori dengan ID " + idParam + " berhasil ditampilkan",
 		"data":    kategori,
 	})
@@ -165,7 +267,11 @@ func GetCategoryByID(c *fiber.Ctx) error {
 // @Param        id             path      string  true   "ID Kategori"
 // @Param        nama_kategori  formData  string  true   "Nama kategori"
 // @Param        deskripsi      formData  string  false  "Deskripsi kategori"
+// @Param        kode           formData  string  false  "Kode singkat kategori untuk penomoran otomatis (kosong = tidak diubah)"
 // @Success      200  {object}  map[string]interface{} "Kategori berhasil diperbarui"
+// @Param        If-Match header string true "ETag dari GET terakhir"
+// @Failure      412 {object} map[string]interface{} "Data sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428 {object} map[string]interface{} "Header If-Match belum diisi"
 // @Router       /kategori/{id} [put]
 func UpdateKategori(c *fiber.Ctx) error {
 
@@ -189,8 +295,9 @@ func UpdateKategori(c *fiber.Ctx) error {
 	// ============================
 	// 2. Ambil data dari form-data
 	// ============================
-	namaKategori := c.FormValue("nama_kategori")
+	namaKategori := strings.TrimSpace(c.FormValue("nama_kategori"))
 	deskripsi := c.FormValue("deskripsi")
+	kode := strings.ToUpper(strings.TrimSpace(c.FormValue("kode")))
 
 	// Validasi
 	if namaKategori == "" {
@@ -198,6 +305,11 @@ func UpdateKategori(c *fiber.Ctx) error {
 			"error": "Nama kategori tidak boleh kosong",
 		})
 	}
+	if kode != "" && !polaKodeKategori.MatchString(kode) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Kode kategori maksimal 10 karakter berupa huruf, angka, atau tanda -",
+		})
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -214,14 +326,17 @@ func UpdateKategori(c *fiber.Ctx) error {
 			"error": "Kategori tidak ditemukan",
 		})
 	}
+	if ok, err := cekIfMatch(c, existing.Versi); !ok {
+		return err
+	}
 
 	// ============================
 	// 4. Cek apakah nama sudah dipakai kategori lain
 	// ============================
 	var checkName model.Kategori
 	err = kategoriCollection.FindOne(ctx, bson.M{
-		"nama_kategori": namaKategori,
-		"_id":           bson.M{"$ne": objID},
+		"nama_normal": model.NormalisasiNama(namaKategori),
+		"_id":         bson.M{"$ne": objID},
 	}).Decode(&checkName)
 
 	if err == nil {
@@ -233,19 +348,32 @@ func UpdateKategori(c *fiber.Ctx) error {
 	// ============================
 	// 5. Update data
 	// ============================
-	update := bson.M{
-		"$set": bson.M{
-			"nama_kategori": namaKategori,
-			"deskripsi":     deskripsi,
-		},
+	setData := bson.M{
+		"nama_kategori": namaKategori,
+		"nama_normal":   model.NormalisasiNama(namaKategori),
+		"deskripsi":     deskripsi,
 	}
+	if kode != "" {
+		setData["kode"] = kode
+	}
+	update := bson.M{"$set": setData}
 
-	_, err = kategoriCollection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	res, err := kategoriCollection.UpdateOne(ctx, filterVersi(objID, existing.Versi), tambahVersi(update))
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Nama kategori sudah digunakan kategori lain",
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Gagal mengupdate data kategori",
 		})
 	}
+	if res.MatchedCount == 0 {
+		versi, _ := versiDokumen(ctx, kategoriCollection, objID)
+		return tolakVersiBerubah(c, versi)
+	}
+	c.Set(fiber.HeaderETag, etagVersi(existing.Versi+1))
 
 	// Ambil ulang data setelah update
 	var updated model.Kategori
@@ -265,6 +393,9 @@ func UpdateKategori(c *fiber.Ctx) error {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "ID kategori"
 // @Success      200  {object}  map[string]interface{}  "Kategori berhasil dihapus"
+// @Param        If-Match header string true "ETag dari GET terakhir"
+// @Failure      412 {object} map[string]interface{} "Data sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428 {object} map[string]interface{} "Header If-Match belum diisi"
 // @Router       /kategori/{id} [delete]
 func DeleteKategoriByID(c *fiber.Ctx) error {
 
@@ -282,8 +413,32 @@ func DeleteKategoriByID(c *fiber.Ctx) error {
 
 	kategoriCollection := config.Ulbimongoconn.Collection("kategori")
 
+	// Hanya hapus jika versi sesuai header If-Match
+	versi, err := versiDokumen(ctx, kategoriCollection, objID)
+	if err == mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Kategori tidak ditemukan",
+		})
+	}
+	if ok, err := cekIfMatch(c, versi); !ok {
+		return err
+	}
+
+	// Kategori yang masih punya sub-kategori tidak boleh dihapus
+	count, err := kategoriCollection.CountDocuments(ctx, bson.M{"parent_id": objID})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengecek sub-kategori",
+		})
+	}
+	if count > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Kategori masih memiliki sub-kategori, pindahkan atau hapus sub-kategori terlebih dahulu",
+		})
+	}
+
 	// Hapus kategori
-	result, err := kategoriCollection.DeleteOne(ctx, bson.M{"_id": objID})
+	result, err := kategoriCollection.DeleteOne(ctx, filterVersi(objID, versi))
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Gagal menghapus kategori",
@@ -291,13 +446,264 @@ func DeleteKategoriByID(c *fiber.Ctx) error {
 	}
 
 	if result.DeletedCount == 0 {
+		versi, _ := versiDokumen(ctx, kategoriCollection, objID)
+		return tolakVersiBerubah(c, versi)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Kategori berhasil dihapus",
+		"id":      idParam,
+	})
+}
+
+// GetKategoriTree godoc
+// @Summary      Get Kategori Tree
+// @Description  Mengambil seluruh kategori dalam bentuk pohon (kategori utama beserta sub-kategorinya)
+// @Tags         Data Kategori
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}
+// @Router       /kategori/tree [get]
+func GetKategoriTree(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.Ulbimongoconn.Collection("kategori").Find(ctx, bson.M{})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"message": "Gagal mengambil data kategori",
+			"error":   err.Error(),
+		})
+	}
+
+	var categories []model.Kategori
+	if err := cursor.All(ctx, &categories); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"message": "Gagal decode data kategori",
+			"error":   err.Error(),
+		})
+	}
+
+	// 🔹 Susun node berdasarkan parent_id
+	nodes := map[primitive.ObjectID]*model.KategoriTree{}
+	for _, k := range categories {
+		nodes[k.ID] = &model.KategoriTree{Kategori: k, SubKategori: []*model.KategoriTree{}}
+	}
+
+	roots := []*model.KategoriTree{}
+	for _, k := range categories {
+		node := nodes[k.ID]
+		if k.ParentID != nil {
+			if parent, ok := nodes[*k.ParentID]; ok {
+				parent.SubKategori = append(parent.SubKategori, node)
+				continue
+			}
+		}
+		// tanpa induk (atau induk sudah tidak ada) → kategori utama
+		roots = append(roots, node)
+	}
+
+	var urutkan func(list []*model.KategoriTree)
+	urutkan = func(list []*model.KategoriTree) {
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].NamaKategori < list[j].NamaKategori
+		})
+		for _, n := range list {
+			urutkan(n.SubKategori)
+		}
+	}
+	urutkan(roots)
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil pohon kategori",
+		"total":   len(categories),
+		"data":    roots,
+	})
+}
+
+// PindahKategori godoc
+// @Summary      Pindah Kategori
+// @Description  Memindahkan kategori (beserta seluruh sub-kategorinya) ke kategori induk lain. Kategori tidak boleh dipindahkan ke dirinya sendiri atau ke sub-kategorinya. Kategori dan path sub-kategori diubah dalam satu transaksi (MongoDB replica set).
+// @Tags         Data Kategori
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string  true   "ID Kategori"
+// @Param        parent_id  formData  string  false  "ID kategori induk baru (kosong = jadikan kategori utama)"
+// @Param        If-Match  header  string  true  "ETag dari GET terakhir"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      412  {object}  map[string]interface{}  "Data sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428  {object}  map[string]interface{}  "Header If-Match belum diisi"
+// @Router       /kategori/{id}/pindah [put]
+func PindahKategori(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	objID, err := primitive.ObjectIDFromHex(idParam)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID kategori tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	kategoriCollection := config.Ulbimongoconn.Collection("kategori")
+
+	// ============================
+	// 1. Ambil kategori yang dipindah
+	// ============================
+	var existing model.Kategori
+	if err := kategoriCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&existing); err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error": "Kategori tidak ditemukan",
 		})
 	}
+	if ok, err := cekIfMatch(c, existing.Versi); !ok {
+		return err
+	}
+
+	// ============================
+	// 2. Tentukan induk baru
+	// ============================
+	var newParentID *primitive.ObjectID
+	if parentID := c.FormValue("parent_id"); parentID != "" {
+		objParentID, err := primitive.ObjectIDFromHex(parentID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "ID kategori induk tidak valid",
+			})
+		}
+		newParentID = &objParentID
+	}
+
+	// ============================
+	// 3. Pindahkan kategori & perbarui path seluruh sub-kategori dalam satu transaksi
+	// ============================
+	sesi, err := config.Ulbimongoconn.Client().StartSession()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memindahkan kategori",
+		})
+	}
+	defer sesi.EndSession(context.Background())
+
+	var jumlahTurunan int
+	_, err = sesi.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		n, err := pindahkanKategori(sc, existing, newParentID)
+		jumlahTurunan = n
+		return nil, err
+	})
+	switch {
+	case errors.Is(err, errIndukKategoriTidakAda):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Kategori induk tidak ditemukan",
+		})
+	case errors.Is(err, errKategoriSiklus):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Kategori tidak boleh dipindahkan ke dirinya sendiri atau ke sub-kategorinya",
+		})
+	case errors.Is(err, errVersiBerubah):
+		versi, _ := versiDokumen(ctx, kategoriCollection, objID)
+		return tolakVersiBerubah(c, versi)
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memindahkan kategori",
+		})
+	}
+
+	var updated model.Kategori
+	kategoriCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&updated)
 
+	c.Set(fiber.HeaderETag, etagVersi(existing.Versi+1))
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Kategori berhasil dihapus",
-		"id":      idParam,
+		"message":             "Kategori berhasil dipindahkan",
+		"data":                updated,
+		"sub_kategori_diubah": jumlahTurunan,
 	})
 }
+
+var (
+	errIndukKategoriTidakAda = errors.New("kategori induk tidak ditemukan")
+	errKategoriSiklus        = errors.New("kategori dipindah ke dirinya sendiri atau sub-kategorinya")
+)
+
+// pindahkanKategori memindahkan kategori k ke bawah parentID (nil = kategori utama) lalu menulis ulang
+// path seluruh sub-kategorinya. Dijalankan di dalam transaksi: pohon kategori dibaca ulang dari snapshot
+// transaksi sehingga cek siklus dan path sub-kategori memakai data yang sama dengan yang ditulis.
+// Mengembalikan jumlah sub-kategori yang path-nya diubah.
+func pindahkanKategori(ctx mongo.SessionContext, k model.Kategori, parentID *primitive.ObjectID) (int, error) {
+	col := config.Ulbimongoconn.Collection("kategori")
+
+	// Semua pemindahan menulis dokumen kunci yang sama, sehingga dua pemindahan yang berjalan bersamaan
+	// (mis. A ke bawah B dan B ke bawah A) bentrok dan salah satunya diulang dengan pohon terbaru.
+	if _, err := config.Ulbimongoconn.Collection("kunci_kategori").UpdateOne(ctx,
+		bson.M{"_id": "pindah"},
+		bson.M{"$set": bson.M{"waktu": time.Now()}},
+		options.Update().SetUpsert(true),
+	); err != nil {
+		return 0, err
+	}
+
+	var kategori model.Kategori
+	if err := col.FindOne(ctx, filterVersi(k.ID, k.Versi)).Decode(&kategori); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, errVersiBerubah
+		}
+		return 0, err
+	}
+
+	newPath := "/"
+	update := bson.M{}
+	if parentID != nil {
+		var parent model.Kategori
+		if err := col.FindOne(ctx, bson.M{"_id": *parentID}).Decode(&parent); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return 0, errIndukKategoriTidakAda
+			}
+			return 0, err
+		}
+		if parent.ID == kategori.ID || strings.HasPrefix(parent.Path, pathAnak(kategori)) {
+			return 0, errKategoriSiklus
+		}
+		newPath = pathAnak(parent)
+		update["$set"] = bson.M{"path": newPath, "parent_id": parent.ID}
+	} else {
+		update["$set"] = bson.M{"path": newPath}
+		update["$unset"] = bson.M{"parent_id": ""}
+	}
+
+	// Filter path & versi: kategori yang sudah dipindah / diubah request lain sejak dibaca tidak ditimpa
+	filter := filterVersi(kategori.ID, kategori.Versi)
+	filter["path"] = kategori.Path
+	res, err := col.UpdateOne(ctx, filter, tambahVersi(update))
+	if err != nil {
+		return 0, err
+	}
+	if res.MatchedCount == 0 {
+		return 0, errVersiBerubah
+	}
+
+	// 🔹 Path sub-kategori diganti awalannya dari path lama ke path baru
+	cursor, err := col.Find(ctx, filterTurunanKategori(kategori))
+	if err != nil {
+		return 0, err
+	}
+	var turunan []model.Kategori
+	if err := cursor.All(ctx, &turunan); err != nil {
+		return 0, err
+	}
+
+	prefixLama := pathAnak(kategori)
+	prefixBaru := newPath + kategori.ID.Hex() + "/"
+	var models []mongo.WriteModel
+	for _, t := range turunan {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": t.ID, "path": t.Path}).
+			SetUpdate(tambahVersi(bson.M{"$set": bson.M{"path": prefixBaru + t.Path[len(prefixLama):]}})))
+	}
+	if len(models) > 0 {
+		if _, err := col.BulkWrite(ctx, models); err != nil {
+			return 0, err
+		}
+	}
+	return len(models), nil
+}
@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"be-internship/model"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestFilterTurunanKategori(t *testing.T) {
+	induk := primitive.NewObjectID()
+	k := model.Kategori{ID: primitive.NewObjectID(), Path: "/" + induk.Hex() + "/"}
+
+	regex := filterTurunanKategori(k)["path"].(bson.M)["$regex"]
+	want := "^/" + induk.Hex() + "/" + k.ID.Hex() + "/"
+	if regex != want {
+		t.Errorf("regex = %v, want %v", regex, want)
+	}
+
+	utama := model.Kategori{ID: primitive.NewObjectID()}
+	if regex := filterTurunanKategori(utama)["path"].(bson.M)["$regex"]; regex != "^/"+utama.ID.Hex()+"/" {
+		t.Errorf("regex kategori utama = %v", regex)
+	}
+}
@@ -3,55 +3,22 @@ package controller
 import (
 	"be-internship/config"
 	"be-internship/model"
-	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io"
-	"mime/multipart"
 	"net/http"
-	"os"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// InsertKoleksi godoc
-// @Summary      Insert Koleksi
-// @Description  Menambahkan data koleksi museum baru, termasuk kategori, tempat penyimpanan, ukuran, foto, dan lain-lain
-// @Tags         Data Koleksi
-// @Accept       multipart/form-data
-// @Produce      json
-// @Param        no_reg             formData string true  "Nomor Registrasi"
-// @Param        no_inv             formData string true  "Nomor Inventaris"
-// @Param        nama_benda         formData string true  "Nama Benda"
-// @Param        deskripsi          formData string false "Deskripsi Koleksi"
-// @Param        kategori_id        formData string true  "ID Kategori"
-// @Param        bahan              formData string false "Bahan Benda"
-// @Param        tempat_perolehan   formData string false "Tempat Perolehan"
-// @Param        tanggal_perolehan  formData string false "Tanggal Perolehan (format: DD-MM-YYYY)"
-// @Param        panjang_keseluruhan formData string false "Panjang Keseluruhan (ukuran)"
-// @Param        lebar              formData string false "Lebar (ukuran)"
-// @Param        tebal              formData string false "Tebal (ukuran)"
-// @Param        tinggi             formData string false "Tinggi (ukuran)"
-// @Param        diameter           formData string false "Diameter (ukuran)"
-// @Param        satuan             formData string false "Satuan ukuran keseluruhan (cm/m/dll)"
-// @Param        berat              formData string false "Berat (ukuran)"
-// @Param        satuan_berat       formData string false "Satuan berat (kg/g/dll)"
-// @Param        gudang_id          formData string true  "ID Gudang"
-// @Param        rak_id             formData string false "ID Rak"
-// @Param        tahap_id           formData string false "ID Tahap"
-// @Param        asal_koleksi       formData string false "Asal Koleksi"
-// @Param        kondisi            formData string false "Kondisi Koleksi"
-// @Param        foto               formData file   false "Upload foto koleksi"
-// @Success      201 {object} map[string]interface{} "Koleksi berhasil disimpan"
-// @Router       /koleksi [post]
-// @Security     BearerAuth
-func InsertKoleksi(c *fiber.Ctx) error {
-
+// koleksiBaruDariForm memvalidasi form koleksi baru, mengupload foto, dan menyiapkan nomor registrasi & inventaris.
+// otomatis menandai nomor yang dibuat dari skema penomoran (boleh dibuat ulang jika bentrok saat disimpan).
+func koleksiBaruDariForm(ctx context.Context, c *fiber.Ctx) (model.Koleksi, map[string]bool, int, string) {
 	noReg := c.FormValue("no_reg")
 	noInv := c.FormValue("no_inv")
 	namaBenda := c.FormValue("nama_benda")
@@ -70,120 +37,76 @@ func InsertKoleksi(c *fiber.Ctx) error {
 	tahapID := c.FormValue("tahap_id")   // 🔹 ambil ID tahap, bukan nama
 
 	// Ukuran
-	panjang := c.FormValue("panjang_keseluruhan")
-	lebar := c.FormValue("lebar")
-	tebal := c.FormValue("tebal")
-	tinggi := c.FormValue("tinggi")
-	diameter := c.FormValue("diameter")
-	satuan := c.FormValue("satuan")
-
-	berat := c.FormValue("berat")
-	satuanBerat := c.FormValue("satuan_berat")
-
-	// 🔹 cek apakah salah satu dimensi diisi
-	adaDimensi := panjang != "" || lebar != "" || tebal != "" || tinggi != "" || diameter != ""
-
-	// Validasi ukuran dan satuan
-	if adaDimensi && satuan == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Satuan wajib diisi jika salah satu dimensi ukuran diisi.",
-		})
-	}
-
-	// Validasi harus mengisi dimensi jika mengisi satuan
-	if !adaDimensi && satuan != "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Tidak boleh mengisi satuan tanpa mengisi dimensi ukuran.",
-		})
-	}
-
-	//======================================================
-	// Validasi berat dan satuan berat
-	if berat != "" && satuanBerat == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Satuan berat wajib diisi jika berat diisi.",
-		})
-	}
-	// Validasi harus mengisi satuan berat jika mengisi berat
-	if berat == "" && satuanBerat != "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Tidak boleh mengisi satuan berat tanpa mengisi berat.",
-		})
-	}
-
-	var ukuran *model.Ukuran
-
-	if adaDimensi || berat != "" {
-		ukuran = &model.Ukuran{
-			PanjangKeseluruhan: panjang,
-			Lebar:              lebar,
-			Tebal:              tebal,
-			Tinggi:             tinggi,
-			Diameter:           diameter,
-			Berat:              berat,
-			Satuan:             satuan,
-			SatuanBerat:        satuanBerat,
-		}
+	ukuran, errMsg := ukuranDariForm(c)
+	if errMsg != "" {
+		return model.Koleksi{}, nil, fiber.StatusBadRequest, errMsg
 	}
 
 	if kategoriID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "ID Kategori tidak boleh kosong.",
-		})
-	}
-	if noReg == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "No registrasi tidak boleh kosong.",
-		})
+		return model.Koleksi{}, nil, fiber.StatusBadRequest, "ID Kategori tidak boleh kosong."
 	}
 
-	if noInv == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "No inventaris tidak boleh kosong.",
-		})
+	if namaBenda == "" {
+		return model.Koleksi{}, nil, fiber.StatusBadRequest, "Nama benda tidak boleh kosong."
 	}
 
-	if namaBenda == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Nama benda tidak boleh kosong.",
-		})
+	Kondisi, errMsg = kondisiKoleksi(Kondisi, "")
+	if errMsg != "" {
+		return model.Koleksi{}, nil, fiber.StatusBadRequest, errMsg
 	}
 
 	// 🔹 Cek kategori berdasarkan ID
 	objID, err := primitive.ObjectIDFromHex(kategoriID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "ID kategori tidak valid.",
-		})
+		return model.Koleksi{}, nil, fiber.StatusBadRequest, "ID kategori tidak valid."
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
 	var kategori model.Kategori
 	kategoriCollection := config.Ulbimongoconn.Collection("kategori")
 	err = kategoriCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&kategori)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "Kategori tidak ditemukan.",
-		})
+		return model.Koleksi{}, nil, fiber.StatusNotFound, "Kategori tidak ditemukan."
+	}
+
+	// 🔹 Validasi atribut tambahan sesuai skema kategori
+	skema, err := skemaAtributKategori(ctx, kategori)
+	if err != nil {
+		return model.Koleksi{}, nil, fiber.StatusInternalServerError, "Gagal mengambil skema atribut kategori."
+	}
+	atribut, errMsg := validasiAtributKoleksi(skema, c.FormValue("atribut"))
+	if errMsg != "" {
+		return model.Koleksi{}, nil, fiber.StatusBadRequest, errMsg
+	}
+
+	// 🔹 Data perolehan terstruktur (opsional)
+	var perolehan *model.Perolehan
+	if v := c.FormValue("perolehan"); v != "" {
+		perolehan = &model.Perolehan{}
+		if err := json.Unmarshal([]byte(v), perolehan); err != nil {
+			return model.Koleksi{}, nil, fiber.StatusBadRequest, "Perolehan harus berupa JSON object."
+		}
+		if errMsg := validasiPerolehan(perolehan, nil); errMsg != "" {
+			return model.Koleksi{}, nil, fiber.StatusBadRequest, errMsg
+		}
+		if tanggalPerolehan == "" {
+			tanggalPerolehan = perolehan.Tanggal
+		}
+		if tempatPerolehan == "" {
+			tempatPerolehan = perolehan.Tempat
+		}
 	}
 
 	// 🔹 Cek data gudang berdasarkan ID
 	objID, err = primitive.ObjectIDFromHex(gudangID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "ID gudang tidak valid.",
-		})
+		return model.Koleksi{}, nil, fiber.StatusBadRequest, "ID gudang tidak valid."
 	}
 
 	var gudang model.Gudang
 	gudangCollection := config.Ulbimongoconn.Collection("gudang")
 	err = gudangCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&gudang)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "Data gudang tidak ditemukan.",
-		})
+		return model.Koleksi{}, nil, fiber.StatusNotFound, "Data gudang tidak ditemukan."
 	}
 
 	// ======================================================
@@ -193,17 +116,13 @@ func InsertKoleksi(c *fiber.Ctx) error {
 	if rakID != "" {
 		objID, err = primitive.ObjectIDFromHex(rakID)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "ID rak tidak valid.",
-			})
+			return model.Koleksi{}, nil, fiber.StatusBadRequest, "ID rak tidak valid."
 		}
 
 		rakCollection := config.Ulbimongoconn.Collection("rak")
 		err = rakCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&rak)
 		if err != nil {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Data rak tidak ditemukan.",
-			})
+			return model.Koleksi{}, nil, fiber.StatusNotFound, "Data rak tidak ditemukan."
 		}
 	}
 
@@ -214,37 +133,16 @@ func InsertKoleksi(c *fiber.Ctx) error {
 	if tahapID != "" {
 		objID, err = primitive.ObjectIDFromHex(tahapID)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "ID tahap tidak valid.",
-			})
+			return model.Koleksi{}, nil, fiber.StatusBadRequest, "ID tahap tidak valid."
 		}
 
 		tahapCollection := config.Ulbimongoconn.Collection("tahap")
 		err = tahapCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&tahap)
 		if err != nil {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Data tahap tidak ditemukan.",
-			})
+			return model.Koleksi{}, nil, fiber.StatusNotFound, "Data tahap tidak ditemukan."
 		}
 	}
 
-	// 🔹 Upload gambar OPSIONAL
-	var imageURL string
-
-	file, err := c.FormFile("foto")
-	if err == nil && file != nil {
-		// Jika ada file → upload ke GitHub
-		imageURL, err = uploadImageToGitHub(file, namaBenda)
-		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": fmt.Sprintf("Gagal upload gambar ke GitHub: %v", err),
-			})
-		}
-	} else {
-		// Jika TIDAK ada file → kosongkan
-		imageURL = ""
-	}
-
 	// 🔹 Upload gambar
 	// file, err := c.FormFile("foto")
 	// if err != nil {
@@ -253,7 +151,7 @@ func InsertKoleksi(c *fiber.Ctx) error {
 	// 	})
 	// }
 
-	// imageURL, err := uploadImageToGitHub(file, namaBenda)
+	// foto, err := uploadMediaKoleksi(file, namaBenda)
 	// if err != nil {
 	// 	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 	// 		"error": fmt.Sprintf("Gagal upload gambar ke GitHub: %v", err),
@@ -288,10 +186,39 @@ func InsertKoleksi(c *fiber.Ctx) error {
 		}
 	}
 
+	// 🔹 No registrasi & inventaris: dibuat otomatis jika kosong, atau dicek dengan skema penomoran
+	otomatis := map[string]bool{}
+	nomor := map[string]*string{"no_reg": &noReg, "no_inv": &noInv}
+	for _, field := range []string{"no_reg", "no_inv"} {
+		hasil, auto, status, errMsg := siapkanNomorKoleksi(ctx, field, *nomor[field], kategori)
+		if errMsg != "" {
+			return model.Koleksi{}, nil, status, errMsg
+		}
+		*nomor[field], otomatis[field] = hasil, auto
+	}
+
+	// 🔹 Upload gambar OPSIONAL → menjadi media utama.
+	// Diupload paling akhir setelah semua validasi, karena reference count file langsung dipegang.
+	var media []model.Media
+
+	file, err := c.FormFile("foto")
+	if err == nil && file != nil {
+		// Jika ada file → upload ke storage
+		foto, err := uploadMediaKoleksi(file, namaBenda, true)
+		if err != nil {
+			return model.Koleksi{}, nil, statusErrorMedia(err), fmt.Sprintf("Gagal upload gambar: %v", err)
+		}
+		foto.ID = primitive.NewObjectID()
+		foto.Tipe = model.TipeMediaFoto
+		foto.Utama = true
+		foto.CreatedAt = time.Now()
+		media = append(media, foto)
+	}
+
 	// 🔹 Buat data koleksi
 	data := model.Koleksi{
 		ID:                primitive.NewObjectID(),
-		Kategori:          kategori,
+		Kategori:          snapshotKategori(kategori),
 		NoRegistrasi:      noReg,
 		NoInventaris:      noInv,
 		NamaBenda:         namaBenda,
@@ -300,133 +227,258 @@ func InsertKoleksi(c *fiber.Ctx) error {
 		Ukuran:            ukuran,
 		TempatPerolehan:   tempatPerolehan,
 		TanggalPerolehan:  tanggalPerolehan,
+		Perolehan:         perolehan,
 		Deskripsi:         deskripsi,
 		TempatPenyimpanan: tempatPenyimpanan,
 		Kondisi:           Kondisi,
-		Foto:              imageURL,
+		Media:             media,
+		Atribut:           atribut,
 		CreatedAt:         time.Now(),
 	}
 
+	return data, otomatis, 0, ""
+}
+
+// simpanKoleksiBaru menyimpan koleksi baru; nomor otomatis diambil dari counter tepat sebelum disimpan
+// dan yang bentrok dibuat ulang maksimal 3 kali. Nomor otomatis yang batal dipakai dikembalikan ke counter.
+func simpanKoleksiBaru(ctx context.Context, data *model.Koleksi, otomatis map[string]bool) error {
 	collection := config.Ulbimongoconn.Collection("koleksi")
 
-	// ======================================================
-	// VALIDASI UNIQUE no_reg
-	// ======================================================
-	count, err := collection.CountDocuments(ctx, bson.M{
-		"no_reg": noReg,
-	})
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Gagal mengecek no registrasi.",
-		})
+	dipesan := map[string]bool{}
+	pesan := func(field string) error {
+		hasil, err := ambilNomorOtomatis(ctx, field, data.Kategori)
+		if err != nil {
+			return err
+		}
+		*nomorKoleksi(data, field) = hasil
+		dipesan[field] = true
+		return nil
+	}
+
+	// no_reg & no_inv dijamin unik oleh unique index (lihat config.SetupDatabase).
+	// Selama index belum aktif, nomor dicek manual sebelum disimpan.
+	data.Versi = 1
+	var err error
+	for _, field := range []string{"no_reg", "no_inv"} {
+		if otomatis[field] && err == nil {
+			err = pesan(field)
+		}
 	}
-	if count > 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "No registrasi sudah digunakan.",
-		})
+	for percobaan := 1; err == nil; percobaan++ {
+		if err = cekNomorDipakai(ctx, "no_reg", data.NoRegistrasi, data.ID); err == nil {
+			err = cekNomorDipakai(ctx, "no_inv", data.NoInventaris, data.ID)
+		}
+		if err == nil {
+			_, err = collection.InsertOne(ctx, data)
+		}
+		field := fieldDuplikatKoleksi(err)
+		if err == nil || !otomatis[field] || percobaan >= 3 {
+			break
+		}
+
+		// Nomor otomatis bentrok dengan nomor lama yang diisi sebelum skema penomoran dipakai.
+		// Nomor yang bentrok memang sudah terpakai, jadi tidak dikembalikan ke counter.
+		delete(dipesan, field)
+		if errPesan := pesan(field); errPesan != nil {
+			break
+		}
+		err = nil
 	}
 
-	// ======================================================
-	// VALIDASI UNIQUE no_inv
-	// ======================================================
-	count, err = collection.CountDocuments(ctx, bson.M{
-		"no_inv": noInv,
-	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Gagal mengecek no inventaris.",
-		})
+		for field := range dipesan {
+			if field != fieldDuplikatKoleksi(err) {
+				kembalikanNomorOtomatis(ctx, field, *nomorKoleksi(data, field), data.Kategori)
+			}
+		}
+		return err
 	}
-	if count > 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "No inventaris sudah digunakan.",
+
+	var manual []string
+	for _, field := range []string{"no_reg", "no_inv"} {
+		if !otomatis[field] {
+			manual = append(manual, field)
+		}
+	}
+	catatNomorManual(ctx, *data, manual...)
+	return nil
+}
+
+// InsertKoleksi godoc
+// @Summary      Insert Koleksi
+// @Description  Menambahkan data koleksi museum baru, termasuk kategori, tempat penyimpanan, ukuran, foto, dan lain-lain
+// @Tags         Data Koleksi
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        no_reg             formData string false "Nomor Registrasi (kosongkan untuk dibuat otomatis sesuai skema penomoran)"
+// @Param        no_inv             formData string false "Nomor Inventaris (kosongkan untuk dibuat otomatis sesuai skema penomoran)"
+// @Param        nama_benda         formData string true  "Nama Benda"
+// @Param        deskripsi          formData string false "Deskripsi Koleksi"
+// @Param        kategori_id        formData string true  "ID Kategori"
+// @Param        bahan              formData string false "Bahan Benda"
+// @Param        tempat_perolehan   formData string false "Tempat Perolehan"
+// @Param        tanggal_perolehan  formData string false "Tanggal Perolehan (format: DD-MM-YYYY)"
+// @Param        panjang_keseluruhan formData number false "Panjang Keseluruhan (ukuran)"
+// @Param        lebar              formData number false "Lebar (ukuran)"
+// @Param        tebal              formData number false "Tebal (ukuran)"
+// @Param        tinggi             formData number false "Tinggi (ukuran)"
+// @Param        diameter           formData number false "Diameter (ukuran)"
+// @Param        satuan             formData string false "Satuan ukuran: mm, cm, m"
+// @Param        berat              formData number false "Berat (ukuran)"
+// @Param        satuan_berat       formData string false "Satuan berat: g, kg"
+// @Param        gudang_id          formData string true  "ID Gudang"
+// @Param        rak_id             formData string false "ID Rak"
+// @Param        tahap_id           formData string false "ID Tahap"
+// @Param        asal_koleksi       formData string false "Asal Koleksi"
+// @Param        kondisi            formData string false "Kondisi Koleksi: baik, cukup, rusak_ringan, rusak_berat, kritis"
+// @Param        atribut            formData string false "Atribut tambahan sesuai skema kategori (JSON object)"
+// @Param        perolehan          formData string false "Data perolehan terstruktur (JSON object model.Perolehan)"
+// @Param        foto               formData file   false "Upload foto koleksi"
+// @Success      201 {object} map[string]interface{} "Koleksi berhasil disimpan"
+// @Success      202 {object} map[string]interface{} "Pengguna ber-role contributor: data disimpan sebagai usulan yang menunggu review"
+// @Router       /koleksi [post]
+// @Security     BearerAuth
+func InsertKoleksi(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	data, otomatis, status, errMsg := koleksiBaruDariForm(ctx, c)
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
 		})
 	}
-	_, err = collection.InsertOne(ctx, data)
+
+	// 🔹 Data dari kontributor disimpan sebagai usulan dan baru berlaku setelah direview
+	if perluReview(c) {
+		var foto *model.Media
+		if len(data.Media) > 0 {
+			foto = &data.Media[0]
+		}
+		return ajukanPerubahanKoleksi(ctx, c, model.JenisPerubahanTambah, data, nil, foto, otomatis)
+	}
+
+	err := simpanKoleksiBaru(ctx, &data, otomatis)
 	if err != nil {
+		for _, m := range data.Media {
+			lepasRefMedia(ctx, m.SemuaKey())
+		}
+		if pesan := pesanDuplikatKoleksi(err); pesan != "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": pesan,
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Gagal menyimpan ke database: " + err.Error(),
 		})
 	}
+	catatRiwayatKoleksi(ctx, model.RiwayatKoleksi{
+		KoleksiID: data.ID,
+		Aksi:      model.JenisPerubahanTambah,
+		Oleh:      penggunaLogin(c),
+	})
+
+	var imageURL string
+	if len(data.Media) > 0 {
+		imageURL = data.Media[0].URL
+	}
 
 	return c.Status(http.StatusCreated).JSON(fiber.Map{
 		"message":   "Koleksi berhasil disimpan.",
+		"id":        data.ID,
+		"no_reg":    data.NoRegistrasi,
+		"no_inv":    data.NoInventaris,
 		"image_url": imageURL,
+		"media":     data.Media,
 	})
 }
 
-// =============================================================
-// 🟣 Fungsi Upload Gambar ke GitHub
-// =============================================================
-func uploadImageToGitHub(file *multipart.FileHeader, namaBenda string) (string, error) {
-	githubToken := os.Getenv("GH_ACCESS_TOKEN")
-	repoOwner := "ghaidafasya24"
-	repoName := "images-koleksi-museum"
-	filePath := fmt.Sprintf("koleksi/%d_%s.jpg", time.Now().Unix(), namaBenda)
+// GetAllKoleksi godoc
+// @Summary      Get All Koleksi
+// @Description  Mengambil semua data koleksi museum beserta kategori, tempat penyimpanan, dan ukuran
+// @Tags         Data Koleksi
+// @Produce      json
+// @Param        kategori_id       query  string  false  "Filter kategori (termasuk seluruh sub-kategorinya)"
+// @Param        metode_perolehan  query  string  false  "Filter metode perolehan: hibah, pembelian, temuan, titipan"
+// @Param        tahun_perolehan   query  int     false  "Filter tahun perolehan"
+// @Param        status_lokasi     query  string  false  "Filter status lokasi: tersimpan, dipinjamkan"
+// @Param        termasuk_deaksesi query  bool    false  "Sertakan koleksi yang sudah dideaksesi (default false)"
+// @Param        deaksesi          query  string  false  "Filter status deaksesi: diajukan, disetujui, selesai"
+// @Param        tinggi_min        query  number  false  "Tinggi minimum (juga tersedia panjang_keseluruhan_, lebar_, tebal_, diameter_ dengan akhiran _min / _max)"
+// @Param        tinggi_max        query  number  false  "Tinggi maksimum"
+// @Param        satuan            query  string  false  "Satuan untuk filter dimensi: mm, cm, m (default cm)"
+// @Param        berat_min         query  number  false  "Berat minimum"
+// @Param        berat_max         query  number  false  "Berat maksimum"
+// @Param        satuan_berat      query  string  false  "Satuan untuk filter berat: g, kg (default kg)"
+// @Param        urut              query  string  false  "Urutkan berdasarkan ukuran: panjang_keseluruhan, lebar, tebal, tinggi, diameter, berat (awali - untuk menurun)"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /koleksi [get]
+func GetAllKoleksi(c *fiber.Ctx) error {
+	db := config.Ulbimongoconn
+	col := db.Collection("koleksi") // nama koleksi MongoDB
+
+	filter := bson.M{}
 
-	if githubToken == "" {
-		return "", fmt.Errorf("GH_ACCESS_TOKEN belum diatur di environment variable")
-	}
+	// 🔹 Filter kategori beserta seluruh sub-kategorinya
+	if kategoriID := c.Query("kategori_id"); kategoriID != "" {
+		objKategoriID, err := primitive.ObjectIDFromHex(kategoriID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "ID kategori tidak valid",
+			})
+		}
 
-	f, err := file.Open()
-	if err != nil {
-		return "", fmt.Errorf("gagal membuka file: %w", err)
+		ids, err := idKategoriDanTurunan(context.TODO(), objKategoriID)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"message": "Gagal mengambil sub-kategori",
+				"error":   err.Error(),
+			})
+		}
+		filter["kategori._id"] = bson.M{"$in": ids}
 	}
-	defer f.Close()
 
-	imageData, err := io.ReadAll(f)
-	if err != nil {
-		return "", fmt.Errorf("gagal membaca file: %w", err)
+	// 🔹 Filter metode & tahun perolehan
+	if metode := c.Query("metode_perolehan"); metode != "" {
+		filter["perolehan.metode"] = metode
 	}
-
-	encodedImage := base64.StdEncoding.EncodeToString(imageData)
-	payload := map[string]string{
-		"message": fmt.Sprintf("Upload image for %s", namaBenda),
-		"content": encodedImage,
+	if tahun := c.QueryInt("tahun_perolehan"); tahun > 0 {
+		filter["perolehan.tahun"] = tahun
 	}
-	payloadBytes, _ := json.Marshal(payload)
-
-	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s", repoOwner, repoName, filePath)
 
-	req, _ := http.NewRequest("PUT", apiURL, bytes.NewReader(payloadBytes))
-	req.Header.Set("Authorization", "Bearer "+githubToken)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("gagal request ke GitHub API: %w", err)
+	// 🔹 Filter status lokasi ("dipinjamkan", atau "tersimpan" untuk yang ada di tempat penyimpanan)
+	if statusLokasi := c.Query("status_lokasi"); statusLokasi == "tersimpan" {
+		filter["status_lokasi"] = bson.M{"$exists": false}
+	} else if statusLokasi != "" {
+		filter["status_lokasi"] = statusLokasi
 	}
-	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("GitHub API error (%d): %s", resp.StatusCode, string(body))
+	// 🔹 Koleksi yang sudah dideaksesi tidak termasuk inventaris aktif kecuali diminta
+	if status := c.Query("deaksesi"); status != "" {
+		filter["deaksesi.status"] = status
+	} else if !c.QueryBool("termasuk_deaksesi") {
+		filterKoleksiAktif(filter)
 	}
 
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
-
-	content, ok := result["content"].(map[string]interface{})
-	if !ok || content["download_url"] == nil {
-		return "", fmt.Errorf("tidak menemukan download_url dari GitHub response")
+	// 🔹 Filter rentang ukuran & berat (dibandingkan dalam satuan SI)
+	if errMsg := filterUkuran(c, filter); errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
 	}
 
-	return content["download_url"].(string), nil
-}
-
-// GetAllKoleksi godoc
-// @Summary      Get All Koleksi
-// @Description  Mengambil semua data koleksi museum beserta kategori, tempat penyimpanan, dan ukuran
-// @Tags         Data Koleksi
-// @Produce      json
-// @Success      200  {object}  map[string]interface{}
-// @Router       /koleksi [get]
-func GetAllKoleksi(c *fiber.Ctx) error {
-	db := config.Ulbimongoconn
-	col := db.Collection("koleksi") // nama koleksi MongoDB
+	opts := options.Find()
+	if urut := c.Query("urut"); urut != "" {
+		sortUkuran, errMsg := urutanUkuran(urut)
+		if errMsg != "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": errMsg,
+			})
+		}
+		opts.SetSort(sortUkuran)
+	}
 
-	filter := bson.M{}
-	cursor, err := col.Find(context.TODO(), filter)
+	cursor, err := col.Find(context.TODO(), filter, opts)
 	if err != nil {
 		fmt.Println("Error GetAllKoleksi:", err)
 		return c.Status(500).JSON(fiber.Map{
@@ -459,6 +511,9 @@ func GetAllKoleksi(c *fiber.Ctx) error {
 // @Produce      json
 // @Param        id   path      string  true  "ID Koleksi"
 // @Success      200  {object}  map[string]interface{}
+// @Param        If-None-Match header string false "ETag dari respons sebelumnya, 304 jika data belum berubah"
+// @Header       200 {string} ETag "Versi data saat ini"
+// @Success      304 {string} string "Data belum berubah"
 // @Router       /koleksi/{id} [get]
 func GetKoleksiByID(c *fiber.Ctx) error {
 	// Ambil parameter ID dari URL
@@ -487,71 +542,16 @@ func GetKoleksiByID(c *fiber.Ctx) error {
 		})
 	}
 
-	// Return hasil
-	return c.JSON(fiber.Map{
+	// Return hasil beserta ETag versi koleksi
+	return kirimDenganETag(c, koleksi.Versi, fiber.Map{
 		"message": "Berhasil mengambil data koleksi",
 		"data":    koleksi,
 	})
 }
 
-// UpdateKoleksi godoc
-// @Summary      Update Koleksi
-// @Description  Memperbarui data koleksi museum berdasarkan ID. Semua field bersifat opsional, kecuali "gudang_id" wajib diisi. Jika foto diupload, akan mengganti foto lama.
-// @Tags         Data Koleksi
-// @Accept       multipart/form-data
-// @Produce      json
-// @Param        id                 path     string true  "ID Koleksi"
-// @Param        no_reg             formData string false "Nomor Registrasi"
-// @Param        no_inv             formData string false "Nomor Inventaris"
-// @Param        nama_benda         formData string false "Nama Benda"
-// @Param        tanggal_perolehan  formData string false "Tanggal Perolehan (format: DD-MM-YYYY)"
-// @Param        kategori_id        formData string false "ID Kategori"
-// @Param        bahan              formData string false "Bahan Benda"
-// @Param        panjang_keseluruhan formData string false "Panjang Keseluruhan (ukuran)"
-// @Param        lebar              formData string false "Lebar (ukuran)"
-// @Param        tebal              formData string false "Tebal (ukuran)"
-// @Param        tinggi             formData string false "Tinggi (ukuran)"
-// @Param        diameter           formData string false "Diameter (ukuran)"
-// @Param        satuan             formData string false "Satuan ukuran keseluruhan (cm/m/dll)"
-// @Param        berat              formData string false "Berat (ukuran)"
-// @Param        satuan_berat       formData string false "Satuan berat (kg/g/dll)"
-// @Param        asal_koleksi       formData string false "Asal Koleksi"
-// @Param        tempat_perolehan   formData string false "Tempat Perolehan"
-// @Param        deskripsi          formData string false "Deskripsi Koleksi"
-// @Param        gudang_id          formData string true  "ID Gudang"
-// @Param        rak_id             formData string false "ID Rak"
-// @Param        tahap_id           formData string false "ID Tahap"
-// @Param        kondisi            formData string false "Kondisi Koleksi"
-// @Param        foto               formData file   false "Upload foto koleksi"
-// @Success      200 {object} map[string]string "Koleksi berhasil diperbarui"
-// @Router       /koleksi/{id} [put]
-// @Security     BearerAuth
-func UpdateKoleksi(c *fiber.Ctx) error {
-	// =========================
-	// VALIDASI ID PARAM
-	// =========================
-	id := c.Params("id")
-	koleksiID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "ID koleksi tidak valid"})
-	}
-
-	// =========================
-	// CONTEXT
-	// =========================
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	collection := config.Ulbimongoconn.Collection("koleksi")
-
-	// =========================
-	// AMBIL DATA LAMA
-	// =========================
-	var existing model.Koleksi
-	if err := collection.FindOne(ctx, bson.M{"_id": koleksiID}).Decode(&existing); err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Koleksi tidak ditemukan"})
-	}
-
+// koleksiUbahDariForm memvalidasi form ubah koleksi dan menghasilkan data baru berdasarkan data dasar.
+// Foto yang diupload dikembalikan terpisah (belum digabung ke media) supaya bisa ditinjau lebih dulu.
+func koleksiUbahDariForm(ctx context.Context, c *fiber.Ctx, dasar model.Koleksi) (model.Koleksi, *model.Media, int, string) {
 	// =========================
 	// FORM DATA
 	// =========================
@@ -566,92 +566,68 @@ func UpdateKoleksi(c *fiber.Ctx) error {
 	tahapID := c.FormValue("tahap_id")
 	catatan := c.FormValue("catatan")
 
-	asalKoleksi := c.FormValue("asal_koleksi")
-	bahan := c.FormValue("bahan")
-	tempatPerolehan := c.FormValue("tempat_perolehan")
-	tanggalPerolehan := c.FormValue("tanggal_perolehan")
-	deskripsi := c.FormValue("deskripsi")
-	kondisi := c.FormValue("kondisi")
-
-	// =========================
-	// FORM DATA - UKURAN
-	// =========================
-	panjang := c.FormValue("panjang_keseluruhan")
-	lebar := c.FormValue("lebar")
-	tebal := c.FormValue("tebal")
-	tinggi := c.FormValue("tinggi")
-	diameter := c.FormValue("diameter")
-	satuan := c.FormValue("satuan")
-
-	berat := c.FormValue("berat")
-	satuanBerat := c.FormValue("satuan_berat")
-
-	adaDimensi := panjang != "" || lebar != "" || tebal != "" || tinggi != "" || diameter != ""
-
 	// =========================
 	// VALIDASI FIELD WAJIB
 	// =========================
 	if kategoriID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "ID Kategori tidak boleh kosong.",
-		})
+		return dasar, nil, fiber.StatusBadRequest, "ID Kategori tidak boleh kosong."
 	}
 	if noReg == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "No registrasi tidak boleh kosong.",
-		})
+		return dasar, nil, fiber.StatusBadRequest, "No registrasi tidak boleh kosong."
 	}
 
 	if noInv == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "No inventaris tidak boleh kosong.",
-		})
+		return dasar, nil, fiber.StatusBadRequest, "No inventaris tidak boleh kosong."
 	}
 
 	if namaBenda == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Nama benda tidak boleh kosong.",
-		})
+		return dasar, nil, fiber.StatusBadRequest, "Nama benda tidak boleh kosong."
 	}
 
 	// =========================
 	// VALIDASI UKURAN
 	// =========================
-	if adaDimensi && satuan == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Satuan wajib diisi jika salah satu dimensi ukuran diisi",
-		})
-	}
-
-	if !adaDimensi && satuan != "" {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Tidak boleh mengisi satuan tanpa mengisi dimensi ukuran",
-		})
-	}
-
-	if berat != "" && satuanBerat == "" {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Satuan berat wajib diisi jika berat diisi",
-		})
+	ukuran, errMsg := ukuranDariForm(c)
+	if errMsg != "" {
+		return dasar, nil, 400, errMsg
 	}
 
-	if berat == "" && satuanBerat != "" {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Tidak boleh mengisi satuan berat tanpa mengisi berat",
-		})
-	} // =========================
+	// =========================
 	// KATEGORI
 	// =========================
 	objKategoriID, err := primitive.ObjectIDFromHex(kategoriID)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "ID kategori tidak valid"})
+		return dasar, nil, 400, "ID kategori tidak valid"
 	}
 
 	var kategori model.Kategori
 	if err := config.Ulbimongoconn.Collection("kategori").
 		FindOne(ctx, bson.M{"_id": objKategoriID}).
 		Decode(&kategori); err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Kategori tidak ditemukan"})
+		return dasar, nil, 404, "Kategori tidak ditemukan"
+	}
+
+	// Atribut tambahan divalidasi dengan skema kategori yang baru
+	skema, err := skemaAtributKategori(ctx, kategori)
+	if err != nil {
+		return dasar, nil, 500, "Gagal mengambil skema atribut kategori"
+	}
+	atribut, errMsg := validasiAtributKoleksi(skema, c.FormValue("atribut"))
+	if errMsg != "" {
+		return dasar, nil, 400, errMsg
+	}
+
+	// Nomor yang diubah harus sesuai skema penomoran
+	for field, nilai := range map[string][2]string{
+		"no_reg": {noReg, dasar.NoRegistrasi},
+		"no_inv": {noInv, dasar.NoInventaris},
+	} {
+		if nilai[0] == nilai[1] {
+			continue
+		}
+		if _, _, status, errMsg := siapkanNomorKoleksi(ctx, field, nilai[0], kategori); errMsg != "" {
+			return dasar, nil, status, errMsg
+		}
 	}
 
 	// =========================
@@ -659,33 +635,32 @@ func UpdateKoleksi(c *fiber.Ctx) error {
 	// =========================
 	objGudangID, err := primitive.ObjectIDFromHex(gudangID)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "ID gudang tidak valid"})
+		return dasar, nil, 400, "ID gudang tidak valid"
 	}
 
 	var gudang model.Gudang
 	if err := config.Ulbimongoconn.Collection("gudang").
 		FindOne(ctx, bson.M{"_id": objGudangID}).
 		Decode(&gudang); err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Gudang tidak ditemukan"})
+		return dasar, nil, 404, "Gudang tidak ditemukan"
 	}
 
 	// =========================
-	// PREPARE UPDATE
-	// =========================
-	setData := bson.M{}
-	unsetData := bson.M{}
-
+	// FIELD WAJIB
 	// =========================
-	// FIELD WAJIB → SELALU SET
-	// =========================
-	setData["kategori"] = kategori
-	setData["no_reg"] = noReg
-	setData["no_inv"] = noInv
-	setData["nama_benda"] = namaBenda
-	setData["tempat_penyimpanan.gudang"] = gudang
+	baru := dasar
+	baru.Kategori = snapshotKategori(kategori)
+	baru.NoRegistrasi = noReg
+	baru.NoInventaris = noInv
+	baru.NamaBenda = namaBenda
+	baru.TempatPenyimpanan = model.TempatPenyimpanan{
+		Gudang:  model.Gudang{ID: gudang.ID, NamaGudang: gudang.NamaGudang},
+		Catatan: catatan,
+	}
 
 	// =========================
-	// OPSIONAL: RAK
+	// OPSIONAL: RAK & TAHAP
+	// simpan snapshot id + nama saja, sama seperti InsertKoleksi
 	// =========================
 	if rakID != "" {
 		objRakID, _ := primitive.ObjectIDFromHex(rakID)
@@ -693,101 +668,165 @@ func UpdateKoleksi(c *fiber.Ctx) error {
 		config.Ulbimongoconn.Collection("rak").
 			FindOne(ctx, bson.M{"_id": objRakID}).
 			Decode(&rak)
-		setData["tempat_penyimpanan.rak"] = rak
-	} else {
-		unsetData["tempat_penyimpanan.rak"] = ""
+		baru.TempatPenyimpanan.Rak = model.Rak{ID: rak.ID, NamaRak: rak.NamaRak}
 	}
-
-	// =========================
-	// OPSIONAL: TAHAP
-	// =========================
 	if tahapID != "" {
 		objTahapID, _ := primitive.ObjectIDFromHex(tahapID)
 		var tahap model.Tahap
 		config.Ulbimongoconn.Collection("tahap").
 			FindOne(ctx, bson.M{"_id": objTahapID}).
 			Decode(&tahap)
-		setData["tempat_penyimpanan.tahap"] = tahap
-	} else {
-		unsetData["tempat_penyimpanan.tahap"] = ""
-	}
-
-	// =========================
-	// OPSIONAL: CATATAN
-	// =========================
-	if catatan != "" {
-		setData["tempat_penyimpanan.catatan"] = catatan
-	} else {
-		unsetData["tempat_penyimpanan.catatan"] = ""
+		baru.TempatPenyimpanan.Tahap = model.Tahap{ID: tahap.ID, NamaTahap: tahap.NamaTahap}
 	}
 
 	// =========================
 	// OPSIONAL FIELD LAIN
 	// =========================
-	handleOptional := func(key, value string) {
-		if value != "" {
-			setData[key] = value
-		} else {
-			unsetData[key] = ""
-		}
+	baru.AsalKoleksi = c.FormValue("asal_koleksi")
+	baru.Bahan = c.FormValue("bahan")
+	baru.TempatPerolehan = c.FormValue("tempat_perolehan")
+	baru.TanggalPerolehan = c.FormValue("tanggal_perolehan")
+	baru.Deskripsi = c.FormValue("deskripsi")
+	if baru.Kondisi, errMsg = kondisiKoleksi(c.FormValue("kondisi"), dasar.Kondisi); errMsg != "" {
+		return dasar, nil, 400, errMsg
 	}
-
-	handleOptional("asal_koleksi", asalKoleksi)
-	handleOptional("bahan", bahan)
-	handleOptional("tempat_perolehan", tempatPerolehan)
-	handleOptional("tanggal_perolehan", tanggalPerolehan)
-	handleOptional("deskripsi", deskripsi)
-	handleOptional("kondisi", kondisi)
+	baru.Atribut = atribut
+	baru.Ukuran = ukuran
 
 	// =========================
 	// FOTO (INI PENTING 🔥)
+	// Foto baru ditambahkan sebagai media utama; media lain tetap ada.
+	// Kelola media satu per satu lewat /koleksi/:id/media.
 	// =========================
 	file, err := c.FormFile("foto")
 	if err == nil && file != nil {
-		imageURL, err := uploadImageToGitHub(file, namaBenda)
+		foto, err := uploadMediaKoleksi(file, namaBenda, true)
 		if err != nil {
-			return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+			return dasar, nil, statusErrorMedia(err), err.Error()
 		}
-		setData["foto"] = imageURL
-	} else {
-		unsetData["foto"] = ""
+		foto.ID = primitive.NewObjectID()
+		foto.Tipe = model.TipeMediaFoto
+		foto.CreatedAt = time.Now()
+		return baru, &foto, 0, ""
 	}
 
+	return baru, nil, 0, ""
+}
+
+// gabungFotoBaru menambahkan foto baru sebagai media utama tanpa mengubah list asal
+func gabungFotoBaru(media []model.Media, foto *model.Media) []model.Media {
+	if foto == nil {
+		return media
+	}
+	list := append(append([]model.Media{}, media...), *foto)
+	return rapikanMedia(list, foto.ID)
+}
+
+// UpdateKoleksi godoc
+// @Summary      Update Koleksi
+// @Description  Memperbarui data koleksi museum berdasarkan ID. Semua field bersifat opsional, kecuali "gudang_id" wajib diisi. Jika foto diupload, foto ditambahkan sebagai media utama (media lama tetap disimpan).
+// @Tags         Data Koleksi
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id                 path     string true  "ID Koleksi"
+// @Param        no_reg             formData string false "Nomor Registrasi"
+// @Param        no_inv             formData string false "Nomor Inventaris"
+// @Param        nama_benda         formData string false "Nama Benda"
+// @Param        tanggal_perolehan  formData string false "Tanggal Perolehan (format: DD-MM-YYYY)"
+// @Param        kategori_id        formData string false "ID Kategori"
+// @Param        bahan              formData string false "Bahan Benda"
+// @Param        panjang_keseluruhan formData number false "Panjang Keseluruhan (ukuran)"
+// @Param        lebar              formData number false "Lebar (ukuran)"
+// @Param        tebal              formData number false "Tebal (ukuran)"
+// @Param        tinggi             formData number false "Tinggi (ukuran)"
+// @Param        diameter           formData number false "Diameter (ukuran)"
+// @Param        satuan             formData string false "Satuan ukuran: mm, cm, m"
+// @Param        berat              formData number false "Berat (ukuran)"
+// @Param        satuan_berat       formData string false "Satuan berat: g, kg"
+// @Param        asal_koleksi       formData string false "Asal Koleksi"
+// @Param        tempat_perolehan   formData string false "Tempat Perolehan"
+// @Param        deskripsi          formData string false "Deskripsi Koleksi"
+// @Param        gudang_id          formData string true  "ID Gudang"
+// @Param        rak_id             formData string false "ID Rak"
+// @Param        tahap_id           formData string false "ID Tahap"
+// @Param        kondisi            formData string false "Kondisi Koleksi: baik, cukup, rusak_ringan, rusak_berat, kritis"
+// @Param        atribut            formData string false "Atribut tambahan sesuai skema kategori (JSON object)"
+// @Param        foto               formData file   false "Upload foto koleksi"
+// @Success      200 {object} map[string]string "Koleksi berhasil diperbarui"
+// @Success      202 {object} map[string]interface{} "Pengguna ber-role contributor: perubahan disimpan sebagai usulan yang menunggu review"
+// @Param        If-Match header string true "ETag dari GET terakhir"
+// @Failure      412 {object} map[string]interface{} "Data sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428 {object} map[string]interface{} "Header If-Match belum diisi"
+// @Router       /koleksi/{id} [put]
+// @Security     BearerAuth
+func UpdateKoleksi(c *fiber.Ctx) error {
 	// =========================
-	// UKURAN (OPSIONAL)
+	// VALIDASI ID PARAM
 	// =========================
-	ukuran := model.Ukuran{}
-	ukuran.PanjangKeseluruhan = c.FormValue("panjang_keseluruhan")
-	ukuran.Lebar = c.FormValue("lebar")
-	ukuran.Tebal = c.FormValue("tebal")
-	ukuran.Tinggi = c.FormValue("tinggi")
-	ukuran.Diameter = c.FormValue("diameter")
-	ukuran.Berat = c.FormValue("berat")
-	ukuran.Satuan = c.FormValue("satuan")
-	ukuran.SatuanBerat = c.FormValue("satuan_berat")
-
-	if ukuran != (model.Ukuran{}) {
-		setData["ukuran"] = ukuran
-	} else {
-		unsetData["ukuran"] = ""
+	id := c.Params("id")
+	koleksiID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "ID koleksi tidak valid"})
 	}
 
-	setData["updated_at"] = time.Now()
+	// =========================
+	// CONTEXT
+	// =========================
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := config.Ulbimongoconn.Collection("koleksi")
 
 	// =========================
-	// EXECUTE UPDATE
+	// AMBIL DATA LAMA
 	// =========================
-	update := bson.M{}
-	if len(setData) > 0 {
-		update["$set"] = setData
+	var existing model.Koleksi
+	if err := collection.FindOne(ctx, bson.M{"_id": koleksiID}).Decode(&existing); err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Koleksi tidak ditemukan"})
+	}
+
+	if ok, err := cekIfMatch(c, existing.Versi); !ok {
+		return err
 	}
-	if len(unsetData) > 0 {
-		update["$unset"] = unsetData
+
+	baru, foto, status, errMsg := koleksiUbahDariForm(ctx, c, existing)
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": errMsg})
 	}
+	baru.Media = gabungFotoBaru(existing.Media, foto)
 
-	if _, err := collection.UpdateOne(ctx, bson.M{"_id": koleksiID}, update); err != nil {
+	// =========================
+	// KONTRIBUTOR → USULAN PERUBAHAN
+	// =========================
+	if perluReview(c) {
+		return ajukanPerubahanKoleksi(ctx, c, model.JenisPerubahanUbah, baru, &existing, foto, nil)
+	}
+
+	// =========================
+	// EXECUTE UPDATE
+	// =========================
+	res, err := collection.UpdateOne(ctx, filterVersiKoleksi(koleksiID, existing.Versi), updateFieldKoleksi(baru, foto != nil, time.Now()))
+	if (err != nil || res.MatchedCount == 0) && foto != nil {
+		// foto baru batal dipakai
+		lepasRefMedia(ctx, foto.SemuaKey())
+	}
+	if err != nil {
+		if pesan := pesanDuplikatKoleksi(err); pesan != "" {
+			return c.Status(400).JSON(fiber.Map{"error": pesan})
+		}
 		return c.Status(500).JSON(fiber.Map{"error": "Gagal update data"})
 	}
+	if res.MatchedCount == 0 {
+		versi, _ := versiDokumen(ctx, collection, koleksiID)
+		return tolakVersiBerubah(c, versi)
+	}
+	catatNomorManual(ctx, baru, "no_reg", "no_inv")
+	catatRiwayatKoleksi(ctx, model.RiwayatKoleksi{
+		KoleksiID: koleksiID,
+		Aksi:      model.JenisPerubahanUbah,
+		Field:     fieldBerubah(existing, baru),
+		Oleh:      penggunaLogin(c),
+	})
 
 	return c.JSON(fiber.Map{
 		"message": "Koleksi berhasil diperbarui",
@@ -804,13 +843,26 @@ func ifNotEmpty(newValue, oldValue string) string {
 
 // DeleteKoleksiByID godoc
 // @Summary      Delete Koleksi by ID
-// @Description  Menghapus data koleksi berdasarkan ID (wajib autentikasi JWT Bearer)
+// @Description  Menghapus data koleksi berdasarkan ID (wajib autentikasi JWT Bearer). Koleksi yang punya usulan deaksesi tidak bisa dihapus agar jejak auditnya tetap ada.
+// @Description  Koleksi yang sedang dipinjamkan, terjadwal di peminjaman keluar yang disetujui, atau di pameran yang sedang / akan berlangsung juga ditolak (409).
 // @Tags         Data Koleksi
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id   path      string  true  "ID koleksi"
+// @Param        If-Match header string true "ETag dari GET terakhir"
+// @Failure      403 {object} map[string]interface{} "Kontributor tidak boleh menghapus koleksi"
+// @Failure      409 {object} map[string]interface{} "Koleksi punya usulan deaksesi, sedang dipinjamkan, atau terjadwal"
+// @Failure      412 {object} map[string]interface{} "Data sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428 {object} map[string]interface{} "Header If-Match belum diisi"
 // @Router       /koleksi/{id} [delete]
 func DeleteKoleksiByID(c *fiber.Ctx) error {
+	// Hapus permanen tidak bisa direview; kontributor mengajukan deaksesi sebagai gantinya
+	if perluReview(c) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Kontributor tidak boleh menghapus koleksi, ajukan deaksesi untuk dikaji admin",
+		})
+	}
+
 	// Ambil ID dari parameter URL
 	idParam := c.Params("id")
 	id, err := primitive.ObjectIDFromHex(idParam)
@@ -827,20 +879,77 @@ func DeleteKoleksiByID(c *fiber.Ctx) error {
 	// Filter berdasarkan ID
 	filter := bson.M{"_id": id}
 
-	// Hapus data
-	result, err := col.DeleteOne(context.TODO(), filter)
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	// Koleksi dalam / setelah proses deaksesi harus tetap tercatat
+	if n, _ := col.CountDocuments(ctx, bson.M{"_id": id, "deaksesi": bson.M{"$exists": true}}); n > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Koleksi memiliki usulan deaksesi dan tidak bisa dihapus, batalkan usulannya terlebih dahulu",
+		})
+	}
+
+	// Koleksi yang sedang dipinjamkan, terjadwal dipinjamkan, atau dipamerkan tidak boleh hilang dari jadwal.
+	// Jadwal dikunci supaya tidak ada pameran / peminjaman yang ditambahkan di antara cek dan hapus.
+	lepas, err := kunciJadwalKoleksi(ctx, []primitive.ObjectID{id})
+	if err != nil {
+		return gagalKunciJadwal(c, err)
+	}
+	defer lepas()
+	if n, _ := col.CountDocuments(ctx, bson.M{"_id": id, "$or": []bson.M{
+		{"status_lokasi": model.StatusLokasiDipinjamkan},
+		{"pinjaman_aktif": bson.M{"$exists": true}},
+	}}); n > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Koleksi sedang dipinjamkan dan tidak bisa dihapus",
+		})
+	}
+	bentrok, err := jadwalBentrok(ctx, []primitive.ObjectID{id}, awalHari(time.Now()), nil, primitive.NilObjectID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": fmt.Sprintf("Gagal menghapus data untuk ID %s: %s", idParam, err.Error()),
+			"error": "Gagal memeriksa jadwal koleksi",
+		})
+	}
+	if len(bentrok) > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   "Keluarkan koleksi dari jadwal berikut sebelum dihapus. " + pesanBentrok(bentrok),
+			"bentrok": bentrok,
 		})
 	}
 
-	// Jika data tidak ditemukan
-	if result.DeletedCount == 0 {
+	// Hanya hapus jika versi sesuai header If-Match
+	versi, err := versiDokumen(ctx, col, id)
+	if err == mongo.ErrNoDocuments {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error": fmt.Sprintf("Data dengan ID %s tidak ditemukan", idParam),
 		})
 	}
+	if ok, err := cekIfMatch(c, versi); !ok {
+		return err
+	}
+	filter = filterVersi(id, versi)
+
+	// Hapus data (dokumen lama dibutuhkan untuk membersihkan file media)
+	var dihapus model.Koleksi
+	err = col.FindOneAndDelete(ctx, filter).Decode(&dihapus)
+
+	// Versi berubah di antara pengecekan dan penghapusan
+	if err == mongo.ErrNoDocuments {
+		versi, _ := versiDokumen(ctx, col, id)
+		return tolakVersiBerubah(c, versi)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fmt.Sprintf("Gagal menghapus data untuk ID %s: %s", idParam, err.Error()),
+		})
+	}
+
+	// File media yang tidak dipakai koleksi lain ikut dibersihkan dari storage
+	for _, m := range dihapus.Media {
+		lepasRefMedia(ctx, keyMediaKoleksi(m))
+	}
+	hapusRiwayatKondisi(ctx, dihapus.ID)
+	hapusPenilaianKoleksi(ctx, dihapus.ID)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": fmt.Sprintf("Koleksi dengan ID %s berhasil dihapus", idParam),
@@ -0,0 +1,539 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// =============================================================
+// 🩹 PATCH koleksi: JSON Merge Patch (RFC 7396) & JSON Patch (RFC 6902)
+// =============================================================
+
+// errTestPatch menandai operasi "test" JSON Patch yang tidak cocok
+var errTestPatch = errors.New("nilai tidak sama dengan value operasi test")
+
+// dokumenPatchKoleksi mengubah koleksi menjadi dokumen yang menjadi sasaran patch
+func dokumenPatchKoleksi(k model.Koleksi) model.PatchKoleksi {
+	tempat := k.TempatPenyimpanan
+	doc := model.PatchKoleksi{
+		NoRegistrasi:     k.NoRegistrasi,
+		NoInventaris:     k.NoInventaris,
+		NamaBenda:        k.NamaBenda,
+		Catatan:          tempat.Catatan,
+		AsalKoleksi:      k.AsalKoleksi,
+		Bahan:            k.Bahan,
+		TempatPerolehan:  k.TempatPerolehan,
+		TanggalPerolehan: k.TanggalPerolehan,
+		Deskripsi:        k.Deskripsi,
+		Kondisi:          k.Kondisi,
+		Atribut:          k.Atribut,
+	}
+	hex := func(id primitive.ObjectID) string {
+		if id.IsZero() {
+			return ""
+		}
+		return id.Hex()
+	}
+	doc.KategoriID = hex(k.Kategori.ID)
+	doc.GudangID = hex(tempat.Gudang.ID)
+	doc.RakID = hex(tempat.Rak.ID)
+	doc.TahapID = hex(tempat.Tahap.ID)
+	if u := k.Ukuran; u != nil {
+		doc.Ukuran = &model.PatchUkuran{
+			PanjangKeseluruhan: u.PanjangKeseluruhan,
+			Lebar:              u.Lebar,
+			Tebal:              u.Tebal,
+			Tinggi:             u.Tinggi,
+			Diameter:           u.Diameter,
+			Satuan:             u.Satuan,
+			Berat:              u.Berat,
+			SatuanBerat:        u.SatuanBerat,
+			Catatan:            u.Catatan,
+		}
+	}
+	return doc
+}
+
+// keMapJSON mengubah nilai menjadi bentuk JSON generik (map / float64 / string / bool)
+func keMapJSON(v interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	hasil := map[string]interface{}{}
+	err = json.Unmarshal(raw, &hasil)
+	return hasil, err
+}
+
+// terapkanMergePatch menerapkan JSON Merge Patch: object digabung rekursif, null menghapus field
+func terapkanMergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for key, nilai := range p {
+		if nilai == nil {
+			delete(t, key)
+		} else {
+			t[key] = terapkanMergePatch(t[key], nilai)
+		}
+	}
+	return t
+}
+
+// tokenPointer memecah JSON Pointer ("/atribut/nominal") menjadi token
+func tokenPointer(path string) ([]string, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("path '%s' harus diawali '/'", path)
+	}
+	token := strings.Split(path[1:], "/")
+	for i, t := range token {
+		token[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return token, nil
+}
+
+// indukPointer mengembalikan object induk & key terakhir dari sebuah JSON Pointer
+func indukPointer(doc map[string]interface{}, path string) (map[string]interface{}, string, error) {
+	token, err := tokenPointer(path)
+	if err != nil {
+		return nil, "", err
+	}
+	induk := doc
+	for _, t := range token[:len(token)-1] {
+		anak, ok := induk[t].(map[string]interface{})
+		if !ok {
+			return nil, "", fmt.Errorf("path '%s' tidak ditemukan", path)
+		}
+		induk = anak
+	}
+	return induk, token[len(token)-1], nil
+}
+
+// terapkanJSONPatch menerapkan operasi JSON Patch berurutan. Dokumen koleksi tidak memiliki array,
+// sehingga path hanya menunjuk field object.
+func terapkanJSONPatch(doc map[string]interface{}, operasi []model.OperasiJSONPatch) error {
+	ambil := func(path string) (map[string]interface{}, string, interface{}, error) {
+		induk, key, err := indukPointer(doc, path)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		nilai, ok := induk[key]
+		if !ok {
+			return nil, "", nil, fmt.Errorf("path '%s' tidak ditemukan", path)
+		}
+		return induk, key, nilai, nil
+	}
+
+	for i, op := range operasi {
+		gagal := func(err error) error {
+			if errors.Is(err, errTestPatch) {
+				return fmt.Errorf("operasi ke-%d (test %s): %w", i+1, op.Path, err)
+			}
+			return fmt.Errorf("operasi ke-%d (%s %s): %v", i+1, op.Op, op.Path, err)
+		}
+
+		switch op.Op {
+		case "add":
+			induk, key, err := indukPointer(doc, op.Path)
+			if err != nil {
+				return gagal(err)
+			}
+			induk[key] = op.Value
+
+		case "remove", "replace":
+			induk, key, _, err := ambil(op.Path)
+			if err != nil {
+				return gagal(err)
+			}
+			if op.Op == "remove" {
+				delete(induk, key)
+			} else {
+				induk[key] = op.Value
+			}
+
+		case "move", "copy":
+			asal, keyAsal, nilai, err := ambil(op.From)
+			if err != nil {
+				return gagal(err)
+			}
+			if op.Op == "move" {
+				delete(asal, keyAsal)
+			} else if raw, err := json.Marshal(nilai); err == nil {
+				// salin nilai agar object tujuan tidak berbagi map dengan asal
+				json.Unmarshal(raw, &nilai)
+			}
+			induk, key, err := indukPointer(doc, op.Path)
+			if err != nil {
+				return gagal(err)
+			}
+			induk[key] = nilai
+
+		case "test":
+			_, _, nilai, err := ambil(op.Path)
+			if err != nil {
+				return gagal(err)
+			}
+			if !reflect.DeepEqual(nilai, op.Value) {
+				return gagal(errTestPatch)
+			}
+
+		default:
+			return gagal(fmt.Errorf("op harus add, remove, replace, move, copy, atau test"))
+		}
+	}
+	return nil
+}
+
+// buangNilaiNull menghapus field bernilai null (dari JSON Patch) agar artinya sama dengan mengosongkan field
+func buangNilaiNull(doc map[string]interface{}) {
+	for key, nilai := range doc {
+		switch v := nilai.(type) {
+		case nil:
+			delete(doc, key)
+		case map[string]interface{}:
+			buangNilaiNull(v)
+		}
+	}
+}
+
+// bacaDokumenPatch mengubah hasil patch kembali menjadi PatchKoleksi dengan pesan error yang mudah dibaca
+func bacaDokumenPatch(doc map[string]interface{}) (model.PatchKoleksi, string) {
+	var hasil model.PatchKoleksi
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return hasil, "Hasil patch tidak valid"
+	}
+	dec := json.NewDecoder(strings.NewReader(string(raw)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&hasil); err != nil {
+		var errTipe *json.UnmarshalTypeError
+		if errors.As(err, &errTipe) {
+			return hasil, fmt.Sprintf("Field '%s' harus bertipe %s", errTipe.Field, errTipe.Type.String())
+		}
+		if pesan := err.Error(); strings.HasPrefix(pesan, "json: unknown field ") {
+			return hasil, fmt.Sprintf("Field %s tidak dikenal atau tidak bisa diubah lewat PATCH", strings.TrimPrefix(pesan, "json: unknown field "))
+		}
+		return hasil, "Hasil patch tidak valid: " + err.Error()
+	}
+	return hasil, ""
+}
+
+// koleksiDariPatch menerapkan dokumen hasil patch ke koleksi. Validasi hanya dijalankan untuk field yang berubah.
+func koleksiDariPatch(ctx context.Context, dasar model.Koleksi, p model.PatchKoleksi, berubah func(string) bool) (model.Koleksi, int, string) {
+	db := config.Ulbimongoconn
+	baru := dasar
+
+	// =========================
+	// FIELD WAJIB
+	// =========================
+	for _, wajib := range []struct{ key, nilai, label string }{
+		{"kategori_id", p.KategoriID, "ID Kategori"},
+		{"no_reg", p.NoRegistrasi, "No registrasi"},
+		{"no_inv", p.NoInventaris, "No inventaris"},
+		{"nama_benda", p.NamaBenda, "Nama benda"},
+		{"gudang_id", p.GudangID, "ID Gudang"},
+	} {
+		if berubah(wajib.key) && strings.TrimSpace(wajib.nilai) == "" {
+			return dasar, 400, wajib.label + " tidak boleh kosong."
+		}
+	}
+
+	// =========================
+	// KATEGORI, ATRIBUT & NOMOR
+	// =========================
+	if berubah("kategori_id") || berubah("atribut") || berubah("no_reg") || berubah("no_inv") {
+		objKategoriID, err := primitive.ObjectIDFromHex(p.KategoriID)
+		if err != nil {
+			return dasar, 400, "ID kategori tidak valid"
+		}
+		var kategori model.Kategori
+		if err := db.Collection("kategori").FindOne(ctx, bson.M{"_id": objKategoriID}).Decode(&kategori); err != nil {
+			return dasar, 404, "Kategori tidak ditemukan"
+		}
+		if berubah("kategori_id") {
+			baru.Kategori = snapshotKategori(kategori)
+		}
+
+		if berubah("kategori_id") || berubah("atribut") {
+			skema, err := skemaAtributKategori(ctx, kategori)
+			if err != nil {
+				return dasar, 500, "Gagal mengambil skema atribut kategori"
+			}
+			raw, _ := json.Marshal(p.Atribut)
+			atribut, errMsg := validasiAtributKoleksi(skema, string(raw))
+			if errMsg != "" {
+				return dasar, 400, errMsg
+			}
+			baru.Atribut = atribut
+		}
+
+		for _, nomor := range []struct {
+			field string
+			nilai string
+			tuju  *string
+		}{
+			{"no_reg", p.NoRegistrasi, &baru.NoRegistrasi},
+			{"no_inv", p.NoInventaris, &baru.NoInventaris},
+		} {
+			if !berubah(nomor.field) {
+				continue
+			}
+			if _, _, status, errMsg := siapkanNomorKoleksi(ctx, nomor.field, nomor.nilai, kategori); errMsg != "" {
+				return dasar, status, errMsg
+			}
+			*nomor.tuju = nomor.nilai
+		}
+	}
+
+	// =========================
+	// LOKASI
+	// =========================
+	if berubah("gudang_id") {
+		objGudangID, err := primitive.ObjectIDFromHex(p.GudangID)
+		if err != nil {
+			return dasar, 400, "ID gudang tidak valid"
+		}
+		var gudang model.Gudang
+		if err := db.Collection("gudang").FindOne(ctx, bson.M{"_id": objGudangID}).Decode(&gudang); err != nil {
+			return dasar, 404, "Gudang tidak ditemukan"
+		}
+		baru.TempatPenyimpanan.Gudang = model.Gudang{ID: gudang.ID, NamaGudang: gudang.NamaGudang}
+	}
+	if berubah("rak_id") {
+		baru.TempatPenyimpanan.Rak = model.Rak{}
+		if p.RakID != "" {
+			objRakID, err := primitive.ObjectIDFromHex(p.RakID)
+			if err != nil {
+				return dasar, 400, "ID rak tidak valid"
+			}
+			var rak model.Rak
+			if err := db.Collection("rak").FindOne(ctx, bson.M{"_id": objRakID}).Decode(&rak); err != nil {
+				return dasar, 404, "Rak tidak ditemukan"
+			}
+			baru.TempatPenyimpanan.Rak = model.Rak{ID: rak.ID, NamaRak: rak.NamaRak}
+		}
+	}
+	if berubah("tahap_id") {
+		baru.TempatPenyimpanan.Tahap = model.Tahap{}
+		if p.TahapID != "" {
+			objTahapID, err := primitive.ObjectIDFromHex(p.TahapID)
+			if err != nil {
+				return dasar, 400, "ID tahap tidak valid"
+			}
+			var tahap model.Tahap
+			if err := db.Collection("tahap").FindOne(ctx, bson.M{"_id": objTahapID}).Decode(&tahap); err != nil {
+				return dasar, 404, "Tahap tidak ditemukan"
+			}
+			baru.TempatPenyimpanan.Tahap = model.Tahap{ID: tahap.ID, NamaTahap: tahap.NamaTahap}
+		}
+	}
+
+	// =========================
+	// UKURAN
+	// =========================
+	if berubah("ukuran") {
+		nilai := map[string]string{}
+		catatan := ""
+		if u := p.Ukuran; u != nil {
+			angka := func(v *float64) string {
+				if v == nil {
+					return ""
+				}
+				return strconv.FormatFloat(*v, 'f', -1, 64)
+			}
+			nilai = map[string]string{
+				"panjang_keseluruhan": angka(u.PanjangKeseluruhan),
+				"lebar":               angka(u.Lebar),
+				"tebal":               angka(u.Tebal),
+				"tinggi":              angka(u.Tinggi),
+				"diameter":            angka(u.Diameter),
+				"satuan":              u.Satuan,
+				"berat":               angka(u.Berat),
+				"satuan_berat":        u.SatuanBerat,
+			}
+			catatan = u.Catatan
+		}
+		ukuran, errMsg := validasiUkuran(func(key string) string { return nilai[key] })
+		if errMsg != "" {
+			return dasar, 400, errMsg
+		}
+		if ukuran == nil && catatan != "" {
+			ukuran = &model.Ukuran{}
+		}
+		if ukuran != nil {
+			ukuran.Catatan = catatan
+			if dasar.Ukuran != nil {
+				ukuran.ID = dasar.Ukuran.ID
+			}
+		}
+		baru.Ukuran = ukuran
+	}
+
+	// =========================
+	// FIELD TEKS OPSIONAL
+	// =========================
+	baru.NamaBenda = p.NamaBenda
+	baru.TempatPenyimpanan.Catatan = p.Catatan
+	baru.AsalKoleksi = p.AsalKoleksi
+	baru.Bahan = p.Bahan
+	baru.TempatPerolehan = p.TempatPerolehan
+	baru.TanggalPerolehan = p.TanggalPerolehan
+	baru.Deskripsi = p.Deskripsi
+	var errMsg string
+	if baru.Kondisi, errMsg = kondisiKoleksi(p.Kondisi, dasar.Kondisi); errMsg != "" {
+		return dasar, 400, errMsg
+	}
+
+	return baru, 0, ""
+}
+
+// PatchKoleksi godoc
+// @Summary      Ubah sebagian data koleksi
+// @Description  Mengubah hanya field yang dikirim, field lain tidak tersentuh.
+// @Description  Content-Type `application/merge-patch+json` (atau `application/json`): JSON Merge Patch, nilai null mengosongkan field opsional, object `atribut` & `ukuran` digabung per field.
+// @Description  Content-Type `application/json-patch+json`: array operasi JSON Patch (add / remove / replace / move / copy / test) dengan path seperti `/deskripsi` atau `/atribut/nominal`.
+// @Description  Validasi hanya dijalankan untuk field yang berubah (mis. atribut divalidasi ulang jika atribut atau kategori berubah). Media dikelola lewat /koleksi/{id}/media.
+// @Description  Pengguna ber-role contributor: perubahan disimpan sebagai usulan yang menunggu review.
+// @Tags         Data Koleksi
+// @Accept       json
+// @Produce      json
+// @Param        id       path   string             true "ID Koleksi"
+// @Param        If-Match header string             true "ETag dari GET /koleksi/{id}"
+// @Param        body     body   model.PatchKoleksi true "Field yang diubah (merge patch) atau array model.OperasiJSONPatch (JSON Patch)"
+// @Success      200 {object} map[string]interface{} "Koleksi berhasil diperbarui"
+// @Success      202 {object} map[string]interface{} "Usulan perubahan menunggu review"
+// @Failure      400 {object} map[string]string
+// @Failure      409 {object} map[string]string "Operasi test JSON Patch gagal"
+// @Failure      412 {object} map[string]interface{} "Koleksi sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      415 {object} map[string]string
+// @Failure      428 {object} map[string]interface{} "Header If-Match belum diisi"
+// @Router       /koleksi/{id} [patch]
+// @Security     BearerAuth
+func PatchKoleksi(c *fiber.Ctx) error {
+	koleksiID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "ID koleksi tidak valid"})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := config.Ulbimongoconn.Collection("koleksi")
+
+	var existing model.Koleksi
+	if err := collection.FindOne(ctx, bson.M{"_id": koleksiID}).Decode(&existing); err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Koleksi tidak ditemukan"})
+	}
+	if ok, err := cekIfMatch(c, existing.Versi); !ok {
+		return err
+	}
+
+	// =========================
+	// TERAPKAN PATCH KE DOKUMEN
+	// =========================
+	awal, err := keMapJSON(dokumenPatchKoleksi(existing))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal membaca data koleksi"})
+	}
+	hasil, _ := keMapJSON(awal) // salinan yang diubah patch
+
+	contentType := strings.ToLower(string(c.Request().Header.ContentType()))
+	switch {
+	case strings.HasPrefix(contentType, "application/json-patch+json"):
+		var operasi []model.OperasiJSONPatch
+		if err := json.Unmarshal(c.Body(), &operasi); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "Body JSON Patch harus berupa array operasi"})
+		}
+		if err := terapkanJSONPatch(hasil, operasi); err != nil {
+			if errors.Is(err, errTestPatch) {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		buangNilaiNull(hasil)
+
+	case contentType == "", strings.HasPrefix(contentType, "application/merge-patch+json"), strings.HasPrefix(contentType, "application/json"):
+		var patch map[string]interface{}
+		if err := json.Unmarshal(c.Body(), &patch); err != nil || patch == nil {
+			return c.Status(400).JSON(fiber.Map{"error": "Body merge patch harus berupa JSON object"})
+		}
+		hasil = terapkanMergePatch(hasil, patch).(map[string]interface{})
+
+	default:
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{
+			"error": "Content-Type harus application/merge-patch+json atau application/json-patch+json",
+		})
+	}
+
+	p, errMsg := bacaDokumenPatch(hasil)
+	if errMsg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": errMsg})
+	}
+	// bandingkan dalam bentuk yang sudah dibaca ulang agar "" dan null dianggap sama
+	akhir, _ := keMapJSON(p)
+	berubah := func(key string) bool { return !reflect.DeepEqual(awal[key], akhir[key]) }
+
+	// =========================
+	// VALIDASI FIELD YANG BERUBAH
+	// =========================
+	baru, status, errMsg := koleksiDariPatch(ctx, existing, p, berubah)
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": errMsg})
+	}
+
+	field := fieldBerubah(existing, baru)
+	if len(field) == 0 {
+		return c.JSON(fiber.Map{"message": "Tidak ada perubahan"})
+	}
+
+	// =========================
+	// KONTRIBUTOR → USULAN PERUBAHAN
+	// =========================
+	if perluReview(c) {
+		return ajukanPerubahanKoleksi(ctx, c, model.JenisPerubahanUbah, baru, &existing, nil, nil)
+	}
+
+	// =========================
+	// SIMPAN HANYA FIELD YANG BERUBAH
+	// =========================
+	res, err := collection.UpdateOne(ctx, filterVersiKoleksi(koleksiID, existing.Versi), updateFieldBerubah(baru, field, time.Now()))
+	if err != nil {
+		if pesan := pesanDuplikatKoleksi(err); pesan != "" {
+			return c.Status(400).JSON(fiber.Map{"error": pesan})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal update data"})
+	}
+	if res.MatchedCount == 0 {
+		versi, _ := versiDokumen(ctx, collection, koleksiID)
+		return tolakVersiBerubah(c, versi)
+	}
+	catatNomorManual(ctx, baru, field...)
+	catatRiwayatKoleksi(ctx, model.RiwayatKoleksi{
+		KoleksiID: koleksiID,
+		Aksi:      model.JenisPerubahanUbah,
+		Field:     field,
+		Oleh:      penggunaLogin(c),
+	})
+
+	return c.JSON(fiber.Map{
+		"message": "Koleksi berhasil diperbarui",
+		"field":   field,
+	})
+}
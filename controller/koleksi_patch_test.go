@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"be-internship/model"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// dariJSON mengubah string JSON menjadi nilai Go seperti hasil keMapJSON / body request
+func dariJSON(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("JSON uji %q tidak valid: %v", s, err)
+	}
+	return v
+}
+
+func TestTokenPointer(t *testing.T) {
+	tests := []struct {
+		path  string
+		want  []string
+		gagal bool
+	}{
+		{path: "/deskripsi", want: []string{"deskripsi"}},
+		{path: "/atribut/nominal", want: []string{"atribut", "nominal"}},
+		{path: "/atribut/a~1b", want: []string{"atribut", "a/b"}},
+		{path: "/atribut/m~0n", want: []string{"atribut", "m~n"}},
+		{path: "/~01", want: []string{"~1"}}, // ~0 didekode setelah ~1, bukan menjadi "/"
+		{path: "/", want: []string{""}},      // key kosong
+		{path: "", gagal: true},              // pointer kosong (seluruh dokumen) tidak didukung
+		{path: "deskripsi", gagal: true},
+	}
+
+	for _, tt := range tests {
+		got, err := tokenPointer(tt.path)
+		if tt.gagal {
+			if err == nil {
+				t.Errorf("tokenPointer(%q) = %q, want error", tt.path, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("tokenPointer(%q) error: %v", tt.path, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("tokenPointer(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestTerapkanMergePatch(t *testing.T) {
+	tests := []struct {
+		target, patch, want string
+	}{
+		{`{"a": "b"}`, `{"a": "c"}`, `{"a": "c"}`},
+		{`{"a": "b"}`, `{"b": "c"}`, `{"a": "b", "b": "c"}`},
+		{`{"a": "b"}`, `{"a": null}`, `{}`},
+		{`{"a": "b", "b": "c"}`, `{"a": null}`, `{"b": "c"}`},
+		{`{"a": {"b": "c"}}`, `{"a": {"b": "d", "c": null}}`, `{"a": {"b": "d"}}`},
+		{`{"a": "c"}`, `{"a": {"b": "c"}}`, `{"a": {"b": "c"}}`},
+		{`{"a": {"b": "c"}}`, `{"a": 1}`, `{"a": 1}`},
+		{`{"a": [1, 2]}`, `{"a": [3]}`, `{"a": [3]}`}, // array selalu diganti utuh
+		{`{"e": null}`, `{"a": 1}`, `{"e": null, "a": 1}`},
+		{`{}`, `{"a": {"bb": {"ccc": null}}}`, `{"a": {"bb": {}}}`},
+		{`{"a": "b"}`, `{}`, `{"a": "b"}`},
+	}
+
+	for _, tt := range tests {
+		got := terapkanMergePatch(dariJSON(t, tt.target), dariJSON(t, tt.patch))
+		if want := dariJSON(t, tt.want); !reflect.DeepEqual(got, want) {
+			t.Errorf("terapkanMergePatch(%s, %s) = %v, want %v", tt.target, tt.patch, got, want)
+		}
+	}
+}
+
+func TestTerapkanJSONPatch(t *testing.T) {
+	const dasar = `{"nama_benda": "Keris", "deskripsi": "lama", "atribut": {"nominal": 100, "a/b": "garis", "m~n": "tilde"}}`
+
+	tests := []struct {
+		nama    string
+		operasi string
+		want    string
+		gagal   bool
+	}{
+		{nama: "add field baru", operasi: `[{"op": "add", "path": "/catatan", "value": "baru"}]`,
+			want: `{"nama_benda": "Keris", "deskripsi": "lama", "catatan": "baru", "atribut": {"nominal": 100, "a/b": "garis", "m~n": "tilde"}}`},
+		{nama: "replace & remove bertingkat", operasi: `[{"op": "replace", "path": "/atribut/nominal", "value": 200}, {"op": "remove", "path": "/deskripsi"}]`,
+			want: `{"nama_benda": "Keris", "atribut": {"nominal": 200, "a/b": "garis", "m~n": "tilde"}}`},
+		{nama: "move", operasi: `[{"op": "move", "from": "/deskripsi", "path": "/catatan"}]`,
+			want: `{"nama_benda": "Keris", "catatan": "lama", "atribut": {"nominal": 100, "a/b": "garis", "m~n": "tilde"}}`},
+		{nama: "copy object lalu ubah salinan", operasi: `[{"op": "copy", "from": "/atribut", "path": "/salinan"}, {"op": "replace", "path": "/salinan/nominal", "value": 1}]`,
+			want: `{"nama_benda": "Keris", "deskripsi": "lama", "atribut": {"nominal": 100, "a/b": "garis", "m~n": "tilde"}, "salinan": {"nominal": 1, "a/b": "garis", "m~n": "tilde"}}`},
+		{nama: "test cocok lalu replace", operasi: `[{"op": "test", "path": "/atribut/nominal", "value": 100}, {"op": "replace", "path": "/deskripsi", "value": "baru"}]`,
+			want: `{"nama_benda": "Keris", "deskripsi": "baru", "atribut": {"nominal": 100, "a/b": "garis", "m~n": "tilde"}}`},
+		{nama: "escape ~1 dan ~0", operasi: `[{"op": "replace", "path": "/atribut/a~1b", "value": "x"}, {"op": "remove", "path": "/atribut/m~0n"}]`,
+			want: `{"nama_benda": "Keris", "deskripsi": "lama", "atribut": {"nominal": 100, "a/b": "x"}}`},
+		{nama: "move dengan escape", operasi: `[{"op": "move", "from": "/atribut/m~0n", "path": "/atribut/a~1c"}]`,
+			want: `{"nama_benda": "Keris", "deskripsi": "lama", "atribut": {"nominal": 100, "a/b": "garis", "a/c": "tilde"}}`},
+
+		{nama: "test tidak cocok", operasi: `[{"op": "test", "path": "/atribut/nominal", "value": 99}]`, gagal: true},
+		{nama: "test path tidak ada", operasi: `[{"op": "test", "path": "/catatan", "value": "x"}]`, gagal: true},
+		{nama: "remove path tidak ada", operasi: `[{"op": "remove", "path": "/catatan"}]`, gagal: true},
+		{nama: "replace path tidak ada", operasi: `[{"op": "replace", "path": "/atribut/warna", "value": "x"}]`, gagal: true},
+		{nama: "add induk tidak ada", operasi: `[{"op": "add", "path": "/tidak/ada", "value": 1}]`, gagal: true},
+		{nama: "move from tidak ada", operasi: `[{"op": "move", "from": "/catatan", "path": "/deskripsi"}]`, gagal: true},
+		{nama: "copy from kosong", operasi: `[{"op": "copy", "path": "/deskripsi"}]`, gagal: true},
+		{nama: "pointer kosong", operasi: `[{"op": "replace", "path": "", "value": {}}]`, gagal: true},
+		{nama: "pointer tanpa /", operasi: `[{"op": "add", "path": "deskripsi", "value": "x"}]`, gagal: true},
+		{nama: "op tidak dikenal", operasi: `[{"op": "ganti", "path": "/deskripsi", "value": "x"}]`, gagal: true},
+	}
+
+	for _, tt := range tests {
+		doc := dariJSON(t, dasar).(map[string]interface{})
+		var operasi []model.OperasiJSONPatch
+		if err := json.Unmarshal([]byte(tt.operasi), &operasi); err != nil {
+			t.Fatalf("%s: operasi uji tidak valid: %v", tt.nama, err)
+		}
+
+		err := terapkanJSONPatch(doc, operasi)
+		if tt.gagal {
+			if err == nil {
+				t.Errorf("%s: terapkanJSONPatch = %v, want error", tt.nama, doc)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: terapkanJSONPatch error: %v", tt.nama, err)
+			continue
+		}
+		if want := dariJSON(t, tt.want); !reflect.DeepEqual(doc, want) {
+			t.Errorf("%s: terapkanJSONPatch = %v, want %v", tt.nama, doc, want)
+		}
+	}
+}
+
+func TestTerapkanJSONPatchTestGagal(t *testing.T) {
+	doc := dariJSON(t, `{"deskripsi": "lama"}`).(map[string]interface{})
+	err := terapkanJSONPatch(doc, []model.OperasiJSONPatch{{Op: "test", Path: "/deskripsi", Value: "baru"}})
+	if !errors.Is(err, errTestPatch) {
+		t.Errorf("terapkanJSONPatch test gagal = %v, want errTestPatch", err)
+	}
+}
@@ -0,0 +1,812 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// =============================================================
+// 🩺 Laporan kondisi & log perawatan konservasi koleksi
+// =============================================================
+
+// maksFotoKondisi membatasi jumlah foto per field dalam satu request
+const maksFotoKondisi = 10
+
+// pesanKondisiTidakValid dikirim jika kondisi tidak sesuai skala model.TingkatKondisi
+const pesanKondisiTidakValid = "Kondisi harus salah satu dari: baik, cukup, rusak_ringan, rusak_berat, kritis"
+
+// kondisiKoleksi memvalidasi field kondisi koleksi yang diisi lewat form, patch, atau ubah massal.
+// Kosong diterima; nilai lama di luar skala tetap diterima selama tidak diubah.
+func kondisiKoleksi(kondisi, lama string) (string, string) {
+	kondisi = strings.TrimSpace(kondisi)
+	if kondisi == "" || kondisi == lama {
+		return kondisi, ""
+	}
+	kondisi = strings.ToLower(kondisi)
+	if _, ok := model.TingkatKondisi[kondisi]; !ok {
+		return "", pesanKondisiTidakValid
+	}
+	return kondisi, ""
+}
+
+// tanggalKondisi membaca tanggal (YYYY-MM-DD) dari form; kosong = hari ini
+func tanggalKondisi(teks string) (time.Time, error) {
+	teks = strings.TrimSpace(teks)
+	if teks == "" {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Parse("2006-01-02", teks)
+}
+
+// uploadFotoKondisi mengupload seluruh file pada satu field form sebagai media tipe kondisi.
+// Jika salah satu foto gagal, reference file yang sudah terupload dilepas lagi.
+// Pemanggil wajib melepas reference foto yang dikembalikan jika data gagal disimpan.
+func uploadFotoKondisi(c *fiber.Ctx, field, namaBenda, keterangan string) ([]model.Media, int, string) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		// bukan multipart → tidak ada foto
+		return nil, 0, ""
+	}
+	files := form.File[field]
+	if len(files) > maksFotoKondisi {
+		return nil, fiber.StatusBadRequest, fmt.Sprintf("Maksimal %d foto pada field %s", maksFotoKondisi, field)
+	}
+
+	var list []model.Media
+	for i, file := range files {
+		media, err := uploadMediaKoleksi(file, namaBenda, true)
+		if err != nil {
+			lepasRefMedia(context.Background(), keyDariMedia(list))
+			return nil, statusErrorMedia(err), fmt.Sprintf("Gagal upload %s: %v", file.Filename, err)
+		}
+		media.ID = primitive.NewObjectID()
+		media.Tipe = model.TipeMediaKondisi
+		media.Keterangan = keterangan
+		media.Urutan = i
+		media.CreatedAt = time.Now()
+		list = append(list, media)
+	}
+	return list, 0, ""
+}
+
+// keyDariMedia mengumpulkan key storage dari beberapa media sekaligus
+func keyDariMedia(list []model.Media) []string {
+	var keys []string
+	for _, m := range list {
+		keys = append(keys, keyMediaKoleksi(m)...)
+	}
+	return keys
+}
+
+// perbaruiRingkasanKondisi menyusun ulang ringkasan kondisi pada dokumen koleksi dari laporan
+// terbaru dan perawatan konservasi. Field kondisi ikut diisi skala kondisi terbaru.
+func perbaruiRingkasanKondisi(ctx context.Context, koleksiID primitive.ObjectID) error {
+	db := config.Ulbimongoconn
+	colKoleksi := db.Collection("koleksi")
+
+	var laporan model.LaporanKondisi
+	err := db.Collection("laporan_kondisi").FindOne(ctx,
+		bson.M{"koleksi_id": koleksiID},
+		options.FindOne().SetSort(bson.D{{Key: "tanggal", Value: -1}, {Key: "created_at", Value: -1}}),
+	).Decode(&laporan)
+	if err == mongo.ErrNoDocuments {
+		_, err = colKoleksi.UpdateOne(ctx, bson.M{"_id": koleksiID}, tambahVersi(bson.M{"$unset": bson.M{"kondisi_terakhir": ""}}))
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	ringkasan := model.RingkasanKondisi{
+		LaporanID:      laporan.ID,
+		Tanggal:        laporan.Tanggal,
+		Kondisi:        laporan.Kondisi,
+		Tingkat:        model.TingkatKondisi[laporan.Kondisi],
+		Pemeriksa:      laporan.Pemeriksa,
+		PerluPerawatan: laporan.PerluPerawatan,
+	}
+	for _, k := range laporan.Kerusakan {
+		ringkasan.JenisKerusakan = append(ringkasan.JenisKerusakan, k.Jenis)
+	}
+
+	colPerawatan := db.Collection("perawatan_konservasi")
+	var selesai model.PerawatanKonservasi
+	err = colPerawatan.FindOne(ctx,
+		bson.M{"koleksi_id": koleksiID, "tanggal_selesai": bson.M{"$exists": true}},
+		options.FindOne().SetSort(bson.D{{Key: "tanggal_selesai", Value: -1}}),
+	).Decode(&selesai)
+	if err == nil {
+		ringkasan.PerawatanTerakhir = selesai.TanggalSelesai
+		// laporan sudah ditindaklanjuti jika perawatan selesai merujuk laporan ini atau selesai setelah pemeriksaan
+		if (selesai.LaporanID != nil && *selesai.LaporanID == laporan.ID) || !selesai.TanggalSelesai.Before(laporan.Tanggal) {
+			ringkasan.PerluPerawatan = false
+		}
+	} else if err != mongo.ErrNoDocuments {
+		return err
+	}
+
+	berjalan, err := colPerawatan.CountDocuments(ctx, bson.M{"koleksi_id": koleksiID, "tanggal_selesai": bson.M{"$exists": false}})
+	if err != nil {
+		return err
+	}
+	ringkasan.PerawatanBerjalan = berjalan > 0
+
+	_, err = colKoleksi.UpdateOne(ctx, bson.M{"_id": koleksiID}, tambahVersi(bson.M{"$set": bson.M{
+		"kondisi_terakhir": ringkasan,
+		"kondisi":          laporan.Kondisi,
+	}}))
+	return err
+}
+
+// hapusRiwayatKondisi menghapus seluruh laporan kondisi & perawatan milik koleksi beserta fotonya
+func hapusRiwayatKondisi(ctx context.Context, koleksiID primitive.ObjectID) {
+	db := config.Ulbimongoconn
+	filter := bson.M{"koleksi_id": koleksiID}
+
+	var laporan []model.LaporanKondisi
+	if cursor, err := db.Collection("laporan_kondisi").Find(ctx, filter); err == nil && cursor.All(ctx, &laporan) == nil {
+		for _, l := range laporan {
+			lepasRefMedia(ctx, keyDariMedia(l.Media))
+		}
+	}
+	var perawatan []model.PerawatanKonservasi
+	if cursor, err := db.Collection("perawatan_konservasi").Find(ctx, filter); err == nil && cursor.All(ctx, &perawatan) == nil {
+		for _, p := range perawatan {
+			lepasRefMedia(ctx, keyDariMedia(p.SemuaMedia()))
+		}
+	}
+
+	if _, err := db.Collection("laporan_kondisi").DeleteMany(ctx, filter); err != nil {
+		log.Printf("⚠️  Gagal menghapus laporan kondisi koleksi %s: %v", koleksiID.Hex(), err)
+	}
+	if _, err := db.Collection("perawatan_konservasi").DeleteMany(ctx, filter); err != nil {
+		log.Printf("⚠️  Gagal menghapus perawatan koleksi %s: %v", koleksiID.Hex(), err)
+	}
+}
+
+// naikkanVersiKoleksi memastikan If-Match sama dengan versi koleksi lalu menaikkan versinya secara atomik.
+// Laporan kondisi & perawatan ada di collection lain, sehingga versi koleksi dinaikkan lebih dulu
+// agar penghapusan bersamaan dari data yang sudah usang ditolak (412). Response error langsung dikirim jika ok false.
+func naikkanVersiKoleksi(ctx context.Context, c *fiber.Ctx, koleksiID primitive.ObjectID) (bool, error) {
+	col := config.Ulbimongoconn.Collection("koleksi")
+	versi, err := versiDokumen(ctx, col, koleksiID)
+	if err == mongo.ErrNoDocuments {
+		return false, c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Koleksi tidak ditemukan",
+		})
+	}
+	if err != nil {
+		return false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil data koleksi",
+		})
+	}
+	if ok, err := cekIfMatch(c, versi); !ok {
+		return false, err
+	}
+
+	res, err := col.UpdateOne(ctx, filterVersiKoleksi(koleksiID, versi), tambahVersi(bson.M{"$set": bson.M{"updated_at": time.Now()}}))
+	if err != nil {
+		return false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memperbarui versi koleksi",
+		})
+	}
+	if res.MatchedCount == 0 {
+		versi, _ := versiDokumen(ctx, col, koleksiID)
+		return false, tolakVersiBerubah(c, versi)
+	}
+	return true, nil
+}
+
+// segarkanRingkasanKondisi memperbarui ringkasan kondisi koleksi; kegagalan hanya dicatat di log
+// karena ringkasan bisa disusun ulang pada perubahan berikutnya.
+func segarkanRingkasanKondisi(ctx context.Context, koleksiID primitive.ObjectID) {
+	if err := perbaruiRingkasanKondisi(ctx, koleksiID); err != nil {
+		log.Printf("⚠️  Gagal memperbarui ringkasan kondisi koleksi %s: %v", koleksiID.Hex(), err)
+	}
+}
+
+// TambahLaporanKondisi godoc
+// @Summary      Tambah Laporan Kondisi
+// @Description  Mencatat hasil pemeriksaan kondisi koleksi dengan skala baku (baik, cukup, rusak_ringan, rusak_berat, kritis), daftar kerusakan, pemeriksa, dan foto. Laporan dengan tanggal terbaru diringkas ke field kondisi_terakhir pada koleksi.
+// @Tags         Kondisi Koleksi
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id               path      string   true   "ID koleksi"
+// @Param        kondisi          formData  string   true   "Skala kondisi: baik, cukup, rusak_ringan, rusak_berat, kritis"
+// @Param        pemeriksa        formData  string   true   "Nama pemeriksa"
+// @Param        tanggal          formData  string   false  "Tanggal pemeriksaan (YYYY-MM-DD), default hari ini"
+// @Param        kerusakan        formData  string   false  "JSON array kerusakan, contoh: [{\"jenis\":\"retak\",\"area\":\"bibir wadah\"}]. Jenis: retak, pecah, patah, sobek, korosi, jamur, serangga, noda, pudar, deformasi, bagian_hilang, lepas, lainnya"
+// @Param        rekomendasi      formData  string   false  "Rekomendasi tindakan"
+// @Param        catatan          formData  string   false  "Catatan"
+// @Param        perlu_perawatan  formData  boolean  false  "Perlu perawatan konservasi (default true untuk rusak_ringan ke atas)"
+// @Param        foto             formData  file     false  "Foto kondisi / kerusakan (boleh lebih dari satu)"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Router       /koleksi/{id}/kondisi [post]
+func TambahLaporanKondisi(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	koleksi, status, errMsg := ambilKoleksiMedia(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	laporan := model.LaporanKondisi{
+		ID:          primitive.NewObjectID(),
+		KoleksiID:   koleksi.ID,
+		Kondisi:     strings.ToLower(strings.TrimSpace(c.FormValue("kondisi"))),
+		Pemeriksa:   strings.TrimSpace(c.FormValue("pemeriksa")),
+		Rekomendasi: strings.TrimSpace(c.FormValue("rekomendasi")),
+		Catatan:     strings.TrimSpace(c.FormValue("catatan")),
+		CreatedBy:   penggunaLogin(c),
+		CreatedAt:   time.Now(),
+	}
+
+	tingkat, ok := model.TingkatKondisi[laporan.Kondisi]
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": pesanKondisiTidakValid,
+		})
+	}
+	if laporan.Pemeriksa == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Nama pemeriksa wajib diisi",
+		})
+	}
+
+	tanggal, err := tanggalKondisi(c.FormValue("tanggal"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Format tanggal harus YYYY-MM-DD",
+		})
+	}
+	laporan.Tanggal = tanggal
+
+	if v := strings.TrimSpace(c.FormValue("kerusakan")); v != "" {
+		if err := json.Unmarshal([]byte(v), &laporan.Kerusakan); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Field kerusakan harus berupa JSON array",
+			})
+		}
+	}
+	for i := range laporan.Kerusakan {
+		k := &laporan.Kerusakan[i]
+		k.Jenis = strings.ToLower(strings.TrimSpace(k.Jenis))
+		k.Area = strings.TrimSpace(k.Area)
+		if !model.JenisKerusakanValid[k.Jenis] {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fmt.Sprintf("Jenis kerusakan %q tidak dikenal", k.Jenis),
+			})
+		}
+	}
+
+	laporan.PerluPerawatan = tingkat >= model.TingkatKondisi[model.KondisiRusakRingan]
+	if v := c.FormValue("perlu_perawatan"); v != "" {
+		if laporan.PerluPerawatan, err = strconv.ParseBool(v); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "perlu_perawatan harus true atau false",
+			})
+		}
+	}
+
+	foto, status, errMsg := uploadFotoKondisi(c, "foto", koleksi.NamaBenda, "Pemeriksaan kondisi "+laporan.Tanggal.Format("2006-01-02"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	laporan.Media = foto
+
+	if _, err := config.Ulbimongoconn.Collection("laporan_kondisi").InsertOne(ctx, laporan); err != nil {
+		lepasRefMedia(ctx, keyDariMedia(foto))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan laporan kondisi",
+		})
+	}
+	segarkanRingkasanKondisi(ctx, koleksi.ID)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Laporan kondisi berhasil disimpan",
+		"data":    laporan,
+	})
+}
+
+// GetLaporanKondisiKoleksi godoc
+// @Summary      Get Laporan Kondisi Koleksi
+// @Description  Mengambil seluruh riwayat pemeriksaan kondisi koleksi, terbaru di depan
+// @Tags         Kondisi Koleksi
+// @Produce      json
+// @Param        id   path  string  true  "ID koleksi"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /koleksi/{id}/kondisi [get]
+func GetLaporanKondisiKoleksi(c *fiber.Ctx) error {
+	koleksiID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID koleksi tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.Ulbimongoconn.Collection("laporan_kondisi").Find(ctx,
+		bson.M{"koleksi_id": koleksiID},
+		options.Find().SetSort(bson.D{{Key: "tanggal", Value: -1}, {Key: "created_at", Value: -1}}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil laporan kondisi",
+		})
+	}
+	laporan := []model.LaporanKondisi{}
+	if err := cursor.All(ctx, &laporan); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca laporan kondisi",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil laporan kondisi",
+		"total":   len(laporan),
+		"data":    laporan,
+	})
+}
+
+// DeleteLaporanKondisi godoc
+// @Summary      Delete Laporan Kondisi
+// @Description  Menghapus satu laporan kondisi beserta fotonya; ringkasan kondisi koleksi disusun ulang dari laporan yang tersisa
+// @Tags         Kondisi Koleksi
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id          path  string  true  "ID koleksi"
+// @Param        laporan_id  path  string  true  "ID laporan kondisi"
+// @Param        If-Match  header  string  true  "ETag dari GET terakhir"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Failure      412  {object}  map[string]interface{}  "Data sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428  {object}  map[string]interface{}  "Header If-Match belum diisi"
+// @Router       /koleksi/{id}/kondisi/{laporan_id} [delete]
+func DeleteLaporanKondisi(c *fiber.Ctx) error {
+	koleksiID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID koleksi tidak valid",
+		})
+	}
+	laporanID, err := primitive.ObjectIDFromHex(c.Params("laporan_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID laporan kondisi tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	// Pastikan data ada sebelum versi koleksi dinaikkan
+	filter := bson.M{"_id": laporanID, "koleksi_id": koleksiID}
+	n, err := config.Ulbimongoconn.Collection("laporan_kondisi").CountDocuments(ctx, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil laporan kondisi",
+		})
+	}
+	if n == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Laporan kondisi tidak ditemukan",
+		})
+	}
+	if ok, err := naikkanVersiKoleksi(ctx, c, koleksiID); !ok {
+		return err
+	}
+
+	var dihapus model.LaporanKondisi
+	err = config.Ulbimongoconn.Collection("laporan_kondisi").FindOneAndDelete(ctx, filter).Decode(&dihapus)
+	if err == mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Laporan kondisi tidak ditemukan",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menghapus laporan kondisi",
+		})
+	}
+
+	lepasRefMedia(ctx, keyDariMedia(dihapus.Media))
+	segarkanRingkasanKondisi(ctx, koleksiID)
+
+	if versi, err := versiDokumen(ctx, config.Ulbimongoconn.Collection("koleksi"), koleksiID); err == nil {
+		c.Set(fiber.HeaderETag, etagVersi(versi))
+	}
+	return c.JSON(fiber.Map{
+		"message": "Laporan kondisi berhasil dihapus",
+	})
+}
+
+// TambahPerawatanKoleksi godoc
+// @Summary      Tambah Perawatan Konservasi
+// @Description  Mencatat tindakan konservasi pada koleksi. Tanpa tanggal_selesai, perawatan dianggap masih berjalan; tandai selesai lewat endpoint selesai.
+// @Tags         Kondisi Koleksi
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id               path      string  true   "ID koleksi"
+// @Param        tindakan         formData  string  true   "Tindakan konservasi"
+// @Param        konservator      formData  string  true   "Nama konservator"
+// @Param        laporan_id       formData  string  false  "ID laporan kondisi yang ditindaklanjuti"
+// @Param        bahan            formData  string  false  "Bahan yang dipakai, pisahkan dengan koma"
+// @Param        tanggal_mulai    formData  string  false  "Tanggal mulai (YYYY-MM-DD), default hari ini"
+// @Param        tanggal_selesai  formData  string  false  "Tanggal selesai (YYYY-MM-DD)"
+// @Param        catatan          formData  string  false  "Catatan"
+// @Param        foto_sebelum     formData  file    false  "Foto sebelum perawatan (boleh lebih dari satu)"
+// @Param        foto_sesudah     formData  file    false  "Foto sesudah perawatan (boleh lebih dari satu)"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Router       /koleksi/{id}/perawatan [post]
+func TambahPerawatanKoleksi(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	koleksi, status, errMsg := ambilKoleksiMedia(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	perawatan := model.PerawatanKonservasi{
+		ID:          primitive.NewObjectID(),
+		KoleksiID:   koleksi.ID,
+		Tindakan:    strings.TrimSpace(c.FormValue("tindakan")),
+		Konservator: strings.TrimSpace(c.FormValue("konservator")),
+		Catatan:     strings.TrimSpace(c.FormValue("catatan")),
+		CreatedBy:   penggunaLogin(c),
+		CreatedAt:   time.Now(),
+	}
+	if perawatan.Tindakan == "" || perawatan.Konservator == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Tindakan dan nama konservator wajib diisi",
+		})
+	}
+	for _, b := range strings.Split(c.FormValue("bahan"), ",") {
+		if b = strings.TrimSpace(b); b != "" {
+			perawatan.Bahan = append(perawatan.Bahan, b)
+		}
+	}
+
+	if v := strings.TrimSpace(c.FormValue("laporan_id")); v != "" {
+		laporanID, err := primitive.ObjectIDFromHex(v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "ID laporan kondisi tidak valid",
+			})
+		}
+		n, err := config.Ulbimongoconn.Collection("laporan_kondisi").CountDocuments(ctx, bson.M{"_id": laporanID, "koleksi_id": koleksi.ID})
+		if err != nil || n == 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Laporan kondisi tidak ditemukan pada koleksi ini",
+			})
+		}
+		perawatan.LaporanID = &laporanID
+	}
+
+	mulai, err := tanggalKondisi(c.FormValue("tanggal_mulai"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Format tanggal_mulai harus YYYY-MM-DD",
+		})
+	}
+	perawatan.TanggalMulai = mulai
+	if v := strings.TrimSpace(c.FormValue("tanggal_selesai")); v != "" {
+		selesai, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Format tanggal_selesai harus YYYY-MM-DD",
+			})
+		}
+		if selesai.Before(mulai) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "tanggal_selesai tidak boleh sebelum tanggal_mulai",
+			})
+		}
+		perawatan.TanggalSelesai = &selesai
+	}
+
+	if perawatan.Sebelum, status, errMsg = uploadFotoKondisi(c, "foto_sebelum", koleksi.NamaBenda, "Sebelum perawatan"); errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if perawatan.Sesudah, status, errMsg = uploadFotoKondisi(c, "foto_sesudah", koleksi.NamaBenda, "Sesudah perawatan"); errMsg != "" {
+		lepasRefMedia(ctx, keyDariMedia(perawatan.Sebelum))
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	if _, err := config.Ulbimongoconn.Collection("perawatan_konservasi").InsertOne(ctx, perawatan); err != nil {
+		lepasRefMedia(ctx, keyDariMedia(append(perawatan.Sebelum, perawatan.Sesudah...)))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan perawatan konservasi",
+		})
+	}
+	segarkanRingkasanKondisi(ctx, koleksi.ID)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Perawatan konservasi berhasil dicatat",
+		"data":    perawatan,
+	})
+}
+
+// SelesaikanPerawatanKoleksi godoc
+// @Summary      Selesaikan Perawatan Konservasi
+// @Description  Menandai perawatan selesai dan menambahkan foto sesudah perawatan. Laporan kondisi yang sudah ditindaklanjuti tidak lagi masuk daftar perlu perawatan.
+// @Tags         Kondisi Koleksi
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id               path      string  true   "ID koleksi"
+// @Param        perawatan_id     path      string  true   "ID perawatan"
+// @Param        tanggal_selesai  formData  string  false  "Tanggal selesai (YYYY-MM-DD), default hari ini"
+// @Param        catatan          formData  string  false  "Catatan hasil perawatan (menggantikan catatan lama jika diisi)"
+// @Param        foto_sesudah     formData  file    false  "Foto sesudah perawatan (boleh lebih dari satu)"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Router       /koleksi/{id}/perawatan/{perawatan_id}/selesai [put]
+func SelesaikanPerawatanKoleksi(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	koleksi, status, errMsg := ambilKoleksiMedia(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	perawatanID, err := primitive.ObjectIDFromHex(c.Params("perawatan_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID perawatan tidak valid",
+		})
+	}
+
+	col := config.Ulbimongoconn.Collection("perawatan_konservasi")
+	filter := bson.M{"_id": perawatanID, "koleksi_id": koleksi.ID}
+	var perawatan model.PerawatanKonservasi
+	if err := col.FindOne(ctx, filter).Decode(&perawatan); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Perawatan tidak ditemukan",
+		})
+	}
+
+	selesai, err := tanggalKondisi(c.FormValue("tanggal_selesai"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Format tanggal_selesai harus YYYY-MM-DD",
+		})
+	}
+	if selesai.Before(perawatan.TanggalMulai) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "tanggal_selesai tidak boleh sebelum tanggal_mulai",
+		})
+	}
+
+	sesudah, status, errMsg := uploadFotoKondisi(c, "foto_sesudah", koleksi.NamaBenda, "Sesudah perawatan")
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	setData := bson.M{"tanggal_selesai": selesai}
+	if catatan := strings.TrimSpace(c.FormValue("catatan")); catatan != "" {
+		setData["catatan"] = catatan
+	}
+	update := bson.M{"$set": setData}
+	if len(sesudah) > 0 {
+		update["$push"] = bson.M{"sesudah": bson.M{"$each": sesudah}}
+	}
+
+	err = col.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&perawatan)
+	if err != nil {
+		lepasRefMedia(ctx, keyDariMedia(sesudah))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memperbarui perawatan",
+		})
+	}
+	segarkanRingkasanKondisi(ctx, koleksi.ID)
+
+	return c.JSON(fiber.Map{
+		"message": "Perawatan ditandai selesai",
+		"data":    perawatan,
+	})
+}
+
+// GetPerawatanKoleksi godoc
+// @Summary      Get Perawatan Konservasi Koleksi
+// @Description  Mengambil log perawatan konservasi koleksi, terbaru di depan
+// @Tags         Kondisi Koleksi
+// @Produce      json
+// @Param        id   path  string  true  "ID koleksi"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /koleksi/{id}/perawatan [get]
+func GetPerawatanKoleksi(c *fiber.Ctx) error {
+	koleksiID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID koleksi tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.Ulbimongoconn.Collection("perawatan_konservasi").Find(ctx,
+		bson.M{"koleksi_id": koleksiID},
+		options.Find().SetSort(bson.D{{Key: "tanggal_mulai", Value: -1}, {Key: "created_at", Value: -1}}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil log perawatan",
+		})
+	}
+	perawatan := []model.PerawatanKonservasi{}
+	if err := cursor.All(ctx, &perawatan); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca log perawatan",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil log perawatan",
+		"total":   len(perawatan),
+		"data":    perawatan,
+	})
+}
+
+// DeletePerawatanKoleksi godoc
+// @Summary      Delete Perawatan Konservasi
+// @Description  Menghapus satu catatan perawatan beserta foto sebelum & sesudahnya
+// @Tags         Kondisi Koleksi
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id            path  string  true  "ID koleksi"
+// @Param        perawatan_id  path  string  true  "ID perawatan"
+// @Param        If-Match  header  string  true  "ETag dari GET terakhir"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Failure      412  {object}  map[string]interface{}  "Data sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428  {object}  map[string]interface{}  "Header If-Match belum diisi"
+// @Router       /koleksi/{id}/perawatan/{perawatan_id} [delete]
+func DeletePerawatanKoleksi(c *fiber.Ctx) error {
+	koleksiID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID koleksi tidak valid",
+		})
+	}
+	perawatanID, err := primitive.ObjectIDFromHex(c.Params("perawatan_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID perawatan tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	// Pastikan data ada sebelum versi koleksi dinaikkan
+	filter := bson.M{"_id": perawatanID, "koleksi_id": koleksiID}
+	n, err := config.Ulbimongoconn.Collection("perawatan_konservasi").CountDocuments(ctx, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil data perawatan",
+		})
+	}
+	if n == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Perawatan tidak ditemukan",
+		})
+	}
+	if ok, err := naikkanVersiKoleksi(ctx, c, koleksiID); !ok {
+		return err
+	}
+
+	var dihapus model.PerawatanKonservasi
+	err = config.Ulbimongoconn.Collection("perawatan_konservasi").FindOneAndDelete(ctx, filter).Decode(&dihapus)
+	if err == mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Perawatan tidak ditemukan",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menghapus perawatan",
+		})
+	}
+
+	lepasRefMedia(ctx, keyDariMedia(dihapus.SemuaMedia()))
+	segarkanRingkasanKondisi(ctx, koleksiID)
+
+	if versi, err := versiDokumen(ctx, config.Ulbimongoconn.Collection("koleksi"), koleksiID); err == nil {
+		c.Set(fiber.HeaderETag, etagVersi(versi))
+	}
+	return c.JSON(fiber.Map{
+		"message": "Perawatan berhasil dihapus",
+	})
+}
+
+// GetKoleksiPerluPerawatan godoc
+// @Summary      Get Koleksi Perlu Perawatan
+// @Description  Daftar koleksi yang pemeriksaan kondisi terakhirnya memerlukan perawatan dan belum ditindaklanjuti, diurutkan dari kondisi terparah lalu pemeriksaan terlama
+// @Tags         Kondisi Koleksi
+// @Produce      json
+// @Param        kondisi_min  query  string   false  "Hanya kondisi minimal ini, misal rusak_berat"
+// @Param        berjalan     query  boolean  false  "true = hanya yang sedang dirawat, false = hanya yang belum dirawat"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /kondisi/perlu-perawatan [get]
+func GetKoleksiPerluPerawatan(c *fiber.Ctx) error {
+	filter := filterKoleksiAktif(bson.M{"kondisi_terakhir.perlu_perawatan": true})
+
+	if v := c.Query("kondisi_min"); v != "" {
+		tingkat, ok := model.TingkatKondisi[strings.ToLower(v)]
+		if !ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "kondisi_min harus salah satu dari: baik, cukup, rusak_ringan, rusak_berat, kritis",
+			})
+		}
+		filter["kondisi_terakhir.tingkat"] = bson.M{"$gte": tingkat}
+	}
+	if v := c.Query("berjalan"); v != "" {
+		berjalan, err := strconv.ParseBool(v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "berjalan harus true atau false",
+			})
+		}
+		filter["kondisi_terakhir.perawatan_berjalan"] = berjalan
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.Ulbimongoconn.Collection("koleksi").Find(ctx, filter,
+		options.Find().
+			SetSort(bson.D{{Key: "kondisi_terakhir.tingkat", Value: -1}, {Key: "kondisi_terakhir.tanggal", Value: 1}}).
+			SetProjection(bson.M{"no_reg": 1, "no_inv": 1, "nama_benda": 1, "kategori": 1, "tempat_penyimpanan": 1, "kondisi_terakhir": 1}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil koleksi",
+		})
+	}
+	koleksi := []model.Koleksi{}
+	if err := cursor.All(ctx, &koleksi); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca data koleksi",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil koleksi yang perlu perawatan",
+		"total":   len(koleksi),
+		"data":    koleksi,
+	})
+}
@@ -0,0 +1,37 @@
+package controller
+
+import "testing"
+
+func TestKondisiKoleksi(t *testing.T) {
+	tests := []struct {
+		kondisi string
+		lama    string
+		want    string
+		gagal   bool
+	}{
+		{kondisi: "baik", want: "baik"},
+		{kondisi: " Rusak_Ringan ", want: "rusak_ringan"},
+		{kondisi: "KRITIS", want: "kritis"},
+		{kondisi: "", want: ""},
+		{kondisi: "   ", lama: "baik", want: ""},
+		{kondisi: "bagus", gagal: true},
+		{kondisi: "rusak ringan", gagal: true},
+		// nilai lama di luar skala tetap diterima selama tidak diubah
+		{kondisi: "Agak kusam", lama: "Agak kusam", want: "Agak kusam"},
+		{kondisi: "Sangat kusam", lama: "Agak kusam", gagal: true},
+		{kondisi: "cukup", lama: "Agak kusam", want: "cukup"},
+	}
+
+	for _, tt := range tests {
+		got, errMsg := kondisiKoleksi(tt.kondisi, tt.lama)
+		if tt.gagal {
+			if errMsg == "" {
+				t.Errorf("kondisiKoleksi(%q, %q) = %q, want error", tt.kondisi, tt.lama, got)
+			}
+			continue
+		}
+		if errMsg != "" || got != tt.want {
+			t.Errorf("kondisiKoleksi(%q, %q) = %q, %q, want %q", tt.kondisi, tt.lama, got, errMsg, tt.want)
+		}
+	}
+}
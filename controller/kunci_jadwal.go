@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"be-internship/config"
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// =============================================================
+// 🔒 Kunci jadwal per koleksi
+// Cek bentrok jadwal (jadwalBentrok) lalu simpan harus berjalan bergantian untuk koleksi
+// yang sama, supaya dua pameran / peminjaman tidak lolos cek bersamaan. Kunci berupa dokumen
+// kunci_jadwal dengan _id = ID koleksi; _id yang unik menjamin hanya satu request yang memegangnya.
+// =============================================================
+
+// lamaKunciJadwal adalah batas waktu kunci; kunci dari request yang mati dianggap lepas setelahnya
+const lamaKunciJadwal = 30 * time.Second
+
+// errJadwalSibuk dikembalikan jika koleksi sedang dikunci request lain terlalu lama
+var errJadwalSibuk = errors.New("jadwal koleksi sedang diubah permintaan lain")
+
+// kunciJadwalKoleksi mengunci jadwal koleksi ids. Fungsi lepas wajib dipanggil setelah data disimpan.
+// Koleksi dikunci berurutan berdasarkan ID supaya dua request tidak saling menunggu.
+func kunciJadwalKoleksi(ctx context.Context, ids []primitive.ObjectID) (func(), error) {
+	col := config.Ulbimongoconn.Collection("kunci_jadwal")
+	pemilik := primitive.NewObjectID()
+
+	urut := append([]primitive.ObjectID(nil), ids...)
+	sort.Slice(urut, func(i, j int) bool { return urut[i].Hex() < urut[j].Hex() })
+
+	var dikunci []primitive.ObjectID
+	lepas := func() {
+		if len(dikunci) == 0 {
+			return
+		}
+		// context baru supaya kunci tetap dilepas walaupun context request sudah habis
+		ctxLepas, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := col.DeleteMany(ctxLepas, bson.M{"_id": bson.M{"$in": dikunci}, "pemilik": pemilik}); err != nil {
+			log.Printf("⚠️  Gagal melepas kunci jadwal koleksi: %v", err)
+		}
+	}
+
+	for i, id := range urut {
+		if i > 0 && id == urut[i-1] {
+			continue
+		}
+		for percobaan := 1; ; percobaan++ {
+			// Upsert hanya mengambil alih kunci yang sudah kedaluwarsa; kunci aktif membuat insert bentrok _id
+			now := time.Now()
+			_, err := col.UpdateOne(ctx,
+				bson.M{"_id": id, "kedaluwarsa": bson.M{"$lt": now}},
+				bson.M{"$set": bson.M{"pemilik": pemilik, "kedaluwarsa": now.Add(lamaKunciJadwal)}},
+				options.Update().SetUpsert(true),
+			)
+			if err == nil {
+				dikunci = append(dikunci, id)
+				break
+			}
+			if !mongo.IsDuplicateKeyError(err) {
+				lepas()
+				return func() {}, err
+			}
+			if percobaan >= 40 {
+				lepas()
+				return func() {}, errJadwalSibuk
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+	return lepas, nil
+}
+
+// gagalKunciJadwal mengirim response untuk error dari kunciJadwalKoleksi
+func gagalKunciJadwal(c *fiber.Ctx, err error) error {
+	if err == errJadwalSibuk {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Jadwal koleksi sedang diubah oleh permintaan lain, coba lagi",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Gagal mengunci jadwal koleksi",
+	})
+}
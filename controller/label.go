@@ -0,0 +1,563 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"bytes"
+	"context"
+	"fmt"
+	"image/png"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+	"github.com/go-pdf/fpdf"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// =============================================================
+// 🏷️ Label QR code & barcode untuk koleksi dan tempat penyimpanan
+// QR code berisi URL data, Code128 berisi no_inv (koleksi) atau kode lokasi.
+// =============================================================
+
+const (
+	maksLabelLembar    = 1000 // batas jumlah label dalam satu PDF
+	ukuranLabelPNG     = 300  // lebar default gambar PNG (px)
+	maksUkuranLabel    = 2000 // lebar maksimum gambar PNG (px)
+	paddingLabel       = 2.0  // jarak tepi label ke isi (mm)
+	formatLabelDefault = "a4-21"
+)
+
+// lokasiLabel memetakan jenis label lokasi ke master data & prefix kode lokasi
+var lokasiLabel = map[string]struct {
+	master masterData
+	prefix string
+}{
+	"gudang": {masterGudang, "GDG"},
+	"rak":    {masterRak, "RAK"},
+	"tahap":  {masterTahap, "THP"},
+}
+
+// kodeLokasi membuat kode yang dicetak di barcode lokasi, contoh: RAK-65f1c0...
+func kodeLokasi(jenis string, id primitive.ObjectID) string {
+	return lokasiLabel[jenis].prefix + "-" + id.Hex()
+}
+
+// urlLabel membuat URL data yang di-encode di QR code.
+// LABEL_BASE_URL dipakai jika server berada di balik proxy / domain publik.
+func urlLabel(c *fiber.Ctx, jenis string, id primitive.ObjectID) string {
+	base := strings.TrimRight(os.Getenv("LABEL_BASE_URL"), "/")
+	if base == "" {
+		base = c.BaseURL()
+	}
+	return base + "/api/" + jenis + "/" + id.Hex()
+}
+
+// labelKoleksi menyusun isi label satu koleksi
+func labelKoleksi(c *fiber.Ctx, k model.Koleksi) model.ItemLabel {
+	kode := k.NoInventaris
+	if kode == "" {
+		kode = k.NoRegistrasi
+	}
+	if kode == "" {
+		kode = k.ID.Hex()
+	}
+
+	item := model.ItemLabel{
+		Kode:  kode,
+		URL:   urlLabel(c, "koleksi", k.ID),
+		Judul: kode,
+	}
+	if k.NamaBenda != "" {
+		item.Baris = append(item.Baris, k.NamaBenda)
+	}
+
+	var lokasi []string
+	for _, nama := range []string{
+		k.TempatPenyimpanan.Gudang.NamaGudang,
+		k.TempatPenyimpanan.Rak.NamaRak,
+		k.TempatPenyimpanan.Tahap.NamaTahap,
+	} {
+		if nama != "" {
+			lokasi = append(lokasi, nama)
+		}
+	}
+	if len(lokasi) > 0 {
+		item.Baris = append(item.Baris, strings.Join(lokasi, " / "))
+	}
+	return item
+}
+
+// labelLokasi menyusun isi label gudang / rak / tahap
+func labelLokasi(c *fiber.Ctx, jenis string, id primitive.ObjectID, nama string) model.ItemLabel {
+	kode := kodeLokasi(jenis, id)
+	judul := nama
+	if judul == "" {
+		judul = kode
+	}
+	return model.ItemLabel{
+		Kode:  kode,
+		URL:   urlLabel(c, jenis, id),
+		Judul: judul,
+		Baris: []string{strings.ToUpper(jenis[:1]) + jenis[1:], kode},
+	}
+}
+
+// idDariQuery membaca query berisi ObjectID
+func idDariQuery(c *fiber.Ctx, nama string) (primitive.ObjectID, bool, string) {
+	v := c.Query(nama)
+	if v == "" {
+		return primitive.NilObjectID, false, ""
+	}
+	id, err := primitive.ObjectIDFromHex(v)
+	if err != nil {
+		return primitive.NilObjectID, false, nama + " tidak valid"
+	}
+	return id, true, ""
+}
+
+// daftarIDDariQuery membaca query ids berisi ObjectID dipisah koma
+func daftarIDDariQuery(c *fiber.Ctx) ([]primitive.ObjectID, string) {
+	var ids []primitive.ObjectID
+	for _, v := range strings.Split(c.Query("ids"), ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		id, err := primitive.ObjectIDFromHex(v)
+		if err != nil {
+			return nil, "ids berisi ID tidak valid: " + v
+		}
+		ids = append(ids, id)
+	}
+	return ids, ""
+}
+
+// ambilItemLabel mengambil data sesuai filter query lalu menyusun isi labelnya
+func ambilItemLabel(ctx context.Context, c *fiber.Ctx, jenis string) ([]model.ItemLabel, int, string) {
+	ids, errMsg := daftarIDDariQuery(c)
+	if errMsg != "" {
+		return nil, fiber.StatusBadRequest, errMsg
+	}
+
+	filter := bson.M{}
+	if len(ids) > 0 {
+		filter["_id"] = bson.M{"$in": ids}
+	}
+
+	// 🔹 Label tempat penyimpanan
+	if lokasi, ok := lokasiLabel[jenis]; ok {
+		cursor, err := config.Ulbimongoconn.Collection(lokasi.master.collection).Find(ctx, filter,
+			options.Find().SetSort(bson.M{lokasi.master.namaField: 1}).SetLimit(maksLabelLembar+1))
+		if err != nil {
+			return nil, fiber.StatusInternalServerError, "Gagal mengambil data " + lokasi.master.label
+		}
+		var data []bson.M
+		if err := cursor.All(ctx, &data); err != nil {
+			return nil, fiber.StatusInternalServerError, "Gagal membaca data " + lokasi.master.label
+		}
+		if len(data) > maksLabelLembar {
+			return nil, fiber.StatusBadRequest, fmt.Sprintf("Maksimal %d label dalam satu lembar, persempit filter", maksLabelLembar)
+		}
+
+		items := make([]model.ItemLabel, 0, len(data))
+		for _, d := range data {
+			id, _ := d["_id"].(primitive.ObjectID)
+			nama, _ := d[lokasi.master.namaField].(string)
+			items = append(items, labelLokasi(c, jenis, id, nama))
+		}
+		return items, 0, ""
+	}
+
+	if jenis != "koleksi" {
+		return nil, fiber.StatusBadRequest, "jenis harus salah satu dari: koleksi, gudang, rak, tahap"
+	}
+
+	// 🔹 Label koleksi, bisa difilter per lokasi penyimpanan & kategori
+	for query, field := range map[string]string{
+		"gudang_id": "tempat_penyimpanan.gudang._id",
+		"rak_id":    "tempat_penyimpanan.rak._id",
+		"tahap_id":  "tempat_penyimpanan.tahap._id",
+	} {
+		id, ada, errMsg := idDariQuery(c, query)
+		if errMsg != "" {
+			return nil, fiber.StatusBadRequest, errMsg
+		}
+		if ada {
+			filter[field] = id
+		}
+	}
+
+	kategoriID, ada, errMsg := idDariQuery(c, "kategori_id")
+	if errMsg != "" {
+		return nil, fiber.StatusBadRequest, errMsg
+	}
+	if ada {
+		kategoriIDs, err := idKategoriDanTurunan(ctx, kategoriID)
+		if err != nil {
+			return nil, fiber.StatusInternalServerError, "Gagal mengambil sub-kategori"
+		}
+		filter["kategori._id"] = bson.M{"$in": kategoriIDs}
+	}
+	filterKoleksiAktif(filter)
+
+	cursor, err := config.Ulbimongoconn.Collection("koleksi").Find(ctx, filter,
+		options.Find().
+			SetProjection(bson.M{"no_inv": 1, "no_reg": 1, "nama_benda": 1, "tempat_penyimpanan": 1}).
+			SetSort(bson.D{{Key: "no_inv", Value: 1}, {Key: "_id", Value: 1}}).
+			SetLimit(maksLabelLembar+1))
+	if err != nil {
+		return nil, fiber.StatusInternalServerError, "Gagal mengambil data koleksi"
+	}
+	var koleksi []model.Koleksi
+	if err := cursor.All(ctx, &koleksi); err != nil {
+		return nil, fiber.StatusInternalServerError, "Gagal membaca data koleksi"
+	}
+	if len(koleksi) > maksLabelLembar {
+		return nil, fiber.StatusBadRequest, fmt.Sprintf("Maksimal %d label dalam satu lembar, persempit filter", maksLabelLembar)
+	}
+
+	items := make([]model.ItemLabel, 0, len(koleksi))
+	for _, k := range koleksi {
+		items = append(items, labelKoleksi(c, k))
+	}
+	return items, 0, ""
+}
+
+// ambilSatuItemLabel mengambil isi label untuk satu koleksi / lokasi berdasarkan ID
+func ambilSatuItemLabel(ctx context.Context, c *fiber.Ctx, jenis, idParam string) (model.ItemLabel, int, string) {
+	id, err := primitive.ObjectIDFromHex(idParam)
+	if err != nil {
+		return model.ItemLabel{}, fiber.StatusBadRequest, "ID tidak valid"
+	}
+
+	if lokasi, ok := lokasiLabel[jenis]; ok {
+		var data bson.M
+		err := config.Ulbimongoconn.Collection(lokasi.master.collection).FindOne(ctx, bson.M{"_id": id}).Decode(&data)
+		if err != nil {
+			return model.ItemLabel{}, fiber.StatusNotFound, strings.ToUpper(jenis[:1]) + jenis[1:] + " tidak ditemukan"
+		}
+		nama, _ := data[lokasi.master.namaField].(string)
+		return labelLokasi(c, jenis, id, nama), 0, ""
+	}
+
+	if jenis != "koleksi" {
+		return model.ItemLabel{}, fiber.StatusBadRequest, "jenis harus salah satu dari: koleksi, gudang, rak, tahap"
+	}
+
+	var k model.Koleksi
+	if err := config.Ulbimongoconn.Collection("koleksi").FindOne(ctx, bson.M{"_id": id}).Decode(&k); err != nil {
+		return model.ItemLabel{}, fiber.StatusNotFound, "Koleksi tidak ditemukan"
+	}
+	return labelKoleksi(c, k), 0, ""
+}
+
+// =============================================================
+// ✏️ Menggambar QR code & barcode
+// =============================================================
+
+// encodeLabel membuat QR code (isi URL) atau Code128 (isi kode)
+func encodeLabel(kode string, item model.ItemLabel) (barcode.Barcode, error) {
+	if kode == "qr" {
+		return qr.Encode(item.URL, qr.M, qr.Auto)
+	}
+	bc, err := code128.Encode(item.Kode)
+	if err != nil {
+		return nil, fmt.Errorf("kode %q tidak bisa dibuat barcode Code128 (hanya karakter ASCII)", item.Kode)
+	}
+	return bc, nil
+}
+
+// modulHitam bernilai true jika modul barcode di posisi (x, y) berwarna hitam
+func modulHitam(bc barcode.Barcode, x, y int) bool {
+	r, _, _, _ := bc.At(x, y).RGBA()
+	return r < 0x8000
+}
+
+// gambarBarcodePDF menggambar barcode sebagai kotak vektor supaya tetap tajam saat dicetak.
+// Modul hitam yang berurutan dalam satu baris digabung menjadi satu kotak.
+func gambarBarcodePDF(pdf *fpdf.Fpdf, bc barcode.Barcode, x, y, w, h float64) {
+	b := bc.Bounds()
+	modulW := w / float64(b.Dx())
+	modulH := h / float64(b.Dy())
+
+	for my := b.Min.Y; my < b.Max.Y; my++ {
+		for mx := b.Min.X; mx < b.Max.X; mx++ {
+			if !modulHitam(bc, mx, my) {
+				continue
+			}
+			awal := mx
+			for mx+1 < b.Max.X && modulHitam(bc, mx+1, my) {
+				mx++
+			}
+			pdf.Rect(x+float64(awal-b.Min.X)*modulW, y+float64(my-b.Min.Y)*modulH,
+				float64(mx-awal+1)*modulW, modulH, "F")
+		}
+	}
+}
+
+// potongTeks memotong teks supaya muat di lebar yang tersedia.
+// Teks sudah diterjemahkan ke encoding font inti PDF (1 byte per karakter).
+func potongTeks(pdf *fpdf.Fpdf, teks string, lebar float64) string {
+	if pdf.GetStringWidth(teks) <= lebar {
+		return teks
+	}
+	for len(teks) > 0 && pdf.GetStringWidth(teks+"...") > lebar {
+		teks = teks[:len(teks)-1]
+	}
+	return teks + "..."
+}
+
+// gambarLabelPDF menggambar satu label di posisi (x, y)
+func gambarLabelPDF(pdf *fpdf.Fpdf, tr func(string) string, f model.FormatLabel, item model.ItemLabel,
+	x, y float64, kode string) error {
+
+	isiX, isiY := x+paddingLabel, y+paddingLabel
+	isiW, isiH := f.Lebar-2*paddingLabel, f.Tinggi-2*paddingLabel
+
+	// 🔳 QR code di sisi kiri
+	if kode == "qr" || kode == "keduanya" {
+		bc, err := encodeLabel("qr", item)
+		if err != nil {
+			return err
+		}
+		sisi := math.Min(isiH, isiW/2)
+		gambarBarcodePDF(pdf, bc, isiX, isiY+(isiH-sisi)/2, sisi, sisi)
+		isiX += sisi + paddingLabel
+		isiW -= sisi + paddingLabel
+	}
+
+	// ▮▯ Barcode di bagian bawah
+	if kode == "barcode" || kode == "keduanya" {
+		bc, err := encodeLabel("barcode", item)
+		if err != nil {
+			return err
+		}
+		tinggi := isiH * 0.35
+		gambarBarcodePDF(pdf, bc, isiX, isiY+isiH-tinggi, isiW, tinggi)
+		isiH -= tinggi + 1
+	}
+
+	// 🔤 Teks: judul tebal lalu baris tambahan sebanyak yang muat
+	ukuranJudul := math.Max(6, math.Min(11, f.Tinggi*0.25))
+	ukuranBaris := ukuranJudul * 0.8
+	mmPerPt := 25.4 / 72
+
+	posY := isiY
+	tulis := func(teks, gaya string, ukuran float64) bool {
+		tinggiBaris := ukuran * mmPerPt * 1.2
+		if posY+tinggiBaris > isiY+isiH+0.01 {
+			return false
+		}
+		pdf.SetFont("Helvetica", gaya, ukuran)
+		posY += tinggiBaris
+		pdf.Text(isiX, posY-tinggiBaris*0.25, potongTeks(pdf, tr(teks), isiW))
+		return true
+	}
+
+	if !tulis(item.Judul, "B", ukuranJudul) {
+		return nil
+	}
+	for _, baris := range item.Baris {
+		if !tulis(baris, "", ukuranBaris) {
+			break
+		}
+	}
+	return nil
+}
+
+// =============================================================
+// 🌐 Handler
+// =============================================================
+
+// GetFormatLabel godoc
+// @Summary      Get Format Kertas Label
+// @Description  Menampilkan format kertas label siap cetak yang didukung endpoint lembar label
+// @Tags         Label
+// @Produce      json
+// @Success      200  {array}  model.FormatLabel
+// @Router       /label/format [get]
+func GetFormatLabel(c *fiber.Ctx) error {
+	daftar := make([]model.FormatLabel, 0, len(model.FormatLabelTersedia))
+	for _, f := range model.FormatLabelTersedia {
+		daftar = append(daftar, f)
+	}
+	sort.Slice(daftar, func(i, j int) bool { return daftar[i].Nama < daftar[j].Nama })
+	return c.JSON(daftar)
+}
+
+// GetGambarLabel godoc
+// @Summary      Get Gambar QR Code / Barcode
+// @Description  Membuat gambar PNG QR code (berisi URL data) atau barcode Code128 (berisi no_inv untuk koleksi, atau kode lokasi GDG-/RAK-/THP-<id>)
+// @Tags         Label
+// @Produce      png
+// @Param        jenis   path   string  true   "Jenis data: koleksi, gudang, rak, tahap"
+// @Param        id      path   string  true   "ID data"
+// @Param        kode    path   string  true   "Jenis kode: qr atau barcode"
+// @Param        ukuran  query  int     false  "Lebar gambar dalam pixel (default 300)"
+// @Success      200  {file}  binary
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /label/{jenis}/{id}/{kode}.png [get]
+func GetGambarLabel(c *fiber.Ctx) error {
+	kode := c.Params("kode")
+	if kode != "qr" && kode != "barcode" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "kode harus qr atau barcode",
+		})
+	}
+
+	ukuran := c.QueryInt("ukuran", ukuranLabelPNG)
+	if ukuran < 50 || ukuran > maksUkuranLabel {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("ukuran harus antara 50 dan %d", maksUkuranLabel),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	item, status, errMsg := ambilSatuItemLabel(ctx, c, c.Params("jenis"), c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	bc, err := encodeLabel(kode, item)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// lebar dibulatkan ke kelipatan jumlah modul supaya setiap garis punya lebar yang sama
+	modul := bc.Bounds().Dx()
+	lebar := int(math.Max(1, math.Round(float64(ukuran)/float64(modul)))) * modul
+	tinggi := lebar
+	if kode == "barcode" {
+		tinggi = int(math.Max(50, float64(lebar)/3))
+	}
+	scaled, err := barcode.Scale(bc, lebar, tinggi)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membuat gambar: " + err.Error(),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, scaled); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membuat gambar: " + err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "image/png")
+	return c.Send(buf.Bytes())
+}
+
+// GetLembarLabel godoc
+// @Summary      Get Lembar Label PDF
+// @Description  Membuat PDF lembar label siap cetak untuk sekumpulan koleksi (misalnya semua koleksi di satu rak) atau tempat penyimpanan. Label disusun sesuai format kertas label standar (lihat /label/format).
+// @Tags         Label
+// @Produce      application/pdf
+// @Param        jenis        query  string  false  "Jenis data: koleksi (default), gudang, rak, tahap"
+// @Param        ids          query  string  false  "Daftar ID dipisah koma"
+// @Param        gudang_id    query  string  false  "Filter koleksi berdasarkan gudang"
+// @Param        rak_id       query  string  false  "Filter koleksi berdasarkan rak"
+// @Param        tahap_id     query  string  false  "Filter koleksi berdasarkan tahap"
+// @Param        kategori_id  query  string  false  "Filter koleksi berdasarkan kategori (termasuk sub-kategori)"
+// @Param        format       query  string  false  "Format kertas label (default a4-21)"
+// @Param        kode         query  string  false  "Kode yang dicetak: qr, barcode, keduanya (default keduanya)"
+// @Param        mulai        query  int     false  "Posisi label pertama di lembar (mulai 1), untuk memakai sisa lembar label"
+// @Param        garis        query  bool    false  "Cetak garis tepi label (untuk kertas biasa)"
+// @Success      200  {file}  binary
+// @Failure      400  {object}  map[string]string
+// @Router       /label/lembar [get]
+func GetLembarLabel(c *fiber.Ctx) error {
+	jenis := c.Query("jenis", "koleksi")
+
+	f, ok := model.FormatLabelTersedia[c.Query("format", formatLabelDefault)]
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Format label tidak dikenal, lihat /api/label/format",
+		})
+	}
+
+	kode := c.Query("kode", "keduanya")
+	if kode != "qr" && kode != "barcode" && kode != "keduanya" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "kode harus qr, barcode, atau keduanya",
+		})
+	}
+
+	perLembar := f.Kolom * f.Baris
+	mulai := c.QueryInt("mulai", 1)
+	if mulai < 1 || mulai > perLembar {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("mulai harus antara 1 dan %d untuk format %s", perLembar, f.Nama),
+		})
+	}
+	garis, _ := strconv.ParseBool(c.Query("garis"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	items, status, errMsg := ambilItemLabel(ctx, c, jenis)
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if len(items) == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Tidak ada data yang cocok dengan filter",
+		})
+	}
+
+	pdf := fpdf.New("P", "mm", f.Kertas, "")
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetTitle("Label "+jenis, true)
+	pdf.SetDrawColor(180, 180, 180)
+	pdf.SetLineWidth(0.1)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	for i, item := range items {
+		posisi := (mulai - 1 + i) % perLembar
+		if i == 0 || posisi == 0 {
+			pdf.AddPage()
+		}
+		x := f.MarginKiri + float64(posisi%f.Kolom)*f.JarakKolom
+		y := f.MarginAtas + float64(posisi/f.Kolom)*f.JarakBaris
+
+		if garis {
+			pdf.Rect(x, y, f.Lebar, f.Tinggi, "D")
+		}
+		if err := gambarLabelPDF(pdf, tr, f, item, x, y, kode); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membuat PDF: " + err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="label-%s.pdf"`, jenis))
+	return c.Send(buf.Bytes())
+}
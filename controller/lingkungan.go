@@ -0,0 +1,782 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// parseWaktuQuery membaca waktu dari query string (RFC3339 atau YYYY-MM-DD)
+func parseWaktuQuery(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// parseRentangWaktu membaca query dari & sampai. Default: 24 jam terakhir.
+func parseRentangWaktu(c *fiber.Ctx) (time.Time, time.Time, string) {
+	sampai := time.Now()
+	if v := c.Query("sampai"); v != "" {
+		t, err := parseWaktuQuery(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, "Format 'sampai' tidak valid (RFC3339 atau YYYY-MM-DD)"
+		}
+		sampai = t
+	}
+
+	dari := sampai.Add(-24 * time.Hour)
+	if v := c.Query("dari"); v != "" {
+		t, err := parseWaktuQuery(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, "Format 'dari' tidak valid (RFC3339 atau YYYY-MM-DD)"
+		}
+		dari = t
+	}
+
+	if dari.After(sampai) {
+		return time.Time{}, time.Time{}, "'dari' tidak boleh setelah 'sampai'"
+	}
+
+	return dari, sampai, ""
+}
+
+// filterLokasiSensor membuat filter lokasi.gudang_id / lokasi.rak_id dari query string
+func filterLokasiSensor(c *fiber.Ctx, prefix string) (bson.M, string) {
+	filter := bson.M{}
+
+	if v := c.Query("gudang_id"); v != "" {
+		id, err := primitive.ObjectIDFromHex(v)
+		if err != nil {
+			return nil, "ID gudang tidak valid"
+		}
+		filter[prefix+"gudang_id"] = id
+	}
+
+	if v := c.Query("rak_id"); v != "" {
+		id, err := primitive.ObjectIDFromHex(v)
+		if err != nil {
+			return nil, "ID rak tidak valid"
+		}
+		filter[prefix+"rak_id"] = id
+	}
+
+	return filter, ""
+}
+
+// parseAngkaOpsional membaca angka desimal opsional dari form-data
+func parseAngkaOpsional(c *fiber.Ctx, key string) (*float64, error) {
+	v := strings.TrimSpace(c.FormValue(key))
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", "."), 64)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// cariAmbangLingkungan mencari ambang batas untuk rak terlebih dahulu, lalu untuk gudang
+func cariAmbangLingkungan(ctx context.Context, lokasi model.LokasiSensor) (*model.AmbangLingkungan, error) {
+	col := config.Ulbimongoconn.Collection("ambang_lingkungan")
+
+	filters := []bson.M{}
+	if !lokasi.RakID.IsZero() {
+		filters = append(filters, bson.M{"gudang_id": lokasi.GudangID, "rak_id": lokasi.RakID})
+	}
+	filters = append(filters, bson.M{"gudang_id": lokasi.GudangID, "rak_id": bson.M{"$exists": false}})
+
+	for _, filter := range filters {
+		var ambang model.AmbangLingkungan
+		err := col.FindOne(ctx, filter).Decode(&ambang)
+		if err == nil {
+			return &ambang, nil
+		}
+		if err != mongo.ErrNoDocuments {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// cekAmbangLingkungan membuat peringatan untuk setiap nilai yang berada di luar ambang batas
+func cekAmbangLingkungan(p model.PembacaanSensor, ambang *model.AmbangLingkungan) []model.PeringatanLingkungan {
+	if ambang == nil {
+		return nil
+	}
+
+	var peringatan []model.PeringatanLingkungan
+	cek := func(parameter, satuan string, nilai *float64, min, max *float64) {
+		if nilai == nil {
+			return
+		}
+		var pesan string
+		switch {
+		case min != nil && *nilai < *min:
+			pesan = fmt.Sprintf("%s %.1f%s di bawah batas minimum %.1f%s", parameter, *nilai, satuan, *min, satuan)
+		case max != nil && *nilai > *max:
+			pesan = fmt.Sprintf("%s %.1f%s di atas batas maksimum %.1f%s", parameter, *nilai, satuan, *max, satuan)
+		default:
+			return
+		}
+		peringatan = append(peringatan, model.PeringatanLingkungan{
+			ID:        primitive.NewObjectID(),
+			Waktu:     p.Waktu,
+			Lokasi:    p.Lokasi,
+			Parameter: strings.ToLower(parameter),
+			Nilai:     *nilai,
+			BatasMin:  min,
+			BatasMax:  max,
+			Pesan:     pesan,
+			CreatedAt: time.Now(),
+		})
+	}
+
+	cek("Suhu", "°C", p.Suhu, ambang.SuhuMin, ambang.SuhuMax)
+	cek("Kelembapan", "%", p.Kelembapan, ambang.KelembapanMin, ambang.KelembapanMax)
+
+	return peringatan
+}
+
+// simpanPeringatanLingkungan menyimpan peringatan. Jika lokasi & parameter yang sama masih punya peringatan
+// yang belum diselesaikan, peringatan itu yang diperbarui (nilai & waktu terakhir, jumlah pembacaan)
+// supaya sensor yang terus di luar ambang tidak membuat peringatan baru di setiap pembacaan.
+func simpanPeringatanLingkungan(ctx context.Context, peringatan []model.PeringatanLingkungan) ([]model.PeringatanLingkungan, error) {
+	col := config.Ulbimongoconn.Collection("peringatan_lingkungan")
+
+	hasil := make([]model.PeringatanLingkungan, 0, len(peringatan))
+	for _, p := range peringatan {
+		filter := bson.M{
+			"lokasi.gudang_id": p.Lokasi.GudangID,
+			"lokasi.rak_id":    bson.M{"$exists": false},
+			"parameter":        p.Parameter,
+			"selesai":          false,
+		}
+		if !p.Lokasi.RakID.IsZero() {
+			filter["lokasi.rak_id"] = p.Lokasi.RakID
+		}
+
+		set := bson.M{"nilai_terakhir": p.Nilai, "waktu_terakhir": p.Waktu, "pesan": p.Pesan}
+		unset := bson.M{}
+		for field, batas := range map[string]*float64{"batas_min": p.BatasMin, "batas_max": p.BatasMax} {
+			if batas != nil {
+				set[field] = *batas
+			} else {
+				unset[field] = ""
+			}
+		}
+		update := bson.M{
+			"$setOnInsert": bson.M{
+				"_id":        p.ID,
+				"waktu":      p.Waktu,
+				"lokasi":     p.Lokasi,
+				"nilai":      p.Nilai,
+				"created_at": p.CreatedAt,
+			},
+			"$set": set,
+			"$inc": bson.M{"jumlah_pembacaan": 1},
+		}
+		if len(unset) > 0 {
+			update["$unset"] = unset
+		}
+
+		var disimpan model.PeringatanLingkungan
+		err := col.FindOneAndUpdate(ctx, filter, update,
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		).Decode(&disimpan)
+		if err != nil {
+			return nil, err
+		}
+		hasil = append(hasil, disimpan)
+	}
+	return hasil, nil
+}
+
+// InsertPembacaanSensor godoc
+// @Summary      Ingest Pembacaan Sensor
+// @Description  Menerima data suhu & kelembapan dari sensor (satu objek atau array). Autentikasi memakai header X-API-Key. Pembacaan di luar ambang batas otomatis membuat peringatan; selama peringatan untuk lokasi & parameter yang sama belum diselesaikan, pembacaan berikutnya hanya memperbarui nilai terakhirnya.
+// @Tags         Monitoring Lingkungan
+// @Accept       json
+// @Produce      json
+// @Param        X-API-Key  header  string                        true  "API key sensor"
+// @Param        request    body    model.PembacaanSensorRequest  true  "Data pembacaan sensor"
+// @Success      201  {object}  map[string]interface{}
+// @Router       /lingkungan/pembacaan [post]
+func InsertPembacaanSensor(c *fiber.Ctx) error {
+	// 🔹 Body boleh berupa satu objek atau array objek
+	var requests []model.PembacaanSensorRequest
+	body := bytes.TrimSpace(c.Body())
+	var err error
+	if len(body) > 0 && body[0] == '[' {
+		err = json.Unmarshal(body, &requests)
+	} else {
+		var req model.PembacaanSensorRequest
+		err = json.Unmarshal(body, &req)
+		requests = append(requests, req)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	if len(requests) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Data pembacaan tidak boleh kosong",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	gudangCollection := config.Ulbimongoconn.Collection("gudang")
+	rakCollection := config.Ulbimongoconn.Collection("rak")
+	gudangAda := map[primitive.ObjectID]bool{}
+	rakAda := map[primitive.ObjectID]bool{}
+
+	var pembacaan []interface{}
+	var peringatan []model.PeringatanLingkungan
+
+	for i, req := range requests {
+		// =========================
+		// VALIDASI PEMBACAAN
+		// =========================
+		gudangID, err := primitive.ObjectIDFromHex(req.GudangID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fmt.Sprintf("Pembacaan ke-%d: ID gudang tidak valid", i+1),
+			})
+		}
+
+		var rakID primitive.ObjectID
+		if req.RakID != "" {
+			rakID, err = primitive.ObjectIDFromHex(req.RakID)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": fmt.Sprintf("Pembacaan ke-%d: ID rak tidak valid", i+1),
+				})
+			}
+		}
+
+		if req.Suhu == nil && req.Kelembapan == nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fmt.Sprintf("Pembacaan ke-%d: suhu atau kelembapan wajib diisi", i+1),
+			})
+		}
+
+		waktu := time.Now()
+		if req.Waktu != "" {
+			waktu, err = time.Parse(time.RFC3339, req.Waktu)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": fmt.Sprintf("Pembacaan ke-%d: format waktu harus RFC3339", i+1),
+				})
+			}
+		}
+
+		if _, ok := gudangAda[gudangID]; !ok {
+			count, err := gudangCollection.CountDocuments(ctx, bson.M{"_id": gudangID})
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": "Gagal mengecek data gudang",
+				})
+			}
+			gudangAda[gudangID] = count > 0
+		}
+		if !gudangAda[gudangID] {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fmt.Sprintf("Pembacaan ke-%d: data gudang tidak ditemukan", i+1),
+			})
+		}
+
+		if !rakID.IsZero() {
+			if _, ok := rakAda[rakID]; !ok {
+				count, err := rakCollection.CountDocuments(ctx, bson.M{"_id": rakID})
+				if err != nil {
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"error": "Gagal mengecek data rak",
+					})
+				}
+				rakAda[rakID] = count > 0
+			}
+			if !rakAda[rakID] {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": fmt.Sprintf("Pembacaan ke-%d: data rak tidak ditemukan", i+1),
+				})
+			}
+		}
+
+		p := model.PembacaanSensor{
+			Waktu: waktu,
+			Lokasi: model.LokasiSensor{
+				GudangID: gudangID,
+				RakID:    rakID,
+				SensorID: req.SensorID,
+			},
+			Suhu:       req.Suhu,
+			Kelembapan: req.Kelembapan,
+		}
+		pembacaan = append(pembacaan, p)
+
+		// =========================
+		// CEK AMBANG BATAS
+		// =========================
+		ambang, err := cariAmbangLingkungan(ctx, p.Lokasi)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Gagal mengambil ambang batas lingkungan",
+			})
+		}
+		peringatan = append(peringatan, cekAmbangLingkungan(p, ambang)...)
+	}
+
+	if _, err := config.Ulbimongoconn.Collection("pembacaan_sensor").InsertMany(ctx, pembacaan); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan data sensor: " + err.Error(),
+		})
+	}
+
+	peringatan, err = simpanPeringatanLingkungan(ctx, peringatan)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan peringatan lingkungan: " + err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message":    "Data sensor berhasil disimpan",
+		"jumlah":     len(pembacaan),
+		"peringatan": peringatan,
+	})
+}
+
+// GetPembacaanSensor godoc
+// @Summary      Get Pembacaan Sensor
+// @Description  Mengambil data mentah suhu & kelembapan dalam rentang waktu (default 24 jam terakhir)
+// @Tags         Monitoring Lingkungan
+// @Produce      json
+// @Param        gudang_id  query  string  false  "ID Gudang"
+// @Param        rak_id     query  string  false  "ID Rak"
+// @Param        dari       query  string  false  "Waktu awal (RFC3339 / YYYY-MM-DD)"
+// @Param        sampai     query  string  false  "Waktu akhir (RFC3339 / YYYY-MM-DD)"
+// @Param        limit      query  int     false  "Jumlah data maksimum (default 500)"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /lingkungan/pembacaan [get]
+func GetPembacaanSensor(c *fiber.Ctx) error {
+	filter, errMsg := filterLokasiSensor(c, "lokasi.")
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": errMsg})
+	}
+
+	dari, sampai, errMsg := parseRentangWaktu(c)
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": errMsg})
+	}
+	filter["waktu"] = bson.M{"$gte": dari, "$lte": sampai}
+
+	limit := c.QueryInt("limit", 500)
+	if limit <= 0 || limit > 5000 {
+		limit = 500
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.M{"waktu": -1}).SetLimit(int64(limit))
+	cursor, err := config.Ulbimongoconn.Collection("pembacaan_sensor").Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"message": "Gagal mengambil data sensor",
+			"error":   err.Error(),
+		})
+	}
+
+	pembacaan := []model.PembacaanSensor{}
+	if err := cursor.All(ctx, &pembacaan); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"message": "Gagal decode data sensor",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil data sensor",
+		"dari":    dari,
+		"sampai":  sampai,
+		"total":   len(pembacaan),
+		"data":    pembacaan,
+	})
+}
+
+// GetStatistikLingkungan godoc
+// @Summary      Get Statistik Lingkungan
+// @Description  Menghitung min/max/rata-rata suhu & kelembapan dalam rentang waktu. Gunakan interval=jam atau interval=hari untuk mengelompokkan hasil.
+// @Tags         Monitoring Lingkungan
+// @Produce      json
+// @Param        gudang_id  query  string  false  "ID Gudang"
+// @Param        rak_id     query  string  false  "ID Rak"
+// @Param        dari       query  string  false  "Waktu awal (RFC3339 / YYYY-MM-DD)"
+// @Param        sampai     query  string  false  "Waktu akhir (RFC3339 / YYYY-MM-DD)"
+// @Param        interval   query  string  false  "Pengelompokan: jam / hari (kosong = satu ringkasan)"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /lingkungan/statistik [get]
+func GetStatistikLingkungan(c *fiber.Ctx) error {
+	filter, errMsg := filterLokasiSensor(c, "lokasi.")
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": errMsg})
+	}
+
+	dari, sampai, errMsg := parseRentangWaktu(c)
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": errMsg})
+	}
+	filter["waktu"] = bson.M{"$gte": dari, "$lte": sampai}
+
+	// 🔹 Tentukan pengelompokan
+	var groupID interface{}
+	switch c.Query("interval") {
+	case "":
+		groupID = nil
+	case "jam":
+		groupID = bson.M{"$dateTrunc": bson.M{"date": "$waktu", "unit": "hour"}}
+	case "hari":
+		groupID = bson.M{"$dateTrunc": bson.M{"date": "$waktu", "unit": "day"}}
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Interval harus 'jam' atau 'hari'",
+		})
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{
+			"_id":            groupID,
+			"jumlah":         bson.M{"$sum": 1},
+			"suhu_min":       bson.M{"$min": "$suhu"},
+			"suhu_max":       bson.M{"$max": "$suhu"},
+			"suhu_avg":       bson.M{"$avg": "$suhu"},
+			"kelembapan_min": bson.M{"$min": "$kelembapan"},
+			"kelembapan_max": bson.M{"$max": "$kelembapan"},
+			"kelembapan_avg": bson.M{"$avg": "$kelembapan"},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	cursor, err := config.Ulbimongoconn.Collection("pembacaan_sensor").Aggregate(ctx, pipeline)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"message": "Gagal menghitung statistik lingkungan",
+			"error":   err.Error(),
+		})
+	}
+
+	statistik := []model.StatistikLingkungan{}
+	if err := cursor.All(ctx, &statistik); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"message": "Gagal decode statistik lingkungan",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil statistik lingkungan",
+		"dari":    dari,
+		"sampai":  sampai,
+		"data":    statistik,
+	})
+}
+
+// SetAmbangLingkungan godoc
+// @Summary      Set Ambang Batas Lingkungan
+// @Description  Mengatur batas suhu & kelembapan untuk satu gudang atau satu rak di gudang tersebut. Ambang rak lebih diutamakan daripada ambang gudang.
+// @Tags         Monitoring Lingkungan
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        gudang_id       formData  string  true   "ID Gudang"
+// @Param        rak_id          formData  string  false  "ID Rak (kosong = berlaku untuk seluruh gudang)"
+// @Param        suhu_min        formData  number  false  "Suhu minimum (°C)"
+// @Param        suhu_max        formData  number  false  "Suhu maksimum (°C)"
+// @Param        kelembapan_min  formData  number  false  "Kelembapan minimum (%)"
+// @Param        kelembapan_max  formData  number  false  "Kelembapan maksimum (%)"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /lingkungan/ambang [put]
+func SetAmbangLingkungan(c *fiber.Ctx) error {
+	gudangID, err := primitive.ObjectIDFromHex(c.FormValue("gudang_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID gudang tidak valid",
+		})
+	}
+
+	var rakID primitive.ObjectID
+	if v := c.FormValue("rak_id"); v != "" {
+		rakID, err = primitive.ObjectIDFromHex(v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "ID rak tidak valid",
+			})
+		}
+	}
+
+	ambang := model.AmbangLingkungan{
+		GudangID:  gudangID,
+		RakID:     rakID,
+		UpdatedAt: time.Now(),
+	}
+
+	for key, target := range map[string]**float64{
+		"suhu_min":       &ambang.SuhuMin,
+		"suhu_max":       &ambang.SuhuMax,
+		"kelembapan_min": &ambang.KelembapanMin,
+		"kelembapan_max": &ambang.KelembapanMax,
+	} {
+		n, err := parseAngkaOpsional(c, key)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fmt.Sprintf("Nilai %s harus berupa angka", key),
+			})
+		}
+		*target = n
+	}
+
+	if ambang.SuhuMin == nil && ambang.SuhuMax == nil && ambang.KelembapanMin == nil && ambang.KelembapanMax == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Minimal satu ambang batas wajib diisi",
+		})
+	}
+	if ambang.SuhuMin != nil && ambang.SuhuMax != nil && *ambang.SuhuMin > *ambang.SuhuMax {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Suhu minimum tidak boleh lebih besar dari suhu maksimum",
+		})
+	}
+	if ambang.KelembapanMin != nil && ambang.KelembapanMax != nil && *ambang.KelembapanMin > *ambang.KelembapanMax {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Kelembapan minimum tidak boleh lebih besar dari kelembapan maksimum",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 🔹 Pastikan gudang & rak ada
+	if err := config.Ulbimongoconn.Collection("gudang").FindOne(ctx, bson.M{"_id": gudangID}).Err(); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Data gudang tidak ditemukan",
+		})
+	}
+	if !rakID.IsZero() {
+		if err := config.Ulbimongoconn.Collection("rak").FindOne(ctx, bson.M{"_id": rakID}).Err(); err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Data rak tidak ditemukan",
+			})
+		}
+	}
+
+	filter := bson.M{"gudang_id": gudangID, "rak_id": bson.M{"$exists": false}}
+	if !rakID.IsZero() {
+		filter = bson.M{"gudang_id": gudangID, "rak_id": rakID}
+	}
+
+	col := config.Ulbimongoconn.Collection("ambang_lingkungan")
+	_, err = col.ReplaceOne(ctx, filter, ambang, options.Replace().SetUpsert(true))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan ambang batas lingkungan",
+		})
+	}
+
+	var saved model.AmbangLingkungan
+	col.FindOne(ctx, filter).Decode(&saved)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Ambang batas lingkungan berhasil disimpan",
+		"data":    saved,
+	})
+}
+
+// GetAllAmbangLingkungan godoc
+// @Summary      Get All Ambang Batas Lingkungan
+// @Description  Mengambil seluruh konfigurasi ambang batas suhu & kelembapan
+// @Tags         Monitoring Lingkungan
+// @Produce      json
+// @Param        gudang_id  query  string  false  "ID Gudang"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /lingkungan/ambang [get]
+func GetAllAmbangLingkungan(c *fiber.Ctx) error {
+	filter, errMsg := filterLokasiSensor(c, "")
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": errMsg})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.Ulbimongoconn.Collection("ambang_lingkungan").Find(ctx, filter)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"message": "Gagal mengambil data ambang batas",
+			"error":   err.Error(),
+		})
+	}
+
+	ambang := []model.AmbangLingkungan{}
+	if err := cursor.All(ctx, &ambang); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"message": "Gagal decode data ambang batas",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil data ambang batas lingkungan",
+		"total":   len(ambang),
+		"data":    ambang,
+	})
+}
+
+// DeleteAmbangLingkungan godoc
+// @Summary      Delete Ambang Batas Lingkungan
+// @Description  Menghapus konfigurasi ambang batas berdasarkan ID
+// @Tags         Monitoring Lingkungan
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID ambang batas"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /lingkungan/ambang/{id} [delete]
+func DeleteAmbangLingkungan(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	objID, err := primitive.ObjectIDFromHex(idParam)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID ambang batas tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := config.Ulbimongoconn.Collection("ambang_lingkungan").DeleteOne(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menghapus ambang batas",
+		})
+	}
+
+	if result.DeletedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Ambang batas tidak ditemukan",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Ambang batas berhasil dihapus",
+		"id":      idParam,
+	})
+}
+
+// GetPeringatanLingkungan godoc
+// @Summary      Get Peringatan Lingkungan
+// @Description  Mengambil peringatan suhu / kelembapan di luar ambang batas, terbaru lebih dulu
+// @Tags         Monitoring Lingkungan
+// @Produce      json
+// @Param        gudang_id  query  string  false  "ID Gudang"
+// @Param        rak_id     query  string  false  "ID Rak"
+// @Param        aktif      query  bool    false  "Hanya peringatan yang belum diselesaikan"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /lingkungan/peringatan [get]
+func GetPeringatanLingkungan(c *fiber.Ctx) error {
+	filter, errMsg := filterLokasiSensor(c, "lokasi.")
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": errMsg})
+	}
+	if c.QueryBool("aktif", false) {
+		filter["selesai"] = false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.M{"waktu": -1}).SetLimit(500)
+	cursor, err := config.Ulbimongoconn.Collection("peringatan_lingkungan").Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"message": "Gagal mengambil data peringatan",
+			"error":   err.Error(),
+		})
+	}
+
+	peringatan := []model.PeringatanLingkungan{}
+	if err := cursor.All(ctx, &peringatan); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"message": "Gagal decode data peringatan",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil data peringatan lingkungan",
+		"total":   len(peringatan),
+		"data":    peringatan,
+	})
+}
+
+// SelesaikanPeringatanLingkungan godoc
+// @Summary      Selesaikan Peringatan Lingkungan
+// @Description  Menandai peringatan lingkungan sudah ditangani
+// @Tags         Monitoring Lingkungan
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID peringatan"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /lingkungan/peringatan/{id}/selesai [put]
+func SelesaikanPeringatanLingkungan(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	objID, err := primitive.ObjectIDFromHex(idParam)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID peringatan tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := config.Ulbimongoconn.Collection("peringatan_lingkungan").UpdateOne(ctx,
+		bson.M{"_id": objID},
+		bson.M{"$set": bson.M{"selesai": true}},
+	)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memperbarui peringatan",
+		})
+	}
+
+	if result.MatchedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Peringatan tidak ditemukan",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Peringatan berhasil ditandai selesai",
+		"id":      idParam,
+	})
+}
@@ -0,0 +1,403 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// =============================================================
+// 📦 Ubah massal koleksi (reklasifikasi, pindah lokasi, dsb.)
+// =============================================================
+
+// maksUbahMassal adalah jumlah koleksi maksimum dalam satu permintaan ubah massal.
+// Bisa diatur lewat env UBAH_MASSAL_MAKS (default 1000).
+func maksUbahMassal() int {
+	if n, err := strconv.Atoi(os.Getenv("UBAH_MASSAL_MAKS")); err == nil && n > 0 {
+		return n
+	}
+	return 1000
+}
+
+// perubahanMassalKosong bernilai true jika tidak ada field yang diminta untuk diubah
+func perubahanMassalKosong(p model.PerubahanMassal) bool {
+	return p.KategoriID == "" && p.GudangID == "" && p.RakID == nil && p.TahapID == nil &&
+		p.Kondisi == nil && len(p.Atribut) == 0
+}
+
+// filterUbahMassal menyusun filter MongoDB dari daftar ID atau kriteria filter.
+// Dengan daftar ID, koleksi yang sudah dideaksesi tetap diambil agar muncul sebagai gagal di hasil.
+func filterUbahMassal(ctx context.Context, req model.UbahMassalRequest) (bson.M, []primitive.ObjectID, string) {
+	if len(req.IDs) > 0 {
+		ids := make([]primitive.ObjectID, 0, len(req.IDs))
+		sudah := map[primitive.ObjectID]bool{}
+		for _, hex := range req.IDs {
+			id, err := primitive.ObjectIDFromHex(hex)
+			if err != nil {
+				return nil, nil, fmt.Sprintf("ID koleksi '%s' tidak valid", hex)
+			}
+			if !sudah[id] {
+				sudah[id] = true
+				ids = append(ids, id)
+			}
+		}
+		return bson.M{"_id": bson.M{"$in": ids}}, ids, ""
+	}
+
+	f := req.Filter
+	filter := bson.M{}
+	if f.KategoriID != "" {
+		id, err := primitive.ObjectIDFromHex(f.KategoriID)
+		if err != nil {
+			return nil, nil, "ID kategori filter tidak valid"
+		}
+		ids, err := idKategoriDanTurunan(ctx, id)
+		if err != nil {
+			return nil, nil, "Gagal mengambil sub-kategori"
+		}
+		filter["kategori._id"] = bson.M{"$in": ids}
+	}
+	for field, hex := range map[string]string{
+		"tempat_penyimpanan.gudang._id": f.GudangID,
+		"tempat_penyimpanan.rak._id":    f.RakID,
+		"tempat_penyimpanan.tahap._id":  f.TahapID,
+	} {
+		if hex == "" {
+			continue
+		}
+		id, err := primitive.ObjectIDFromHex(hex)
+		if err != nil {
+			return nil, nil, "ID lokasi filter tidak valid"
+		}
+		filter[field] = id
+	}
+	if f.Kondisi != "" {
+		filter["kondisi"] = f.Kondisi
+	}
+	if len(filter) == 0 {
+		return nil, nil, "Filter minimal berisi satu kriteria"
+	}
+	return filterKoleksiAktif(filter), nil, ""
+}
+
+// targetUbahMassal berisi data referensi perubahan massal yang sudah dicek keberadaannya
+type targetUbahMassal struct {
+	kategori *model.Kategori
+	skema    []model.AtributKategori // skema kategori baru
+	gudang   *model.Gudang
+	rak      *model.Rak
+	tahap    *model.Tahap
+}
+
+// siapkanTargetUbahMassal mengambil kategori / gudang / rak / tahap tujuan sekali untuk semua koleksi
+func siapkanTargetUbahMassal(ctx context.Context, p model.PerubahanMassal) (targetUbahMassal, int, string) {
+	var t targetUbahMassal
+	db := config.Ulbimongoconn
+
+	if p.KategoriID != "" {
+		id, err := primitive.ObjectIDFromHex(p.KategoriID)
+		if err != nil {
+			return t, 400, "ID kategori tidak valid"
+		}
+		var kategori model.Kategori
+		if err := db.Collection("kategori").FindOne(ctx, bson.M{"_id": id}).Decode(&kategori); err != nil {
+			return t, 404, "Kategori tidak ditemukan"
+		}
+		skema, err := skemaAtributKategori(ctx, kategori)
+		if err != nil {
+			return t, 500, "Gagal mengambil skema atribut kategori"
+		}
+		t.kategori, t.skema = &kategori, skema
+	}
+	if p.GudangID != "" {
+		id, err := primitive.ObjectIDFromHex(p.GudangID)
+		if err != nil {
+			return t, 400, "ID gudang tidak valid"
+		}
+		var gudang model.Gudang
+		if err := db.Collection("gudang").FindOne(ctx, bson.M{"_id": id}).Decode(&gudang); err != nil {
+			return t, 404, "Gudang tidak ditemukan"
+		}
+		t.gudang = &model.Gudang{ID: gudang.ID, NamaGudang: gudang.NamaGudang}
+	}
+	if p.RakID != nil {
+		t.rak = &model.Rak{}
+		if *p.RakID != "" {
+			id, err := primitive.ObjectIDFromHex(*p.RakID)
+			if err != nil {
+				return t, 400, "ID rak tidak valid"
+			}
+			var rak model.Rak
+			if err := db.Collection("rak").FindOne(ctx, bson.M{"_id": id}).Decode(&rak); err != nil {
+				return t, 404, "Rak tidak ditemukan"
+			}
+			t.rak = &model.Rak{ID: rak.ID, NamaRak: rak.NamaRak}
+		}
+	}
+	if p.TahapID != nil {
+		t.tahap = &model.Tahap{}
+		if *p.TahapID != "" {
+			id, err := primitive.ObjectIDFromHex(*p.TahapID)
+			if err != nil {
+				return t, 400, "ID tahap tidak valid"
+			}
+			var tahap model.Tahap
+			if err := db.Collection("tahap").FindOne(ctx, bson.M{"_id": id}).Decode(&tahap); err != nil {
+				return t, 404, "Tahap tidak ditemukan"
+			}
+			t.tahap = &model.Tahap{ID: tahap.ID, NamaTahap: tahap.NamaTahap}
+		}
+	}
+	return t, 0, ""
+}
+
+// terapkanUbahMassal menerapkan perubahan ke salinan koleksi lalu memvalidasi atribut terhadap skema kategori.
+// skemaKategori dipakai untuk mengambil skema kategori lama (di-cache per kategori).
+func terapkanUbahMassal(k model.Koleksi, p model.PerubahanMassal, t targetUbahMassal, skemaKategori func(model.Kategori) ([]model.AtributKategori, error)) (model.Koleksi, string) {
+	if k.Deaksesi != nil && k.Deaksesi.Status == model.StatusDeaksesiSelesai {
+		return k, "Koleksi sudah dideaksesi dan hanya bisa dibaca"
+	}
+
+	baru := k
+	if t.kategori != nil {
+		baru.Kategori = snapshotKategori(*t.kategori)
+	}
+	if t.gudang != nil {
+		baru.TempatPenyimpanan.Gudang = *t.gudang
+	}
+	if t.rak != nil {
+		baru.TempatPenyimpanan.Rak = *t.rak
+	}
+	if t.tahap != nil {
+		baru.TempatPenyimpanan.Tahap = *t.tahap
+	}
+	if p.Kondisi != nil {
+		baru.Kondisi = *p.Kondisi
+	}
+
+	// Atribut hanya divalidasi ulang jika atribut atau kategorinya berubah
+	if len(p.Atribut) == 0 && t.kategori == nil {
+		return baru, ""
+	}
+	skema := t.skema
+	if t.kategori == nil {
+		var err error
+		if skema, err = skemaKategori(k.Kategori); err != nil {
+			return k, "Gagal mengambil skema atribut kategori"
+		}
+	}
+
+	// salin atribut lama agar map milik koleksi asal tidak ikut berubah
+	atribut := map[string]interface{}{}
+	for key, nilai := range k.Atribut {
+		atribut[key] = nilai
+	}
+	for key, nilai := range p.Atribut {
+		if nilai == nil {
+			delete(atribut, key)
+		} else {
+			atribut[key] = nilai
+		}
+	}
+	if p.BuangAtributLain {
+		dikenal := map[string]bool{}
+		for _, a := range skema {
+			dikenal[a.Nama] = true
+		}
+		for key := range atribut {
+			if !dikenal[key] {
+				delete(atribut, key)
+			}
+		}
+	}
+
+	raw, err := json.Marshal(atribut)
+	if err != nil {
+		return k, "Atribut koleksi tidak bisa dibaca"
+	}
+	hasil, errMsg := validasiAtributKoleksi(skema, string(raw))
+	if errMsg != "" {
+		return k, errMsg
+	}
+	baru.Atribut = hasil
+	return baru, ""
+}
+
+// UbahMassalKoleksi godoc
+// @Summary      Ubah massal koleksi
+// @Description  Mengubah kategori, lokasi (gudang / rak / tahap), kondisi, dan atribut tambahan banyak koleksi sekaligus.
+// @Description  Koleksi dipilih dengan daftar `ids` atau `filter` (kategori termasuk sub-kategori, gudang, rak, tahap, kondisi; koleksi yang sudah dideaksesi dilewati).
+// @Description  Atribut digabung dengan atribut lama (nilai null menghapus atribut) lalu divalidasi per koleksi terhadap skema kategori.
+// @Description  Gunakan `dry_run: true` untuk melihat jumlah koleksi yang terdampak dan hasil validasi tanpa menyimpan perubahan.
+// @Description  Koleksi yang gagal validasi tidak menghalangi koleksi lain. Setiap koleksi yang berubah mendapat satu entri riwayat dengan `massal_id` yang sama.
+// @Description  Kontributor tidak dapat melakukan ubah massal.
+// @Tags         Data Koleksi
+// @Accept       json
+// @Produce      json
+// @Param        body body model.UbahMassalRequest true "Pilihan koleksi & perubahan"
+// @Success      200 {object} model.LaporanUbahMassal
+// @Failure      400 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Router       /koleksi/ubah-massal [post]
+// @Security     BearerAuth
+func UbahMassalKoleksi(c *fiber.Ctx) error {
+	if perluReview(c) {
+		return c.Status(403).JSON(fiber.Map{"error": "Kontributor tidak dapat melakukan ubah massal, ajukan perubahan per koleksi"})
+	}
+
+	var req model.UbahMassalRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Body request tidak valid"})
+	}
+	if len(req.IDs) == 0 && req.Filter == nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Isi ids atau filter untuk memilih koleksi"})
+	}
+	if len(req.IDs) > 0 && req.Filter != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Gunakan ids atau filter, tidak keduanya"})
+	}
+	if perubahanMassalKosong(req.Perubahan) {
+		return c.Status(400).JSON(fiber.Map{"error": "Tidak ada perubahan yang diminta"})
+	}
+	if req.Perubahan.Kondisi != nil {
+		kondisi, errMsg := kondisiKoleksi(*req.Perubahan.Kondisi, "")
+		if errMsg != "" {
+			return c.Status(400).JSON(fiber.Map{"error": errMsg})
+		}
+		req.Perubahan.Kondisi = &kondisi
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	filter, ids, errMsg := filterUbahMassal(ctx, req)
+	if errMsg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": errMsg})
+	}
+	target, status, errMsg := siapkanTargetUbahMassal(ctx, req.Perubahan)
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": errMsg})
+	}
+
+	collection := config.Ulbimongoconn.Collection("koleksi")
+	maks := maksUbahMassal()
+	jumlah, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghitung koleksi"})
+	}
+	if int(jumlah) > maks {
+		return c.Status(400).JSON(fiber.Map{"error": fmt.Sprintf("Terdapat %d koleksi, maksimal %d per ubah massal. Persempit filter.", jumlah, maks)})
+	}
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil koleksi"})
+	}
+	var daftar []model.Koleksi
+	if err := cursor.All(ctx, &daftar); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal decode koleksi"})
+	}
+
+	// 🔹 Skema kategori lama di-cache per kategori
+	cacheSkema := map[primitive.ObjectID][]model.AtributKategori{}
+	skemaKategori := func(k model.Kategori) ([]model.AtributKategori, error) {
+		if skema, ok := cacheSkema[k.ID]; ok {
+			return skema, nil
+		}
+		var kategori model.Kategori
+		if err := config.Ulbimongoconn.Collection("kategori").FindOne(ctx, bson.M{"_id": k.ID}).Decode(&kategori); err != nil {
+			return nil, err
+		}
+		skema, err := skemaAtributKategori(ctx, kategori)
+		if err != nil {
+			return nil, err
+		}
+		cacheSkema[k.ID] = skema
+		return skema, nil
+	}
+
+	laporan := model.LaporanUbahMassal{DryRun: req.DryRun, Hasil: []model.HasilUbahMassal{}}
+	catat := func(h model.HasilUbahMassal) {
+		switch h.Status {
+		case model.StatusMassalGagal:
+			laporan.Gagal++
+		case model.StatusMassalTidakBerubah:
+			laporan.TidakBerubah++
+		default:
+			laporan.Diubah++
+		}
+		laporan.Hasil = append(laporan.Hasil, h)
+	}
+
+	massalID := primitive.NewObjectID()
+	oleh := penggunaLogin(c)
+	ditemukan := map[primitive.ObjectID]bool{}
+	for _, k := range daftar {
+		ditemukan[k.ID] = true
+		h := model.HasilUbahMassal{KoleksiID: k.ID.Hex(), NoInventaris: k.NoInventaris, NamaBenda: k.NamaBenda}
+
+		baru, errMsg := terapkanUbahMassal(k, req.Perubahan, target, skemaKategori)
+		if errMsg != "" {
+			h.Status, h.Error = model.StatusMassalGagal, errMsg
+			catat(h)
+			continue
+		}
+		h.Field = fieldBerubah(k, baru)
+		if len(h.Field) == 0 {
+			h.Status = model.StatusMassalTidakBerubah
+			catat(h)
+			continue
+		}
+		if req.DryRun {
+			h.Status = model.StatusMassalSiap
+			catat(h)
+			continue
+		}
+
+		// 🔹 Simpan hanya jika koleksi tidak diubah pihak lain sejak dibaca
+		res, err := collection.UpdateOne(ctx, filterVersiKoleksi(k.ID, k.Versi), updateFieldBerubah(baru, h.Field, time.Now()))
+		if err != nil {
+			h.Status, h.Error = model.StatusMassalGagal, "Gagal menyimpan perubahan"
+			catat(h)
+			continue
+		}
+		if res.MatchedCount == 0 {
+			h.Status, h.Error = model.StatusMassalGagal, "Koleksi diubah pengguna lain saat proses berjalan, ulangi untuk koleksi ini"
+			catat(h)
+			continue
+		}
+
+		catatRiwayatKoleksi(ctx, model.RiwayatKoleksi{
+			KoleksiID: k.ID,
+			Aksi:      model.JenisPerubahanMassal,
+			Field:     h.Field,
+			Oleh:      oleh,
+			MassalID:  &massalID,
+		})
+		h.Status = model.StatusMassalBerhasil
+		catat(h)
+	}
+
+	// 🔹 ID yang diminta tetapi tidak ada di database
+	for _, id := range ids {
+		if !ditemukan[id] {
+			catat(model.HasilUbahMassal{KoleksiID: id.Hex(), Status: model.StatusMassalGagal, Error: "Koleksi tidak ditemukan"})
+		}
+	}
+
+	laporan.Total = len(laporan.Hasil)
+	if req.DryRun {
+		laporan.Message = "Pratinjau ubah massal, belum ada data yang diubah"
+	} else {
+		laporan.Message = fmt.Sprintf("%d koleksi berhasil diubah", laporan.Diubah)
+	}
+	return c.JSON(laporan)
+}
@@ -0,0 +1,604 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"be-internship/storage"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/text/unicode/norm"
+)
+
+// maksPanjangSlug membatasi panjang slug nama benda pada nama file
+const maksPanjangSlug = 60
+
+// =============================================================
+// 🟣 Fungsi Upload Media ke storage (GitHub / lokal / S3, lihat STORAGE_DRIVER)
+// Gambar divalidasi, dibersihkan dari EXIF, lalu diupload bersama rendisinya.
+// Jika hanyaGambar false, lampiran lain (PDF, TIFF, dsb.) diupload apa adanya tanpa rendisi.
+// =============================================================
+func uploadMediaKoleksi(file *multipart.FileHeader, namaBenda string, hanyaGambar bool) (model.Media, error) {
+	var media model.Media
+
+	if file.Size > maksUkuranMedia() {
+		return media, &errMedia{fiber.StatusRequestEntityTooLarge, fmt.Sprintf("Ukuran file melebihi batas %d MB", maksUkuranMedia()>>20)}
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return media, fmt.Errorf("gagal membuka file: %w", err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return media, fmt.Errorf("gagal membaca file: %w", err)
+	}
+
+	gambar, err := prosesMedia(data, hanyaGambar)
+	if err != nil {
+		return media, err
+	}
+
+	return uploadMedia(gambar, namaBenda, false)
+}
+
+// uploadMedia mengupload file hasil prosesMedia beserta rendisinya. Reference count setiap key
+// sudah dinaikkan saat fungsi ini selesai; pemanggil wajib melepasnya jika media batal disimpan.
+// Jika unik bernilai true, nama file diberi akhiran acak (tidak memakai ulang file yang sudah ada).
+func uploadMedia(gambar *hasilGambar, namaBenda string, unik bool) (model.Media, error) {
+	var media model.Media
+	var err error
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	store := storage.Aktif()
+	var dipakai []string
+	// bentrok bernilai true jika file dengan key yang sama sedang dihapus
+	bentrok := false
+	put := func(key string, isi []byte, contentType string) (string, error) {
+		// File dengan key yang sama sudah ada (isi identik) → dipakai ulang tanpa upload.
+		// Reference count langsung dinaikkan supaya file tidak dihapus sebelum media disimpan.
+		if o := cariObjekMedia(ctx, bson.M{"_id": key}); o != nil {
+			if !pakaiObjekMedia(ctx, key) {
+				bentrok = true
+				return "", fmt.Errorf("file %s sedang dihapus", key)
+			}
+			dipakai = append(dipakai, key)
+			return o.URL, nil
+		}
+		url, err := store.Put(ctx, key, isi, contentType)
+		if err == nil {
+			dipakai = append(dipakai, key)
+			catatObjekMedia(ctx, key, url, contentType, int64(len(isi)), hashSHA256(isi))
+		}
+		return url, err
+	}
+	// Jika salah satu upload gagal, reference count yang sudah diambil dilepas lagi
+	// (file yang baru terupload ikut terhapus)
+	batal := func(err error) (model.Media, error) {
+		lepasRefMedia(ctx, dipakai)
+		if bentrok && !unik {
+			// ulangi dengan nama file lain agar tidak bentrok dengan file yang sedang dihapus
+			return uploadMedia(gambar, namaBenda, true)
+		}
+		return model.Media{}, err
+	}
+
+	// Nama file berdasarkan hash isi file, sehingga foto identik hanya disimpan sekali.
+	// Slug nama benda hanya untuk memudahkan dibaca; foto identik memakai key yang sudah ada.
+	media.SHA256 = hashSHA256(gambar.Data)
+	var base string
+	if o := cariObjekMedia(ctx, bson.M{"sha256": media.SHA256, "content_type": gambar.MIME, "dihapus": bson.M{"$exists": false}}); o != nil && !unik {
+		base = strings.TrimSuffix(o.Key, path.Ext(o.Key))
+	} else {
+		base = fmt.Sprintf("%s%s/%s-%s", prefixMediaKoleksi, media.SHA256[:2], media.SHA256[:16], slugNamaFile(namaBenda))
+		if unik {
+			base += "-" + primitive.NewObjectID().Hex()[16:]
+		}
+	}
+
+	media.Key = base + gambar.Ekstensi
+	media.MIME = gambar.MIME
+	media.Lebar = gambar.Lebar
+	media.Tinggi = gambar.Tinggi
+	media.Ukuran = int64(len(gambar.Data))
+	if media.URL, err = put(media.Key, gambar.Data, gambar.MIME); err != nil {
+		return batal(err)
+	}
+
+	media.Rendisi = map[string]model.RendisiMedia{}
+	for _, r := range gambar.Rendisi {
+		rendisi := model.RendisiMedia{
+			Lebar:   r.Lebar,
+			Tinggi:  r.Tinggi,
+			Key:     fmt.Sprintf("%s_%s.jpg", base, r.Nama),
+			WebPKey: fmt.Sprintf("%s_%s.webp", base, r.Nama),
+		}
+		if rendisi.URL, err = put(rendisi.Key, r.JPEG, "image/jpeg"); err != nil {
+			return batal(err)
+		}
+		if rendisi.WebPURL, err = put(rendisi.WebPKey, r.WebP, "image/webp"); err != nil {
+			return batal(err)
+		}
+		media.Rendisi[r.Nama] = rendisi
+	}
+
+	return media, nil
+}
+
+// hashSHA256 mengembalikan hash SHA-256 (hex) dari isi file
+func hashSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// slugNamaFile membuat potongan nama file yang aman dari nama benda: huruf kecil ASCII,
+// angka dan tanda minus. Huruf beraksen diubah ke huruf dasarnya (é → e), karakter lain dibuang.
+func slugNamaFile(nama string) string {
+	var b strings.Builder
+	strip := false
+	for _, r := range norm.NFD.String(strings.ToLower(strings.TrimSpace(nama))) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// tanda aksen hasil dekomposisi
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+			strip = false
+		case !strip && b.Len() > 0:
+			b.WriteRune('-')
+			strip = true
+		}
+		if b.Len() >= maksPanjangSlug {
+			break
+		}
+	}
+	slug := strings.Trim(b.String(), "-")
+	if slug == "" {
+		slug = "koleksi"
+	}
+	return slug
+}
+
+// mediaDariForm membaca metadata media dari form (tipe, keterangan, fotografer, tanggal)
+func mediaDariForm(c *fiber.Ctx) (model.Media, string) {
+	media := model.Media{
+		ID:         primitive.NewObjectID(),
+		Tipe:       strings.ToLower(strings.TrimSpace(c.FormValue("tipe"))),
+		Keterangan: strings.TrimSpace(c.FormValue("keterangan")),
+		Fotografer: strings.TrimSpace(c.FormValue("fotografer")),
+		Tanggal:    strings.TrimSpace(c.FormValue("tanggal")),
+		CreatedAt:  time.Now(),
+	}
+
+	if media.Tipe == "" {
+		media.Tipe = model.TipeMediaFoto
+	}
+	if !model.TipeMediaValid[media.Tipe] {
+		return media, "Tipe media tidak valid (gunakan foto, kondisi, xray, dokumen, atau lainnya)"
+	}
+	if media.Tanggal != "" {
+		if _, err := time.Parse("2006-01-02", media.Tanggal); err != nil {
+			return media, "Format tanggal harus YYYY-MM-DD"
+		}
+	}
+
+	return media, ""
+}
+
+// rapikanMedia menomori ulang urutan dan memastikan hanya ada satu media utama.
+// Jika utamaID diisi, media tersebut dijadikan utama; jika belum ada yang utama, media pertama yang dipakai.
+func rapikanMedia(list []model.Media, utamaID primitive.ObjectID) []model.Media {
+	adaUtama := false
+	for i := range list {
+		list[i].Urutan = i
+		if !utamaID.IsZero() {
+			list[i].Utama = list[i].ID == utamaID
+		} else if list[i].Utama {
+			// hanya media utama pertama yang dipertahankan
+			list[i].Utama = !adaUtama
+		}
+		if list[i].Utama {
+			adaUtama = true
+		}
+	}
+	if !adaUtama && len(list) > 0 {
+		list[0].Utama = true
+	}
+	return list
+}
+
+// cariMedia mengembalikan index media pada list, atau -1 jika tidak ada
+func cariMedia(list []model.Media, id primitive.ObjectID) int {
+	for i, m := range list {
+		if m.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// ambilKoleksiMedia membaca koleksi untuk handler media. Jika gagal, mengembalikan status & pesan error.
+func ambilKoleksiMedia(ctx context.Context, idParam string) (model.Koleksi, int, string) {
+	var koleksi model.Koleksi
+
+	koleksiID, err := primitive.ObjectIDFromHex(idParam)
+	if err != nil {
+		return koleksi, fiber.StatusBadRequest, "ID koleksi tidak valid"
+	}
+
+	err = config.Ulbimongoconn.Collection("koleksi").FindOne(ctx, bson.M{"_id": koleksiID}).Decode(&koleksi)
+	if err != nil {
+		return koleksi, fiber.StatusNotFound, "Koleksi tidak ditemukan"
+	}
+
+	return koleksi, 0, ""
+}
+
+// simpanMediaKoleksi menyimpan daftar media koleksi (field dihapus jika daftar kosong).
+// Daftar hanya disimpan jika koleksi masih di versi yang dibaca; jika tidak, errVersiBerubah dikembalikan
+// agar perubahan media dari request lain tidak tertimpa.
+func simpanMediaKoleksi(ctx context.Context, koleksiID primitive.ObjectID, versi int64, list []model.Media) error {
+	update := bson.M{"$set": bson.M{"media": list, "updated_at": time.Now()}}
+	if len(list) == 0 {
+		update = bson.M{
+			"$set":   bson.M{"updated_at": time.Now()},
+			"$unset": bson.M{"media": ""},
+		}
+	}
+
+	res, err := config.Ulbimongoconn.Collection("koleksi").UpdateOne(ctx, filterVersiKoleksi(koleksiID, versi), tambahVersi(update))
+	if err == nil && res.MatchedCount == 0 {
+		err = errVersiBerubah
+	}
+	return err
+}
+
+// gagalSimpanMedia mengirim response untuk error dari simpanMediaKoleksi
+func gagalSimpanMedia(ctx context.Context, c *fiber.Ctx, koleksiID primitive.ObjectID, err error) error {
+	if err == errVersiBerubah {
+		versi, _ := versiDokumen(ctx, config.Ulbimongoconn.Collection("koleksi"), koleksiID)
+		return tolakVersiBerubah(c, versi)
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Gagal menyimpan media koleksi",
+	})
+}
+
+// TambahMediaKoleksi godoc
+// @Summary      Tambah Media Koleksi
+// @Description  Menambahkan satu foto / lampiran (foto kondisi, X-ray, dokumen scan) ke koleksi tanpa mengirim ulang seluruh form koleksi
+// @Tags         Media Koleksi
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id          path      string  true   "ID koleksi"
+// @Param        file        formData  file    true   "File gambar (JPEG, PNG, GIF, WebP) atau lampiran (PDF, TIFF, BMP, TXT, ZIP). Metadata EXIF gambar dibuang dan rendisi thumbnail / medium / large (JPEG & WebP) dibuat otomatis; GIF dan lampiran disimpan tanpa diubah."
+// @Param        tipe        formData  string  false  "Tipe media: foto (default), kondisi, xray, dokumen, lainnya"
+// @Param        keterangan  formData  string  false  "Keterangan / caption"
+// @Param        fotografer  formData  string  false  "Nama fotografer"
+// @Param        tanggal     formData  string  false  "Tanggal pengambilan (YYYY-MM-DD)"
+// @Param        utama       formData  boolean false  "Jadikan media utama"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      412  {object}  map[string]interface{}
+// @Router       /koleksi/{id}/media [post]
+func TambahMediaKoleksi(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	defer cancel()
+
+	koleksi, status, errMsg := ambilKoleksiMedia(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil || file == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "File media wajib diunggah.",
+		})
+	}
+
+	media, errMsg := mediaDariForm(c)
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	utama := false
+	if v := c.FormValue("utama"); v != "" {
+		if utama, err = strconv.ParseBool(v); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "utama harus true atau false",
+			})
+		}
+	}
+
+	upload, err := uploadMediaKoleksi(file, koleksi.NamaBenda, false)
+	if err != nil {
+		return c.Status(statusErrorMedia(err)).JSON(fiber.Map{
+			"error": fmt.Sprintf("Gagal upload media: %v", err),
+		})
+	}
+	upload.ID = media.ID
+	upload.Tipe = media.Tipe
+	upload.Keterangan = media.Keterangan
+	upload.Fotografer = media.Fotografer
+	upload.Tanggal = media.Tanggal
+	upload.CreatedAt = media.CreatedAt
+	media = upload
+
+	utamaID := primitive.NilObjectID
+	if utama {
+		utamaID = media.ID
+	}
+	list := rapikanMedia(append(koleksi.Media, media), utamaID)
+	if err := simpanMediaKoleksi(ctx, koleksi.ID, koleksi.Versi, list); err != nil {
+		lepasRefMedia(ctx, media.SemuaKey())
+		return gagalSimpanMedia(ctx, c, koleksi.ID, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Media berhasil ditambahkan",
+		"data":    list,
+	})
+}
+
+// UrutkanMediaKoleksi godoc
+// @Summary      Urutkan Media Koleksi
+// @Description  Mengatur ulang urutan media. media_ids wajib berisi seluruh ID media koleksi dengan urutan yang baru.
+// @Tags         Media Koleksi
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                    true  "ID koleksi"
+// @Param        request  body  model.UrutanMediaRequest  true  "Urutan media"
+// @Param        If-Match  header  string  true  "ETag dari GET terakhir"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      412  {object}  map[string]interface{}
+// @Failure      428  {object}  map[string]interface{}  "Header If-Match belum diisi"
+// @Router       /koleksi/{id}/media/urutan [put]
+func UrutkanMediaKoleksi(c *fiber.Ctx) error {
+	var req model.UrutanMediaRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	koleksi, status, errMsg := ambilKoleksiMedia(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if ok, err := cekIfMatch(c, koleksi.Versi); !ok {
+		return err
+	}
+
+	if len(req.MediaIDs) != len(koleksi.Media) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("media_ids harus berisi seluruh %d media koleksi", len(koleksi.Media)),
+		})
+	}
+
+	urutan := make([]model.Media, 0, len(koleksi.Media))
+	dipakai := map[primitive.ObjectID]bool{}
+	for _, idStr := range req.MediaIDs {
+		id, err := primitive.ObjectIDFromHex(idStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "ID media tidak valid: " + idStr,
+			})
+		}
+		i := cariMedia(koleksi.Media, id)
+		if i < 0 || dipakai[id] {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "ID media tidak ditemukan atau duplikat: " + idStr,
+			})
+		}
+		dipakai[id] = true
+		urutan = append(urutan, koleksi.Media[i])
+	}
+
+	list := rapikanMedia(urutan, primitive.NilObjectID)
+	if err := simpanMediaKoleksi(ctx, koleksi.ID, koleksi.Versi, list); err != nil {
+		return gagalSimpanMedia(ctx, c, koleksi.ID, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Urutan media berhasil disimpan",
+		"data":    list,
+	})
+}
+
+// SetMediaUtamaKoleksi godoc
+// @Summary      Set Media Utama Koleksi
+// @Description  Menjadikan satu media sebagai media utama (thumbnail) koleksi
+// @Tags         Media Koleksi
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path  string  true  "ID koleksi"
+// @Param        media_id  path  string  true  "ID media"
+// @Param        If-Match  header  string  true  "ETag dari GET terakhir"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      412  {object}  map[string]interface{}
+// @Failure      428  {object}  map[string]interface{}  "Header If-Match belum diisi"
+// @Router       /koleksi/{id}/media/{media_id}/utama [put]
+func SetMediaUtamaKoleksi(c *fiber.Ctx) error {
+	mediaID, err := primitive.ObjectIDFromHex(c.Params("media_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID media tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	koleksi, status, errMsg := ambilKoleksiMedia(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if ok, err := cekIfMatch(c, koleksi.Versi); !ok {
+		return err
+	}
+
+	if cariMedia(koleksi.Media, mediaID) < 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Media tidak ditemukan",
+		})
+	}
+
+	list := rapikanMedia(koleksi.Media, mediaID)
+	if err := simpanMediaKoleksi(ctx, koleksi.ID, koleksi.Versi, list); err != nil {
+		return gagalSimpanMedia(ctx, c, koleksi.ID, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Media utama berhasil diubah",
+		"data":    list,
+	})
+}
+
+// DeleteMediaKoleksi godoc
+// @Summary      Delete Media Koleksi
+// @Description  Menghapus satu media dari koleksi. File di storage ikut dihapus (atau diarsipkan jika MEDIA_ARSIP=true) bila tidak dipakai koleksi lain. Jika media utama dihapus, media pertama menjadi utama.
+// @Tags         Media Koleksi
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path  string  true  "ID koleksi"
+// @Param        media_id  path  string  true  "ID media"
+// @Param        If-Match  header  string  true  "ETag dari GET terakhir"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      412  {object}  map[string]interface{}
+// @Failure      428  {object}  map[string]interface{}  "Header If-Match belum diisi"
+// @Router       /koleksi/{id}/media/{media_id} [delete]
+func DeleteMediaKoleksi(c *fiber.Ctx) error {
+	mediaID, err := primitive.ObjectIDFromHex(c.Params("media_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID media tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	koleksi, status, errMsg := ambilKoleksiMedia(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if ok, err := cekIfMatch(c, koleksi.Versi); !ok {
+		return err
+	}
+
+	i := cariMedia(koleksi.Media, mediaID)
+	if i < 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Media tidak ditemukan",
+		})
+	}
+	dihapus := koleksi.Media[i]
+
+	list := rapikanMedia(append(koleksi.Media[:i:i], koleksi.Media[i+1:]...), primitive.NilObjectID)
+	if err := simpanMediaKoleksi(ctx, koleksi.ID, koleksi.Versi, list); err != nil {
+		return gagalSimpanMedia(ctx, c, koleksi.ID, err)
+	}
+
+	// File di storage (asli & rendisi) dibersihkan jika tidak dipakai koleksi lain
+	lepasRefMedia(ctx, keyMediaKoleksi(dihapus))
+
+	if list == nil {
+		list = []model.Media{}
+	}
+	return c.JSON(fiber.Map{
+		"message": "Media berhasil dihapus",
+		"data":    list,
+	})
+}
+
+// VerifikasiMediaKoleksi godoc
+// @Summary      Verifikasi Integritas Media
+// @Description  Membaca ulang file media dari storage dan membandingkan hash SHA-256-nya dengan hash yang tercatat saat upload
+// @Tags         Media Koleksi
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path  string  true  "ID koleksi"
+// @Param        media_id  path  string  true  "ID media"
+// @Success      200  {object}  model.VerifikasiMedia
+// @Router       /koleksi/{id}/media/{media_id}/verifikasi [get]
+func VerifikasiMediaKoleksi(c *fiber.Ctx) error {
+	mediaID, err := primitive.ObjectIDFromHex(c.Params("media_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID media tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	koleksi, status, errMsg := ambilKoleksiMedia(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	i := cariMedia(koleksi.Media, mediaID)
+	if i < 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Media tidak ditemukan",
+		})
+	}
+	media := koleksi.Media[i]
+	if media.Key == "" || media.SHA256 == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Media ini diupload sebelum hash dicatat, tidak bisa diverifikasi",
+		})
+	}
+
+	data, err := storage.Aktif().Get(ctx, media.Key)
+	if err == storage.ErrNotFound {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "File media tidak ditemukan di storage",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca file media: " + err.Error(),
+		})
+	}
+
+	aktual := hashSHA256(data)
+	return c.JSON(model.VerifikasiMedia{
+		Key:          media.Key,
+		SHA256:       media.SHA256,
+		SHA256Aktual: aktual,
+		Cocok:        aktual == media.SHA256,
+	})
+}
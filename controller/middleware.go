@@ -1,7 +1,9 @@
 package controller
 
 import (
+	"crypto/subtle"
 	"errors"
+	"os"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
@@ -41,7 +43,8 @@ func JWTAuth(c *fiber.Ctx) error {
 	tokenString := tokenParts[1]
 
 	// Parse token dan ambil claims
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
 		return jwtKey, nil
 	})
 
@@ -66,7 +69,71 @@ func JWTAuth(c *fiber.Ctx) error {
 		})
 	}
 
-	// Jika valid → lanjutkan handler berikutnya
+	// Jika valid → simpan claims untuk handler berikutnya, lalu lanjutkan
+	c.Locals("claims", claims)
+	return c.Next()
+}
+
+// JWTOpsional memverifikasi token jika dikirim, untuk route publik yang menampilkan data lebih lengkap
+// bagi pengguna login. Tanpa header Authorization request tetap diteruskan tanpa claims.
+func JWTOpsional(c *fiber.Ctx) error {
+	if c.Get("Authorization") == "" {
+		return c.Next()
+	}
+	return JWTAuth(c)
+}
+
+// penggunaLogin mengembalikan username dari token JWT (kosong jika route tidak memakai JWTAuth)
+func penggunaLogin(c *fiber.Ctx) string {
+	if claims, ok := c.Locals("claims").(*Claims); ok {
+		return claims.Username
+	}
+	return ""
+}
+
+// RequireRole middleware membatasi route untuk role tertentu. Harus dipasang setelah JWTAuth.
+func RequireRole(roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("claims").(*Claims)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"message": "token tidak ditemukan",
+			})
+		}
+		for _, role := range roles {
+			if claims.Role == role {
+				return c.Next()
+			}
+		}
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "akses ditolak, hanya untuk role: " + strings.Join(roles, ", "),
+		})
+	}
+}
+
+// SensorAPIKeyAuth middleware untuk perangkat sensor yang mengirim data lingkungan.
+// API key dikirim lewat header X-API-Key dan dicocokkan dengan env SENSOR_API_KEY.
+func SensorAPIKeyAuth(c *fiber.Ctx) error {
+	expected := os.Getenv("SENSOR_API_KEY")
+	if expected == "" {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"message": "SENSOR_API_KEY belum diatur di environment variable",
+		})
+	}
+
+	apiKey := c.Get("X-API-Key")
+	if apiKey == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "API key tidak ditemukan",
+		})
+	}
+
+	if subtle.ConstantTimeCompare([]byte(apiKey), []byte(expected)) != 1 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "API key tidak valid",
+		})
+	}
+
 	return c.Next()
 }
 
@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// =============================================================
+// 🔁 Nomor registrasi / inventaris ganda yang menghalangi unique index
+// =============================================================
+
+// cariNomorGanda mengelompokkan koleksi yang memakai nomor yang sama pada field no_reg / no_inv
+func cariNomorGanda(ctx context.Context, field string) ([]model.NomorGanda, error) {
+	cursor, err := config.Ulbimongoconn.Collection("koleksi").Aggregate(ctx, bson.A{
+		bson.M{"$match": bson.M{field: bson.M{"$gt": ""}}},
+		bson.M{"$sort": bson.M{"created_at": 1, "_id": 1}},
+		bson.M{"$group": bson.M{
+			"_id":    "$" + field,
+			"jumlah": bson.M{"$sum": 1},
+			"koleksi": bson.M{"$push": bson.M{
+				"_id":        "$_id",
+				"nama_benda": "$nama_benda",
+				"created_at": "$created_at",
+			}},
+		}},
+		bson.M{"$match": bson.M{"jumlah": bson.M{"$gt": 1}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var hasil []struct {
+		Nomor   string               `bson:"_id"`
+		Koleksi []model.KoleksiGanda `bson:"koleksi"`
+	}
+	if err := cursor.All(ctx, &hasil); err != nil {
+		return nil, err
+	}
+
+	ganda := make([]model.NomorGanda, 0, len(hasil))
+	for _, h := range hasil {
+		ganda = append(ganda, model.NomorGanda{Field: field, Nomor: h.Nomor, Koleksi: h.Koleksi})
+	}
+	sort.Slice(ganda, func(i, j int) bool { return ganda[i].Nomor < ganda[j].Nomor })
+	return ganda, nil
+}
+
+// laporanNomorGanda menyusun status index dan daftar nomor ganda untuk no_reg & no_inv
+func laporanNomorGanda(ctx context.Context) (model.LaporanNomorGanda, error) {
+	laporan := model.LaporanNomorGanda{
+		IndexAktif: map[string]bool{},
+		IndexGagal: config.IndexNomorGagal(),
+		Ganda:      []model.NomorGanda{},
+	}
+	for _, field := range []string{"no_reg", "no_inv"} {
+		laporan.IndexAktif[field+"_unik"] = config.IndexNomorSiap(field + "_unik")
+		ganda, err := cariNomorGanda(ctx, field)
+		if err != nil {
+			return laporan, err
+		}
+		laporan.Ganda = append(laporan.Ganda, ganda...)
+	}
+	return laporan, nil
+}
+
+// GetNomorGandaKoleksi godoc
+// @Summary      Get Nomor Ganda Koleksi
+// @Description  Menampilkan status unique index no_reg / no_inv dan nomor yang dipakai lebih dari satu koleksi.
+// @Description  Selama index belum aktif, keunikan nomor dicek manual sebelum koleksi disimpan.
+// @Tags         Penomoran
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  model.LaporanNomorGanda
+// @Router       /koleksi/nomor-ganda [get]
+func GetNomorGandaKoleksi(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	laporan, err := laporanNomorGanda(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mencari nomor ganda: " + err.Error(),
+		})
+	}
+	return c.JSON(laporan)
+}
+
+// PerbaikiNomorGandaKoleksi godoc
+// @Summary      Perbaiki Nomor Ganda Koleksi
+// @Description  Membereskan nomor ganda agar unique index no_reg / no_inv bisa dibuat: koleksi yang paling lama dibuat mempertahankan nomornya,
+// @Description  koleksi lain diberi akhiran -D2, -D3, dst. (tercatat di riwayat koleksi) untuk dicek ulang terhadap label fisik.
+// @Description  Setelah itu index dibuat ulang. Gunakan dry_run=true untuk melihat nomor pengganti tanpa menyimpan.
+// @Tags         Penomoran
+// @Produce      json
+// @Security     BearerAuth
+// @Param        dry_run  query  boolean  false  "Hanya tampilkan rencana perubahan"
+// @Success      200  {object}  model.LaporanNomorGanda
+// @Router       /koleksi/nomor-ganda/perbaiki [post]
+func PerbaikiNomorGandaKoleksi(c *fiber.Ctx) error {
+	dryRun := false
+	if v := c.Query("dry_run"); v != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "dry_run harus true atau false",
+			})
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	laporan, err := laporanNomorGanda(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mencari nomor ganda: " + err.Error(),
+		})
+	}
+	laporan.DryRun = dryRun
+
+	col := config.Ulbimongoconn.Collection("koleksi")
+	oleh := penggunaLogin(c)
+	dipesan := map[string]bool{} // nomor pengganti yang sudah dipakai pada proses ini
+
+	for i := range laporan.Ganda {
+		g := &laporan.Ganda[i]
+		// koleksi pertama (paling lama) mempertahankan nomornya
+		for j := 1; j < len(g.Koleksi); j++ {
+			k := &g.Koleksi[j]
+
+			for n := j + 1; ; n++ {
+				kandidat := fmt.Sprintf("%s-D%d", g.Nomor, n)
+				if dipesan[g.Field+kandidat] {
+					continue
+				}
+				jumlah, err := col.CountDocuments(ctx, bson.M{g.Field: kandidat})
+				if err != nil {
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"error": "Gagal mengecek nomor pengganti",
+					})
+				}
+				if jumlah == 0 {
+					k.NomorBaru = kandidat
+					dipesan[g.Field+kandidat] = true
+					break
+				}
+			}
+			if dryRun {
+				continue
+			}
+
+			res, err := col.UpdateOne(ctx,
+				bson.M{"_id": k.ID, g.Field: g.Nomor},
+				tambahVersi(bson.M{"$set": bson.M{g.Field: k.NomorBaru, "updated_at": time.Now()}}),
+			)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":      "Gagal mengganti nomor koleksi " + k.ID.Hex(),
+					"diperbaiki": laporan.Diperbaiki,
+				})
+			}
+			if res.ModifiedCount == 0 {
+				// nomor koleksi sudah diubah sejak dicek
+				k.NomorBaru = ""
+				continue
+			}
+			laporan.Diperbaiki++
+			catatRiwayatKoleksi(ctx, model.RiwayatKoleksi{
+				KoleksiID: k.ID,
+				Aksi:      model.JenisPerubahanUbah,
+				Field:     []string{g.Field},
+				Oleh:      oleh,
+			})
+		}
+	}
+
+	if dryRun {
+		return c.JSON(laporan)
+	}
+
+	// 🔹 Index dibuat ulang setelah nomor ganda dibereskan
+	config.BuatIndexNomorKoleksi(ctx)
+	laporan.IndexGagal = config.IndexNomorGagal()
+	for nama := range laporan.IndexAktif {
+		laporan.IndexAktif[nama] = config.IndexNomorSiap(nama)
+	}
+
+	return c.JSON(laporan)
+}
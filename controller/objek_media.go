@@ -0,0 +1,518 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"be-internship/storage"
+	"context"
+	"errors"
+	"log"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	prefixMediaKoleksi = "koleksi/" // semua file media koleksi disimpan di bawah prefix ini
+	prefixArsipMedia   = "arsip/"   // file yang diarsipkan (MEDIA_ARSIP=true) dipindah ke sini
+)
+
+// =============================================================
+// 🧮 Pencatatan file media & reference count
+// =============================================================
+
+// catatObjekMedia mencatat file yang baru diupload. Reference count langsung dinaikkan untuk pengupload,
+// yang wajib melepasnya (lepasRefMedia) jika media batal disimpan.
+func catatObjekMedia(ctx context.Context, key, url, contentType string, ukuran int64, sha string) {
+	now := time.Now()
+	_, err := config.Ulbimongoconn.Collection("objek_media").UpdateOne(ctx,
+		bson.M{"_id": key},
+		bson.M{
+			"$set": bson.M{
+				"url":          url,
+				"content_type": contentType,
+				"ukuran":       ukuran,
+				"sha256":       sha,
+				"updated_at":   now,
+			},
+			"$inc":         bson.M{"ref_count": 1},
+			"$unset":       bson.M{"yatim_sejak": ""},
+			"$setOnInsert": bson.M{"created_at": now},
+		},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		log.Printf("⚠️  Gagal mencatat objek media %s: %v", key, err)
+	}
+}
+
+// pakaiObjekMedia menaikkan reference count file yang sudah ada untuk dipakai ulang (deduplikasi).
+// Mengembalikan false jika file tidak tercatat atau sedang dihapus, sehingga tidak boleh dipakai.
+func pakaiObjekMedia(ctx context.Context, key string) bool {
+	res, err := config.Ulbimongoconn.Collection("objek_media").UpdateOne(ctx,
+		bson.M{"_id": key, "dihapus": bson.M{"$exists": false}},
+		bson.M{
+			"$inc":   bson.M{"ref_count": 1},
+			"$set":   bson.M{"updated_at": time.Now()},
+			"$unset": bson.M{"yatim_sejak": ""},
+		},
+	)
+	return err == nil && res.MatchedCount > 0
+}
+
+// cariObjekMedia mengembalikan catatan file berdasarkan filter, atau nil jika belum ada
+func cariObjekMedia(ctx context.Context, filter bson.M) *model.ObjekMedia {
+	var o model.ObjekMedia
+	if err := config.Ulbimongoconn.Collection("objek_media").FindOne(ctx, filter).Decode(&o); err != nil {
+		return nil
+	}
+	return &o
+}
+
+// lepasRefMedia menurunkan reference count. File yang tidak dipakai lagi langsung dihapus
+// (atau dipindah ke folder arsip jika MEDIA_ARSIP=true).
+func lepasRefMedia(ctx context.Context, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	col := config.Ulbimongoconn.Collection("objek_media")
+	now := time.Now()
+
+	if _, err := col.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": keys}},
+		bson.M{"$inc": bson.M{"ref_count": -1}, "$set": bson.M{"updated_at": now}},
+	); err != nil {
+		log.Printf("⚠️  Gagal mengurangi reference count media: %v", err)
+		return
+	}
+
+	cursor, err := col.Find(ctx, bson.M{"_id": bson.M{"$in": keys}, "ref_count": bson.M{"$lte": 0}})
+	if err != nil {
+		return
+	}
+	var yatim []model.ObjekMedia
+	if err := cursor.All(ctx, &yatim); err != nil {
+		return
+	}
+
+	for _, o := range yatim {
+		if err := bersihkanObjekMedia(ctx, o.Key); err != nil {
+			// dibiarkan untuk garbage collection berikutnya
+			log.Printf("⚠️  Gagal membersihkan media %s: %v", o.Key, err)
+			col.UpdateOne(ctx, bson.M{"_id": o.Key, "ref_count": bson.M{"$lte": 0}}, bson.M{"$set": bson.M{"ref_count": 0, "yatim_sejak": now}})
+		}
+	}
+}
+
+// mediaDiarsipkan bernilai true jika file yatim dipindah ke folder arsip, bukan dihapus
+func mediaDiarsipkan() bool {
+	arsip, _ := strconv.ParseBool(os.Getenv("MEDIA_ARSIP"))
+	return arsip
+}
+
+// bersihkanObjekMedia menghapus (atau mengarsipkan) file dari storage lalu menghapus catatannya.
+// File ditandai dihapus lebih dulu; file yang reference count-nya naik lagi (dipakai ulang) tidak dihapus.
+func bersihkanObjekMedia(ctx context.Context, key string) error {
+	store := storage.Aktif()
+	col := config.Ulbimongoconn.Collection("objek_media")
+
+	res, err := col.UpdateOne(ctx,
+		bson.M{"_id": key, "ref_count": bson.M{"$lte": 0}},
+		bson.M{"$set": bson.M{"dihapus": time.Now()}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		// masih dipakai, atau catatannya sudah dihapus proses lain
+		return nil
+	}
+
+	// jika gagal, tanda dihapus dilepas agar file boleh dipakai ulang
+	gagal := func(err error) error {
+		col.UpdateOne(ctx, bson.M{"_id": key}, bson.M{"$unset": bson.M{"dihapus": ""}})
+		return err
+	}
+
+	if mediaDiarsipkan() {
+		data, err := store.Get(ctx, key)
+		if err != nil && err != storage.ErrNotFound {
+			return gagal(err)
+		}
+		if err == nil {
+			if _, err := store.Put(ctx, prefixArsipMedia+key, data, ""); err != nil {
+				return gagal(err)
+			}
+		}
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		return gagal(err)
+	}
+
+	_, err = col.DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
+
+// keyDariURL menebak key storage dari URL media (bagian path mulai dari prefix media koleksi).
+// Dipakai untuk URL lama yang base URL-nya berbeda dari storage yang aktif sekarang.
+func keyDariURL(alamat string) string {
+	u, err := url.Parse(alamat)
+	if err != nil {
+		return ""
+	}
+	if i := strings.Index(u.Path, "/"+prefixMediaKoleksi); i >= 0 {
+		return u.Path[i+1:]
+	}
+	return ""
+}
+
+// keyMediaKoleksi mengembalikan semua key storage dari media; untuk data lama tanpa key,
+// key ditebak dari URL jika URL berasal dari storage yang aktif.
+func keyMediaKoleksi(m model.Media) []string {
+	keys := m.SemuaKey()
+	if m.Key == "" && m.URL != "" {
+		if base := storage.Aktif().URL(""); strings.HasPrefix(m.URL, base) {
+			keys = append(keys, strings.TrimPrefix(m.URL, base))
+		}
+	}
+	return keys
+}
+
+// =============================================================
+// 🗑️ Garbage collection media
+// =============================================================
+
+// jedaKoreksiRef adalah lama catatan file tidak disentuh sebelum reference count-nya boleh dikoreksi GC.
+// Upload yang sedang berjalan sudah menaikkan ref_count sebelum koleksinya tersimpan, sehingga belum
+// terlihat di keyMediaDipakai.
+const jedaKoreksiRef = time.Hour
+
+// errUmurGCNol dikembalikan jika penghapusan media yatim diminta tanpa batas umur
+var errUmurGCNol = errors.New("penghapusan media yatim membutuhkan umur minimal lebih dari 0")
+
+// keyMediaDipakai menghitung pemakaian setiap key dari seluruh media koleksi, foto laporan kondisi,
+// foto perawatan konservasi, dan foto usulan perubahan yang belum selesai direview (sumber kebenaran)
+func keyMediaDipakai(ctx context.Context) (map[string]int, error) {
+	dipakai := map[string]int{}
+	hitung := func(list []model.Media) {
+		for _, m := range list {
+			keys := map[string]bool{}
+			for _, key := range keyMediaKoleksi(m) {
+				keys[key] = true
+			}
+			// URL dari base URL lama tetap melindungi file-nya
+			alamat := []string{m.URL}
+			for _, r := range m.Rendisi {
+				alamat = append(alamat, r.URL, r.WebPURL)
+			}
+			for _, a := range alamat {
+				if key := keyDariURL(a); key != "" {
+					keys[key] = true
+				}
+			}
+			for key := range keys {
+				dipakai[key]++
+			}
+		}
+	}
+
+	cursor, err := config.Ulbimongoconn.Collection("koleksi").Find(ctx,
+		bson.M{"media": bson.M{"$exists": true}},
+		options.Find().SetProjection(bson.M{"media": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var k model.Koleksi
+		if err := cursor.Decode(&k); err != nil {
+			continue
+		}
+		hitung(k.Media)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	cursorLaporan, err := config.Ulbimongoconn.Collection("laporan_kondisi").Find(ctx,
+		bson.M{"media": bson.M{"$exists": true}},
+		options.Find().SetProjection(bson.M{"media": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursorLaporan.Close(ctx)
+
+	for cursorLaporan.Next(ctx) {
+		var l model.LaporanKondisi
+		if err := cursorLaporan.Decode(&l); err != nil {
+			continue
+		}
+		hitung(l.Media)
+	}
+	if err := cursorLaporan.Err(); err != nil {
+		return nil, err
+	}
+
+	cursorPerawatan, err := config.Ulbimongoconn.Collection("perawatan_konservasi").Find(ctx,
+		bson.M{},
+		options.Find().SetProjection(bson.M{"sebelum": 1, "sesudah": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursorPerawatan.Close(ctx)
+
+	for cursorPerawatan.Next(ctx) {
+		var p model.PerawatanKonservasi
+		if err := cursorPerawatan.Decode(&p); err != nil {
+			continue
+		}
+		hitung(p.SemuaMedia())
+	}
+	if err := cursorPerawatan.Err(); err != nil {
+		return nil, err
+	}
+
+	// Foto pada usulan perubahan kontributor yang belum direview atau sedang diterapkan
+	cursorUsulan, err := config.Ulbimongoconn.Collection("perubahan_koleksi").Find(ctx,
+		bson.M{
+			"status":    bson.M{"$in": bson.A{model.StatusPerubahanMenunggu, model.StatusPerubahanDiproses}},
+			"foto_baru": bson.M{"$exists": true},
+		},
+		options.Find().SetProjection(bson.M{"foto_baru": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursorUsulan.Close(ctx)
+
+	for cursorUsulan.Next(ctx) {
+		var u model.PerubahanKoleksi
+		if err := cursorUsulan.Decode(&u); err != nil || u.FotoBaru == nil {
+			continue
+		}
+		hitung([]model.Media{*u.FotoBaru})
+	}
+	return dipakai, cursorUsulan.Err()
+}
+
+// GarbageCollectMedia mencari file media yang tidak direferensikan koleksi mana pun.
+// File yang lebih muda dari umurMin dilewati supaya upload yang sedang berjalan tidak ikut terhapus;
+// file di storage yang belum tercatat dihitung yatim sejak pertama kali ditemukan.
+// Jika hapus bernilai false, hanya mengembalikan daftar (dry run); penghapusan wajib memakai umurMin > 0.
+// Reference count tidak pernah ditimpa begitu saja: counter hanya dinaikkan jika kurang dari pemakaian,
+// dan file baru ditandai yatim lewat update bersyarat (ref_count & updated_at masih sama dengan snapshot)
+// setelah tidak disentuh selama jedaKoreksiRef, supaya referensi dari upload yang sedang berjalan tidak hilang.
+func GarbageCollectMedia(ctx context.Context, hapus bool, umurMin time.Duration) (model.LaporanGCMedia, error) {
+	laporan := model.LaporanGCMedia{Hapus: hapus, Yatim: []model.MediaYatim{}}
+	if hapus && umurMin <= 0 {
+		return laporan, errUmurGCNol
+	}
+	store := storage.Aktif()
+	col := config.Ulbimongoconn.Collection("objek_media")
+	batas := time.Now().Add(-umurMin)
+
+	dipakai, err := keyMediaDipakai(ctx)
+	if err != nil {
+		return laporan, err
+	}
+	laporan.JumlahDipakai = len(dipakai)
+
+	// 1️⃣ File yang tercatat di objek_media
+	cursor, err := col.Find(ctx, bson.M{})
+	if err != nil {
+		return laporan, err
+	}
+	var tercatat []model.ObjekMedia
+	if err := cursor.All(ctx, &tercatat); err != nil {
+		return laporan, err
+	}
+
+	// snapshot cocok hanya jika counter belum disentuh (catat / pakai / lepas) sejak dibaca
+	snapshot := func(o model.ObjekMedia) bson.M {
+		return bson.M{"_id": o.Key, "ref_count": o.RefCount, "updated_at": o.UpdatedAt}
+	}
+	lama := time.Now().Add(-jedaKoreksiRef)
+
+	sudahDicek := map[string]bool{}
+	for _, o := range tercatat {
+		sudahDicek[o.Key] = true
+
+		if n := dipakai[o.Key]; n > 0 {
+			// counter yang kurang dinaikkan (melindungi file); counter berlebih dibiarkan
+			set := bson.M{}
+			if o.RefCount < n {
+				set["ref_count"] = n
+			}
+			unset := bson.M{}
+			if o.YatimSejak != nil {
+				unset["yatim_sejak"] = ""
+			}
+			if o.Dihapus != nil && o.Dihapus.Before(lama) {
+				// tanda dihapus dari proses yang terhenti
+				unset["dihapus"] = ""
+			}
+			update := bson.M{}
+			if len(set) > 0 {
+				update["$set"] = set
+			}
+			if len(unset) > 0 {
+				update["$unset"] = unset
+			}
+			if len(update) > 0 {
+				col.UpdateOne(ctx, snapshot(o), update)
+			}
+			continue
+		}
+
+		if o.YatimSejak == nil || o.RefCount != 0 {
+			if o.RefCount > 0 && o.UpdatedAt.After(lama) {
+				// bisa jadi upload yang koleksinya belum tersimpan
+				continue
+			}
+			now := time.Now()
+			res, err := col.UpdateOne(ctx, snapshot(o), bson.M{"$set": bson.M{"ref_count": 0, "yatim_sejak": now}})
+			if err != nil || res.MatchedCount == 0 {
+				// counter berubah sejak snapshot, diperiksa lagi pada GC berikutnya
+				continue
+			}
+			o.YatimSejak = &now
+		}
+		if o.YatimSejak.After(batas) || o.CreatedAt.After(batas) {
+			continue
+		}
+
+		laporan.Yatim = append(laporan.Yatim, model.MediaYatim{
+			Key:        o.Key,
+			URL:        o.URL,
+			Ukuran:     o.Ukuran,
+			Tercatat:   true,
+			YatimSejak: o.YatimSejak,
+		})
+		laporan.TotalUkuran += o.Ukuran
+	}
+
+	// 2️⃣ File lama di storage yang belum pernah dicatat.
+	// Dicatat sebagai yatim sejak sekarang, sehingga baru dihapus setelah melewati umurMin
+	// (storage tidak memberi waktu upload, file ini bisa saja upload yang sedang berjalan).
+	keys, err := store.List(ctx, prefixMediaKoleksi)
+	if err != nil {
+		return laporan, err
+	}
+	laporan.JumlahFile = len(keys)
+	for _, key := range keys {
+		if sudahDicek[key] || dipakai[key] > 0 {
+			continue
+		}
+		now := time.Now()
+		res, err := col.UpdateOne(ctx,
+			bson.M{"_id": key},
+			bson.M{"$setOnInsert": bson.M{
+				"url":         store.URL(key),
+				"ref_count":   0,
+				"yatim_sejak": now,
+				"created_at":  now,
+				"updated_at":  now,
+			}},
+			options.Update().SetUpsert(true),
+		)
+		// baru tercatat jika upsert membuat dokumen; jika tidak, file baru saja dicatat upload lain
+		if err != nil || res.UpsertedCount == 0 || umurMin > 0 {
+			continue
+		}
+		laporan.Yatim = append(laporan.Yatim, model.MediaYatim{
+			Key:        key,
+			URL:        store.URL(key),
+			YatimSejak: &now,
+		})
+	}
+
+	sort.Slice(laporan.Yatim, func(i, j int) bool { return laporan.Yatim[i].Key < laporan.Yatim[j].Key })
+
+	if !hapus {
+		return laporan, nil
+	}
+
+	for _, y := range laporan.Yatim {
+		if err := bersihkanObjekMedia(ctx, y.Key); err != nil {
+			log.Printf("⚠️  Gagal menghapus media yatim %s: %v", y.Key, err)
+			laporan.Gagal = append(laporan.Gagal, y.Key)
+			continue
+		}
+		laporan.Dihapus = append(laporan.Dihapus, y.Key)
+	}
+
+	return laporan, nil
+}
+
+// umurMinGC membaca query umur_jam (default 24 jam)
+func umurMinGC(c *fiber.Ctx) (time.Duration, string) {
+	jam := 24.0
+	if v := c.Query("umur_jam"); v != "" {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil || n < 0 {
+			return 0, "umur_jam harus berupa angka >= 0"
+		}
+		jam = n
+	}
+	return time.Duration(jam * float64(time.Hour)), ""
+}
+
+// GetMediaYatim godoc
+// @Summary      Get Media Yatim
+// @Description  Menampilkan file media di storage yang tidak dipakai koleksi mana pun (dry run garbage collection)
+// @Tags         Media Koleksi
+// @Produce      json
+// @Security     BearerAuth
+// @Param        umur_jam  query  number  false  "Hanya file yang sudah yatim minimal sekian jam (default 24)"
+// @Success      200  {object}  model.LaporanGCMedia
+// @Router       /media/yatim [get]
+func GetMediaYatim(c *fiber.Ctx) error {
+	return jalankanGCMedia(c, false)
+}
+
+// HapusMediaYatim godoc
+// @Summary      Hapus Media Yatim
+// @Description  Menghapus file media yang tidak dipakai koleksi mana pun dari storage (atau memindahkannya ke folder arsip jika MEDIA_ARSIP=true). Khusus admin.
+// @Tags         Media Koleksi
+// @Produce      json
+// @Security     BearerAuth
+// @Param        umur_jam  query  number  false  "Hanya file yang sudah yatim minimal sekian jam (default 24, harus > 0)"
+// @Success      200  {object}  model.LaporanGCMedia
+// @Router       /media/yatim [delete]
+func HapusMediaYatim(c *fiber.Ctx) error {
+	return jalankanGCMedia(c, true)
+}
+
+func jalankanGCMedia(c *fiber.Ctx, hapus bool) error {
+	umurMin, errMsg := umurMinGC(c)
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if hapus && umurMin <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "umur_jam harus lebih dari 0 untuk menghapus media yatim",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	laporan, err := GarbageCollectMedia(ctx, hapus, umurMin)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menjalankan garbage collection media: " + err.Error(),
+		})
+	}
+
+	return c.JSON(laporan)
+}
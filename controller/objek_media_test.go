@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestKeyDariURL(t *testing.T) {
+	tests := []struct {
+		alamat string
+		want   string
+	}{
+		// base URL GitHub lama (branch / repo berbeda dari storage aktif)
+		{"https://raw.githubusercontent.com/museum/images-lama/master/koleksi/ab/ab12-keris.jpg", "koleksi/ab/ab12-keris.jpg"},
+		// storage lokal / S3 dengan query string
+		{"https://cdn.example.org/media/koleksi/ab/ab12-keris_thumbnail.webp?v=2", "koleksi/ab/ab12-keris_thumbnail.webp"},
+		// karakter yang di-escape
+		{"https://cdn.example.org/koleksi/ab/nama%20benda.jpg", "koleksi/ab/nama benda.jpg"},
+		// bukan file media koleksi
+		{"https://cdn.example.org/arsip/lain.jpg", ""},
+		{"https://cdn.example.org/koleksi-lama.jpg", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := keyDariURL(tt.alamat); got != tt.want {
+			t.Errorf("keyDariURL(%q) = %q, want %q", tt.alamat, got, tt.want)
+		}
+	}
+}
+
+func TestGarbageCollectMediaTolakHapusTanpaUmur(t *testing.T) {
+	for _, umur := range []time.Duration{0, -time.Hour} {
+		if _, err := GarbageCollectMedia(context.Background(), true, umur); err != errUmurGCNol {
+			t.Errorf("GarbageCollectMedia(hapus, %v) error = %v, want errUmurGCNol", umur, err)
+		}
+	}
+}
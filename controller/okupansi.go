@@ -0,0 +1,217 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// beratKg mengambil berat koleksi dalam kilogram.
+// Nilai false dikembalikan jika berat kosong.
+func beratKg(ukuran *model.Ukuran) (float64, bool) {
+	if ukuran == nil || ukuran.Normal == nil || ukuran.Normal.Berat == nil {
+		return 0, false
+	}
+	return *ukuran.Normal.Berat, true
+}
+
+// parseKapasitas membaca field kapasitas_slot dan berat_maks dari form-data.
+// Field yang tidak dikirim dikembalikan sebagai nil.
+func parseKapasitas(c *fiber.Ctx) (*int, *float64, string) {
+	var slot *int
+	var berat *float64
+
+	if v := strings.TrimSpace(c.FormValue("kapasitas_slot")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, nil, "Kapasitas slot harus berupa bilangan bulat tidak negatif"
+		}
+		slot = &n
+	}
+
+	if v := strings.TrimSpace(c.FormValue("berat_maks")); v != "" {
+		n, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", "."), 64)
+		if err != nil || n < 0 {
+			return nil, nil, "Berat maksimum harus berupa angka tidak negatif (kg)"
+		}
+		berat = &n
+	}
+
+	return slot, berat, ""
+}
+
+// hitungStatusKapasitas mengisi sisa kapasitas dan flag penuh / melebihi kapasitas
+func hitungStatusKapasitas(o *model.OkupansiLokasi) {
+	if o.KapasitasSlot > 0 {
+		sisa := o.KapasitasSlot - o.JumlahKoleksi
+		o.SisaSlot = &sisa
+		if o.JumlahKoleksi >= o.KapasitasSlot {
+			o.Penuh = true
+		}
+		if o.JumlahKoleksi > o.KapasitasSlot {
+			o.MelebihiKapasitas = true
+		}
+	}
+
+	if o.BeratMaks > 0 {
+		sisa := o.BeratMaks - o.TotalBerat
+		o.SisaBerat = &sisa
+		if o.TotalBerat >= o.BeratMaks {
+			o.Penuh = true
+		}
+		if o.TotalBerat > o.BeratMaks {
+			o.MelebihiKapasitas = true
+		}
+	}
+}
+
+// urutkanOkupansi mengubah map okupansi menjadi slice yang terurut berdasarkan nama
+func urutkanOkupansi(data map[primitive.ObjectID]*model.OkupansiLokasi, hanyaPenuh bool) []model.OkupansiLokasi {
+	hasil := []model.OkupansiLokasi{}
+	for _, o := range data {
+		hitungStatusKapasitas(o)
+		if hanyaPenuh && !o.Penuh {
+			continue
+		}
+		hasil = append(hasil, *o)
+	}
+
+	sort.Slice(hasil, func(i, j int) bool {
+		return hasil[i].Nama < hasil[j].Nama
+	})
+
+	return hasil
+}
+
+// GetOkupansi godoc
+// @Summary      Get Okupansi Tempat Penyimpanan
+// @Description  Menghitung jumlah koleksi dan total berat (kg) per gudang, rak, dan tahap, lalu menandai lokasi yang penuh atau melebihi kapasitas
+// @Tags         Okupansi
+// @Produce      json
+// @Param        hanya_penuh  query  bool  false  "Hanya tampilkan lokasi yang penuh / melebihi kapasitas"
+// @Success      200  {object}  model.OkupansiResponse
+// @Router       /okupansi [get]
+func GetOkupansi(c *fiber.Ctx) error {
+	hanyaPenuh := c.QueryBool("hanya_penuh", false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	db := config.Ulbimongoconn
+
+	// =========================
+	// AMBIL MASTER DATA LOKASI
+	// =========================
+	gudangMap := map[primitive.ObjectID]*model.OkupansiLokasi{}
+	rakMap := map[primitive.ObjectID]*model.OkupansiLokasi{}
+	tahapMap := map[primitive.ObjectID]*model.OkupansiLokasi{}
+
+	var gudangs []model.Gudang
+	cursor, err := db.Collection("gudang").Find(ctx, bson.M{})
+	if err == nil {
+		err = cursor.All(ctx, &gudangs)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal mengambil data gudang",
+			"error":   err.Error(),
+		})
+	}
+	for _, g := range gudangs {
+		gudangMap[g.ID] = &model.OkupansiLokasi{ID: g.ID, Nama: g.NamaGudang}
+	}
+
+	var raks []model.Rak
+	cursor, err = db.Collection("rak").Find(ctx, bson.M{})
+	if err == nil {
+		err = cursor.All(ctx, &raks)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal mengambil data rak",
+			"error":   err.Error(),
+		})
+	}
+	for _, r := range raks {
+		rakMap[r.ID] = &model.OkupansiLokasi{ID: r.ID, Nama: r.NamaRak, KapasitasSlot: r.KapasitasSlot, BeratMaks: r.BeratMaks}
+	}
+
+	var tahaps []model.Tahap
+	cursor, err = db.Collection("tahap").Find(ctx, bson.M{})
+	if err == nil {
+		err = cursor.All(ctx, &tahaps)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal mengambil data tahap",
+			"error":   err.Error(),
+		})
+	}
+	for _, t := range tahaps {
+		tahapMap[t.ID] = &model.OkupansiLokasi{ID: t.ID, Nama: t.NamaTahap, KapasitasSlot: t.KapasitasSlot, BeratMaks: t.BeratMaks}
+	}
+
+	// =========================
+	// HITUNG ISI SETIAP LOKASI
+	// =========================
+	// Penjumlahan berat (ukuran.normal.berat) dilakukan di aplikasi; koleksi yang sudah dideaksesi tidak menempati lokasi
+	opts := options.Find().SetProjection(bson.M{"tempat_penyimpanan": 1, "ukuran": 1})
+	cursor, err = db.Collection("koleksi").Find(ctx, filterKoleksiAktif(bson.M{}), opts)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal mengambil data koleksi",
+			"error":   err.Error(),
+		})
+	}
+	defer cursor.Close(ctx)
+
+	tambah := func(data map[primitive.ObjectID]*model.OkupansiLokasi, id primitive.ObjectID, nama string, berat float64, adaBerat bool) {
+		if id.IsZero() {
+			return
+		}
+		o, ok := data[id]
+		if !ok {
+			// lokasi sudah dihapus, tetap tampilkan dengan nama yang tersimpan di koleksi
+			o = &model.OkupansiLokasi{ID: id, Nama: nama}
+			data[id] = o
+		}
+		o.JumlahKoleksi++
+		if adaBerat {
+			o.TotalBerat += berat
+		} else {
+			o.TanpaBerat++
+		}
+	}
+
+	for cursor.Next(ctx) {
+		var k model.Koleksi
+		if err := cursor.Decode(&k); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"message": "Gagal decode data koleksi",
+				"error":   err.Error(),
+			})
+		}
+
+		berat, adaBerat := beratKg(k.Ukuran)
+		tp := k.TempatPenyimpanan
+		tambah(gudangMap, tp.Gudang.ID, tp.Gudang.NamaGudang, berat, adaBerat)
+		tambah(rakMap, tp.Rak.ID, tp.Rak.NamaRak, berat, adaBerat)
+		tambah(tahapMap, tp.Tahap.ID, tp.Tahap.NamaTahap, berat, adaBerat)
+	}
+
+	return c.JSON(model.OkupansiResponse{
+		Message: "Berhasil mengambil data okupansi",
+		Gudang:  urutkanOkupansi(gudangMap, hanyaPenuh),
+		Rak:     urutkanOkupansi(rakMap, hanyaPenuh),
+		Tahap:   urutkanOkupansi(tahapMap, hanyaPenuh),
+	})
+}
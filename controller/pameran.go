@@ -0,0 +1,763 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// =============================================================
+// 🖼️ Pameran, penempatan koleksi & cek bentrok jadwal
+// =============================================================
+
+// jadwalBentrok mencari pameran dan peminjaman keluar aktif (disetujui / dikirim) yang memakai salah satu
+// koleksi pada periode yang beririsan. selesai nil berarti tanpa batas akhir (pameran tetap).
+// Dokumen dengan ID kecuali (pameran / peminjaman yang sedang diperiksa) dilewati.
+func jadwalBentrok(ctx context.Context, ids []primitive.ObjectID, mulai time.Time, selesai *time.Time, kecuali primitive.ObjectID) ([]model.BentrokJadwal, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	dicari := map[primitive.ObjectID]bool{}
+	for _, id := range ids {
+		dicari[id] = true
+	}
+	var bentrok []model.BentrokJadwal
+	db := config.Ulbimongoconn
+
+	// 🔹 Pameran lain (pameran tetap tidak punya tanggal_selesai)
+	filterPameran := bson.M{
+		"_id":                bson.M{"$ne": kecuali},
+		"koleksi.koleksi_id": bson.M{"$in": ids},
+		"$or": []bson.M{
+			{"tanggal_selesai": bson.M{"$exists": false}},
+			{"tanggal_selesai": bson.M{"$gte": mulai}},
+		},
+	}
+	if selesai != nil {
+		filterPameran["tanggal_mulai"] = bson.M{"$lte": *selesai}
+	}
+	cursor, err := db.Collection("pameran").Find(ctx, filterPameran)
+	if err != nil {
+		return nil, err
+	}
+	var pameran []model.Pameran
+	if err := cursor.All(ctx, &pameran); err != nil {
+		return nil, err
+	}
+	for _, p := range pameran {
+		for _, item := range p.Koleksi {
+			if dicari[item.KoleksiID] {
+				bentrok = append(bentrok, model.BentrokJadwal{
+					KoleksiID: item.KoleksiID, NamaBenda: item.NamaBenda,
+					Jenis: "pameran", ID: p.ID, Nama: p.Judul,
+					TanggalMulai: p.TanggalMulai, TanggalSelesai: p.TanggalSelesai,
+				})
+			}
+		}
+	}
+
+	// 🔹 Peminjaman keluar yang sudah disetujui / sedang berjalan
+	filterPinjam := bson.M{
+		"_id":                bson.M{"$ne": kecuali},
+		"arah":               model.ArahPeminjamanKeluar,
+		"status":             bson.M{"$in": statusPinjamAktif},
+		"koleksi.koleksi_id": bson.M{"$in": ids},
+		"tanggal_selesai":    bson.M{"$gte": mulai},
+	}
+	if selesai != nil {
+		filterPinjam["tanggal_mulai"] = bson.M{"$lte": *selesai}
+	}
+	cursor, err = db.Collection("peminjaman").Find(ctx, filterPinjam)
+	if err != nil {
+		return nil, err
+	}
+	var pinjaman []model.Peminjaman
+	if err := cursor.All(ctx, &pinjaman); err != nil {
+		return nil, err
+	}
+	for _, p := range pinjaman {
+		selesaiPinjam := p.TanggalSelesai
+		for _, item := range p.Koleksi {
+			if item.KoleksiID != nil && dicari[*item.KoleksiID] {
+				bentrok = append(bentrok, model.BentrokJadwal{
+					KoleksiID: *item.KoleksiID, NamaBenda: item.NamaBenda,
+					Jenis: "peminjaman", ID: p.ID, Nama: p.Institusi.Nama,
+					TanggalMulai: p.TanggalMulai, TanggalSelesai: &selesaiPinjam,
+				})
+			}
+		}
+	}
+
+	return bentrok, nil
+}
+
+// pesanBentrok merangkum jadwal bentrok menjadi satu pesan error
+func pesanBentrok(bentrok []model.BentrokJadwal) string {
+	var bagian []string
+	for _, b := range bentrok {
+		periode := b.TanggalMulai.Format("2006-01-02") + " s/d "
+		if b.TanggalSelesai != nil {
+			periode += b.TanggalSelesai.Format("2006-01-02")
+		} else {
+			periode += "seterusnya"
+		}
+		bagian = append(bagian, fmt.Sprintf("%s sudah terjadwal di %s %s (%s)", b.NamaBenda, b.Jenis, b.Nama, periode))
+	}
+	return "Jadwal koleksi bentrok: " + strings.Join(bagian, "; ")
+}
+
+// idKoleksiPameran mengembalikan ID koleksi yang ditempatkan di pameran
+func idKoleksiPameran(p model.Pameran) []primitive.ObjectID {
+	ids := make([]primitive.ObjectID, 0, len(p.Koleksi))
+	for _, item := range p.Koleksi {
+		ids = append(ids, item.KoleksiID)
+	}
+	return ids
+}
+
+// pameranDariRequest memvalidasi & mengisi data utama pameran
+func pameranDariRequest(req model.PameranRequest, p *model.Pameran) string {
+	p.Judul = strings.TrimSpace(req.Judul)
+	p.Tempat = strings.TrimSpace(req.Tempat)
+	p.Kurator = strings.TrimSpace(req.Kurator)
+	p.Deskripsi = strings.TrimSpace(req.Deskripsi)
+	if p.Judul == "" || p.Tempat == "" {
+		return "Judul dan tempat pameran wajib diisi"
+	}
+
+	mulai, err := time.Parse("2006-01-02", strings.TrimSpace(req.TanggalMulai))
+	if err != nil {
+		return "tanggal_mulai wajib diisi dengan format YYYY-MM-DD"
+	}
+	p.TanggalMulai = mulai
+	p.TanggalSelesai = nil
+	if v := strings.TrimSpace(req.TanggalSelesai); v != "" {
+		selesai, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return "Format tanggal_selesai harus YYYY-MM-DD"
+		}
+		if selesai.Before(mulai) {
+			return "tanggal_selesai tidak boleh sebelum tanggal_mulai"
+		}
+		p.TanggalSelesai = &selesai
+	}
+	return ""
+}
+
+// ambilPameran membaca satu pameran dari parameter :id
+func ambilPameran(ctx context.Context, idParam string) (model.Pameran, int, string) {
+	var p model.Pameran
+	id, err := primitive.ObjectIDFromHex(idParam)
+	if err != nil {
+		return p, fiber.StatusBadRequest, "ID pameran tidak valid"
+	}
+	if err := config.Ulbimongoconn.Collection("pameran").FindOne(ctx, bson.M{"_id": id}).Decode(&p); err != nil {
+		return p, fiber.StatusNotFound, "Pameran tidak ditemukan"
+	}
+	return p, 0, ""
+}
+
+// InsertPameran godoc
+// @Summary      Insert Pameran
+// @Description  Membuat pameran baru. Kosongkan tanggal_selesai untuk pameran tetap. Koleksi ditambahkan lewat endpoint /pameran/{id}/koleksi.
+// @Tags         Pameran
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  model.PameranRequest  true  "Data pameran"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Router       /pameran [post]
+func InsertPameran(c *fiber.Ctx) error {
+	var req model.PameranRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Body request tidak valid",
+		})
+	}
+
+	now := time.Now()
+	p := model.Pameran{
+		ID:        primitive.NewObjectID(),
+		Koleksi:   []model.ItemPameran{},
+		CreatedBy: penggunaLogin(c),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if errMsg := pameranDariRequest(req, &p); errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := config.Ulbimongoconn.Collection("pameran").InsertOne(ctx, p); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan pameran",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Pameran berhasil dibuat",
+		"data":    p,
+	})
+}
+
+// GetAllPameran godoc
+// @Summary      Get All Pameran
+// @Description  Mengambil daftar pameran, terbaru di depan
+// @Tags         Pameran
+// @Produce      json
+// @Param        aktif    query  boolean  false  "true = hanya pameran yang sedang berlangsung hari ini"
+// @Param        tanggal  query  string   false  "Hanya pameran yang berlangsung pada tanggal ini (YYYY-MM-DD)"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /pameran [get]
+func GetAllPameran(c *fiber.Ctx) error {
+	filter := bson.M{}
+
+	var pada *time.Time
+	if v := c.Query("tanggal"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Format tanggal harus YYYY-MM-DD",
+			})
+		}
+		pada = &t
+	} else if c.QueryBool("aktif") {
+		t := awalHari(time.Now())
+		pada = &t
+	}
+	if pada != nil {
+		filter["tanggal_mulai"] = bson.M{"$lte": *pada}
+		filter["$or"] = []bson.M{
+			{"tanggal_selesai": bson.M{"$exists": false}},
+			{"tanggal_selesai": bson.M{"$gte": *pada}},
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.Ulbimongoconn.Collection("pameran").Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "tanggal_mulai", Value: -1}}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil data pameran",
+		})
+	}
+	list := []model.Pameran{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca data pameran",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil data pameran",
+		"total":   len(list),
+		"data":    list,
+	})
+}
+
+// GetPameranByID godoc
+// @Summary      Get Pameran By ID
+// @Description  Mengambil satu pameran beserta daftar koleksinya
+// @Tags         Pameran
+// @Produce      json
+// @Param        id   path  string  true  "ID pameran"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Router       /pameran/{id} [get]
+func GetPameranByID(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	p, status, errMsg := ambilPameran(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil data pameran",
+		"data":    p,
+	})
+}
+
+// UpdatePameran godoc
+// @Summary      Update Pameran
+// @Description  Mengubah data utama pameran. Jika periode berubah, seluruh koleksi pameran diperiksa ulang terhadap pameran & peminjaman lain.
+// @Description  Ditolak (409) jika daftar koleksi pameran berubah selama pemeriksaan; muat ulang lalu kirim lagi.
+// @Tags         Pameran
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                true  "ID pameran"
+// @Param        request  body  model.PameranRequest  true  "Data pameran"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Router       /pameran/{id} [put]
+func UpdatePameran(c *fiber.Ctx) error {
+	var req model.PameranRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Body request tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	p, status, errMsg := ambilPameran(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if errMsg := pameranDariRequest(req, &p); errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	// Jadwal koleksi pameran dikunci sampai periode baru tersimpan (lihat kunciJadwalKoleksi)
+	lepas, err := kunciJadwalKoleksi(ctx, idKoleksiPameran(p))
+	if err != nil {
+		return gagalKunciJadwal(c, err)
+	}
+	defer lepas()
+
+	bentrok, err := jadwalBentrok(ctx, idKoleksiPameran(p), p.TanggalMulai, p.TanggalSelesai, p.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memeriksa jadwal koleksi",
+		})
+	}
+	if len(bentrok) > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   pesanBentrok(bentrok),
+			"bentrok": bentrok,
+		})
+	}
+
+	setData := bson.M{
+		"judul":         p.Judul,
+		"tempat":        p.Tempat,
+		"tanggal_mulai": p.TanggalMulai,
+		"kurator":       p.Kurator,
+		"deskripsi":     p.Deskripsi,
+		"updated_at":    time.Now(),
+	}
+	update := bson.M{"$set": setData}
+	if p.TanggalSelesai != nil {
+		setData["tanggal_selesai"] = *p.TanggalSelesai
+	} else {
+		update["$unset"] = bson.M{"tanggal_selesai": ""}
+	}
+
+	// Jumlah koleksi ikut difilter: koleksi yang ditambahkan setelah dicek belum diperiksa terhadap periode baru
+	filter := bson.M{"_id": p.ID, "koleksi": bson.M{"$size": len(p.Koleksi)}}
+	if len(p.Koleksi) == 0 {
+		// daftar kosong juga bisa tersimpan sebagai null / tidak ada
+		filter = bson.M{"_id": p.ID, "koleksi.0": bson.M{"$exists": false}}
+	}
+	err = config.Ulbimongoconn.Collection("pameran").FindOneAndUpdate(ctx,
+		filter,
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&p)
+	if err == mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Daftar koleksi pameran baru saja berubah, muat ulang data",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memperbarui pameran",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Pameran berhasil diperbarui",
+		"data":    p,
+	})
+}
+
+// DeletePameran godoc
+// @Summary      Delete Pameran
+// @Description  Menghapus pameran beserta daftar penempatan koleksinya (data koleksi tidak ikut terhapus)
+// @Tags         Pameran
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID pameran"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Router       /pameran/{id} [delete]
+func DeletePameran(c *fiber.Ctx) error {
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID pameran tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := config.Ulbimongoconn.Collection("pameran").DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menghapus pameran",
+		})
+	}
+	if res.DeletedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Pameran tidak ditemukan",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Pameran berhasil dihapus",
+	})
+}
+
+// TambahKoleksiPameran godoc
+// @Summary      Tambah Koleksi ke Pameran
+// @Description  Menempatkan satu atau beberapa koleksi di pameran. Ditolak (409) jika koleksi sudah terjadwal di pameran lain atau peminjaman keluar (disetujui / dikirim) pada periode yang beririsan; daftar bentrok dikembalikan di field bentrok.
+// @Tags         Pameran
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                             true  "ID pameran"
+// @Param        request  body  model.TambahKoleksiPameranRequest  true  "Koleksi yang ditambahkan"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Router       /pameran/{id}/koleksi [post]
+func TambahKoleksiPameran(c *fiber.Ctx) error {
+	var req model.TambahKoleksiPameranRequest
+	if err := c.BodyParser(&req); err != nil || len(req.Koleksi) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Daftar koleksi wajib diisi",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	p, status, errMsg := ambilPameran(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	sudahAda := map[primitive.ObjectID]bool{}
+	for _, id := range idKoleksiPameran(p) {
+		sudahAda[id] = true
+	}
+	var ids []primitive.ObjectID
+	for _, input := range req.Koleksi {
+		id, err := primitive.ObjectIDFromHex(input.KoleksiID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "ID koleksi tidak valid: " + input.KoleksiID,
+			})
+		}
+		if sudahAda[id] {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Koleksi " + input.KoleksiID + " sudah ada di pameran ini",
+			})
+		}
+		sudahAda[id] = true
+		ids = append(ids, id)
+	}
+
+	// Jadwal koleksi dikunci sampai koleksi tersimpan di pameran, supaya pameran / peminjaman lain
+	// yang memakai koleksi yang sama tidak lolos cek bentrok bersamaan
+	lepas, err := kunciJadwalKoleksi(ctx, ids)
+	if err != nil {
+		return gagalKunciJadwal(c, err)
+	}
+	defer lepas()
+
+	cursor, err := config.Ulbimongoconn.Collection("koleksi").Find(ctx,
+		bson.M{"_id": bson.M{"$in": ids}},
+		options.Find().SetProjection(bson.M{"nama_benda": 1, "no_inv": 1, "deaksesi": 1}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil data koleksi",
+		})
+	}
+	var koleksi []model.Koleksi
+	if err := cursor.All(ctx, &koleksi); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca data koleksi",
+		})
+	}
+	koleksiByID := map[primitive.ObjectID]model.Koleksi{}
+	for _, k := range koleksi {
+		koleksiByID[k.ID] = k
+	}
+
+	now := time.Now()
+	var items []model.ItemPameran
+	for i, input := range req.Koleksi {
+		k, ok := koleksiByID[ids[i]]
+		if !ok {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Koleksi tidak ditemukan: " + input.KoleksiID,
+			})
+		}
+		if k.Deaksesi != nil {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Koleksi " + k.NamaBenda + " dalam proses deaksesi (" + k.Deaksesi.Status + ") dan tidak bisa dipamerkan",
+			})
+		}
+		items = append(items, model.ItemPameran{
+			KoleksiID:    k.ID,
+			NamaBenda:    k.NamaBenda,
+			NoInventaris: k.NoInventaris,
+			Posisi:       strings.TrimSpace(input.Posisi),
+			Catatan:      strings.TrimSpace(input.Catatan),
+			CreatedAt:    now,
+		})
+	}
+
+	bentrok, err := jadwalBentrok(ctx, ids, p.TanggalMulai, p.TanggalSelesai, p.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memeriksa jadwal koleksi",
+		})
+	}
+	if len(bentrok) > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   pesanBentrok(bentrok),
+			"bentrok": bentrok,
+		})
+	}
+
+	// Koleksi yang ditambahkan bersamaan oleh request lain tidak boleh tercatat dua kali,
+	// dan periode pameran harus masih sama dengan yang dipakai saat cek bentrok
+	filter := bson.M{"_id": p.ID, "koleksi.koleksi_id": bson.M{"$nin": ids}, "tanggal_mulai": p.TanggalMulai}
+	if p.TanggalSelesai != nil {
+		filter["tanggal_selesai"] = *p.TanggalSelesai
+	} else {
+		filter["tanggal_selesai"] = bson.M{"$exists": false}
+	}
+	err = config.Ulbimongoconn.Collection("pameran").FindOneAndUpdate(ctx,
+		filter,
+		bson.M{
+			"$push": bson.M{"koleksi": bson.M{"$each": items}},
+			"$set":  bson.M{"updated_at": now},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&p)
+	if err == mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Sebagian koleksi atau periode pameran baru saja berubah, muat ulang data",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menambahkan koleksi ke pameran",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": fmt.Sprintf("%d koleksi ditambahkan ke pameran", len(items)),
+		"data":    p,
+	})
+}
+
+// HapusKoleksiPameran godoc
+// @Summary      Hapus Koleksi dari Pameran
+// @Description  Mengeluarkan satu koleksi dari daftar pameran
+// @Tags         Pameran
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id          path  string  true  "ID pameran"
+// @Param        koleksi_id  path  string  true  "ID koleksi"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Router       /pameran/{id}/koleksi/{koleksi_id} [delete]
+func HapusKoleksiPameran(c *fiber.Ctx) error {
+	pameranID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID pameran tidak valid",
+		})
+	}
+	koleksiID, err := primitive.ObjectIDFromHex(c.Params("koleksi_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID koleksi tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var p model.Pameran
+	err = config.Ulbimongoconn.Collection("pameran").FindOneAndUpdate(ctx,
+		bson.M{"_id": pameranID, "koleksi.koleksi_id": koleksiID},
+		bson.M{
+			"$pull": bson.M{"koleksi": bson.M{"koleksi_id": koleksiID}},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&p)
+	if err == mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Koleksi tidak ada di pameran ini",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengeluarkan koleksi dari pameran",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Koleksi dikeluarkan dari pameran",
+		"data":    p,
+	})
+}
+
+// GetChecklistPameran godoc
+// @Summary      Checklist Pameran
+// @Description  Daftar koleksi pameran beserta lokasi penyimpanannya, diurutkan per gudang, rak, dan tahap untuk memudahkan pengambilan
+// @Tags         Pameran
+// @Produce      json
+// @Param        id   path  string  true  "ID pameran"
+// @Success      200  {object}  model.ChecklistPameran
+// @Failure      404  {object}  map[string]string
+// @Router       /pameran/{id}/checklist [get]
+func GetChecklistPameran(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	p, status, errMsg := ambilPameran(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	cursor, err := config.Ulbimongoconn.Collection("koleksi").Find(ctx,
+		bson.M{"_id": bson.M{"$in": idKoleksiPameran(p)}},
+		options.Find().SetProjection(bson.M{
+			"no_reg": 1, "no_inv": 1, "nama_benda": 1, "tempat_penyimpanan": 1, "status_lokasi": 1, "kondisi": 1,
+		}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil data koleksi",
+		})
+	}
+	var koleksi []model.Koleksi
+	if err := cursor.All(ctx, &koleksi); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca data koleksi",
+		})
+	}
+	koleksiByID := map[primitive.ObjectID]model.Koleksi{}
+	for _, k := range koleksi {
+		koleksiByID[k.ID] = k
+	}
+
+	data := make([]model.ItemChecklistPameran, 0, len(p.Koleksi))
+	for _, item := range p.Koleksi {
+		baris := model.ItemChecklistPameran{
+			KoleksiID:    item.KoleksiID,
+			NoInventaris: item.NoInventaris,
+			NamaBenda:    item.NamaBenda,
+			Posisi:       item.Posisi,
+			Catatan:      item.Catatan,
+		}
+		if k, ok := koleksiByID[item.KoleksiID]; ok {
+			baris.Ditemukan = true
+			baris.NoRegistrasi = k.NoRegistrasi
+			baris.NoInventaris = k.NoInventaris
+			baris.NamaBenda = k.NamaBenda
+			baris.Gudang = k.TempatPenyimpanan.Gudang.NamaGudang
+			baris.Rak = k.TempatPenyimpanan.Rak.NamaRak
+			baris.Tahap = k.TempatPenyimpanan.Tahap.NamaTahap
+			baris.StatusLokasi = k.StatusLokasi
+			baris.Kondisi = k.Kondisi
+		}
+		data = append(data, baris)
+	}
+	sort.SliceStable(data, func(i, j int) bool {
+		a, b := data[i], data[j]
+		if a.Gudang != b.Gudang {
+			return a.Gudang < b.Gudang
+		}
+		if a.Rak != b.Rak {
+			return a.Rak < b.Rak
+		}
+		return a.Tahap < b.Tahap
+	})
+
+	return c.JSON(model.ChecklistPameran{
+		Message: "Berhasil menyusun checklist pameran",
+		Pameran: p,
+		Total:   len(data),
+		Data:    data,
+	})
+}
+
+// GetRiwayatPameranKoleksi godoc
+// @Summary      Riwayat Pameran Koleksi
+// @Description  Daftar pameran yang pernah / sedang / akan menampilkan koleksi ini, terbaru di depan
+// @Tags         Pameran
+// @Produce      json
+// @Param        id   path  string  true  "ID koleksi"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /koleksi/{id}/pameran [get]
+func GetRiwayatPameranKoleksi(c *fiber.Ctx) error {
+	koleksiID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID koleksi tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.Ulbimongoconn.Collection("pameran").Find(ctx,
+		bson.M{"koleksi.koleksi_id": koleksiID},
+		options.Find().
+			SetSort(bson.D{{Key: "tanggal_mulai", Value: -1}}).
+			SetProjection(bson.M{"koleksi": bson.M{"$elemMatch": bson.M{"koleksi_id": koleksiID}},
+				"judul": 1, "tempat": 1, "tanggal_mulai": 1, "tanggal_selesai": 1, "kurator": 1}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil riwayat pameran",
+		})
+	}
+	list := []model.Pameran{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca riwayat pameran",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil riwayat pameran koleksi",
+		"total":   len(list),
+		"data":    list,
+	})
+}
@@ -0,0 +1,654 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// =============================================================
+// 🚚 Peminjaman koleksi (keluar ke / masuk dari institusi lain)
+// =============================================================
+
+// statusPinjamAktif adalah status peminjaman yang mengikat koleksi
+var statusPinjamAktif = []string{model.StatusPinjamDisetujui, model.StatusPinjamDikirim}
+
+// awalHari mengembalikan jam 00:00 UTC dari tanggal
+func awalHari(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// sembunyikanNilaiAsuransi menghapus nilai asuransi dari response untuk pengguna selain admin / finance
+func sembunyikanNilaiAsuransi(c *fiber.Ctx, p *model.Peminjaman) {
+	if bolehLihatNilai(c) {
+		return
+	}
+	p.NilaiAsuransi = nil
+	for i := range p.Koleksi {
+		p.Koleksi[i].NilaiAsuransi = nil
+	}
+}
+
+// tandaiTerlambat mengisi field terlambat: benda sudah dikirim tetapi tanggal_selesai sudah lewat
+func tandaiTerlambat(p *model.Peminjaman, sekarang time.Time) {
+	hariIni := awalHari(sekarang)
+	if p.Status == model.StatusPinjamDikirim && p.TanggalSelesai.Before(hariIni) {
+		p.Terlambat = true
+		p.HariTerlambat = int(math.Round(hariIni.Sub(awalHari(p.TanggalSelesai)).Hours() / 24))
+	}
+}
+
+// idKoleksiPinjaman mengembalikan ID koleksi yang tercatat pada peminjaman
+func idKoleksiPinjaman(p model.Peminjaman) []primitive.ObjectID {
+	var ids []primitive.ObjectID
+	for _, item := range p.Koleksi {
+		if item.KoleksiID != nil {
+			ids = append(ids, *item.KoleksiID)
+		}
+	}
+	return ids
+}
+
+// peminjamanDariRequest memvalidasi request dan mengisi data peminjaman (tanpa status & riwayat)
+func peminjamanDariRequest(ctx context.Context, req model.PeminjamanRequest, p *model.Peminjaman, bolehNilai bool) (int, string) {
+	p.NoPerjanjian = strings.TrimSpace(req.NoPerjanjian)
+	p.Arah = strings.ToLower(strings.TrimSpace(req.Arah))
+	if p.Arah != model.ArahPeminjamanKeluar && p.Arah != model.ArahPeminjamanMasuk {
+		return fiber.StatusBadRequest, "Arah peminjaman harus keluar atau masuk"
+	}
+
+	p.Institusi = req.Institusi
+	p.Institusi.Nama = strings.TrimSpace(p.Institusi.Nama)
+	if p.Institusi.Nama == "" {
+		return fiber.StatusBadRequest, "Nama institusi wajib diisi"
+	}
+	p.Keperluan = strings.TrimSpace(req.Keperluan)
+	p.Catatan = strings.TrimSpace(req.Catatan)
+
+	var err error
+	if p.TanggalMulai, err = time.Parse("2006-01-02", strings.TrimSpace(req.TanggalMulai)); err != nil {
+		return fiber.StatusBadRequest, "tanggal_mulai wajib diisi dengan format YYYY-MM-DD"
+	}
+	if p.TanggalSelesai, err = time.Parse("2006-01-02", strings.TrimSpace(req.TanggalSelesai)); err != nil {
+		return fiber.StatusBadRequest, "tanggal_selesai wajib diisi dengan format YYYY-MM-DD"
+	}
+	if p.TanggalSelesai.Before(p.TanggalMulai) {
+		return fiber.StatusBadRequest, "tanggal_selesai tidak boleh sebelum tanggal_mulai"
+	}
+
+	if len(req.Koleksi) == 0 {
+		return fiber.StatusBadRequest, "Daftar koleksi yang dipinjam wajib diisi"
+	}
+
+	// 🔹 Ambil data koleksi sekaligus untuk nama benda & no inventaris
+	var ids []primitive.ObjectID
+	for _, item := range req.Koleksi {
+		if item.KoleksiID == "" {
+			continue
+		}
+		id, err := primitive.ObjectIDFromHex(item.KoleksiID)
+		if err != nil {
+			return fiber.StatusBadRequest, "ID koleksi tidak valid: " + item.KoleksiID
+		}
+		ids = append(ids, id)
+	}
+	koleksiByID := map[primitive.ObjectID]model.Koleksi{}
+	if len(ids) > 0 {
+		cursor, err := config.Ulbimongoconn.Collection("koleksi").Find(ctx,
+			bson.M{"_id": bson.M{"$in": ids}},
+			options.Find().SetProjection(bson.M{"nama_benda": 1, "no_inv": 1, "deaksesi": 1}))
+		if err != nil {
+			return fiber.StatusInternalServerError, "Gagal mengambil data koleksi"
+		}
+		var list []model.Koleksi
+		if err := cursor.All(ctx, &list); err != nil {
+			return fiber.StatusInternalServerError, "Gagal membaca data koleksi"
+		}
+		for _, k := range list {
+			koleksiByID[k.ID] = k
+		}
+	}
+
+	// 🔹 Peminjaman keluar: nilai asuransi yang kosong diisi dari penilaian terakhir koleksi.
+	// Penilaian hanya boleh dilihat admin / finance; untuk pengguna lain nilai yang sudah tersimpan
+	// dipertahankan karena mereka tidak bisa melihat (dan mengirim ulang) nilai tersebut.
+	nilaiPenilaian := map[primitive.ObjectID]float64{}
+	if !bolehNilai {
+		for _, item := range p.Koleksi {
+			if item.KoleksiID != nil && item.NilaiAsuransi != nil {
+				nilaiPenilaian[*item.KoleksiID] = *item.NilaiAsuransi
+			}
+		}
+	} else if p.Arah == model.ArahPeminjamanKeluar {
+		mataUang := strings.ToUpper(strings.TrimSpace(req.MataUang))
+		if mataUang == "" {
+			mataUang = "IDR"
+		}
+		if nilaiPenilaian, err = nilaiTerakhirKoleksi(ctx, ids, mataUang); err != nil {
+			return fiber.StatusInternalServerError, "Gagal mengambil penilaian koleksi"
+		}
+	}
+
+	p.Koleksi = nil
+	dipakai := map[primitive.ObjectID]bool{}
+	var totalAsuransi float64
+	adaAsuransi := false
+	for _, input := range req.Koleksi {
+		item := model.ItemPeminjaman{
+			NamaBenda:     strings.TrimSpace(input.NamaBenda),
+			NilaiAsuransi: input.NilaiAsuransi,
+			Catatan:       strings.TrimSpace(input.Catatan),
+		}
+		if input.KoleksiID != "" {
+			id, _ := primitive.ObjectIDFromHex(input.KoleksiID)
+			k, ok := koleksiByID[id]
+			if !ok {
+				return fiber.StatusBadRequest, "Koleksi tidak ditemukan: " + input.KoleksiID
+			}
+			if dipakai[id] {
+				return fiber.StatusBadRequest, "Koleksi " + k.NamaBenda + " tercantum lebih dari sekali"
+			}
+			if k.Deaksesi != nil && p.Arah == model.ArahPeminjamanKeluar {
+				return fiber.StatusConflict, "Koleksi " + k.NamaBenda + " dalam proses deaksesi (" + k.Deaksesi.Status + ") dan tidak bisa dipinjamkan"
+			}
+			dipakai[id] = true
+			item.KoleksiID = &id
+			item.NamaBenda = k.NamaBenda
+			item.NoInventaris = k.NoInventaris
+			if nilai, ada := nilaiPenilaian[id]; ada && item.NilaiAsuransi == nil {
+				item.NilaiAsuransi = &nilai
+			}
+		} else if p.Arah == model.ArahPeminjamanKeluar {
+			return fiber.StatusBadRequest, "koleksi_id wajib diisi untuk peminjaman keluar"
+		} else if item.NamaBenda == "" {
+			return fiber.StatusBadRequest, "nama_benda wajib diisi untuk benda tanpa koleksi_id"
+		}
+		if item.NilaiAsuransi != nil {
+			if *item.NilaiAsuransi < 0 {
+				return fiber.StatusBadRequest, "Nilai asuransi tidak boleh negatif"
+			}
+			totalAsuransi += *item.NilaiAsuransi
+			adaAsuransi = true
+		}
+		p.Koleksi = append(p.Koleksi, item)
+	}
+
+	// 🔹 Nilai asuransi total; jika kosong dijumlahkan dari nilai per benda
+	p.NilaiAsuransi = req.NilaiAsuransi
+	if p.NilaiAsuransi == nil && adaAsuransi {
+		p.NilaiAsuransi = &totalAsuransi
+	}
+	if p.NilaiAsuransi != nil && *p.NilaiAsuransi < 0 {
+		return fiber.StatusBadRequest, "Nilai asuransi tidak boleh negatif"
+	}
+	p.MataUang = strings.ToUpper(strings.TrimSpace(req.MataUang))
+	if p.MataUang == "" && p.NilaiAsuransi != nil {
+		p.MataUang = "IDR"
+	}
+
+	return 0, ""
+}
+
+// cekBentrokPinjaman memastikan koleksi pada peminjaman keluar tidak sedang terikat
+// peminjaman keluar lain (disetujui / dikirim) atau pameran pada periode yang beririsan.
+func cekBentrokPinjaman(ctx context.Context, p model.Peminjaman) ([]model.BentrokJadwal, int, string) {
+	ids := idKoleksiPinjaman(p)
+	if p.Arah != model.ArahPeminjamanKeluar || len(ids) == 0 {
+		return nil, 0, ""
+	}
+
+	bentrok, err := jadwalBentrok(ctx, ids, p.TanggalMulai, &p.TanggalSelesai, p.ID)
+	if err != nil {
+		return nil, fiber.StatusInternalServerError, "Gagal memeriksa jadwal koleksi"
+	}
+	if len(bentrok) > 0 {
+		return bentrok, fiber.StatusConflict, pesanBentrok(bentrok)
+	}
+	return nil, 0, ""
+}
+
+// ambilPeminjaman membaca satu peminjaman dari parameter :id
+func ambilPeminjaman(ctx context.Context, idParam string) (model.Peminjaman, int, string) {
+	var p model.Peminjaman
+	id, err := primitive.ObjectIDFromHex(idParam)
+	if err != nil {
+		return p, fiber.StatusBadRequest, "ID peminjaman tidak valid"
+	}
+	if err := config.Ulbimongoconn.Collection("peminjaman").FindOne(ctx, bson.M{"_id": id}).Decode(&p); err != nil {
+		return p, fiber.StatusNotFound, "Peminjaman tidak ditemukan"
+	}
+	return p, 0, ""
+}
+
+// InsertPeminjaman godoc
+// @Summary      Insert Peminjaman
+// @Description  Membuat perjanjian pinjam baru dengan status diajukan. Arah keluar = koleksi museum dipinjamkan ke institusi lain (koleksi_id wajib), arah masuk = museum meminjam benda dari institusi lain. Untuk peminjaman keluar, nilai asuransi per benda yang kosong diisi dari penilaian terakhir koleksi (mata uang yang sama). Nilai asuransi total dijumlahkan dari nilai per benda jika tidak diisi. Peminjaman keluar ditolak (409) jika koleksi sudah terjadwal di pameran atau peminjaman keluar lain pada periode yang sama; jadwal dicek ulang saat disetujui / dikirim.
+// @Tags         Peminjaman
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  model.PeminjamanRequest  true  "Data peminjaman"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Router       /peminjaman [post]
+func InsertPeminjaman(c *fiber.Ctx) error {
+	var req model.PeminjamanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Body request tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	p := model.Peminjaman{
+		ID:        primitive.NewObjectID(),
+		Status:    model.StatusPinjamDiajukan,
+		CreatedBy: penggunaLogin(c),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if status, errMsg := peminjamanDariRequest(ctx, req, &p, bolehLihatNilai(c)); errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if bentrok, status, errMsg := cekBentrokPinjaman(ctx, p); errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error":   errMsg,
+			"bentrok": bentrok,
+		})
+	}
+	p.Riwayat = []model.RiwayatStatusPinjam{{Status: p.Status, Tanggal: now, Oleh: p.CreatedBy}}
+
+	if _, err := config.Ulbimongoconn.Collection("peminjaman").InsertOne(ctx, p); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "No perjanjian sudah digunakan.",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan peminjaman",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Peminjaman berhasil diajukan",
+		"data":    p,
+	})
+}
+
+// GetAllPeminjaman godoc
+// @Summary      Get All Peminjaman
+// @Description  Mengambil daftar peminjaman, terbaru di depan. Field terlambat bernilai true jika benda sudah dikirim dan tanggal_selesai sudah lewat.
+// @Description  Nilai asuransi hanya ditampilkan untuk admin / finance (kirim token Bearer).
+// @Tags         Peminjaman
+// @Produce      json
+// @Param        arah        query  string   false  "keluar / masuk"
+// @Param        status      query  string   false  "diajukan, disetujui, dikirim, dikembalikan, dibatalkan"
+// @Param        koleksi_id  query  string   false  "Hanya peminjaman yang memuat koleksi ini"
+// @Param        terlambat   query  boolean  false  "true = hanya peminjaman yang terlambat dikembalikan"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /peminjaman [get]
+func GetAllPeminjaman(c *fiber.Ctx) error {
+	filter := bson.M{}
+	if arah := c.Query("arah"); arah != "" {
+		filter["arah"] = arah
+	}
+	if status := c.Query("status"); status != "" {
+		if _, ok := model.TransisiStatusPinjam[status]; !ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Status peminjaman tidak dikenal",
+			})
+		}
+		filter["status"] = status
+	}
+	if v := c.Query("koleksi_id"); v != "" {
+		id, err := primitive.ObjectIDFromHex(v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "ID koleksi tidak valid",
+			})
+		}
+		filter["koleksi.koleksi_id"] = id
+	}
+	now := time.Now()
+	if c.QueryBool("terlambat") {
+		filter["status"] = model.StatusPinjamDikirim
+		filter["tanggal_selesai"] = bson.M{"$lt": awalHari(now)}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.Ulbimongoconn.Collection("peminjaman").Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "tanggal_mulai", Value: -1}, {Key: "created_at", Value: -1}}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil data peminjaman",
+		})
+	}
+	list := []model.Peminjaman{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca data peminjaman",
+		})
+	}
+	for i := range list {
+		tandaiTerlambat(&list[i], now)
+		sembunyikanNilaiAsuransi(c, &list[i])
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil data peminjaman",
+		"total":   len(list),
+		"data":    list,
+	})
+}
+
+// GetPeminjamanByID godoc
+// @Summary      Get Peminjaman By ID
+// @Description  Mengambil satu perjanjian pinjam beserta riwayat statusnya. Nilai asuransi hanya ditampilkan untuk admin / finance (kirim token Bearer).
+// @Tags         Peminjaman
+// @Produce      json
+// @Param        id   path  string  true  "ID peminjaman"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Router       /peminjaman/{id} [get]
+func GetPeminjamanByID(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	p, status, errMsg := ambilPeminjaman(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	tandaiTerlambat(&p, time.Now())
+	sembunyikanNilaiAsuransi(c, &p)
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil data peminjaman",
+		"data":    p,
+	})
+}
+
+// UpdatePeminjaman godoc
+// @Summary      Update Peminjaman
+// @Description  Mengubah isi perjanjian pinjam. Hanya bisa selama status masih diajukan.
+// @Tags         Peminjaman
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                   true  "ID peminjaman"
+// @Param        request  body  model.PeminjamanRequest  true  "Data peminjaman"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Router       /peminjaman/{id} [put]
+func UpdatePeminjaman(c *fiber.Ctx) error {
+	var req model.PeminjamanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Body request tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	p, status, errMsg := ambilPeminjaman(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if p.Status != model.StatusPinjamDiajukan {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Peminjaman dengan status " + p.Status + " tidak bisa diubah",
+		})
+	}
+	if status, errMsg := peminjamanDariRequest(ctx, req, &p, bolehLihatNilai(c)); errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if bentrok, status, errMsg := cekBentrokPinjaman(ctx, p); errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error":   errMsg,
+			"bentrok": bentrok,
+		})
+	}
+	p.UpdatedAt = time.Now()
+
+	// status ikut difilter supaya perubahan status yang berjalan bersamaan tidak tertimpa
+	res, err := config.Ulbimongoconn.Collection("peminjaman").ReplaceOne(ctx,
+		bson.M{"_id": p.ID, "status": model.StatusPinjamDiajukan}, p)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "No perjanjian sudah digunakan.",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memperbarui peminjaman",
+		})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Status peminjaman sudah berubah, muat ulang data",
+		})
+	}
+
+	sembunyikanNilaiAsuransi(c, &p)
+	return c.JSON(fiber.Map{
+		"message": "Peminjaman berhasil diperbarui",
+		"data":    p,
+	})
+}
+
+// UbahStatusPeminjaman godoc
+// @Summary      Ubah Status Peminjaman
+// @Description  Memindahkan status peminjaman: diajukan → disetujui → dikirim → dikembalikan (atau dibatalkan sebelum dikirim). Saat peminjaman keluar dikirim, status_lokasi koleksi menjadi dipinjamkan; saat dikembalikan, status lokasi dikosongkan lagi. Saat disetujui / dikirim, koleksi tidak boleh terjadwal di peminjaman keluar lain atau pameran pada periode yang sama, dan tidak boleh dalam proses deaksesi.
+// @Tags         Peminjaman
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                         true  "ID peminjaman"
+// @Param        request  body  model.StatusPeminjamanRequest  true  "Status baru"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Router       /peminjaman/{id}/status [put]
+func UbahStatusPeminjaman(c *fiber.Ctx) error {
+	var req model.StatusPeminjamanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Body request tidak valid",
+		})
+	}
+	req.Status = strings.ToLower(strings.TrimSpace(req.Status))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	p, status, errMsg := ambilPeminjaman(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	boleh := false
+	for _, s := range model.TransisiStatusPinjam[p.Status] {
+		boleh = boleh || s == req.Status
+	}
+	if !boleh {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": fmt.Sprintf("Status tidak bisa diubah dari %s ke %s", p.Status, req.Status),
+		})
+	}
+
+	colKoleksi := config.Ulbimongoconn.Collection("koleksi")
+	ids := idKoleksiPinjaman(p)
+	keluar := p.Arah == model.ArahPeminjamanKeluar
+
+	// 🔹 Koleksi tidak boleh terikat peminjaman keluar lain maupun pameran.
+	// Jadwal koleksi dikunci sampai status tersimpan supaya cek bentrok tidak lolos bersamaan.
+	if req.Status == model.StatusPinjamDisetujui || req.Status == model.StatusPinjamDikirim {
+		if keluar && len(ids) > 0 {
+			lepas, err := kunciJadwalKoleksi(ctx, ids)
+			if err != nil {
+				return gagalKunciJadwal(c, err)
+			}
+			defer lepas()
+
+			// Deaksesi bisa diajukan setelah peminjaman dibuat
+			var dideaksesi model.Koleksi
+			err = colKoleksi.FindOne(ctx, bson.M{"_id": bson.M{"$in": ids}, "deaksesi": bson.M{"$exists": true}}).Decode(&dideaksesi)
+			if err == nil {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+					"error": "Koleksi " + dideaksesi.NamaBenda + " dalam proses deaksesi (" + dideaksesi.Deaksesi.Status + ") dan tidak bisa dipinjamkan",
+				})
+			}
+			if err != mongo.ErrNoDocuments {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": "Gagal memeriksa status deaksesi koleksi",
+				})
+			}
+		}
+		if bentrok, status, errMsg := cekBentrokPinjaman(ctx, p); errMsg != "" {
+			return c.Status(status).JSON(fiber.Map{
+				"error":   errMsg,
+				"bentrok": bentrok,
+			})
+		}
+	}
+	if req.Status == model.StatusPinjamDikirim && keluar && len(ids) > 0 {
+		var dipinjam model.Koleksi
+		err := colKoleksi.FindOne(ctx, bson.M{
+			"_id":                          bson.M{"$in": ids},
+			"status_lokasi":                model.StatusLokasiDipinjamkan,
+			"pinjaman_aktif.peminjaman_id": bson.M{"$ne": p.ID},
+		}).Decode(&dipinjam)
+		if err == nil {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Koleksi " + dipinjam.NamaBenda + " masih berada di institusi lain",
+			})
+		}
+	}
+
+	now := time.Now()
+	riwayat := model.RiwayatStatusPinjam{
+		Status:  req.Status,
+		Tanggal: now,
+		Oleh:    penggunaLogin(c),
+		Catatan: strings.TrimSpace(req.Catatan),
+	}
+	err := config.Ulbimongoconn.Collection("peminjaman").FindOneAndUpdate(ctx,
+		bson.M{"_id": p.ID, "status": p.Status},
+		bson.M{
+			"$set":  bson.M{"status": req.Status, "updated_at": now},
+			"$push": bson.M{"riwayat": riwayat},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&p)
+	if err == mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Status peminjaman sudah berubah, muat ulang data",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengubah status peminjaman",
+		})
+	}
+
+	// 🔹 Status lokasi koleksi untuk peminjaman keluar
+	if keluar && len(ids) > 0 {
+		switch req.Status {
+		case model.StatusPinjamDikirim:
+			_, err = colKoleksi.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}}, tambahVersi(bson.M{"$set": bson.M{
+				"status_lokasi": model.StatusLokasiDipinjamkan,
+				"pinjaman_aktif": model.PinjamanAktif{
+					PeminjamanID:   p.ID,
+					NoPerjanjian:   p.NoPerjanjian,
+					Institusi:      p.Institusi.Nama,
+					TanggalKirim:   now,
+					TanggalSelesai: p.TanggalSelesai,
+				},
+			}}))
+		case model.StatusPinjamDikembalikan:
+			_, err = colKoleksi.UpdateMany(ctx,
+				bson.M{"_id": bson.M{"$in": ids}, "pinjaman_aktif.peminjaman_id": p.ID},
+				tambahVersi(bson.M{"$unset": bson.M{"status_lokasi": "", "pinjaman_aktif": ""}}))
+		}
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Status peminjaman tersimpan, tetapi gagal memperbarui status lokasi koleksi",
+			})
+		}
+	}
+	tandaiTerlambat(&p, now)
+	sembunyikanNilaiAsuransi(c, &p)
+
+	return c.JSON(fiber.Map{
+		"message": "Status peminjaman menjadi " + req.Status,
+		"data":    p,
+	})
+}
+
+// DeletePeminjaman godoc
+// @Summary      Delete Peminjaman
+// @Description  Menghapus peminjaman yang masih diajukan atau sudah dibatalkan. Peminjaman yang pernah disetujui / dikirim disimpan sebagai arsip.
+// @Tags         Peminjaman
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID peminjaman"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]string
+// @Router       /peminjaman/{id} [delete]
+func DeletePeminjaman(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	p, status, errMsg := ambilPeminjaman(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	res, err := config.Ulbimongoconn.Collection("peminjaman").DeleteOne(ctx, bson.M{
+		"_id":    p.ID,
+		"status": bson.M{"$in": []string{model.StatusPinjamDiajukan, model.StatusPinjamDibatalkan}},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menghapus peminjaman",
+		})
+	}
+	if res.DeletedCount == 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Hanya peminjaman berstatus diajukan atau dibatalkan yang bisa dihapus",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Peminjaman berhasil dihapus",
+	})
+}
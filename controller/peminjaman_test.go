@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"be-internship/model"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSembunyikanNilaiAsuransi(t *testing.T) {
+	nilai := 1500000.0
+	app := fiber.New()
+	app.Get("/", JWTOpsional, func(c *fiber.Ctx) error {
+		p := model.Peminjaman{
+			NilaiAsuransi: &nilai,
+			Koleksi:       []model.ItemPeminjaman{{NamaBenda: "Keris", NilaiAsuransi: &nilai}},
+		}
+		sembunyikanNilaiAsuransi(c, &p)
+		return c.JSON(p)
+	})
+
+	tests := []struct {
+		role   string // kosong = tanpa token
+		tampil bool
+	}{
+		{role: "", tampil: false},
+		{role: model.RoleContributor, tampil: false},
+		{role: model.RoleFinance, tampil: true},
+		{role: model.RoleAdmin, tampil: true},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		if tt.role != "" {
+			req.Header.Set("Authorization", "Bearer "+tokenUji(t, primitive.NewObjectID(), tt.role))
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("GET sebagai %q error: %v", tt.role, err)
+		}
+		var p model.Peminjaman
+		if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+			t.Fatalf("GET sebagai %q: response tidak valid: %v", tt.role, err)
+		}
+		if got := p.NilaiAsuransi != nil; got != tt.tampil {
+			t.Errorf("nilai_asuransi untuk role %q tampil = %v, want %v", tt.role, got, tt.tampil)
+		}
+		if got := len(p.Koleksi) == 1 && p.Koleksi[0].NilaiAsuransi != nil; got != tt.tampil {
+			t.Errorf("koleksi[0].nilai_asuransi untuk role %q tampil = %v, want %v", tt.role, got, tt.tampil)
+		}
+	}
+}
@@ -0,0 +1,484 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"context"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// =============================================================
+// 💰 Penilaian (valuasi) koleksi untuk asuransi, peminjaman & laporan aset
+// =============================================================
+
+// maksUmurPenilaian membaca umur maksimal penilaian dalam tahun (env PENILAIAN_MAKS_TAHUN, default 5)
+func maksUmurPenilaian() int {
+	n, err := strconv.Atoi(os.Getenv("PENILAIAN_MAKS_TAHUN"))
+	if err != nil || n < 1 {
+		return 5
+	}
+	return n
+}
+
+// hapusPenilaianKoleksi menghapus seluruh penilaian milik koleksi yang dihapus
+func hapusPenilaianKoleksi(ctx context.Context, koleksiID primitive.ObjectID) {
+	if _, err := config.Ulbimongoconn.Collection("penilaian_koleksi").DeleteMany(ctx, bson.M{"koleksi_id": koleksiID}); err != nil {
+		log.Printf("⚠️  Gagal menghapus penilaian koleksi %s: %v", koleksiID.Hex(), err)
+	}
+}
+
+// bolehLihatNilai mengecek apakah pengguna login boleh melihat nilai koleksi (admin / finance)
+func bolehLihatNilai(c *fiber.Ctx) bool {
+	claims, ok := c.Locals("claims").(*Claims)
+	return ok && (claims.Role == model.RoleAdmin || claims.Role == model.RoleFinance)
+}
+
+// nilaiTerakhirKoleksi mengembalikan nilai dari penilaian terakhir setiap koleksi dalam mata uang tertentu
+func nilaiTerakhirKoleksi(ctx context.Context, ids []primitive.ObjectID, mataUang string) (map[primitive.ObjectID]float64, error) {
+	nilai := map[primitive.ObjectID]float64{}
+	if len(ids) == 0 {
+		return nilai, nil
+	}
+	cursor, err := config.Ulbimongoconn.Collection("penilaian_koleksi").Aggregate(ctx, []bson.M{
+		{"$match": bson.M{"koleksi_id": bson.M{"$in": ids}, "mata_uang": mataUang}},
+		{"$sort": bson.D{{Key: "koleksi_id", Value: 1}, {Key: "tanggal", Value: -1}, {Key: "created_at", Value: -1}}},
+		{"$group": bson.M{"_id": "$koleksi_id", "nilai": bson.M{"$first": "$nilai"}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var hasil []struct {
+		ID    primitive.ObjectID `bson:"_id"`
+		Nilai float64            `bson:"nilai"`
+	}
+	if err := cursor.All(ctx, &hasil); err != nil {
+		return nil, err
+	}
+	for _, h := range hasil {
+		nilai[h.ID] = h.Nilai
+	}
+	return nilai, nil
+}
+
+// TambahPenilaianKoleksi godoc
+// @Summary      Tambah Penilaian Koleksi
+// @Description  Mencatat nilai koleksi pada tanggal tertentu (hanya admin & finance). Metode: pasar, penggantian, ahli, perolehan, lainnya. Tujuan: asuransi, peminjaman, laporan_aset, lainnya.
+// @Tags         Penilaian Koleksi
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                  true  "ID koleksi"
+// @Param        request  body  model.PenilaianRequest  true  "Data penilaian"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Router       /koleksi/{id}/penilaian [post]
+func TambahPenilaianKoleksi(c *fiber.Ctx) error {
+	koleksiID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID koleksi tidak valid",
+		})
+	}
+
+	var req model.PenilaianRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Body request tidak valid",
+		})
+	}
+
+	p := model.PenilaianKoleksi{
+		ID:        primitive.NewObjectID(),
+		KoleksiID: koleksiID,
+		Nilai:     req.Nilai,
+		MataUang:  strings.ToUpper(strings.TrimSpace(req.MataUang)),
+		Penilai:   strings.TrimSpace(req.Penilai),
+		Metode:    strings.ToLower(strings.TrimSpace(req.Metode)),
+		Tujuan:    strings.ToLower(strings.TrimSpace(req.Tujuan)),
+		Catatan:   strings.TrimSpace(req.Catatan),
+		CreatedBy: penggunaLogin(c),
+		CreatedAt: time.Now(),
+	}
+	if p.Nilai <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Nilai harus lebih dari 0",
+		})
+	}
+	if p.MataUang == "" {
+		p.MataUang = "IDR"
+	}
+	if len(p.MataUang) != 3 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Mata uang harus kode 3 huruf (misal IDR, USD)",
+		})
+	}
+	if p.Penilai == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Nama penilai wajib diisi",
+		})
+	}
+	if !model.MetodePenilaianValid[p.Metode] {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Metode harus salah satu dari: pasar, penggantian, ahli, perolehan, lainnya",
+		})
+	}
+	if !model.TujuanPenilaianValid[p.Tujuan] {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Tujuan harus salah satu dari: asuransi, peminjaman, laporan_aset, lainnya",
+		})
+	}
+	if p.Tanggal, err = tanggalKondisi(req.Tanggal); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Format tanggal harus YYYY-MM-DD",
+		})
+	}
+	if p.Tanggal.After(time.Now()) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Tanggal penilaian tidak boleh di masa depan",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	n, err := config.Ulbimongoconn.Collection("koleksi").CountDocuments(ctx, bson.M{"_id": koleksiID})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil data koleksi",
+		})
+	}
+	if n == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Koleksi tidak ditemukan",
+		})
+	}
+
+	if _, err := config.Ulbimongoconn.Collection("penilaian_koleksi").InsertOne(ctx, p); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan penilaian",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Penilaian koleksi berhasil disimpan",
+		"data":    p,
+	})
+}
+
+// GetPenilaianKoleksi godoc
+// @Summary      Get Penilaian Koleksi
+// @Description  Mengambil riwayat penilaian koleksi, terbaru di depan (hanya admin & finance)
+// @Tags         Penilaian Koleksi
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID koleksi"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /koleksi/{id}/penilaian [get]
+func GetPenilaianKoleksi(c *fiber.Ctx) error {
+	koleksiID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID koleksi tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.Ulbimongoconn.Collection("penilaian_koleksi").Find(ctx,
+		bson.M{"koleksi_id": koleksiID},
+		options.Find().SetSort(bson.D{{Key: "tanggal", Value: -1}, {Key: "created_at", Value: -1}}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil penilaian koleksi",
+		})
+	}
+	list := []model.PenilaianKoleksi{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca penilaian koleksi",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil penilaian koleksi",
+		"total":   len(list),
+		"data":    list,
+	})
+}
+
+// DeletePenilaianKoleksi godoc
+// @Summary      Delete Penilaian Koleksi
+// @Description  Menghapus satu entri penilaian yang salah input (hanya admin & finance)
+// @Tags         Penilaian Koleksi
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id            path  string  true  "ID koleksi"
+// @Param        penilaian_id  path  string  true  "ID penilaian"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Router       /koleksi/{id}/penilaian/{penilaian_id} [delete]
+func DeletePenilaianKoleksi(c *fiber.Ctx) error {
+	koleksiID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID koleksi tidak valid",
+		})
+	}
+	penilaianID, err := primitive.ObjectIDFromHex(c.Params("penilaian_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID penilaian tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := config.Ulbimongoconn.Collection("penilaian_koleksi").DeleteOne(ctx,
+		bson.M{"_id": penilaianID, "koleksi_id": koleksiID})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menghapus penilaian",
+		})
+	}
+	if res.DeletedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Penilaian tidak ditemukan",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Penilaian berhasil dihapus",
+	})
+}
+
+// GetLaporanNilaiKoleksi godoc
+// @Summary      Laporan Nilai Koleksi
+// @Description  Total nilai koleksi aktif per kategori atau gudang, memakai penilaian terakhir setiap koleksi sampai per_tanggal. Total dipisah per mata uang (hanya admin & finance).
+// @Tags         Penilaian Koleksi
+// @Produce      json
+// @Security     BearerAuth
+// @Param        kelompok     query  string  false  "kategori (default) atau gudang"
+// @Param        per_tanggal  query  string  false  "Tanggal acuan (YYYY-MM-DD), default hari ini"
+// @Success      200  {object}  model.LaporanNilaiKoleksi
+// @Router       /laporan/nilai-koleksi [get]
+func GetLaporanNilaiKoleksi(c *fiber.Ctx) error {
+	kelompok := c.Query("kelompok", "kategori")
+	fieldKelompok := map[string]string{
+		"kategori": "kategori",
+		"gudang":   "tempat_penyimpanan.gudang",
+	}[kelompok]
+	namaKelompok := map[string]string{
+		"kategori": "nama_kategori",
+		"gudang":   "nama_gudang",
+	}[kelompok]
+	if fieldKelompok == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Kelompok harus kategori atau gudang",
+		})
+	}
+
+	perTanggal, err := tanggalKondisi(c.Query("per_tanggal"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Format per_tanggal harus YYYY-MM-DD",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 🔹 Penilaian terakhir tiap koleksi sampai per_tanggal, lalu dijumlahkan per kelompok
+	cursor, err := config.Ulbimongoconn.Collection("penilaian_koleksi").Aggregate(ctx, []bson.M{
+		{"$match": bson.M{"tanggal": bson.M{"$lt": perTanggal.AddDate(0, 0, 1)}}},
+		{"$sort": bson.D{{Key: "koleksi_id", Value: 1}, {Key: "tanggal", Value: -1}, {Key: "created_at", Value: -1}}},
+		{"$group": bson.M{
+			"_id":       "$koleksi_id",
+			"nilai":     bson.M{"$first": "$nilai"},
+			"mata_uang": bson.M{"$first": "$mata_uang"},
+		}},
+		{"$lookup": bson.M{"from": "koleksi", "localField": "_id", "foreignField": "_id", "as": "koleksi"}},
+		{"$unwind": "$koleksi"},
+		{"$match": bson.M{"koleksi.deaksesi.status": bson.M{"$ne": model.StatusDeaksesiSelesai}}},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"kelompok":  "$koleksi." + fieldKelompok + "._id",
+				"mata_uang": "$mata_uang",
+			},
+			"nama":   bson.M{"$first": "$koleksi." + fieldKelompok + "." + namaKelompok},
+			"jumlah": bson.M{"$sum": 1},
+			"total":  bson.M{"$sum": "$nilai"},
+		}},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyusun laporan nilai koleksi",
+		})
+	}
+
+	var hasil []struct {
+		ID struct {
+			Kelompok primitive.ObjectID `bson:"kelompok"`
+			MataUang string             `bson:"mata_uang"`
+		} `bson:"_id"`
+		Nama   string  `bson:"nama"`
+		Jumlah int     `bson:"jumlah"`
+		Total  float64 `bson:"total"`
+	}
+	if err := cursor.All(ctx, &hasil); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca laporan nilai koleksi",
+		})
+	}
+
+	// Gabungkan per kelompok, total nilai dipisah per mata uang
+	laporan := model.LaporanNilaiKoleksi{
+		Message:    "Berhasil menyusun laporan nilai koleksi",
+		Kelompok:   kelompok,
+		PerTanggal: perTanggal,
+		Data:       []model.RekapNilaiKoleksi{},
+		TotalNilai: map[string]float64{},
+	}
+	indeks := map[primitive.ObjectID]int{}
+	for _, h := range hasil {
+		i, ada := indeks[h.ID.Kelompok]
+		if !ada {
+			i = len(laporan.Data)
+			indeks[h.ID.Kelompok] = i
+			laporan.Data = append(laporan.Data, model.RekapNilaiKoleksi{
+				ID:         h.ID.Kelompok,
+				Nama:       h.Nama,
+				TotalNilai: map[string]float64{},
+			})
+		}
+		laporan.Data[i].Jumlah += h.Jumlah
+		laporan.Data[i].TotalNilai[h.ID.MataUang] += h.Total
+		laporan.TotalNilai[h.ID.MataUang] += h.Total
+		laporan.JumlahDinilai += h.Jumlah
+	}
+	sort.Slice(laporan.Data, func(i, j int) bool {
+		return laporan.Data[i].Nama < laporan.Data[j].Nama
+	})
+
+	aktif, err := config.Ulbimongoconn.Collection("koleksi").CountDocuments(ctx, filterKoleksiAktif(bson.M{}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menghitung koleksi aktif",
+		})
+	}
+	if belum := int(aktif) - laporan.JumlahDinilai; belum > 0 {
+		laporan.BelumDinilai = belum
+	}
+
+	return c.JSON(laporan)
+}
+
+// GetPenilaianKedaluwarsa godoc
+// @Summary      Koleksi dengan Penilaian Kedaluwarsa
+// @Description  Daftar koleksi aktif yang penilaian terakhirnya lebih tua dari batas umur (default PENILAIAN_MAKS_TAHUN atau 5 tahun), penilaian paling lama di depan (hanya admin & finance).
+// @Tags         Penilaian Koleksi
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tahun                   query  int      false  "Umur maksimal penilaian dalam tahun"
+// @Param        termasuk_belum_dinilai  query  boolean  false  "Sertakan koleksi yang belum pernah dinilai"
+// @Param        kategori_id             query  string   false  "Filter kategori (termasuk sub-kategori)"
+// @Param        gudang_id               query  string   false  "Filter gudang"
+// @Success      200  {object}  model.LaporanPenilaianKedaluwarsa
+// @Router       /laporan/penilaian-kedaluwarsa [get]
+func GetPenilaianKedaluwarsa(c *fiber.Ctx) error {
+	maksUmur := c.QueryInt("tahun", maksUmurPenilaian())
+	if maksUmur < 1 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Tahun harus lebih dari 0",
+		})
+	}
+	batas := awalHari(time.Now()).AddDate(-maksUmur, 0, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	match := filterKoleksiAktif(bson.M{})
+	kategoriID, ada, errMsg := idDariQuery(c, "kategori_id")
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if ada {
+		ids, err := idKategoriDanTurunan(ctx, kategoriID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Gagal mengambil sub-kategori",
+			})
+		}
+		match["kategori._id"] = bson.M{"$in": ids}
+	}
+	gudangID, ada, errMsg := idDariQuery(c, "gudang_id")
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if ada {
+		match["tempat_penyimpanan.gudang._id"] = gudangID
+	}
+
+	kedaluwarsa := []interface{}{bson.M{"penilaian_terakhir.tanggal": bson.M{"$lt": batas}}}
+	if c.QueryBool("termasuk_belum_dinilai") {
+		kedaluwarsa = append(kedaluwarsa, bson.M{"penilaian_terakhir": bson.M{"$exists": false}})
+	}
+
+	cursor, err := config.Ulbimongoconn.Collection("koleksi").Aggregate(ctx, []bson.M{
+		{"$match": match},
+		{"$lookup": bson.M{
+			"from": "penilaian_koleksi",
+			"let":  bson.M{"koleksi_id": "$_id"},
+			"pipeline": []bson.M{
+				{"$match": bson.M{"$expr": bson.M{"$eq": []string{"$koleksi_id", "$$koleksi_id"}}}},
+				{"$sort": bson.D{{Key: "tanggal", Value: -1}, {Key: "created_at", Value: -1}}},
+				{"$limit": 1},
+			},
+			"as": "penilaian",
+		}},
+		{"$project": bson.M{
+			"no_reg":             1,
+			"no_inv":             1,
+			"nama_benda":         1,
+			"kategori":           "$kategori.nama_kategori",
+			"gudang":             "$tempat_penyimpanan.gudang.nama_gudang",
+			"penilaian_terakhir": bson.M{"$arrayElemAt": []interface{}{"$penilaian", 0}},
+		}},
+		{"$match": bson.M{"$or": kedaluwarsa}},
+		{"$sort": bson.D{{Key: "penilaian_terakhir.tanggal", Value: 1}, {Key: "nama_benda", Value: 1}}},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil koleksi dengan penilaian kedaluwarsa",
+		})
+	}
+	list := []model.PenilaianKedaluwarsa{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca koleksi dengan penilaian kedaluwarsa",
+		})
+	}
+
+	return c.JSON(model.LaporanPenilaianKedaluwarsa{
+		Message:  "Berhasil mengambil koleksi dengan penilaian kedaluwarsa",
+		MaksUmur: maksUmur,
+		Batas:    batas,
+		Total:    len(list),
+		Data:     list,
+	})
+}
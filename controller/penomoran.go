@@ -0,0 +1,569 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// =============================================================
+// 🔢 Penomoran otomatis no_reg / no_inv
+// Counter disimpan per field & per hasil pola tanpa {seq}, sehingga urutan
+// otomatis mulai dari 1 lagi untuk setiap kategori / tahun baru.
+// Counter baru dinaikkan saat koleksi disimpan (nomor otomatis) atau setelah berhasil
+// disimpan (nomor manual yang lebih besar). Nomor otomatis yang gagal disimpan dikembalikan
+// selama belum ada nomor sesudahnya; jika sudah ada, urutan dibiarkan berlubang.
+// =============================================================
+
+// labelNomor berisi field yang bisa dinomori otomatis beserta labelnya di pesan error
+var labelNomor = map[string]string{
+	"no_reg": "No registrasi",
+	"no_inv": "No inventaris",
+}
+
+var (
+	polaTokenNomor   = regexp.MustCompile(`\{([a-z_]+)(?::(\d+))?\}`)
+	polaKodeKategori = regexp.MustCompile(`^[A-Z0-9-]{1,10}$`)
+)
+
+// bagianNomor adalah potongan pola: teks biasa atau token
+type bagianNomor struct {
+	teks  string
+	token string
+	lebar int // jumlah digit minimum untuk {seq:NN}
+}
+
+// uraiPolaNomor memecah pola menjadi potongan teks & token, sekaligus memvalidasinya
+func uraiPolaNomor(pola string) ([]bagianNomor, error) {
+	if strings.TrimSpace(pola) == "" {
+		return nil, fmt.Errorf("pola tidak boleh kosong")
+	}
+
+	var bagian []bagianNomor
+	jumlahSeq := 0
+	awal := 0
+	for _, m := range polaTokenNomor.FindAllStringSubmatchIndex(pola, -1) {
+		if m[0] > awal {
+			bagian = append(bagian, bagianNomor{teks: pola[awal:m[0]]})
+		}
+		token := pola[m[2]:m[3]]
+		lebar := 0
+		if m[4] >= 0 {
+			lebar, _ = strconv.Atoi(pola[m[4]:m[5]])
+		}
+
+		switch token {
+		case "seq":
+			jumlahSeq++
+			if lebar > 12 {
+				return nil, fmt.Errorf("lebar {seq} maksimal 12 digit")
+			}
+		case "kategori_code", "year", "month":
+			if m[4] >= 0 {
+				return nil, fmt.Errorf("token {%s} tidak memakai lebar digit", token)
+			}
+		default:
+			return nil, fmt.Errorf("token {%s} tidak dikenal, gunakan {kategori_code}, {year}, {month}, {seq}", token)
+		}
+
+		bagian = append(bagian, bagianNomor{token: token, lebar: lebar})
+		awal = m[1]
+	}
+	if awal < len(pola) {
+		bagian = append(bagian, bagianNomor{teks: pola[awal:]})
+	}
+
+	for _, b := range bagian {
+		if strings.ContainsAny(b.teks, "{}") {
+			return nil, fmt.Errorf("penulisan token tidak valid: %q", b.teks)
+		}
+	}
+	if jumlahSeq != 1 {
+		return nil, fmt.Errorf("pola harus berisi tepat satu token {seq}")
+	}
+	return bagian, nil
+}
+
+// pakaiKodeKategori bernilai true jika pola membutuhkan kode kategori
+func pakaiKodeKategori(bagian []bagianNomor) bool {
+	for _, b := range bagian {
+		if b.token == "kategori_code" {
+			return true
+		}
+	}
+	return false
+}
+
+// susunNomor mengisi token pola. Jika seq < 0, {seq} diganti "#" (dipakai sebagai key counter).
+func susunNomor(bagian []bagianNomor, nilai map[string]string, seq int64) string {
+	var sb strings.Builder
+	for _, b := range bagian {
+		switch {
+		case b.token == "":
+			sb.WriteString(b.teks)
+		case b.token == "seq" && seq < 0:
+			sb.WriteString("#")
+		case b.token == "seq":
+			sb.WriteString(fmt.Sprintf("%0*d", b.lebar, seq))
+		default:
+			sb.WriteString(nilai[b.token])
+		}
+	}
+	return sb.String()
+}
+
+// regexNomor membuat regex untuk memvalidasi nomor yang diisi manual
+func regexNomor(bagian []bagianNomor, kodeKategori string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, b := range bagian {
+		switch b.token {
+		case "":
+			sb.WriteString(regexp.QuoteMeta(b.teks))
+		case "kategori_code":
+			sb.WriteString(regexp.QuoteMeta(kodeKategori))
+		case "year":
+			sb.WriteString(`(?P<year>\d{4})`)
+		case "month":
+			sb.WriteString(`(?P<month>0[1-9]|1[0-2])`)
+		case "seq":
+			sb.WriteString(fmt.Sprintf(`(?P<seq>\d{%d,})`, max(b.lebar, 1)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
+
+// nilaiTokenNomor mengisi nilai token selain {seq} untuk waktu tertentu
+func nilaiTokenNomor(kodeKategori string, waktu time.Time) map[string]string {
+	return map[string]string{
+		"kategori_code": kodeKategori,
+		"year":          waktu.Format("2006"),
+		"month":         waktu.Format("01"),
+	}
+}
+
+// cariSkemaPenomoran mengambil pola penomoran field, atau nil jika belum diatur
+func cariSkemaPenomoran(ctx context.Context, field string) (*model.SkemaPenomoran, error) {
+	var skema model.SkemaPenomoran
+	err := config.Ulbimongoconn.Collection("skema_penomoran").FindOne(ctx, bson.M{"_id": field}).Decode(&skema)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &skema, nil
+}
+
+// kodeKategoriNomor mengambil kode kategori; sub-kategori tanpa kode memakai kode induk terdekat
+func kodeKategoriNomor(ctx context.Context, k model.Kategori) (string, error) {
+	if k.Kode != "" {
+		return k.Kode, nil
+	}
+	if k.ID.IsZero() {
+		return "", fmt.Errorf("kategori_id wajib diisi karena pola memakai {kategori_code}")
+	}
+
+	var leluhurIDs []primitive.ObjectID
+	for _, hex := range strings.Split(k.Path, "/") {
+		if id, err := primitive.ObjectIDFromHex(hex); err == nil {
+			leluhurIDs = append(leluhurIDs, id)
+		}
+	}
+	if len(leluhurIDs) > 0 {
+		cursor, err := config.Ulbimongoconn.Collection("kategori").Find(ctx,
+			bson.M{"_id": bson.M{"$in": leluhurIDs}, "kode": bson.M{"$gt": ""}},
+			options.Find().SetProjection(bson.M{"kode": 1}))
+		if err != nil {
+			return "", err
+		}
+		var leluhur []model.Kategori
+		if err := cursor.All(ctx, &leluhur); err != nil {
+			return "", err
+		}
+		kode := map[primitive.ObjectID]string{}
+		for _, l := range leluhur {
+			kode[l.ID] = l.Kode
+		}
+		// path berurutan dari akar, jadi dicari dari induk terdekat
+		for i := len(leluhurIDs) - 1; i >= 0; i-- {
+			if kode[leluhurIDs[i]] != "" {
+				return kode[leluhurIDs[i]], nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("kategori %s belum memiliki kode untuk penomoran otomatis", k.NamaKategori)
+}
+
+// keyCounterNomor adalah _id dokumen counter untuk satu field & cakupan pola
+func keyCounterNomor(field string, bagian []bagianNomor, nilai map[string]string) string {
+	return field + ":" + susunNomor(bagian, nilai, -1)
+}
+
+// nomorBerikutnya membuat nomor berikutnya. Jika pesan bernilai false, counter tidak dinaikkan (preview).
+func nomorBerikutnya(ctx context.Context, field string, skema model.SkemaPenomoran, kategori model.Kategori, pesan bool) (string, error) {
+	bagian, err := uraiPolaNomor(skema.Pola)
+	if err != nil {
+		return "", err
+	}
+
+	kodeKategori := ""
+	if pakaiKodeKategori(bagian) {
+		if kodeKategori, err = kodeKategoriNomor(ctx, kategori); err != nil {
+			return "", err
+		}
+	}
+	nilai := nilaiTokenNomor(kodeKategori, time.Now())
+
+	col := config.Ulbimongoconn.Collection("penomoran_counter")
+	key := keyCounterNomor(field, bagian, nilai)
+
+	var counter struct {
+		Seq int64 `bson:"seq"`
+	}
+	if !pesan {
+		err := col.FindOne(ctx, bson.M{"_id": key}).Decode(&counter)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return "", err
+		}
+		return susunNomor(bagian, nilai, counter.Seq+1), nil
+	}
+
+	// $inc dengan upsert bersifat atomik, dua request bersamaan tidak akan mendapat nomor yang sama
+	err = col.FindOneAndUpdate(ctx,
+		bson.M{"_id": key},
+		bson.M{"$inc": bson.M{"seq": 1}, "$set": bson.M{"updated_at": time.Now()}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	if err != nil {
+		return "", err
+	}
+	return susunNomor(bagian, nilai, counter.Seq), nil
+}
+
+// urutanNomor mencocokkan nomor dengan pola, lalu mengembalikan key counter dan urutan {seq} di dalamnya
+func urutanNomor(ctx context.Context, field, nomor string, skema model.SkemaPenomoran, kategori model.Kategori) (string, int64, error) {
+	bagian, err := uraiPolaNomor(skema.Pola)
+	if err != nil {
+		return "", 0, err
+	}
+
+	kodeKategori := ""
+	if pakaiKodeKategori(bagian) {
+		if kodeKategori, err = kodeKategoriNomor(ctx, kategori); err != nil {
+			return "", 0, err
+		}
+	}
+
+	re := regexNomor(bagian, kodeKategori)
+	cocok := re.FindStringSubmatch(nomor)
+	if cocok == nil {
+		contoh := susunNomor(bagian, nilaiTokenNomor(kodeKategori, time.Now()), 1)
+		return "", 0, fmt.Errorf("%s %q tidak sesuai pola %s (contoh: %s)", labelNomor[field], nomor, skema.Pola, contoh)
+	}
+
+	nilai := nilaiTokenNomor(kodeKategori, time.Now())
+	var seq int64
+	for i, nama := range re.SubexpNames() {
+		switch nama {
+		case "year", "month":
+			nilai[nama] = cocok[i]
+		case "seq":
+			seq, _ = strconv.ParseInt(cocok[i], 10, 64)
+		}
+	}
+	return keyCounterNomor(field, bagian, nilai), seq, nil
+}
+
+// nomorKoleksi mengembalikan pointer ke field no_reg / no_inv koleksi
+func nomorKoleksi(k *model.Koleksi, field string) *string {
+	if field == "no_reg" {
+		return &k.NoRegistrasi
+	}
+	return &k.NoInventaris
+}
+
+// ambilNomorOtomatis menaikkan counter dan mengembalikan nomor otomatis berikutnya.
+// Dipanggil tepat sebelum koleksi disimpan supaya validasi yang gagal tidak menghabiskan nomor.
+func ambilNomorOtomatis(ctx context.Context, field string, kategori model.Kategori) (string, error) {
+	skema, err := cariSkemaPenomoran(ctx, field)
+	if err != nil {
+		return "", err
+	}
+	if skema == nil {
+		return "", fmt.Errorf("skema penomoran %s belum diatur", field)
+	}
+	return nomorBerikutnya(ctx, field, *skema, kategori, true)
+}
+
+// kembalikanNomorOtomatis menurunkan counter jika nomor otomatis batal disimpan,
+// selama belum ada nomor lain yang diambil sesudahnya. Jika sudah ada, urutan dibiarkan berlubang.
+func kembalikanNomorOtomatis(ctx context.Context, field, nomor string, kategori model.Kategori) {
+	skema, err := cariSkemaPenomoran(ctx, field)
+	if err != nil || skema == nil {
+		return
+	}
+	key, seq, err := urutanNomor(ctx, field, nomor, *skema, kategori)
+	if err != nil {
+		return
+	}
+	_, err = config.Ulbimongoconn.Collection("penomoran_counter").UpdateOne(ctx,
+		bson.M{"_id": key, "seq": seq},
+		bson.M{"$inc": bson.M{"seq": -1}, "$set": bson.M{"updated_at": time.Now()}},
+	)
+	if err != nil {
+		log.Printf("⚠️  Gagal mengembalikan %s %s ke counter: %v", field, nomor, err)
+	}
+}
+
+// catatNomorManual menaikkan counter jika nomor manual yang sudah tersimpan lebih besar,
+// supaya nomor otomatis berikutnya tidak bentrok. Dipanggil setelah koleksi berhasil disimpan,
+// sehingga nomor dari request yang gagal tidak ikut menaikkan counter.
+func catatNomorManual(ctx context.Context, k model.Koleksi, fields ...string) {
+	for _, field := range fields {
+		if _, ok := labelNomor[field]; !ok {
+			continue
+		}
+		nomor := *nomorKoleksi(&k, field)
+		if nomor == "" {
+			continue
+		}
+		skema, err := cariSkemaPenomoran(ctx, field)
+		if err != nil || skema == nil {
+			continue
+		}
+		key, seq, err := urutanNomor(ctx, field, nomor, *skema, k.Kategori)
+		if err != nil {
+			// nomor lama yang tidak mengikuti pola tidak memengaruhi counter
+			continue
+		}
+		_, err = config.Ulbimongoconn.Collection("penomoran_counter").UpdateOne(ctx,
+			bson.M{"_id": key},
+			bson.M{"$max": bson.M{"seq": seq}, "$set": bson.M{"updated_at": time.Now()}},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			log.Printf("⚠️  Gagal mencatat %s %s ke counter: %v", field, nomor, err)
+		}
+	}
+}
+
+// siapkanNomorKoleksi memvalidasi no_reg / no_inv sebelum koleksi disimpan.
+// Nomor kosong diisi perkiraan nomor otomatis (otomatis bernilai true) tanpa menaikkan counter;
+// nomor sebenarnya diambil saat disimpan (lihat simpanKoleksiBaru). Nomor manual dicocokkan dengan pola.
+func siapkanNomorKoleksi(ctx context.Context, field, nomor string, kategori model.Kategori) (string, bool, int, string) {
+	skema, err := cariSkemaPenomoran(ctx, field)
+	if err != nil {
+		return "", false, fiber.StatusInternalServerError, "Gagal mengambil skema penomoran."
+	}
+
+	if nomor == "" {
+		if skema == nil {
+			return "", false, fiber.StatusBadRequest, labelNomor[field] + " tidak boleh kosong."
+		}
+		nomor, err = nomorBerikutnya(ctx, field, *skema, kategori, false)
+		if err != nil {
+			return "", false, fiber.StatusBadRequest, "Gagal membuat " + strings.ToLower(labelNomor[field]) + " otomatis: " + err.Error()
+		}
+		return nomor, true, 0, ""
+	}
+
+	if skema != nil {
+		if _, _, err := urutanNomor(ctx, field, nomor, *skema, kategori); err != nil {
+			return "", false, fiber.StatusBadRequest, err.Error()
+		}
+	}
+	if err := cekNomorDipakai(ctx, field, nomor, primitive.NilObjectID); err != nil {
+		if pesan := pesanDuplikatKoleksi(err); pesan != "" {
+			return "", false, fiber.StatusBadRequest, pesan
+		}
+		return "", false, fiber.StatusInternalServerError, "Gagal mengecek " + strings.ToLower(labelNomor[field]) + "."
+	}
+	return nomor, false, 0, ""
+}
+
+// GetSkemaPenomoran godoc
+// @Summary      Get Skema Penomoran
+// @Description  Menampilkan pola penomoran otomatis no_reg dan no_inv yang sedang dipakai
+// @Tags         Penomoran
+// @Produce      json
+// @Success      200  {array}  model.SkemaPenomoran
+// @Router       /penomoran [get]
+func GetSkemaPenomoran(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := config.Ulbimongoconn.Collection("skema_penomoran").Find(ctx, bson.M{},
+		options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil skema penomoran",
+		})
+	}
+	skema := []model.SkemaPenomoran{}
+	if err := cursor.All(ctx, &skema); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca skema penomoran",
+		})
+	}
+
+	return c.JSON(skema)
+}
+
+// SetSkemaPenomoran godoc
+// @Summary      Set Skema Penomoran
+// @Description  Mengatur pola penomoran otomatis untuk no_reg / no_inv. Token: {kategori_code} (kode kategori), {year}, {month}, {seq} atau {seq:04}. Nomor yang diisi manual saat insert/update koleksi harus sesuai pola ini.
+// @Tags         Penomoran
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        field    path  string                        true  "no_reg atau no_inv"
+// @Param        request  body  model.SkemaPenomoranRequest   true  "Pola penomoran"
+// @Success      200  {object}  model.SkemaPenomoran
+// @Failure      400  {object}  map[string]string
+// @Router       /penomoran/{field} [put]
+func SetSkemaPenomoran(c *fiber.Ctx) error {
+	field := c.Params("field")
+	if _, ok := labelNomor[field]; !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "field harus no_reg atau no_inv",
+		})
+	}
+
+	var req model.SkemaPenomoranRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Format request tidak valid",
+		})
+	}
+	req.Pola = strings.TrimSpace(req.Pola)
+	if _, err := uraiPolaNomor(req.Pola); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Pola tidak valid: " + err.Error(),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	skema := model.SkemaPenomoran{Field: field, Pola: req.Pola, UpdatedAt: time.Now()}
+	_, err := config.Ulbimongoconn.Collection("skema_penomoran").ReplaceOne(ctx,
+		bson.M{"_id": field}, skema, options.Replace().SetUpsert(true))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan skema penomoran",
+		})
+	}
+
+	return c.JSON(skema)
+}
+
+// DeleteSkemaPenomoran godoc
+// @Summary      Delete Skema Penomoran
+// @Description  Mematikan penomoran otomatis untuk no_reg / no_inv (nomor kembali wajib diisi manual). Counter tidak dihapus.
+// @Tags         Penomoran
+// @Produce      json
+// @Security     BearerAuth
+// @Param        field  path  string  true  "no_reg atau no_inv"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /penomoran/{field} [delete]
+func DeleteSkemaPenomoran(c *fiber.Ctx) error {
+	field := c.Params("field")
+	if _, ok := labelNomor[field]; !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "field harus no_reg atau no_inv",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := config.Ulbimongoconn.Collection("skema_penomoran").DeleteOne(ctx, bson.M{"_id": field})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menghapus skema penomoran",
+		})
+	}
+	if res.DeletedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Skema penomoran belum diatur",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Penomoran otomatis " + field + " dimatikan",
+	})
+}
+
+// PreviewNomorBerikutnya godoc
+// @Summary      Preview Nomor Berikutnya
+// @Description  Menampilkan no_reg / no_inv yang akan dipakai jika field dikosongkan saat insert koleksi. Nomor belum dipesan, sehingga bisa berubah jika ada koleksi lain yang disimpan lebih dulu.
+// @Tags         Penomoran
+// @Produce      json
+// @Param        field        path   string  true   "no_reg atau no_inv"
+// @Param        kategori_id  query  string  false  "ID kategori (wajib jika pola memakai {kategori_code})"
+// @Success      200  {object}  model.PreviewNomor
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /penomoran/{field}/berikutnya [get]
+func PreviewNomorBerikutnya(c *fiber.Ctx) error {
+	field := c.Params("field")
+	if _, ok := labelNomor[field]; !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "field harus no_reg atau no_inv",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	skema, err := cariSkemaPenomoran(ctx, field)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil skema penomoran",
+		})
+	}
+	if skema == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Penomoran otomatis " + field + " belum diatur",
+		})
+	}
+
+	var kategori model.Kategori
+	kategoriID, ada, errMsg := idDariQuery(c, "kategori_id")
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if ada {
+		if err := config.Ulbimongoconn.Collection("kategori").FindOne(ctx, bson.M{"_id": kategoriID}).Decode(&kategori); err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Kategori tidak ditemukan",
+			})
+		}
+	}
+
+	nomor, err := nomorBerikutnya(ctx, field, *skema, kategori, false)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(model.PreviewNomor{Field: field, Pola: skema.Pola, Nomor: nomor})
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"testing"
+)
+
+func TestUraiPolaNomor(t *testing.T) {
+	tests := []struct {
+		pola  string
+		want  []bagianNomor
+		gagal bool
+	}{
+		{pola: "{seq}", want: []bagianNomor{{token: "seq"}}},
+		{pola: "REG-{year}-{seq:4}", want: []bagianNomor{
+			{teks: "REG-"}, {token: "year"}, {teks: "-"}, {token: "seq", lebar: 4},
+		}},
+		{pola: "{kategori_code}/{month}.{seq:3}/A", want: []bagianNomor{
+			{token: "kategori_code"}, {teks: "/"}, {token: "month"}, {teks: "."}, {token: "seq", lebar: 3}, {teks: "/A"},
+		}},
+		{pola: "", gagal: true},
+		{pola: "   ", gagal: true},
+		{pola: "REG-{year}", gagal: true},      // tanpa {seq}
+		{pola: "{seq}-{seq}", gagal: true},     // {seq} lebih dari satu
+		{pola: "{seq:13}", gagal: true},        // lebar melebihi 12 digit
+		{pola: "{year:4}-{seq}", gagal: true},  // token selain {seq} tidak memakai lebar
+		{pola: "{tanggal}-{seq}", gagal: true}, // token tidak dikenal
+		{pola: "{seq}-{YEAR}", gagal: true},    // huruf besar tidak dikenali sebagai token
+		{pola: "{seq}-{year", gagal: true},     // kurung kurawal tidak lengkap
+		{pola: "REG}-{seq}", gagal: true},
+	}
+
+	for _, tt := range tests {
+		got, err := uraiPolaNomor(tt.pola)
+		if tt.gagal {
+			if err == nil {
+				t.Errorf("uraiPolaNomor(%q) = %v, want error", tt.pola, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("uraiPolaNomor(%q) error: %v", tt.pola, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("uraiPolaNomor(%q) = %+v, want %+v", tt.pola, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("uraiPolaNomor(%q)[%d] = %+v, want %+v", tt.pola, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestSusunNomor(t *testing.T) {
+	nilai := map[string]string{"kategori_code": "KRM", "year": "2024", "month": "07"}
+
+	tests := []struct {
+		pola string
+		seq  int64
+		want string
+	}{
+		{pola: "{seq}", seq: 7, want: "7"},
+		{pola: "{seq:4}", seq: 7, want: "0007"},
+		{pola: "{seq:2}", seq: 1234, want: "1234"}, // urutan lebih panjang tidak dipotong
+		{pola: "{kategori_code}-{year}{month}-{seq:3}", seq: 12, want: "KRM-202407-012"},
+		// seq < 0 dipakai sebagai key counter
+		{pola: "{kategori_code}-{year}-{seq:3}", seq: -1, want: "KRM-2024-#"},
+		{pola: "REG.{seq}", seq: -1, want: "REG.#"},
+	}
+
+	for _, tt := range tests {
+		bagian, err := uraiPolaNomor(tt.pola)
+		if err != nil {
+			t.Fatalf("uraiPolaNomor(%q) error: %v", tt.pola, err)
+		}
+		if got := susunNomor(bagian, nilai, tt.seq); got != tt.want {
+			t.Errorf("susunNomor(%q, %d) = %q, want %q", tt.pola, tt.seq, got, tt.want)
+		}
+	}
+}
+
+func TestRegexNomor(t *testing.T) {
+	tests := []struct {
+		pola  string
+		kode  string
+		nomor string
+		cocok bool
+		seq   string
+	}{
+		{pola: "{kategori_code}-{year}-{seq:4}", kode: "KRM", nomor: "KRM-2024-0012", cocok: true, seq: "0012"},
+		{pola: "{kategori_code}-{year}-{seq:4}", kode: "KRM", nomor: "KRM-2024-12345", cocok: true, seq: "12345"},
+		{pola: "{kategori_code}-{year}-{seq:4}", kode: "KRM", nomor: "KRM-2024-012", cocok: false}, // kurang dari lebar minimum
+		{pola: "{kategori_code}-{year}-{seq:4}", kode: "KRM", nomor: "ARK-2024-0012", cocok: false},
+		{pola: "{kategori_code}-{year}-{seq:4}", kode: "KRM", nomor: "KRM-24-0012", cocok: false},
+		{pola: "{year}/{month}/{seq}", nomor: "2024/12/1", cocok: true, seq: "1"},
+		{pola: "{year}/{month}/{seq}", nomor: "2024/13/1", cocok: false},
+		{pola: "{year}/{month}/{seq}", nomor: "2024/00/1", cocok: false},
+		// teks biasa di-escape, titik tidak cocok dengan karakter lain
+		{pola: "REG.{seq}", nomor: "REG.5", cocok: true, seq: "5"},
+		{pola: "REG.{seq}", nomor: "REGX5", cocok: false},
+		{pola: "REG.{seq}", nomor: "REG.5A", cocok: false},
+		{pola: "REG.{seq}", nomor: "xREG.5", cocok: false},
+		// kode kategori juga di-escape
+		{pola: "{kategori_code}{seq}", kode: "A-1", nomor: "A-17", cocok: true, seq: "7"},
+	}
+
+	for _, tt := range tests {
+		bagian, err := uraiPolaNomor(tt.pola)
+		if err != nil {
+			t.Fatalf("uraiPolaNomor(%q) error: %v", tt.pola, err)
+		}
+		re := regexNomor(bagian, tt.kode)
+		cocok := re.FindStringSubmatch(tt.nomor)
+		if (cocok != nil) != tt.cocok {
+			t.Errorf("regexNomor(%q) cocok %q = %v, want %v", tt.pola, tt.nomor, cocok != nil, tt.cocok)
+			continue
+		}
+		if cocok != nil {
+			if got := cocok[re.SubexpIndex("seq")]; got != tt.seq {
+				t.Errorf("regexNomor(%q) seq %q = %q, want %q", tt.pola, tt.nomor, got, tt.seq)
+			}
+		}
+	}
+}
+
+func TestRegexNomorCocokDenganSusunNomor(t *testing.T) {
+	nilai := map[string]string{"kategori_code": "KRM", "year": "2024", "month": "07"}
+	for _, pola := range []string{"{seq}", "{kategori_code}-{year}-{seq:5}", "{year}.{month}/{seq:3}"} {
+		bagian, err := uraiPolaNomor(pola)
+		if err != nil {
+			t.Fatalf("uraiPolaNomor(%q) error: %v", pola, err)
+		}
+		for _, seq := range []int64{1, 42, 123456} {
+			nomor := susunNomor(bagian, nilai, seq)
+			if !regexNomor(bagian, "KRM").MatchString(nomor) {
+				t.Errorf("nomor %q hasil pola %q tidak cocok dengan regexNomor", nomor, pola)
+			}
+		}
+	}
+}
@@ -0,0 +1,616 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// =============================================================
+// 🧾 Perolehan koleksi & riwayat provenans
+// =============================================================
+
+// isiTanggalPerolehan mengisi tanggal_mulai / tanggal_selesai dari teks tanggal
+func isiTanggalPerolehan(teks string) (*time.Time, *time.Time, string) {
+	if strings.TrimSpace(teks) == "" {
+		return nil, nil, ""
+	}
+	mulai, selesai, err := model.ParseTanggalPerolehan(teks)
+	if err != nil {
+		return nil, nil, err.Error()
+	}
+	return &mulai, &selesai, ""
+}
+
+// validasiPerolehan memeriksa & melengkapi data perolehan. media dipakai untuk mengecek dokumen pendukung.
+func validasiPerolehan(p *model.Perolehan, media []model.Media) string {
+	p.Metode = strings.ToLower(strings.TrimSpace(p.Metode))
+	if !model.MetodePerolehanValid[p.Metode] {
+		return "Metode perolehan harus salah satu dari: hibah, pembelian, temuan, titipan"
+	}
+
+	p.Tanggal = strings.TrimSpace(p.Tanggal)
+	var errMsg string
+	p.TanggalMulai, p.TanggalSelesai, errMsg = isiTanggalPerolehan(p.Tanggal)
+	if errMsg != "" {
+		return "Tanggal perolehan tidak valid: " + errMsg
+	}
+	p.Tahun = 0
+	if p.TanggalMulai != nil {
+		p.Tahun = p.TanggalMulai.Year()
+	}
+
+	if p.Sumber != nil {
+		p.Sumber.Nama = strings.TrimSpace(p.Sumber.Nama)
+		if p.Sumber.Nama == "" {
+			return "Nama sumber perolehan wajib diisi"
+		}
+		if p.Sumber.Jenis != "" && !model.JenisPihakValid[p.Sumber.Jenis] {
+			return "Jenis sumber harus salah satu dari: perorangan, instansi, komunitas, lainnya"
+		}
+	}
+
+	if p.Harga != nil {
+		if *p.Harga < 0 {
+			return "Harga perolehan tidak boleh negatif"
+		}
+		p.MataUang = strings.ToUpper(strings.TrimSpace(p.MataUang))
+		if p.MataUang == "" {
+			p.MataUang = "IDR"
+		}
+		if len(p.MataUang) != 3 {
+			return "Mata uang harus berupa kode ISO 4217 (contoh: IDR, USD, EUR)"
+		}
+	} else {
+		p.MataUang = ""
+	}
+
+	return validasiDokumen(p.Dokumen, media)
+}
+
+// validasiDokumen memeriksa dokumen pendukung; media_id harus merujuk media milik koleksi
+func validasiDokumen(dokumen []model.DokumenPerolehan, media []model.Media) string {
+	for i, d := range dokumen {
+		if strings.TrimSpace(d.Jenis) == "" {
+			return fmt.Sprintf("Jenis dokumen ke-%d wajib diisi", i+1)
+		}
+		if d.MediaID != nil {
+			if cariMedia(media, *d.MediaID) < 0 {
+				return fmt.Sprintf("Media dokumen ke-%d tidak ditemukan di media koleksi", i+1)
+			}
+		}
+	}
+	return ""
+}
+
+// validasiProvenans memeriksa & melengkapi satu entri provenans
+func validasiProvenans(p *model.Provenans) string {
+	p.Peristiwa = strings.TrimSpace(p.Peristiwa)
+	p.Pemilik = strings.TrimSpace(p.Pemilik)
+	if p.Peristiwa == "" {
+		return "Peristiwa provenans wajib diisi (contoh: pembelian, warisan, lelang)"
+	}
+
+	p.Tanggal = strings.TrimSpace(p.Tanggal)
+	var errMsg string
+	p.TanggalMulai, p.TanggalSelesai, errMsg = isiTanggalPerolehan(p.Tanggal)
+	if errMsg != "" {
+		return "Tanggal provenans tidak valid: " + errMsg
+	}
+	return ""
+}
+
+// setPerolehanLama menyamakan field teks lama supaya client lama tetap menampilkan data perolehan.
+// Field lama dihapus jika nilai barunya kosong, supaya tidak menampilkan data perolehan sebelumnya.
+func setPerolehanLama(set, unset bson.M, p model.Perolehan) {
+	for field, nilai := range map[string]string{
+		"tanggal_perolehan": p.Tanggal,
+		"tempat_perolehan":  p.Tempat,
+	} {
+		if nilai != "" {
+			set[field] = nilai
+		} else {
+			unset[field] = ""
+		}
+	}
+}
+
+// cariProvenans mencari indeks entri provenans berdasarkan ID
+func cariProvenans(list []model.Provenans, idParam string) (int, bool) {
+	id, err := primitive.ObjectIDFromHex(idParam)
+	if err != nil {
+		return -1, false
+	}
+	for i, p := range list {
+		if p.ID == id {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// simpanProvenansKoleksi menyimpan seluruh riwayat provenans koleksi (field dihapus jika daftar kosong).
+// Hanya disimpan jika koleksi masih di versi yang dibaca; jika tidak, errVersiBerubah dikembalikan.
+func simpanProvenansKoleksi(ctx context.Context, koleksiID primitive.ObjectID, versi int64, list []model.Provenans) error {
+	update := bson.M{"$set": bson.M{"provenans": list, "updated_at": time.Now()}}
+	if len(list) == 0 {
+		update = bson.M{
+			"$set":   bson.M{"updated_at": time.Now()},
+			"$unset": bson.M{"provenans": ""},
+		}
+	}
+
+	res, err := config.Ulbimongoconn.Collection("koleksi").UpdateOne(ctx, filterVersiKoleksi(koleksiID, versi), tambahVersi(update))
+	if err == nil && res.MatchedCount == 0 {
+		err = errVersiBerubah
+	}
+	return err
+}
+
+// ubahProvenansKoleksi menjalankan update satu entri provenans ($push / positional $set / $pull)
+// dan mengembalikan riwayat provenans & versi koleksi setelah diubah. mongo.ErrNoDocuments jika filter tidak cocok.
+func ubahProvenansKoleksi(ctx context.Context, filter, update bson.M) ([]model.Provenans, int64, error) {
+	var hasil model.Koleksi
+	err := config.Ulbimongoconn.Collection("koleksi").FindOneAndUpdate(ctx,
+		filterKoleksiAktif(filter),
+		tambahVersi(update),
+		options.FindOneAndUpdate().
+			SetReturnDocument(options.After).
+			SetProjection(bson.M{"provenans": 1, "versi": 1}),
+	).Decode(&hasil)
+	return hasil.Provenans, hasil.Versi, err
+}
+
+// SetPerolehanKoleksi godoc
+// @Summary      Set Perolehan Koleksi
+// @Description  Mengisi / mengganti data perolehan terstruktur: metode (hibah, pembelian, temuan, titipan), tanggal (DD-MM-YYYY, MM-YYYY, YYYY, atau rentang "1990 s/d 1995"), sumber, harga & mata uang, dan dokumen pendukung. Field tanggal_mulai, tanggal_selesai, dan tahun diisi server.
+// @Tags         Perolehan Koleksi
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string           true  "ID koleksi"
+// @Param        request  body  model.Perolehan  true  "Data perolehan"
+// @Param        If-Match  header  string  true  "ETag dari GET terakhir"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      412  {object}  map[string]interface{}  "Data sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428  {object}  map[string]interface{}  "Header If-Match belum diisi"
+// @Router       /koleksi/{id}/perolehan [put]
+func SetPerolehanKoleksi(c *fiber.Ctx) error {
+	var req model.Perolehan
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	koleksi, status, errMsg := ambilKoleksiMedia(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if ok, err := cekIfMatch(c, koleksi.Versi); !ok {
+		return err
+	}
+
+	if errMsg := validasiPerolehan(&req, koleksi.Media); errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	set := bson.M{"perolehan": req, "updated_at": time.Now()}
+	unset := bson.M{}
+	setPerolehanLama(set, unset, req)
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	col := config.Ulbimongoconn.Collection("koleksi")
+	res, err := col.UpdateOne(ctx, filterVersiKoleksi(koleksi.ID, koleksi.Versi), tambahVersi(update))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan data perolehan",
+		})
+	}
+	if res.MatchedCount == 0 {
+		versi, _ := versiDokumen(ctx, col, koleksi.ID)
+		return tolakVersiBerubah(c, versi)
+	}
+
+	c.Set(fiber.HeaderETag, etagVersi(koleksi.Versi+1))
+	return c.JSON(fiber.Map{
+		"message": "Data perolehan berhasil disimpan",
+		"data":    req,
+	})
+}
+
+// TambahProvenansKoleksi godoc
+// @Summary      Tambah Provenans Koleksi
+// @Description  Menambahkan satu pemilik / peristiwa ke riwayat provenans koleksi. Entri baru ditambahkan di akhir (paling baru), gunakan endpoint urutan untuk menyusun ulang.
+// @Tags         Perolehan Koleksi
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string           true  "ID koleksi"
+// @Param        request  body  model.Provenans  true  "Entri provenans"
+// @Success      201  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Router       /koleksi/{id}/provenans [post]
+func TambahProvenansKoleksi(c *fiber.Ctx) error {
+	var req model.Provenans
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+	if errMsg := validasiProvenans(&req); errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	req.ID = primitive.NewObjectID()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	koleksi, status, errMsg := ambilKoleksiMedia(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	// $push supaya entri yang ditambahkan bersamaan tidak saling menimpa
+	list, versi, err := ubahProvenansKoleksi(ctx,
+		bson.M{"_id": koleksi.ID},
+		bson.M{"$push": bson.M{"provenans": req}, "$set": bson.M{"updated_at": time.Now()}},
+	)
+	if err == mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Koleksi tidak ditemukan",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan provenans",
+		})
+	}
+
+	c.Set(fiber.HeaderETag, etagVersi(versi))
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message":   "Provenans berhasil ditambahkan",
+		"data":      req,
+		"provenans": list,
+	})
+}
+
+// UpdateProvenansKoleksi godoc
+// @Summary      Update Provenans Koleksi
+// @Description  Mengganti isi satu entri provenans tanpa mengubah urutannya
+// @Tags         Perolehan Koleksi
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id            path  string           true  "ID koleksi"
+// @Param        provenans_id  path  string           true  "ID entri provenans"
+// @Param        request       body  model.Provenans  true  "Entri provenans"
+// @Param        If-Match  header  string  true  "ETag dari GET terakhir"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      412  {object}  map[string]interface{}  "Data sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428  {object}  map[string]interface{}  "Header If-Match belum diisi"
+// @Router       /koleksi/{id}/provenans/{provenans_id} [put]
+func UpdateProvenansKoleksi(c *fiber.Ctx) error {
+	var req model.Provenans
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+	if errMsg := validasiProvenans(&req); errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	koleksi, status, errMsg := ambilKoleksiMedia(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if ok, err := cekIfMatch(c, koleksi.Versi); !ok {
+		return err
+	}
+
+	idx, ok := cariProvenans(koleksi.Provenans, c.Params("provenans_id"))
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Provenans tidak ditemukan",
+		})
+	}
+	req.ID = koleksi.Provenans[idx].ID
+
+	// Positional $set hanya mengganti entri ini; filter versi menolak jika koleksi berubah sejak dibaca
+	filter := filterVersi(koleksi.ID, koleksi.Versi)
+	filter["provenans._id"] = req.ID
+	_, versi, err := ubahProvenansKoleksi(ctx,
+		filter,
+		bson.M{"$set": bson.M{"provenans.$": req, "updated_at": time.Now()}},
+	)
+	if err == mongo.ErrNoDocuments {
+		versi, _ := versiDokumen(ctx, config.Ulbimongoconn.Collection("koleksi"), koleksi.ID)
+		return tolakVersiBerubah(c, versi)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan provenans",
+		})
+	}
+
+	c.Set(fiber.HeaderETag, etagVersi(versi))
+	return c.JSON(fiber.Map{
+		"message": "Provenans berhasil diperbarui",
+		"data":    req,
+	})
+}
+
+// UrutkanProvenansKoleksi godoc
+// @Summary      Urutkan Provenans Koleksi
+// @Description  Menyusun ulang riwayat provenans secara kronologis (paling lama di depan). provenans_ids harus berisi seluruh entri.
+// @Tags         Perolehan Koleksi
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                        true  "ID koleksi"
+// @Param        request  body  model.UrutanProvenansRequest  true  "Urutan ID provenans"
+// @Param        If-Match  header  string  true  "ETag dari GET terakhir"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      412  {object}  map[string]interface{}
+// @Failure      428  {object}  map[string]interface{}  "Header If-Match belum diisi"
+// @Router       /koleksi/{id}/provenans/urutan [put]
+func UrutkanProvenansKoleksi(c *fiber.Ctx) error {
+	var req model.UrutanProvenansRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	koleksi, status, errMsg := ambilKoleksiMedia(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if ok, err := cekIfMatch(c, koleksi.Versi); !ok {
+		return err
+	}
+
+	if len(req.ProvenansIDs) != len(koleksi.Provenans) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("provenans_ids harus berisi seluruh %d entri provenans", len(koleksi.Provenans)),
+		})
+	}
+
+	urutan := make([]model.Provenans, 0, len(koleksi.Provenans))
+	dipakai := map[int]bool{}
+	for _, idStr := range req.ProvenansIDs {
+		idx, ok := cariProvenans(koleksi.Provenans, idStr)
+		if !ok || dipakai[idx] {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "ID provenans tidak valid atau duplikat: " + idStr,
+			})
+		}
+		dipakai[idx] = true
+		urutan = append(urutan, koleksi.Provenans[idx])
+	}
+
+	// Urutan disimpan utuh, jadi hanya berlaku jika provenans belum berubah sejak dibaca
+	if err := simpanProvenansKoleksi(ctx, koleksi.ID, koleksi.Versi, urutan); err != nil {
+		if err == errVersiBerubah {
+			versi, _ := versiDokumen(ctx, config.Ulbimongoconn.Collection("koleksi"), koleksi.ID)
+			return tolakVersiBerubah(c, versi)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan urutan provenans",
+		})
+	}
+
+	c.Set(fiber.HeaderETag, etagVersi(koleksi.Versi+1))
+	return c.JSON(fiber.Map{
+		"message":   "Urutan provenans berhasil disimpan",
+		"provenans": urutan,
+	})
+}
+
+// DeleteProvenansKoleksi godoc
+// @Summary      Delete Provenans Koleksi
+// @Description  Menghapus satu entri dari riwayat provenans koleksi
+// @Tags         Perolehan Koleksi
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id            path  string  true  "ID koleksi"
+// @Param        provenans_id  path  string  true  "ID entri provenans"
+// @Param        If-Match  header  string  true  "ETag dari GET terakhir"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      412  {object}  map[string]interface{}  "Data sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428  {object}  map[string]interface{}  "Header If-Match belum diisi"
+// @Router       /koleksi/{id}/provenans/{provenans_id} [delete]
+func DeleteProvenansKoleksi(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	koleksi, status, errMsg := ambilKoleksiMedia(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if ok, err := cekIfMatch(c, koleksi.Versi); !ok {
+		return err
+	}
+
+	idx, ok := cariProvenans(koleksi.Provenans, c.Params("provenans_id"))
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Provenans tidak ditemukan",
+		})
+	}
+
+	provenansID := koleksi.Provenans[idx].ID
+	filter := filterVersi(koleksi.ID, koleksi.Versi)
+	filter["provenans._id"] = provenansID
+	update := bson.M{"$pull": bson.M{"provenans": bson.M{"_id": provenansID}}, "$set": bson.M{"updated_at": time.Now()}}
+	if len(koleksi.Provenans) == 1 {
+		// entri terakhir: field dihapus sekalian (aman karena versi koleksi dikunci filter)
+		update = bson.M{"$unset": bson.M{"provenans": ""}, "$set": bson.M{"updated_at": time.Now()}}
+	}
+	list, versi, err := ubahProvenansKoleksi(ctx, filter, update)
+	if err == mongo.ErrNoDocuments {
+		versi, _ := versiDokumen(ctx, config.Ulbimongoconn.Collection("koleksi"), koleksi.ID)
+		return tolakVersiBerubah(c, versi)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menghapus provenans",
+		})
+	}
+	if list == nil {
+		list = []model.Provenans{}
+	}
+
+	c.Set(fiber.HeaderETag, etagVersi(versi))
+	return c.JSON(fiber.Map{
+		"message":   "Provenans berhasil dihapus",
+		"provenans": list,
+	})
+}
+
+// GetLaporanPerolehan godoc
+// @Summary      Get Laporan Perolehan
+// @Description  Rekap jumlah koleksi dan total harga per metode & tahun perolehan. Koleksi tanpa data perolehan terstruktur dihitung di belum_terstruktur.
+// @Tags         Perolehan Koleksi
+// @Produce      json
+// @Param        metode       query  string  false  "Filter metode perolehan"
+// @Param        tahun_dari   query  int     false  "Tahun perolehan awal"
+// @Param        tahun_sampai query  int     false  "Tahun perolehan akhir"
+// @Success      200  {object}  model.LaporanPerolehan
+// @Router       /laporan/perolehan [get]
+func GetLaporanPerolehan(c *fiber.Ctx) error {
+	match := bson.M{"perolehan.metode": bson.M{"$exists": true}}
+	if metode := c.Query("metode"); metode != "" {
+		if !model.MetodePerolehanValid[metode] {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Metode perolehan harus salah satu dari: hibah, pembelian, temuan, titipan",
+			})
+		}
+		match["perolehan.metode"] = metode
+	}
+	tahun := bson.M{}
+	if v := c.QueryInt("tahun_dari"); v > 0 {
+		tahun["$gte"] = v
+	}
+	if v := c.QueryInt("tahun_sampai"); v > 0 {
+		tahun["$lte"] = v
+	}
+	if len(tahun) > 0 {
+		match["perolehan.tahun"] = tahun
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	col := config.Ulbimongoconn.Collection("koleksi")
+	cursor, err := col.Aggregate(ctx, []bson.M{
+		{"$match": match},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"metode":    "$perolehan.metode",
+				"tahun":     bson.M{"$ifNull": []interface{}{"$perolehan.tahun", 0}},
+				"mata_uang": "$perolehan.mata_uang",
+			},
+			"jumlah": bson.M{"$sum": 1},
+			"harga":  bson.M{"$sum": bson.M{"$ifNull": []interface{}{"$perolehan.harga", 0}}},
+		}},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil laporan perolehan",
+		})
+	}
+
+	var hasil []struct {
+		ID struct {
+			Metode   string `bson:"metode"`
+			Tahun    int    `bson:"tahun"`
+			MataUang string `bson:"mata_uang"`
+		} `bson:"_id"`
+		Jumlah int     `bson:"jumlah"`
+		Harga  float64 `bson:"harga"`
+	}
+	if err := cursor.All(ctx, &hasil); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca laporan perolehan",
+		})
+	}
+
+	// Gabungkan per metode & tahun, total harga dipisah per mata uang
+	laporan := model.LaporanPerolehan{Message: "Berhasil mengambil laporan perolehan", Data: []model.RekapPerolehan{}}
+	indeks := map[string]int{}
+	for _, h := range hasil {
+		key := fmt.Sprintf("%s|%d", h.ID.Metode, h.ID.Tahun)
+		i, ada := indeks[key]
+		if !ada {
+			i = len(laporan.Data)
+			indeks[key] = i
+			laporan.Data = append(laporan.Data, model.RekapPerolehan{Metode: h.ID.Metode, Tahun: h.ID.Tahun})
+		}
+		laporan.Data[i].Jumlah += h.Jumlah
+		if h.ID.MataUang != "" {
+			if laporan.Data[i].TotalHarga == nil {
+				laporan.Data[i].TotalHarga = map[string]float64{}
+			}
+			laporan.Data[i].TotalHarga[h.ID.MataUang] += h.Harga
+		}
+		laporan.TotalKoleksi += h.Jumlah
+	}
+	sort.Slice(laporan.Data, func(i, j int) bool {
+		if laporan.Data[i].Tahun != laporan.Data[j].Tahun {
+			return laporan.Data[i].Tahun > laporan.Data[j].Tahun
+		}
+		return laporan.Data[i].Metode < laporan.Data[j].Metode
+	})
+
+	belum, err := col.CountDocuments(ctx, bson.M{"perolehan.metode": bson.M{"$exists": false}})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menghitung koleksi tanpa data perolehan",
+		})
+	}
+	laporan.BelumTerstruktur = int(belum)
+
+	return c.JSON(laporan)
+}
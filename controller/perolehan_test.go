@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"be-internship/model"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestSetPerolehanLama(t *testing.T) {
+	set, unset := bson.M{}, bson.M{}
+	setPerolehanLama(set, unset, model.Perolehan{Tanggal: "1998", Tempat: "Cirebon"})
+	if set["tanggal_perolehan"] != "1998" || set["tempat_perolehan"] != "Cirebon" || len(unset) != 0 {
+		t.Errorf("perolehan lengkap: set = %v, unset = %v", set, unset)
+	}
+
+	// nilai kosong menghapus field lama supaya data perolehan sebelumnya tidak tetap tampil
+	set, unset = bson.M{}, bson.M{}
+	setPerolehanLama(set, unset, model.Perolehan{Tempat: "Cirebon"})
+	if _, ada := set["tanggal_perolehan"]; ada {
+		t.Errorf("tanggal kosong tidak boleh di-set: %v", set)
+	}
+	if _, ada := unset["tanggal_perolehan"]; !ada || len(unset) != 1 {
+		t.Errorf("tanggal kosong harus di-unset: %v", unset)
+	}
+}
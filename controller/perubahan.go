@@ -0,0 +1,708 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"bytes"
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// =============================================================
+// 📝 Review perubahan koleksi dari kontributor & riwayat koleksi
+// =============================================================
+
+// perluReview bernilai true jika tambah / ubah koleksi oleh pengguna login harus direview lebih dulu
+func perluReview(c *fiber.Ctx) bool {
+	claims, ok := c.Locals("claims").(*Claims)
+	return ok && claims.Role == model.RoleContributor
+}
+
+// TolakKontributor middleware menolak perubahan langsung dari kontributor pada data koleksi yang tidak
+// lewat usulan (media, perolehan, provenans, kondisi, perawatan). Harus dipasang setelah JWTAuth.
+func TolakKontributor(c *fiber.Ctx) error {
+	if perluReview(c) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Kontributor tidak bisa mengubah data ini secara langsung, ajukan perubahan lewat form koleksi untuk direview",
+		})
+	}
+	return c.Next()
+}
+
+// fieldEditKoleksi mengembalikan nilai field koleksi yang diubah lewat form koleksi.
+// Nilai nil berarti field kosong dan dihapus dari dokumen.
+func fieldEditKoleksi(k model.Koleksi) bson.M {
+	opsional := func(v string) interface{} {
+		if v == "" {
+			return nil
+		}
+		return v
+	}
+	field := bson.M{
+		"kategori":                   k.Kategori,
+		"no_reg":                     k.NoRegistrasi,
+		"no_inv":                     k.NoInventaris,
+		"nama_benda":                 k.NamaBenda,
+		"tempat_penyimpanan.gudang":  k.TempatPenyimpanan.Gudang,
+		"tempat_penyimpanan.rak":     nil,
+		"tempat_penyimpanan.tahap":   nil,
+		"tempat_penyimpanan.catatan": opsional(k.TempatPenyimpanan.Catatan),
+		"asal_koleksi":               opsional(k.AsalKoleksi),
+		"bahan":                      opsional(k.Bahan),
+		"tempat_perolehan":           opsional(k.TempatPerolehan),
+		"tanggal_perolehan":          opsional(k.TanggalPerolehan),
+		"deskripsi":                  opsional(k.Deskripsi),
+		"kondisi":                    opsional(k.Kondisi),
+		"atribut":                    nil,
+		"ukuran":                     nil,
+		"media":                      nil,
+	}
+	if !k.TempatPenyimpanan.Rak.ID.IsZero() {
+		field["tempat_penyimpanan.rak"] = k.TempatPenyimpanan.Rak
+	}
+	if !k.TempatPenyimpanan.Tahap.ID.IsZero() {
+		field["tempat_penyimpanan.tahap"] = k.TempatPenyimpanan.Tahap
+	}
+	if len(k.Atribut) > 0 {
+		field["atribut"] = k.Atribut
+	}
+	if k.Ukuran != nil {
+		field["ukuran"] = k.Ukuran
+	}
+	if len(k.Media) > 0 {
+		field["media"] = k.Media
+	}
+	return field
+}
+
+// updateFieldKoleksi menyusun $set / $unset untuk menyimpan field form koleksi.
+// Media hanya ikut disimpan jika ada foto baru; selebihnya media dikelola lewat /koleksi/:id/media.
+func updateFieldKoleksi(k model.Koleksi, denganMedia bool, now time.Time) bson.M {
+	setData := bson.M{"updated_at": now}
+	unsetData := bson.M{}
+	for key, nilai := range fieldEditKoleksi(k) {
+		if key == "media" && !denganMedia {
+			continue
+		}
+		if nilai == nil {
+			unsetData[key] = ""
+		} else {
+			setData[key] = nilai
+		}
+	}
+
+	update := bson.M{"$set": setData}
+	if len(unsetData) > 0 {
+		update["$unset"] = unsetData
+	}
+	return tambahVersi(update)
+}
+
+// updateFieldBerubah menyusun $set / $unset hanya untuk field yang berubah
+func updateFieldBerubah(k model.Koleksi, field []string, now time.Time) bson.M {
+	nilai := fieldEditKoleksi(k)
+	setData := bson.M{"updated_at": now}
+	unsetData := bson.M{}
+	for _, key := range field {
+		if nilai[key] == nil {
+			unsetData[key] = ""
+		} else {
+			setData[key] = nilai[key]
+		}
+	}
+
+	update := bson.M{"$set": setData}
+	if len(unsetData) > 0 {
+		update["$unset"] = unsetData
+	}
+	return tambahVersi(update)
+}
+
+// filterVersiKoleksi memilih koleksi aktif yang belum diubah sejak dibaca (versi masih sama).
+// Dipakai agar perubahan tidak menimpa perubahan lain yang terjadi di antaranya.
+func filterVersiKoleksi(id primitive.ObjectID, versi int64) bson.M {
+	return filterKoleksiAktif(filterVersi(id, versi))
+}
+
+// fieldBerubah membandingkan field form koleksi dan mengembalikan nama field yang nilainya berbeda
+func fieldBerubah(lama, baru model.Koleksi) []string {
+	nilaiLama := fieldEditKoleksi(lama)
+	var berubah []string
+	for key, nilai := range fieldEditKoleksi(baru) {
+		a, _ := bson.Marshal(bson.M{"v": nilaiLama[key]})
+		b, _ := bson.Marshal(bson.M{"v": nilai})
+		if !bytes.Equal(a, b) {
+			berubah = append(berubah, key)
+		}
+	}
+	sort.Strings(berubah)
+	return berubah
+}
+
+// catatRiwayatKoleksi menyimpan satu entri riwayat koleksi. Kegagalan hanya dicatat di log.
+func catatRiwayatKoleksi(ctx context.Context, r model.RiwayatKoleksi) {
+	r.ID = primitive.NewObjectID()
+	if r.Tanggal.IsZero() {
+		r.Tanggal = time.Now()
+	}
+	if _, err := config.Ulbimongoconn.Collection("riwayat_koleksi").InsertOne(ctx, r); err != nil {
+		log.Printf("⚠️  Gagal mencatat riwayat koleksi %s: %v", r.KoleksiID.Hex(), err)
+	}
+}
+
+// ajukanPerubahanKoleksi menyimpan tambah / ubah koleksi dari kontributor sebagai usulan yang menunggu review
+func ajukanPerubahanKoleksi(ctx context.Context, c *fiber.Ctx, jenis string, data model.Koleksi, sebelum *model.Koleksi, foto *model.Media, otomatis map[string]bool) error {
+	now := time.Now()
+	pengusul := penggunaLogin(c)
+	u := model.PerubahanKoleksi{
+		ID:           primitive.NewObjectID(),
+		Jenis:        jenis,
+		KoleksiID:    data.ID,
+		NamaBenda:    data.NamaBenda,
+		Data:         data,
+		Sebelum:      sebelum,
+		FotoBaru:     foto,
+		Status:       model.StatusPerubahanMenunggu,
+		DiajukanOleh: pengusul,
+		Riwayat:      []model.RiwayatPerubahan{{Aksi: "diajukan", Oleh: pengusul, Tanggal: now}},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if sebelum != nil {
+		u.Field = fieldBerubah(*sebelum, data)
+	}
+	for field, auto := range otomatis {
+		if auto {
+			u.Otomatis = append(u.Otomatis, field)
+		}
+	}
+
+	// foto usulan (reference count-nya sudah dipegang sejak upload) ditahan sampai usulan disetujui / ditolak
+	if _, err := config.Ulbimongoconn.Collection("perubahan_koleksi").InsertOne(ctx, u); err != nil {
+		if foto != nil {
+			lepasRefMedia(ctx, foto.SemuaKey())
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan usulan perubahan koleksi",
+		})
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message":      "Perubahan disimpan sebagai usulan dan menunggu review",
+		"perubahan_id": u.ID,
+		"data":         u,
+	})
+}
+
+// ambilPerubahanKoleksi membaca satu usulan perubahan dari parameter :id
+func ambilPerubahanKoleksi(ctx context.Context, idParam string) (model.PerubahanKoleksi, int, string) {
+	var u model.PerubahanKoleksi
+	id, err := primitive.ObjectIDFromHex(idParam)
+	if err != nil {
+		return u, fiber.StatusBadRequest, "ID usulan perubahan tidak valid"
+	}
+	if err := config.Ulbimongoconn.Collection("perubahan_koleksi").FindOne(ctx, bson.M{"_id": id}).Decode(&u); err != nil {
+		return u, fiber.StatusNotFound, "Usulan perubahan tidak ditemukan"
+	}
+	return u, 0, ""
+}
+
+// GetAllPerubahanKoleksi godoc
+// @Summary      Get All Usulan Perubahan Koleksi
+// @Description  Mengambil daftar usulan tambah / ubah koleksi dari kontributor, terbaru di depan. Kontributor hanya melihat usulannya sendiri.
+// @Tags         Review Koleksi
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status      query  string  false  "menunggu, diproses, disetujui, ditolak"
+// @Param        jenis       query  string  false  "tambah, ubah"
+// @Param        koleksi_id  query  string  false  "Filter koleksi"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /perubahan [get]
+func GetAllPerubahanKoleksi(c *fiber.Ctx) error {
+	filter := bson.M{}
+	if status := c.Query("status"); status != "" {
+		filter["status"] = status
+	}
+	if jenis := c.Query("jenis"); jenis != "" {
+		filter["jenis"] = jenis
+	}
+	koleksiID, ada, errMsg := idDariQuery(c, "koleksi_id")
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if ada {
+		filter["koleksi_id"] = koleksiID
+	}
+	if perluReview(c) {
+		filter["diajukan_oleh"] = penggunaLogin(c)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.Ulbimongoconn.Collection("perubahan_koleksi").Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil usulan perubahan",
+		})
+	}
+	list := []model.PerubahanKoleksi{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca usulan perubahan",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil usulan perubahan",
+		"total":   len(list),
+		"data":    list,
+	})
+}
+
+// GetPerubahanKoleksiByID godoc
+// @Summary      Get Usulan Perubahan Koleksi By ID
+// @Description  Mengambil satu usulan perubahan beserta data sebelum & sesudah serta daftar field yang berubah
+// @Tags         Review Koleksi
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID usulan perubahan"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Router       /perubahan/{id} [get]
+func GetPerubahanKoleksiByID(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	u, status, errMsg := ambilPerubahanKoleksi(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if perluReview(c) && u.DiajukanOleh != penggunaLogin(c) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Usulan perubahan tidak ditemukan",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil usulan perubahan",
+		"data":    u,
+	})
+}
+
+// EditPerubahanKoleksi godoc
+// @Summary      Edit Usulan Perubahan Koleksi
+// @Description  Reviewer memperbaiki usulan sebelum disetujui. Form sama dengan PUT /koleksi/{id}. Usulan ubah disusun ulang dari data koleksi terbaru; foto baru menggantikan foto usulan.
+// @Tags         Review Koleksi
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id           path      string  true   "ID usulan perubahan"
+// @Param        no_reg       formData  string  true   "Nomor Registrasi"
+// @Param        no_inv       formData  string  true   "Nomor Inventaris"
+// @Param        nama_benda   formData  string  true   "Nama Benda"
+// @Param        kategori_id  formData  string  true   "ID Kategori"
+// @Param        gudang_id    formData  string  true   "ID Gudang"
+// @Param        foto         formData  file    false  "Foto pengganti"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]string
+// @Router       /perubahan/{id} [put]
+func EditPerubahanKoleksi(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	u, status, errMsg := ambilPerubahanKoleksi(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	if u.Status != model.StatusPerubahanMenunggu {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Usulan berstatus " + u.Status + " tidak bisa diedit",
+		})
+	}
+
+	// 🔹 Data dasar: usulan tambah memakai datanya sendiri, usulan ubah memakai koleksi terbaru
+	dasar := u.Data
+	dasar.Media = nil
+	var sebelum *model.Koleksi
+	if u.Jenis == model.JenisPerubahanUbah {
+		var terbaru model.Koleksi
+		if err := config.Ulbimongoconn.Collection("koleksi").FindOne(ctx, bson.M{"_id": u.KoleksiID}).Decode(&terbaru); err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Koleksi tidak ditemukan",
+			})
+		}
+		dasar, sebelum = terbaru, &terbaru
+	}
+
+	baru, foto, status, errMsg := koleksiUbahDariForm(ctx, c, dasar)
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	fotoLama := u.FotoBaru
+	if foto == nil {
+		foto = fotoLama
+	}
+	baru.Media = gabungFotoBaru(dasar.Media, foto)
+
+	var field []string
+	if sebelum != nil {
+		field = fieldBerubah(*sebelum, baru)
+	}
+	// 🔹 Nomor usulan tambah yang diganti manual tidak lagi dibuat otomatis saat disetujui
+	var otomatis []string
+	for _, f := range u.Otomatis {
+		if *nomorKoleksi(&baru, f) == *nomorKoleksi(&dasar, f) {
+			otomatis = append(otomatis, f)
+		}
+	}
+	reviewer := penggunaLogin(c)
+	now := time.Now()
+	err := config.Ulbimongoconn.Collection("perubahan_koleksi").FindOneAndUpdate(ctx,
+		bson.M{"_id": u.ID, "status": model.StatusPerubahanMenunggu},
+		bson.M{
+			"$set": bson.M{
+				"data":       baru,
+				"nama_benda": baru.NamaBenda,
+				"sebelum":    sebelum,
+				"field":      field,
+				"foto_baru":  foto,
+				"otomatis":   otomatis,
+				"reviewer":   reviewer,
+				"updated_at": now,
+			},
+			"$push": bson.M{"riwayat": model.RiwayatPerubahan{Aksi: "diedit", Oleh: reviewer, Tanggal: now}},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&u)
+	if err != nil && foto != fotoLama {
+		// foto pengganti batal dipakai
+		lepasRefMedia(ctx, foto.SemuaKey())
+	}
+	if err == mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Status usulan sudah berubah, muat ulang data",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyimpan usulan perubahan",
+		})
+	}
+
+	// 🔹 Foto pengganti menggantikan foto usulan sebelumnya
+	if foto != fotoLama && fotoLama != nil {
+		lepasRefMedia(ctx, keyMediaKoleksi(*fotoLama))
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Usulan perubahan berhasil diedit",
+		"data":    u,
+	})
+}
+
+// batasKlaimPerubahan adalah lama usulan boleh berstatus diproses. Setelah itu klaim dianggap terputus
+// (proses mati / status akhir gagal disimpan) dan persetujuan berikutnya boleh mengambil alih.
+const batasKlaimPerubahan = 5 * time.Minute
+
+// klaimPerubahanKedaluwarsa mengecek apakah usulan berstatus diproses sudah melewati batasKlaimPerubahan
+func klaimPerubahanKedaluwarsa(u model.PerubahanKoleksi, now time.Time) bool {
+	if u.Status != model.StatusPerubahanDiproses {
+		return false
+	}
+	return u.DiprosesSejak == nil || u.DiprosesSejak.Before(now.Add(-batasKlaimPerubahan))
+}
+
+// SetujuiPerubahanKoleksi godoc
+// @Summary      Setujui Usulan Perubahan Koleksi
+// @Description  Menerapkan usulan ke data koleksi lalu mencatatnya di riwayat koleksi. Usulan ubah ditolak (409) jika koleksi sudah berubah sejak usulan dibuat; edit usulan untuk menyesuaikan dengan data terbaru.
+// @Description  Usulan yang tertahan berstatus diproses lebih dari 5 menit (persetujuan sebelumnya terputus) bisa disetujui ulang; perubahan yang ternyata sudah diterapkan tidak diterapkan dua kali.
+// @Tags         Review Koleksi
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID usulan perubahan"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]string
+// @Router       /perubahan/{id}/setujui [put]
+func SetujuiPerubahanKoleksi(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	u, status, errMsg := ambilPerubahanKoleksi(ctx, c.Params("id"))
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+	reviewer := penggunaLogin(c)
+	now := time.Now().Truncate(time.Millisecond) // presisi tanggal MongoDB, supaya filter diproses_sejak cocok
+	if u.Status != model.StatusPerubahanMenunggu && !klaimPerubahanKedaluwarsa(u, now) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Usulan berstatus " + u.Status + " tidak bisa disetujui",
+		})
+	}
+
+	col := config.Ulbimongoconn.Collection("koleksi")
+	perubahanCol := config.Ulbimongoconn.Collection("perubahan_koleksi")
+
+	// 🔹 Klaim usulan secara atomik supaya tidak bisa diedit / ditolak (dan fotonya dilepas) selama diterapkan.
+	// Klaim diproses yang sudah kedaluwarsa boleh diambil alih. Data yang diterapkan adalah data saat klaim.
+	err := perubahanCol.FindOneAndUpdate(ctx,
+		bson.M{"_id": u.ID, "$or": []bson.M{
+			{"status": model.StatusPerubahanMenunggu},
+			{"status": model.StatusPerubahanDiproses, "diproses_sejak": bson.M{"$lt": now.Add(-batasKlaimPerubahan)}},
+			{"status": model.StatusPerubahanDiproses, "diproses_sejak": bson.M{"$exists": false}},
+		}},
+		bson.M{"$set": bson.M{"status": model.StatusPerubahanDiproses, "diproses_sejak": now, "updated_at": now}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&u)
+	if err == mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Status usulan sudah berubah, muat ulang data",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memproses usulan perubahan",
+		})
+	}
+	diterapkan := false
+	defer func() {
+		if diterapkan {
+			return
+		}
+		// Gagal diterapkan → kembalikan ke menunggu supaya bisa diedit / ditolak / disetujui ulang
+		ctxBatal, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := perubahanCol.UpdateOne(ctxBatal,
+			bson.M{"_id": u.ID, "status": model.StatusPerubahanDiproses, "diproses_sejak": now},
+			bson.M{"$set": bson.M{"status": model.StatusPerubahanMenunggu}, "$unset": bson.M{"diproses_sejak": ""}},
+		); err != nil {
+			log.Printf("⚠️  Gagal mengembalikan status usulan %s ke menunggu: %v", u.ID.Hex(), err)
+		}
+	}()
+
+	// 🔹 Terapkan perubahan. Koleksi yang berubah sejak usulan dibuat tidak ditimpa.
+	// Jika persetujuan sebelumnya terputus setelah koleksi ditulis, penulisan dilewati dan hanya status yang diselesaikan.
+	if u.Jenis == model.JenisPerubahanTambah {
+		otomatis := map[string]bool{}
+		for _, field := range u.Otomatis {
+			otomatis[field] = true
+		}
+		u.Data.CreatedAt = now
+		if err := simpanKoleksiBaru(ctx, &u.Data, otomatis); err != nil && fieldDuplikatKoleksi(err) != "_id" {
+			// Duplikat _id berarti koleksi usulan ini sudah dibuat oleh persetujuan sebelumnya
+			if pesan := pesanDuplikatKoleksi(err); pesan != "" {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+					"error": pesan + " Edit usulan sebelum disetujui.",
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Gagal menyimpan koleksi baru",
+			})
+		}
+	} else {
+		var versi int64
+		if u.Sebelum != nil {
+			versi = u.Sebelum.Versi
+		}
+		filter := filterVersiKoleksi(u.KoleksiID, versi)
+		update := updateFieldKoleksi(u.Data, u.FotoBaru != nil, now)
+		update["$set"].(bson.M)["perubahan_id"] = u.ID
+		res, err := col.UpdateOne(ctx, filter, update)
+		if err != nil {
+			if pesan := pesanDuplikatKoleksi(err); pesan != "" {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+					"error": pesan + " Edit usulan sebelum disetujui.",
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Gagal menerapkan perubahan koleksi",
+			})
+		}
+		if res.MatchedCount == 0 {
+			n, err := col.CountDocuments(ctx, bson.M{"_id": u.KoleksiID, "perubahan_id": u.ID})
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": "Gagal menerapkan perubahan koleksi",
+				})
+			}
+			if n == 0 {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+					"error": "Koleksi sudah berubah, sudah dideaksesi, atau dihapus sejak usulan dibuat. Edit usulan untuk menyesuaikan dengan data terbaru.",
+				})
+			}
+		}
+		catatNomorManual(ctx, u.Data, "no_reg", "no_inv")
+	}
+	diterapkan = true
+
+	res, err := perubahanCol.UpdateOne(ctx,
+		bson.M{"_id": u.ID, "status": model.StatusPerubahanDiproses, "diproses_sejak": now},
+		bson.M{
+			"$set": bson.M{
+				"status":     model.StatusPerubahanDisetujui,
+				"data":       u.Data,
+				"reviewer":   reviewer,
+				"updated_at": now,
+			},
+			"$unset": bson.M{"diproses_sejak": ""},
+			"$push":  bson.M{"riwayat": model.RiwayatPerubahan{Aksi: model.StatusPerubahanDisetujui, Oleh: reviewer, Tanggal: now}},
+		})
+	if err != nil || res.MatchedCount == 0 {
+		// Koleksi sudah berubah; usulan tetap diproses dan bisa disetujui ulang setelah klaim kedaluwarsa
+		log.Printf("⚠️  Perubahan koleksi %s diterapkan tetapi status usulan %s gagal diperbarui: %v", u.KoleksiID.Hex(), u.ID.Hex(), err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Perubahan sudah diterapkan tetapi status usulan gagal diperbarui. Setujui ulang usulan ini setelah 5 menit untuk menyelesaikannya.",
+		})
+	}
+	u.Status = model.StatusPerubahanDisetujui
+	u.Reviewer = reviewer
+
+	catatRiwayatKoleksi(ctx, model.RiwayatKoleksi{
+		KoleksiID:     u.KoleksiID,
+		Aksi:          u.Jenis,
+		Field:         u.Field,
+		Oleh:          u.DiajukanOleh,
+		DisetujuiOleh: reviewer,
+		PerubahanID:   &u.ID,
+		Tanggal:       now,
+	})
+
+	return c.JSON(fiber.Map{
+		"message": "Usulan disetujui dan diterapkan ke data koleksi",
+		"data":    u,
+	})
+}
+
+// TolakPerubahanKoleksi godoc
+// @Summary      Tolak Usulan Perubahan Koleksi
+// @Description  Menolak usulan dengan komentar untuk kontributor. Foto usulan ikut dilepas.
+// @Tags         Review Koleksi
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                       true  "ID usulan perubahan"
+// @Param        request  body  model.TolakPerubahanRequest  true  "Komentar reviewer"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]string
+// @Router       /perubahan/{id}/tolak [put]
+func TolakPerubahanKoleksi(c *fiber.Ctx) error {
+	var req model.TolakPerubahanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Body request tidak valid",
+		})
+	}
+	req.Catatan = strings.TrimSpace(req.Catatan)
+	if req.Catatan == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Komentar penolakan wajib diisi",
+		})
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID usulan perubahan tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	reviewer := penggunaLogin(c)
+	now := time.Now()
+	var u model.PerubahanKoleksi
+	err = config.Ulbimongoconn.Collection("perubahan_koleksi").FindOneAndUpdate(ctx,
+		bson.M{"_id": id, "status": model.StatusPerubahanMenunggu},
+		bson.M{
+			"$set": bson.M{
+				"status":     model.StatusPerubahanDitolak,
+				"reviewer":   reviewer,
+				"catatan":    req.Catatan,
+				"updated_at": now,
+			},
+			"$push": bson.M{"riwayat": model.RiwayatPerubahan{Aksi: model.StatusPerubahanDitolak, Oleh: reviewer, Tanggal: now, Catatan: req.Catatan}},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&u)
+	if err == mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Usulan tidak ditemukan atau sudah direview",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menolak usulan perubahan",
+		})
+	}
+
+	if u.FotoBaru != nil {
+		lepasRefMedia(ctx, keyMediaKoleksi(*u.FotoBaru))
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Usulan perubahan ditolak",
+		"data":    u,
+	})
+}
+
+// GetRiwayatKoleksi godoc
+// @Summary      Riwayat Perubahan Koleksi
+// @Description  Mengambil riwayat tambah / ubah data koleksi, terbaru di depan, termasuk perubahan dari usulan kontributor yang disetujui
+// @Tags         Review Koleksi
+// @Produce      json
+// @Param        id   path  string  true  "ID koleksi"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /koleksi/{id}/riwayat [get]
+func GetRiwayatKoleksi(c *fiber.Ctx) error {
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID koleksi tidak valid",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.Ulbimongoconn.Collection("riwayat_koleksi").Find(ctx,
+		bson.M{"koleksi_id": id},
+		options.Find().SetSort(bson.D{{Key: "tanggal", Value: -1}}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mengambil riwayat koleksi",
+		})
+	}
+	list := []model.RiwayatKoleksi{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membaca riwayat koleksi",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil riwayat koleksi",
+		"total":   len(list),
+		"data":    list,
+	})
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"be-internship/model"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestContributorTidakBisaHapusKoleksi(t *testing.T) {
+	app := fiber.New()
+	app.Delete("/koleksi/:id", JWTAuth, DeleteKoleksiByID)
+
+	id := primitive.NewObjectID()
+	req := httptest.NewRequest(fiber.MethodDelete, "/koleksi/"+id.Hex(), nil)
+	req.Header.Set("Authorization", "Bearer "+tokenUji(t, primitive.NewObjectID(), model.RoleContributor))
+	req.Header.Set("If-Match", `"1"`)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("DELETE /koleksi/%s error: %v", id.Hex(), err)
+	}
+	if resp.StatusCode != fiber.StatusForbidden {
+		t.Errorf("DELETE /koleksi/%s sebagai contributor = %d, want %d", id.Hex(), resp.StatusCode, fiber.StatusForbidden)
+	}
+}
+
+func TestTolakKontributor(t *testing.T) {
+	app := fiber.New()
+	app.Post("/koleksi/:id/media", JWTAuth, TolakKontributor, func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	tests := []struct {
+		role string
+		want int
+	}{
+		{model.RoleContributor, fiber.StatusForbidden},
+		{model.RoleAdmin, fiber.StatusCreated},
+		{model.RoleFinance, fiber.StatusCreated},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(fiber.MethodPost, "/koleksi/"+primitive.NewObjectID().Hex()+"/media", nil)
+		req.Header.Set("Authorization", "Bearer "+tokenUji(t, primitive.NewObjectID(), tt.role))
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("POST media sebagai %s error: %v", tt.role, err)
+		}
+		if resp.StatusCode != tt.want {
+			t.Errorf("POST media sebagai %s = %d, want %d", tt.role, resp.StatusCode, tt.want)
+		}
+	}
+}
+
+func TestKlaimPerubahanKedaluwarsa(t *testing.T) {
+	now := time.Now()
+	baru := now.Add(-time.Minute)
+	lama := now.Add(-batasKlaimPerubahan - time.Second)
+	tests := []struct {
+		nama string
+		u    model.PerubahanKoleksi
+		want bool
+	}{
+		{"menunggu", model.PerubahanKoleksi{Status: model.StatusPerubahanMenunggu}, false},
+		{"disetujui", model.PerubahanKoleksi{Status: model.StatusPerubahanDisetujui, DiprosesSejak: &lama}, false},
+		{"diproses baru", model.PerubahanKoleksi{Status: model.StatusPerubahanDiproses, DiprosesSejak: &baru}, false},
+		{"diproses lama", model.PerubahanKoleksi{Status: model.StatusPerubahanDiproses, DiprosesSejak: &lama}, true},
+		{"diproses tanpa waktu klaim", model.PerubahanKoleksi{Status: model.StatusPerubahanDiproses}, true},
+	}
+	for _, tt := range tests {
+		if got := klaimPerubahanKedaluwarsa(tt.u, now); got != tt.want {
+			t.Errorf("%s: klaimPerubahanKedaluwarsa = %v, want %v", tt.nama, got, tt.want)
+		}
+	}
+}
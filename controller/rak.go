@@ -5,11 +5,13 @@ import (
 	"be-internship/model"
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // InsertRak godoc
@@ -20,11 +22,13 @@ import (
 // @Produce      json
 // @Security     BearerAuth
 // @Param        nama_rak  formData string true "Nama Rak"
+// @Param        kapasitas_slot  formData  int     false  "Jumlah maksimum koleksi (0 = tidak dibatasi)"
+// @Param        berat_maks      formData  number  false  "Beban maksimum dalam kg (0 = tidak dibatasi)"
 // @Success      201 {object} map[string]interface{} "Data Rak berhasil ditambahkan"
 // @Router       /rak [post]
 func InsertRak(c *fiber.Ctx) error {
 	// 🔹 Ambil value dari form-data
-	namaRak := c.FormValue("nama_rak")
+	namaRak := strings.TrimSpace(c.FormValue("nama_rak"))
 	// 🔹 Validasi field wajib
 	if namaRak == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -32,6 +36,14 @@ func InsertRak(c *fiber.Ctx) error {
 		})
 	}
 
+	// 🔹 Kapasitas opsional
+	kapasitasSlot, beratMaks, errMsg := parseKapasitas(c)
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -40,7 +52,7 @@ func InsertRak(c *fiber.Ctx) error {
 	// 🔹 Cek apakah kategori sudah ada berdasarkan nama
 	var existing model.Rak
 	err := rakCollection.FindOne(ctx, bson.M{
-		"nama_rak": namaRak,
+		"nama_normal": model.NormalisasiNama(namaRak),
 	}).Decode(&existing)
 
 	if err == nil {
@@ -51,13 +63,26 @@ func InsertRak(c *fiber.Ctx) error {
 
 	// 🔹 Buat data kategori baru
 	newRak := model.Rak{
-		ID:      primitive.NewObjectID(),
-		NamaRak: namaRak,
+		ID:         primitive.NewObjectID(),
+		NamaRak:    namaRak,
+		NamaNormal: model.NormalisasiNama(namaRak),
+		Versi:      1,
+	}
+	if kapasitasSlot != nil {
+		newRak.KapasitasSlot = *kapasitasSlot
+	}
+	if beratMaks != nil {
+		newRak.BeratMaks = *beratMaks
 	}
 
 	// 🔹 Insert ke database
 	_, err = rakCollection.InsertOne(ctx, newRak)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Data Rak sudah terdaftar",
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Gagal menyimpan data rak ke database",
 		})
@@ -79,7 +104,12 @@ func InsertRak(c *fiber.Ctx) error {
 // @Security     BearerAuth
 // @Param        id        path      string  true  "ID Rak"
 // @Param        nama_rak  formData  string  true  "Nama Rak"
+// @Param        kapasitas_slot  formData  int     false  "Jumlah maksimum koleksi (0 = tidak dibatasi)"
+// @Param        berat_maks      formData  number  false  "Beban maksimum dalam kg (0 = tidak dibatasi)"
 // @Success      200  {object}  map[string]interface{} "Data rak berhasil diperbarui"
+// @Param        If-Match header string true "ETag dari GET terakhir"
+// @Failure      412 {object} map[string]interface{} "Data sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428 {object} map[string]interface{} "Header If-Match belum diisi"
 // @Router       /rak/{id} [put]
 func UpdateRakByID(c *fiber.Ctx) error {
 	// =========================
@@ -96,13 +126,21 @@ func UpdateRakByID(c *fiber.Ctx) error {
 	// =========================
 	// AMBIL FORM DATA
 	// =========================
-	namaRak := c.FormValue("nama_rak")
+	namaRak := strings.TrimSpace(c.FormValue("nama_rak"))
 	if namaRak == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Nama rak tidak boleh kosong",
 		})
 	}
 
+	// 🔹 Kapasitas opsional
+	kapasitasSlot, beratMaks, errMsg := parseKapasitas(c)
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -118,32 +156,69 @@ func UpdateRakByID(c *fiber.Ctx) error {
 			"error": "Data rak tidak ditemukan",
 		})
 	}
+	if ok, err := cekIfMatch(c, existing.Versi); !ok {
+		return err
+	}
+
+	// =========================
+	// CEK NAMA SUDAH DIPAKAI DATA LAIN
+	// =========================
+	var checkName model.Rak
+	err = rakCollection.FindOne(ctx, bson.M{
+		"nama_normal": model.NormalisasiNama(namaRak),
+		"_id":         bson.M{"$ne": objID},
+	}).Decode(&checkName)
+	if err == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Nama rak sudah digunakan data lain",
+		})
+	}
 
 	// =========================
 	// PROSES UPDATE
 	// =========================
+	setData := bson.M{
+		"nama_rak":    namaRak,
+		"nama_normal": model.NormalisasiNama(namaRak),
+	}
+	if kapasitasSlot != nil {
+		setData["kapasitas_slot"] = *kapasitasSlot
+	}
+	if beratMaks != nil {
+		setData["berat_maks"] = *beratMaks
+	}
+
 	update := bson.M{
-		"$set": bson.M{
-			"nama_rak": namaRak,
-		},
+		"$set": setData,
 	}
 
-	_, err = rakCollection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	res, err := rakCollection.UpdateOne(ctx, filterVersi(objID, existing.Versi), tambahVersi(update))
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Nama rak sudah digunakan data lain",
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Gagal memperbarui data rak",
 		})
 	}
+	if res.MatchedCount == 0 {
+		versi, _ := versiDokumen(ctx, rakCollection, objID)
+		return tolakVersiBerubah(c, versi)
+	}
+	c.Set(fiber.HeaderETag, etagVersi(existing.Versi+1))
+
+	// Ambil ulang data setelah update
+	var updated model.Rak
+	rakCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&updated)
 
 	// =========================
 	// RESPONSE
 	// =========================
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Data rak berhasil diperbarui",
-		"data": fiber.Map{
-			"_id":      objID,
-			"nama_rak": namaRak,
-		},
+		"data":    updated,
 	})
 }
 
@@ -194,6 +269,9 @@ func GetAllRak(c *fiber.Ctx) error {
 // @Produce      json
 // @Param        id   path      string  true  "ID Rak"
 // @Success      200  {object}  model.Rak  "Data rak berhasil ditemukan"
+// @Param        If-None-Match header string false "ETag dari respons sebelumnya, 304 jika data belum berubah"
+// @Header       200 {string} ETag "Versi data saat ini"
+// @Success      304 {string} string "Data belum berubah"
 // @Router       /rak/{id} [get]
 func GetRakByID(c *fiber.Ctx) error {
 	// Ambil parameter ID dari URL
@@ -223,7 +301,7 @@ func GetRakByID(c *fiber.Ctx) error {
 	}
 
 	// Return hasil
-	return c.Status(fiber.StatusOK).JSON(rak)
+	return kirimDenganETag(c, rak.Versi, rak)
 }
 
 // DeleteRakByID godoc
@@ -234,6 +312,9 @@ func GetRakByID(c *fiber.Ctx) error {
 // @Produce      json
 // @Param        id   path      string  true  "ID Rak"
 // @Success      200  {object}  map[string]string "Data rak berhasil dihapus"
+// @Param        If-Match header string true "ETag dari GET terakhir"
+// @Failure      412 {object} map[string]interface{} "Data sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428 {object} map[string]interface{} "Header If-Match belum diisi"
 // @Router       /rak/{id} [delete]
 func DeleteRakByID(c *fiber.Ctx) error {
 	// Ambil ID dari parameter
@@ -250,8 +331,18 @@ func DeleteRakByID(c *fiber.Ctx) error {
 
 	rakCollection := config.Ulbimongoconn.Collection("rak")
 
-	// Hapus kategori
-	result, err := rakCollection.DeleteOne(ctx, bson.M{"_id": objID})
+	// 🔹 Hanya hapus jika versi sesuai header If-Match
+	versi, err := versiDokumen(ctx, rakCollection, objID)
+	if err == mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Data rak tidak ditemukan",
+		})
+	}
+	if ok, err := cekIfMatch(c, versi); !ok {
+		return err
+	}
+
+	result, err := rakCollection.DeleteOne(ctx, filterVersi(objID, versi))
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Gagal menghapus data rak",
@@ -259,9 +350,8 @@ func DeleteRakByID(c *fiber.Ctx) error {
 	}
 
 	if result.DeletedCount == 0 {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "Data rak tidak ditemukan",
-		})
+		versi, _ := versiDokumen(ctx, rakCollection, objID)
+		return tolakVersiBerubah(c, versi)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
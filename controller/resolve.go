@@ -0,0 +1,273 @@
+package controller
+
+import (
+	"be-internship/config"
+	"be-internship/model"
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// =============================================================
+// 🔎 Lookup koleksi & tempat penyimpanan dari kode hasil scan label
+// =============================================================
+
+// errNomorDipakai dikembalikan pengecekan manual nomor koleksi selama unique index belum aktif
+type errNomorDipakai struct{ field string }
+
+func (e *errNomorDipakai) Error() string { return e.field + " sudah digunakan" }
+
+// cekNomorDipakai mengecek manual apakah nomor sudah dipakai koleksi lain (selain kecuali).
+// Hanya dijalankan selama unique index nomor belum aktif (lihat config.IndexNomorSiap).
+func cekNomorDipakai(ctx context.Context, field, nomor string, kecuali primitive.ObjectID) error {
+	if nomor == "" || config.IndexNomorSiap(field+"_unik") {
+		return nil
+	}
+	n, err := config.Ulbimongoconn.Collection("koleksi").CountDocuments(ctx,
+		bson.M{field: nomor, "_id": bson.M{"$ne": kecuali}})
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return &errNomorDipakai{field}
+	}
+	return nil
+}
+
+// fieldDuplikatKoleksi mengembalikan field (no_reg / no_inv) yang melanggar unique index, atau "" jika bukan error duplikat
+func fieldDuplikatKoleksi(err error) string {
+	var dipakai *errNomorDipakai
+	if errors.As(err, &dipakai) {
+		return dipakai.field
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return ""
+	}
+	for field := range labelNomor {
+		if strings.Contains(err.Error(), field+"_unik") {
+			return field
+		}
+	}
+	return "_id"
+}
+
+// pesanDuplikatKoleksi menerjemahkan error unique index no_reg / no_inv menjadi pesan untuk user
+func pesanDuplikatKoleksi(err error) string {
+	field := fieldDuplikatKoleksi(err)
+	if label, ok := labelNomor[field]; ok {
+		return label + " sudah digunakan."
+	}
+	if field != "" {
+		return "Data koleksi sudah terdaftar."
+	}
+	return ""
+}
+
+// cariDataResolve mengambil data koleksi / tempat penyimpanan berdasarkan ID
+func cariDataResolve(ctx context.Context, jenis string, id primitive.ObjectID) (interface{}, error) {
+	var data interface{}
+	switch jenis {
+	case "koleksi":
+		data = &model.Koleksi{}
+	case "gudang":
+		data = &model.Gudang{}
+	case "rak":
+		data = &model.Rak{}
+	case "tahap":
+		data = &model.Tahap{}
+	default:
+		return nil, mongo.ErrNoDocuments
+	}
+
+	collection := jenis
+	if lokasi, ok := lokasiLabel[jenis]; ok {
+		collection = lokasi.master.collection
+	}
+	err := config.Ulbimongoconn.Collection(collection).FindOne(ctx, bson.M{"_id": id}).Decode(data)
+	return data, err
+}
+
+// jenisDanIDDariURL membaca URL yang di-encode di QR code label: .../api/<jenis>/<id>
+func jenisDanIDDariURL(kode string) (string, primitive.ObjectID, bool) {
+	u, err := url.Parse(kode)
+	if err != nil || u.Scheme == "" {
+		return "", primitive.NilObjectID, false
+	}
+	bagian := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(bagian) < 2 {
+		return "", primitive.NilObjectID, false
+	}
+	jenis := bagian[len(bagian)-2]
+	id, err := primitive.ObjectIDFromHex(bagian[len(bagian)-1])
+	if err != nil {
+		return "", primitive.NilObjectID, false
+	}
+	if _, ok := lokasiLabel[jenis]; !ok && jenis != "koleksi" {
+		return "", primitive.NilObjectID, false
+	}
+	return jenis, id, true
+}
+
+// jenisDanIDDariKodeLokasi membaca kode lokasi yang dicetak di barcode label (GDG-/RAK-/THP-<id>)
+func jenisDanIDDariKodeLokasi(kode string) (string, primitive.ObjectID, bool) {
+	prefix, hex, ada := strings.Cut(strings.ToUpper(kode), "-")
+	if !ada {
+		return "", primitive.NilObjectID, false
+	}
+	for jenis, lokasi := range lokasiLabel {
+		if lokasi.prefix != prefix {
+			continue
+		}
+		id, err := primitive.ObjectIDFromHex(strings.ToLower(hex))
+		if err != nil {
+			return "", primitive.NilObjectID, false
+		}
+		return jenis, id, true
+	}
+	return "", primitive.NilObjectID, false
+}
+
+// resolveKode mencari data dari kode hasil scan, berurutan:
+// URL QR code, kode lokasi, no_inv / no_reg koleksi, lalu ID koleksi.
+func resolveKode(ctx context.Context, kode string) (model.HasilResolve, int, string) {
+	hasil := model.HasilResolve{Message: "Kode ditemukan", Kode: kode}
+
+	jenis, id, ok := jenisDanIDDariURL(kode)
+	if ok {
+		hasil.Cocok = "url"
+	} else if jenis, id, ok = jenisDanIDDariKodeLokasi(kode); ok {
+		hasil.Cocok = "kode_lokasi"
+	}
+	if ok {
+		data, err := cariDataResolve(ctx, jenis, id)
+		if err != nil {
+			return hasil, fiber.StatusNotFound, "Data untuk kode ini tidak ditemukan"
+		}
+		hasil.Jenis, hasil.ID, hasil.Data = jenis, id.Hex(), data
+		return hasil, 0, ""
+	}
+
+	// 🔹 Nomor inventaris / registrasi koleksi
+	cursor, err := config.Ulbimongoconn.Collection("koleksi").Find(ctx,
+		bson.M{"$or": []bson.M{{"no_inv": kode}, {"no_reg": kode}}},
+		options.Find().SetLimit(2))
+	if err != nil {
+		return hasil, fiber.StatusInternalServerError, "Gagal mencari koleksi"
+	}
+	var koleksi []model.Koleksi
+	if err := cursor.All(ctx, &koleksi); err != nil {
+		return hasil, fiber.StatusInternalServerError, "Gagal membaca data koleksi"
+	}
+	if len(koleksi) > 1 {
+		return hasil, fiber.StatusConflict, "Kode cocok dengan no_inv dan no_reg dari koleksi yang berbeda, gunakan /koleksi/by-inv atau /koleksi/by-reg"
+	}
+	if len(koleksi) == 1 {
+		hasil.Jenis, hasil.ID, hasil.Data = "koleksi", koleksi[0].ID.Hex(), koleksi[0]
+		hasil.Cocok = "no_reg"
+		if koleksi[0].NoInventaris == kode {
+			hasil.Cocok = "no_inv"
+		}
+		return hasil, 0, ""
+	}
+
+	// 🔹 ID koleksi (label koleksi tanpa nomor)
+	if id, err := primitive.ObjectIDFromHex(kode); err == nil {
+		if data, err := cariDataResolve(ctx, "koleksi", id); err == nil {
+			hasil.Jenis, hasil.Cocok, hasil.ID, hasil.Data = "koleksi", "id", id.Hex(), data
+			return hasil, 0, ""
+		}
+	}
+
+	return hasil, fiber.StatusNotFound, "Kode tidak dikenali"
+}
+
+// koleksiByNomor mengambil satu koleksi berdasarkan no_inv / no_reg
+func koleksiByNomor(c *fiber.Ctx, field, label string) error {
+	nomor := strings.TrimSpace(paramPath(c, field))
+	if nomor == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": label + " wajib diisi",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var koleksi model.Koleksi
+	err := config.Ulbimongoconn.Collection("koleksi").FindOne(ctx, bson.M{field: nomor}).Decode(&koleksi)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Koleksi dengan " + label + " " + nomor + " tidak ditemukan",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Berhasil mengambil data koleksi",
+		"data":    koleksi,
+	})
+}
+
+// GetKoleksiByNoInv godoc
+// @Summary      Get Koleksi By No Inventaris
+// @Description  Mengambil satu data koleksi berdasarkan nomor inventaris (misalnya hasil scan barcode label). Karakter "/" pada nomor di-encode menjadi %2F.
+// @Tags         Data Koleksi
+// @Produce      json
+// @Param        no_inv  path  string  true  "Nomor Inventaris"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Router       /koleksi/by-inv/{no_inv} [get]
+func GetKoleksiByNoInv(c *fiber.Ctx) error {
+	return koleksiByNomor(c, "no_inv", "no inventaris")
+}
+
+// GetKoleksiByNoReg godoc
+// @Summary      Get Koleksi By No Registrasi
+// @Description  Mengambil satu data koleksi berdasarkan nomor registrasi. Karakter "/" pada nomor di-encode menjadi %2F.
+// @Tags         Data Koleksi
+// @Produce      json
+// @Param        no_reg  path  string  true  "Nomor Registrasi"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]string
+// @Router       /koleksi/by-reg/{no_reg} [get]
+func GetKoleksiByNoReg(c *fiber.Ctx) error {
+	return koleksiByNomor(c, "no_reg", "no registrasi")
+}
+
+// ResolveKode godoc
+// @Summary      Resolve Kode Label
+// @Description  Mencari koleksi atau tempat penyimpanan dari kode hasil scan label: URL di QR code, kode lokasi (GDG-/RAK-/THP-<id>), no_inv, no_reg, atau ID koleksi. Kode di-encode sebagai path (contoh "/" menjadi %2F).
+// @Tags         Label
+// @Produce      json
+// @Param        code  path  string  true  "Kode hasil scan"
+// @Success      200  {object}  model.HasilResolve
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Router       /resolve/{code} [get]
+func ResolveKode(c *fiber.Ctx) error {
+	kode := strings.TrimSpace(paramPath(c, "code"))
+	if kode == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Kode wajib diisi",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	hasil, status, errMsg := resolveKode(ctx, kode)
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
+	return c.JSON(hasil)
+}
@@ -5,11 +5,13 @@ import (
 	"be-internship/model"
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // InsertTahap godoc
@@ -20,11 +22,13 @@ import (
 // @Produce      json
 // @Security     BearerAuth
 // @Param        nama_tahap  formData  string  true  "Nama Tahap"
+// @Param        kapasitas_slot  formData  int     false  "Jumlah maksimum koleksi (0 = tidak dibatasi)"
+// @Param        berat_maks      formData  number  false  "Beban maksimum dalam kg (0 = tidak dibatasi)"
 // @Success      201  {object}  map[string]interface{}  "Data tahap berhasil ditambahkan"
 // @Router       /tahap [post]
 func InsertTahap(c *fiber.Ctx) error {
 	// 🔹 Ambil value dari form-data
-	namaTahap := c.FormValue("nama_tahap")
+	namaTahap := strings.TrimSpace(c.FormValue("nama_tahap"))
 	// 🔹 Validasi field wajib
 	if namaTahap == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -32,6 +36,14 @@ func InsertTahap(c *fiber.Ctx) error {
 		})
 	}
 
+	// 🔹 Kapasitas opsional
+	kapasitasSlot, beratMaks, errMsg := parseKapasitas(c)
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -40,7 +52,7 @@ func InsertTahap(c *fiber.Ctx) error {
 	// 🔹 Cek apakah kategori sudah ada berdasarkan nama
 	var existing model.Tahap
 	err := tahapCollection.FindOne(ctx, bson.M{
-		"nama_tahap": namaTahap,
+		"nama_normal": model.NormalisasiNama(namaTahap),
 	}).Decode(&existing)
 
 	if err == nil {
@@ -51,13 +63,26 @@ func InsertTahap(c *fiber.Ctx) error {
 
 	// 🔹 Buat data kategori baru
 	newTahap := model.Tahap{
-		ID:        primitive.NewObjectID(),
-		NamaTahap: namaTahap,
+		ID:         primitive.NewObjectID(),
+		NamaTahap:  namaTahap,
+		NamaNormal: model.NormalisasiNama(namaTahap),
+		Versi:      1,
+	}
+	if kapasitasSlot != nil {
+		newTahap.KapasitasSlot = *kapasitasSlot
+	}
+	if beratMaks != nil {
+		newTahap.BeratMaks = *beratMaks
 	}
 
 	// 🔹 Insert ke database
 	_, err = tahapCollection.InsertOne(ctx, newTahap)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Data Tahap sudah terdaftar",
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Gagal menyimpan data tahap ke database",
 		})
@@ -79,7 +104,12 @@ func InsertTahap(c *fiber.Ctx) error {
 // @Security     BearerAuth
 // @Param        id          path      string  true  "ID Tahap"
 // @Param        nama_tahap  formData  string  true  "Nama Tahap"
+// @Param        kapasitas_slot  formData  int     false  "Jumlah maksimum koleksi (0 = tidak dibatasi)"
+// @Param        berat_maks      formData  number  false  "Beban maksimum dalam kg (0 = tidak dibatasi)"
 // @Success      200 {object} map[string]interface{} "Data tahap berhasil diperbarui"
+// @Param        If-Match header string true "ETag dari GET terakhir"
+// @Failure      412 {object} map[string]interface{} "Data sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428 {object} map[string]interface{} "Header If-Match belum diisi"
 // @Router       /tahap/{id} [put]
 func UpdateTahapByID(c *fiber.Ctx) error {
 	// =========================
@@ -96,13 +126,21 @@ func UpdateTahapByID(c *fiber.Ctx) error {
 	// =========================
 	// AMBIL FORM DATA
 	// =========================
-	namaTahap := c.FormValue("nama_tahap")
+	namaTahap := strings.TrimSpace(c.FormValue("nama_tahap"))
 	if namaTahap == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Nama tahap tidak boleh kosong",
 		})
 	}
 
+	// 🔹 Kapasitas opsional
+	kapasitasSlot, beratMaks, errMsg := parseKapasitas(c)
+	if errMsg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errMsg,
+		})
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -118,32 +156,69 @@ func UpdateTahapByID(c *fiber.Ctx) error {
 			"error": "Data tahap tidak ditemukan",
 		})
 	}
+	if ok, err := cekIfMatch(c, existing.Versi); !ok {
+		return err
+	}
+
+	// =========================
+	// CEK NAMA SUDAH DIPAKAI DATA LAIN
+	// =========================
+	var checkName model.Tahap
+	err = tahapCollection.FindOne(ctx, bson.M{
+		"nama_normal": model.NormalisasiNama(namaTahap),
+		"_id":         bson.M{"$ne": objID},
+	}).Decode(&checkName)
+	if err == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Nama tahap sudah digunakan data lain",
+		})
+	}
 
 	// =========================
 	// PROSES UPDATE
 	// =========================
+	setData := bson.M{
+		"nama_tahap":  namaTahap,
+		"nama_normal": model.NormalisasiNama(namaTahap),
+	}
+	if kapasitasSlot != nil {
+		setData["kapasitas_slot"] = *kapasitasSlot
+	}
+	if beratMaks != nil {
+		setData["berat_maks"] = *beratMaks
+	}
+
 	update := bson.M{
-		"$set": bson.M{
-			"nama_tahap": namaTahap,
-		},
+		"$set": setData,
 	}
 
-	_, err = tahapCollection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	res, err := tahapCollection.UpdateOne(ctx, filterVersi(objID, existing.Versi), tambahVersi(update))
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Nama tahap sudah digunakan data lain",
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Gagal memperbarui data tahap",
 		})
 	}
+	if res.MatchedCount == 0 {
+		versi, _ := versiDokumen(ctx, tahapCollection, objID)
+		return tolakVersiBerubah(c, versi)
+	}
+	c.Set(fiber.HeaderETag, etagVersi(existing.Versi+1))
+
+	// Ambil ulang data setelah update
+	var updated model.Tahap
+	tahapCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&updated)
 
 	// =========================
 	// RESPONSE
 	// =========================
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Data tahap berhasil diperbarui",
-		"data": fiber.Map{
-			"_id":        objID,
-			"nama_tahap": namaTahap,
-		},
+		"data":    updated,
 	})
 }
 
@@ -194,6 +269,9 @@ func GetAllTahap(c *fiber.Ctx) error {
 // @Produce      json
 // @Param        id   path      string  true  "ID Tahap"
 // @Success      200  {object}  model.Tahap  "Data tahap berhasil ditemukan"
+// @Param        If-None-Match header string false "ETag dari respons sebelumnya, 304 jika data belum berubah"
+// @Header       200 {string} ETag "Versi data saat ini"
+// @Success      304 {string} string "Data belum berubah"
 // @Router       /tahap/{id} [get]
 func GetTahapByID(c *fiber.Ctx) error {
 	// Ambil parameter ID dari URL
@@ -223,7 +301,7 @@ func GetTahapByID(c *fiber.Ctx) error {
 	}
 
 	// Return hasil
-	return c.Status(fiber.StatusOK).JSON(tahap)
+	return kirimDenganETag(c, tahap.Versi, tahap)
 }
 
 // DeleteTahapByID godoc
@@ -234,6 +312,9 @@ func GetTahapByID(c *fiber.Ctx) error {
 // @Produce      json
 // @Param        id   path      string  true  "ID Tahap"
 // @Success      200  {object}  map[string]string "Data tahap berhasil dihapus"
+// @Param        If-Match header string true "ETag dari GET terakhir"
+// @Failure      412 {object} map[string]interface{} "Data sudah diubah pengguna lain, berisi versi terbaru"
+// @Failure      428 {object} map[string]interface{} "Header If-Match belum diisi"
 // @Router       /tahap/{id} [delete]
 func DeleteTahapByID(c *fiber.Ctx) error {
 	// Ambil ID dari parameter
@@ -250,8 +331,18 @@ func DeleteTahapByID(c *fiber.Ctx) error {
 
 	tahapCollection := config.Ulbimongoconn.Collection("tahap")
 
-	// Hapus kategori
-	result, err := tahapCollection.DeleteOne(ctx, bson.M{"_id": objID})
+	// 🔹 Hanya hapus jika versi sesuai header If-Match
+	versi, err := versiDokumen(ctx, tahapCollection, objID)
+	if err == mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Data tahap tidak ditemukan",
+		})
+	}
+	if ok, err := cekIfMatch(c, versi); !ok {
+		return err
+	}
+
+	result, err := tahapCollection.DeleteOne(ctx, filterVersi(objID, versi))
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Gagal menghapus data tahap",
@@ -259,9 +350,8 @@ func DeleteTahapByID(c *fiber.Ctx) error {
 	}
 
 	if result.DeletedCount == 0 {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "Data tahap tidak ditemukan",
-		})
+		versi, _ := versiDokumen(ctx, tahapCollection, objID)
+		return tolakVersiBerubah(c, versi)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
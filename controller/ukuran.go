@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"be-internship/model"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// =============================================================
+// 📏 Ukuran koleksi: parsing form, validasi satuan & filter rentang
+// =============================================================
+
+// daftarSatuan mengembalikan daftar satuan yang diterima, untuk pesan error
+func daftarSatuan(satuan map[string]float64) string {
+	var list []string
+	for s := range satuan {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool { return satuan[list[i]] < satuan[list[j]] })
+	return strings.Join(list, ", ")
+}
+
+// ukuranDariForm membaca & memvalidasi field ukuran dari form.
+// Mengembalikan nil jika tidak ada dimensi maupun berat yang diisi.
+func ukuranDariForm(c *fiber.Ctx) (*model.Ukuran, string) {
+	return validasiUkuran(func(key string) string { return c.FormValue(key) })
+}
+
+// validasiUkuran memvalidasi field ukuran yang dibaca lewat fungsi nilai (nama field → teks)
+func validasiUkuran(nilaiField func(string) string) (*model.Ukuran, string) {
+	ukuran := &model.Ukuran{
+		Satuan:      strings.ToLower(strings.TrimSpace(nilaiField("satuan"))),
+		SatuanBerat: strings.ToLower(strings.TrimSpace(nilaiField("satuan_berat"))),
+	}
+
+	for _, d := range model.DimensiUkuran {
+		nilai, err := model.ParseAngkaUkuran(nilaiField(d))
+		if err != nil {
+			return nil, "Nilai " + d + " tidak valid: " + err.Error() + "."
+		}
+		*ukuran.Dimensi(d) = nilai
+	}
+	berat, err := model.ParseAngkaUkuran(nilaiField("berat"))
+	if err != nil {
+		return nil, "Nilai berat tidak valid: " + err.Error() + "."
+	}
+	ukuran.Berat = berat
+
+	adaDimensi := ukuran.AdaDimensi()
+
+	// Validasi ukuran dan satuan
+	if adaDimensi && ukuran.Satuan == "" {
+		return nil, "Satuan wajib diisi jika salah satu dimensi ukuran diisi."
+	}
+	// Validasi harus mengisi dimensi jika mengisi satuan
+	if !adaDimensi && ukuran.Satuan != "" {
+		return nil, "Tidak boleh mengisi satuan tanpa mengisi dimensi ukuran."
+	}
+	if _, ok := model.SatuanPanjang[ukuran.Satuan]; adaDimensi && !ok {
+		return nil, "Satuan ukuran tidak valid, gunakan salah satu dari: " + daftarSatuan(model.SatuanPanjang) + "."
+	}
+
+	// Validasi berat dan satuan berat
+	if ukuran.Berat != nil && ukuran.SatuanBerat == "" {
+		return nil, "Satuan berat wajib diisi jika berat diisi."
+	}
+	// Validasi harus mengisi satuan berat jika mengisi berat
+	if ukuran.Berat == nil && ukuran.SatuanBerat != "" {
+		return nil, "Tidak boleh mengisi satuan berat tanpa mengisi berat."
+	}
+	if _, ok := model.SatuanBerat[ukuran.SatuanBerat]; ukuran.Berat != nil && !ok {
+		return nil, "Satuan berat tidak valid, gunakan salah satu dari: " + daftarSatuan(model.SatuanBerat) + "."
+	}
+
+	if ukuran.Kosong() {
+		return nil, ""
+	}
+	ukuran.HitungNormal()
+	return ukuran, ""
+}
+
+// batasRentangUkuran membaca query <nama>_min / <nama>_max dan mengonversinya ke satuan SI.
+// Konversi sama dengan model.Ukuran.HitungNormal supaya batas yang sama dengan nilai tersimpan tetap cocok.
+func batasRentangUkuran(c *fiber.Ctx, nama string, pembagi float64) (bson.M, string) {
+	rentang := bson.M{}
+	for _, batas := range []struct{ akhiran, operator string }{{"_min", "$gte"}, {"_max", "$lte"}} {
+		teks := c.Query(nama + batas.akhiran)
+		if teks == "" {
+			continue
+		}
+		nilai, err := strconv.ParseFloat(strings.ReplaceAll(teks, ",", "."), 64)
+		if err != nil {
+			return nil, fmt.Sprintf("Query %s%s harus berupa angka", nama, batas.akhiran)
+		}
+		rentang[batas.operator] = model.KonversiUkuran(nilai, pembagi)
+	}
+	if len(rentang) == 0 {
+		return nil, ""
+	}
+	return rentang, ""
+}
+
+// filterUkuran menambahkan filter rentang dimensi & berat ke filter koleksi.
+// Contoh: tinggi_min=20&tinggi_max=50&satuan=cm, berat_max=2&satuan_berat=kg
+func filterUkuran(c *fiber.Ctx, filter bson.M) string {
+	satuan := strings.ToLower(c.Query("satuan", "cm"))
+	pembagiPanjang, ok := model.SatuanPanjang[satuan]
+	if !ok {
+		return "Query satuan tidak valid, gunakan salah satu dari: " + daftarSatuan(model.SatuanPanjang)
+	}
+	satuanBerat := strings.ToLower(c.Query("satuan_berat", "kg"))
+	pembagiBerat, ok := model.SatuanBerat[satuanBerat]
+	if !ok {
+		return "Query satuan_berat tidak valid, gunakan salah satu dari: " + daftarSatuan(model.SatuanBerat)
+	}
+
+	for _, d := range model.DimensiUkuran {
+		rentang, errMsg := batasRentangUkuran(c, d, pembagiPanjang)
+		if errMsg != "" {
+			return errMsg
+		}
+		if rentang != nil {
+			filter["ukuran.normal."+d] = rentang
+		}
+	}
+	rentang, errMsg := batasRentangUkuran(c, "berat", pembagiBerat)
+	if errMsg != "" {
+		return errMsg
+	}
+	if rentang != nil {
+		filter["ukuran.normal.berat"] = rentang
+	}
+	return ""
+}
+
+// urutanUkuran membaca query urut (misal "tinggi" atau "-berat") menjadi sort MongoDB
+func urutanUkuran(urut string) (bson.D, string) {
+	arah := 1
+	if strings.HasPrefix(urut, "-") {
+		arah, urut = -1, urut[1:]
+	}
+	for _, d := range append(model.DimensiUkuran, "berat") {
+		if d == urut {
+			return bson.D{{Key: "ukuran.normal." + d, Value: arah}, {Key: "_id", Value: 1}}, ""
+		}
+	}
+	return nil, "Query urut tidak valid, gunakan salah satu dari: " + strings.Join(append(model.DimensiUkuran, "berat"), ", ") + " (awali dengan - untuk urutan menurun)"
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// =============================================================
+// 🔖 Versi dokumen, ETag & request bersyarat (If-Match / If-None-Match)
+// =============================================================
+
+// errVersiBerubah dikembalikan fungsi simpan jika dokumen sudah berubah sejak dibaca
+var errVersiBerubah = errors.New("versi dokumen sudah berubah")
+
+// tambahVersi menambahkan $inc versi ke update agar setiap perubahan menaikkan nomor versi dokumen
+func tambahVersi(update bson.M) bson.M {
+	inc, ok := update["$inc"].(bson.M)
+	if !ok {
+		inc = bson.M{}
+	}
+	inc["versi"] = 1
+	update["$inc"] = inc
+	return update
+}
+
+// filterVersi memilih dokumen dengan versi tertentu. Dokumen lama tanpa field versi dianggap versi 0.
+func filterVersi(id primitive.ObjectID, versi int64) bson.M {
+	if versi == 0 {
+		return bson.M{"_id": id, "versi": bson.M{"$exists": false}}
+	}
+	return bson.M{"_id": id, "versi": versi}
+}
+
+// etagVersi mengubah nomor versi menjadi nilai header ETag
+func etagVersi(versi int64) string {
+	return `"` + strconv.FormatInt(versi, 10) + `"`
+}
+
+// cocokETag memeriksa apakah daftar ETag di header If-Match / If-None-Match memuat versi dokumen.
+// If-Match memakai perbandingan kuat (lemah = false): ETag lemah W/"..." tidak pernah cocok.
+// If-None-Match memakai perbandingan lemah sehingga awalan W/ diabaikan.
+func cocokETag(header string, versi int64, lemah bool) bool {
+	etag := etagVersi(versi)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if strings.HasPrefix(tag, "W/") {
+			if !lemah {
+				continue
+			}
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// kirimDenganETag mengirim data beserta header ETag, atau 304 jika If-None-Match sudah memuat versi ini
+func kirimDenganETag(c *fiber.Ctx, versi int64, data interface{}) error {
+	c.Set(fiber.HeaderETag, etagVersi(versi))
+	if ifNoneMatch := c.Get(fiber.HeaderIfNoneMatch); ifNoneMatch != "" && cocokETag(ifNoneMatch, versi, true) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	return c.Status(fiber.StatusOK).JSON(data)
+}
+
+// cekIfMatch memastikan header If-Match ada dan sama dengan versi dokumen saat ini.
+// Jika tidak, response 428 / 412 langsung dikirim dan ok bernilai false.
+func cekIfMatch(c *fiber.Ctx, versi int64) (bool, error) {
+	ifMatch := c.Get(fiber.HeaderIfMatch)
+	if ifMatch == "" {
+		c.Set(fiber.HeaderETag, etagVersi(versi))
+		return false, c.Status(fiber.StatusPreconditionRequired).JSON(fiber.Map{
+			"error": "Header If-Match wajib diisi dengan ETag dari data terakhir yang dibaca",
+			"versi": versi,
+		})
+	}
+	if !cocokETag(ifMatch, versi, false) {
+		return false, tolakVersiBerubah(c, versi)
+	}
+	return true, nil
+}
+
+// tolakVersiBerubah mengirim 412 beserta versi dokumen saat ini
+func tolakVersiBerubah(c *fiber.Ctx, versi int64) error {
+	c.Set(fiber.HeaderETag, etagVersi(versi))
+	return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{
+		"error": "Data sudah diubah pengguna lain, muat ulang lalu ulangi",
+		"versi": versi,
+	})
+}
+
+// versiDokumen membaca versi terbaru dokumen tanpa mengambil seluruh isinya.
+// Mengembalikan mongo.ErrNoDocuments jika dokumen tidak ada.
+func versiDokumen(ctx context.Context, col *mongo.Collection, id primitive.ObjectID) (int64, error) {
+	var doc struct {
+		Versi int64 `bson:"versi"`
+	}
+	err := col.FindOne(ctx, bson.M{"_id": id}, options.FindOne().SetProjection(bson.M{"versi": 1})).Decode(&doc)
+	return doc.Versi, err
+}
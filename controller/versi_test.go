@@ -0,0 +1,37 @@
+package controller
+
+import "testing"
+
+func TestCocokETag(t *testing.T) {
+	tests := []struct {
+		header string
+		versi  int64
+		lemah  bool
+		want   bool
+	}{
+		{header: `"3"`, versi: 3, want: true},
+		{header: `"3"`, versi: 4, want: false},
+		{header: `"1", "2" ,"3"`, versi: 3, want: true},
+		{header: `*`, versi: 7, want: true},
+		{header: `"0"`, versi: 0, want: true}, // dokumen lama tanpa field versi
+		{header: `3`, versi: 3, want: false},  // ETag wajib dalam tanda kutip
+		{header: ``, versi: 3, want: false},
+
+		// If-Match (perbandingan kuat): ETag lemah tidak pernah cocok
+		{header: `W/"3"`, versi: 3, want: false},
+		{header: `W/"3", "3"`, versi: 3, want: true},
+		{header: `W/"2", W/"3"`, versi: 3, want: false},
+
+		// If-None-Match (perbandingan lemah): awalan W/ diabaikan
+		{header: `W/"3"`, versi: 3, lemah: true, want: true},
+		{header: `"3"`, versi: 3, lemah: true, want: true},
+		{header: `W/"2"`, versi: 3, lemah: true, want: false},
+		{header: `*`, versi: 3, lemah: true, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := cocokETag(tt.header, tt.versi, tt.lemah); got != tt.want {
+			t.Errorf("cocokETag(%q, %d, lemah=%v) = %v, want %v", tt.header, tt.versi, tt.lemah, got, tt.want)
+		}
+	}
+}
@@ -19,69 +19,88 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/gudang": {
+        "/deaksesi": {
             "get": {
-                "description": "Mengambil seluruh data gudang dari database MongoDB",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mengambil daftar usulan deaksesi, terbaru di depan",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Data Tempat Penyimpanan (Gudang)"
+                    "Deaksesi"
+                ],
+                "summary": "Get All Usulan Deaksesi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "diajukan, disetujui, ditolak, dibatalkan, selesai",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter koleksi",
+                        "name": "koleksi_id",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get All Gudang",
                 "responses": {
                     "200": {
-                        "description": "Berhasil mengambil semua data gudang",
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Menambahkan data gudang baru ke dalam sistem",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+            }
+        },
+        "/deaksesi/{id}": {
+            "get": {
+                "description": "Mengambil satu usulan deaksesi beserta persetujuan dan riwayat statusnya",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Data Tempat Penyimpanan (Gudang)"
+                    "Deaksesi"
                 ],
-                "summary": "Insert Gudang",
+                "summary": "Get Usulan Deaksesi By ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Nama Gudang",
-                        "name": "nama_gudang",
-                        "in": "formData",
+                        "description": "ID usulan deaksesi",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Data gudang berhasil ditambahkan",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/gudang/{id}": {
-            "get": {
-                "description": "Mengambil data gudang berdasarkan ID",
+        "/deaksesi/{id}/batal": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membatalkan usulan deaksesi yang belum selesai. Hanya pengusul atau admin yang bisa membatalkan.",
                 "consumes": [
                     "application/json"
                 ],
@@ -89,77 +108,119 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Data Tempat Penyimpanan (Gudang)"
+                    "Deaksesi"
                 ],
-                "summary": "Get Gudang by ID",
+                "summary": "Batalkan Deaksesi",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Gudang",
+                        "description": "ID usulan deaksesi",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Alasan pembatalan",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.CatatanRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data gudang berhasil ditemukan",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Gudang"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
+            }
+        },
+        "/deaksesi/{id}/persetujuan": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui data gudang berdasarkan ID",
+                "description": "Admin menyetujui atau menolak usulan deaksesi. Pengusul tidak bisa menyetujui usulannya sendiri dan setiap admin hanya bisa memberi satu keputusan. Satu penolakan membuat usulan ditolak; usulan disetujui setelah jumlah persetujuan mencapai min_persetujuan.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Data Tempat Penyimpanan (Gudang)"
+                    "Deaksesi"
                 ],
-                "summary": "Update Gudang by ID",
+                "summary": "Persetujuan Deaksesi",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Gudang",
+                        "description": "ID usulan deaksesi",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Nama Gudang",
-                        "name": "nama_gudang",
-                        "in": "formData",
-                        "required": true
+                        "description": "Keputusan: setuju / tolak",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PersetujuanDeaksesiRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data gudang berhasil diperbarui",
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/deaksesi/{id}/selesai": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus data gudang berdasarkan ID",
+                "description": "Mencatat pelepasan koleksi yang deaksesinya sudah disetujui (metode: hibah, pengembalian, pertukaran, penjualan, pemusnahan, dihapuskan). Setelah selesai, data koleksi menjadi hanya-baca dan tidak muncul di laporan inventaris aktif, tetapi riwayatnya tetap tersimpan.",
                 "consumes": [
                     "application/json"
                 ],
@@ -167,41 +228,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Data Tempat Penyimpanan (Gudang)"
+                    "Deaksesi"
                 ],
-                "summary": "Delete Gudang by ID",
+                "summary": "Selesaikan Deaksesi",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Gudang",
+                        "description": "ID usulan deaksesi",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Data pelepasan",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PelepasanDeaksesiRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data gudang berhasil dihapus",
+                        "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/kategori": {
+        "/duplikat": {
             "get": {
-                "description": "Mengambil semua data kategori koleksi",
+                "description": "Mencari kategori, gudang, rak, dan tahap yang namanya sama jika huruf besar/kecil dan spasi diabaikan (kandidat untuk digabung)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Data Kategori"
+                    "Master Data"
                 ],
-                "summary": "Get All Kategori",
+                "summary": "Get Duplikat Master Data",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -211,6 +286,30 @@ const docTemplate = `{
                         }
                     }
                 }
+            }
+        },
+        "/gudang": {
+            "get": {
+                "description": "Mengambil seluruh data gudang dari database MongoDB",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Tempat Penyimpanan (Gudang)"
+                ],
+                "summary": "Get All Gudang",
+                "responses": {
+                    "200": {
+                        "description": "Berhasil mengambil semua data gudang",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
             },
             "post": {
                 "security": [
@@ -218,7 +317,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menambahkan data kategori museum menggunakan form-data (wajib token)",
+                "description": "Menambahkan data gudang baru ke dalam sistem",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -226,27 +325,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Data Kategori"
+                    "Data Tempat Penyimpanan (Gudang)"
                 ],
-                "summary": "Insert Kategori",
+                "summary": "Insert Gudang",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Nama kategori",
-                        "name": "nama_kategori",
+                        "description": "Nama Gudang",
+                        "name": "nama_gudang",
                         "in": "formData",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deskripsi kategori",
-                        "name": "deskripsi",
-                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created",
+                        "description": "Data gudang berhasil ditambahkan",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -255,31 +348,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/kategori/{id}": {
+        "/gudang/{id}": {
             "get": {
-                "description": "Mengambil satu data kategori koleksi berdasarkan ID",
+                "description": "Mengambil data gudang berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Data Kategori"
+                    "Data Tempat Penyimpanan (Gudang)"
                 ],
-                "summary": "Get Kategori by ID",
+                "summary": "Get Gudang by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Kategori",
+                        "description": "ID Gudang",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari respons sebelumnya, 304 jika data belum berubah",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Data gudang berhasil ditemukan",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Gudang"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versi data saat ini"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Data belum berubah",
+                        "schema": {
+                            "type": "string"
                         }
                     }
                 }
@@ -290,7 +403,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengubah data kategori berdasarkan ID. Endpoint ini memerlukan autentikasi JWT Bearer dan menggunakan form-data.",
+                "description": "Memperbarui data gudang berdasarkan ID",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -298,34 +411,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Data Kategori"
+                    "Data Tempat Penyimpanan (Gudang)"
                 ],
-                "summary": "Update Kategori",
+                "summary": "Update Gudang by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Kategori",
+                        "description": "ID Gudang",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Nama kategori",
-                        "name": "nama_kategori",
+                        "description": "Nama Gudang",
+                        "name": "nama_gudang",
                         "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Deskripsi kategori",
-                        "name": "deskripsi",
-                        "in": "formData"
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Kategori berhasil diperbarui",
+                        "description": "Data gudang berhasil diperbarui",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -339,61 +467,68 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus data kategori berdasarkan ID (wajib autentikasi JWT Bearer)",
+                "description": "Menghapus data gudang berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Data Kategori"
+                    "Data Tempat Penyimpanan (Gudang)"
                 ],
-                "summary": "Delete Kategori",
+                "summary": "Delete Gudang by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID kategori",
+                        "description": "ID Gudang",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
                     "200": {
-                        "description": "Kategori berhasil dihapus",
+                        "description": "Data gudang berhasil dihapus",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah pengguna lain, berisi versi terbaru",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
-                    }
-                }
-            }
-        },
-        "/koleksi": {
-            "get": {
-                "description": "Mengambil semua data koleksi museum beserta kategori, tempat penyimpanan, dan ukuran",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Data Koleksi"
-                ],
-                "summary": "Get All Koleksi",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
+            }
+        },
+        "/gudang/{id}/gabung": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menambahkan data koleksi museum baru, termasuk kategori, tempat penyimpanan, ukuran, foto, dan lain-lain",
+                "description": "Menggabungkan gudang duplikat ke gudang tujuan: semua koleksi, data sensor, dan ambang batas dipindahkan ke gudang tujuan, lalu gudang duplikat dihapus",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -401,173 +536,167 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Data Koleksi"
+                    "Data Tempat Penyimpanan (Gudang)"
                 ],
-                "summary": "Insert Koleksi",
+                "summary": "Gabung Gudang",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Nomor Registrasi",
-                        "name": "no_reg",
-                        "in": "formData",
+                        "description": "ID gudang duplikat (akan dihapus)",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Nomor Inventaris",
-                        "name": "no_inv",
+                        "description": "ID gudang tujuan (dipertahankan)",
+                        "name": "target_id",
                         "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Nama Benda",
-                        "name": "nama_benda",
-                        "in": "formData",
+                        "description": "ETag data duplikat dari GET terakhir (tidak diperiksa saat melanjutkan penggabungan)",
+                        "name": "If-Match",
+                        "in": "header",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Deskripsi Koleksi",
-                        "name": "deskripsi",
-                        "in": "formData"
+                    "412": {
+                        "description": "Data duplikat sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/iiif/image/{id}/info.json": {
+            "get": {
+                "description": "Dokumen info.json IIIF Image API 3.0 untuk satu media koleksi (dipakai Mirador / Universal Viewer)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "IIIF"
+                ],
+                "summary": "IIIF Image Information",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Kategori",
-                        "name": "kategori_id",
-                        "in": "formData",
+                        "description": "ID media",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Bahan Benda",
-                        "name": "bahan",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Tempat Perolehan",
-                        "name": "tempat_perolehan",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Tanggal Perolehan (format: DD-MM-YYYY)",
-                        "name": "tanggal_perolehan",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Panjang Keseluruhan (ukuran)",
-                        "name": "panjang_keseluruhan",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Lebar (ukuran)",
-                        "name": "lebar",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Tebal (ukuran)",
-                        "name": "tebal",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Tinggi (ukuran)",
-                        "name": "tinggi",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Diameter (ukuran)",
-                        "name": "diameter",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Satuan ukuran keseluruhan (cm/m/dll)",
-                        "name": "satuan",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Berat (ukuran)",
-                        "name": "berat",
-                        "in": "formData"
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/iiif/image/{id}/{region}/{size}/{rotation}/{quality}.{format}": {
+            "get": {
+                "description": "Mengambil potongan / ukuran / rotasi gambar sesuai IIIF Image API 3.0, termasuk tile untuk zoom",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/webp"
+                ],
+                "tags": [
+                    "IIIF"
+                ],
+                "summary": "IIIF Image Request",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Satuan berat (kg/g/dll)",
-                        "name": "satuan_berat",
-                        "in": "formData"
+                        "description": "ID media",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "ID Gudang",
-                        "name": "gudang_id",
-                        "in": "formData",
+                        "description": "full | square | x,y,w,h | pct:x,y,w,h",
+                        "name": "region",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "ID Rak",
-                        "name": "rak_id",
-                        "in": "formData"
+                        "description": "max | w, | ,h | pct:n | w,h | !w,h (awalan ^ untuk upscale)",
+                        "name": "size",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "ID Tahap",
-                        "name": "tahap_id",
-                        "in": "formData"
+                        "description": "Derajat searah jarum jam, awalan ! untuk mirror",
+                        "name": "rotation",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Asal Koleksi",
-                        "name": "asal_koleksi",
-                        "in": "formData"
+                        "description": "default | color | gray | bitonal",
+                        "name": "quality",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Kondisi Koleksi",
-                        "name": "kondisi",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Upload foto koleksi",
-                        "name": "foto",
-                        "in": "formData"
+                        "description": "jpg | png | webp",
+                        "name": "format",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Koleksi berhasil disimpan",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "file"
                         }
                     }
                 }
             }
         },
-        "/koleksi/{id}": {
+        "/iiif/koleksi/{id}/manifest": {
             "get": {
-                "description": "Mengambil satu data koleksi museum berdasarkan ID MongoDB",
+                "description": "Manifest IIIF Presentation API 3.0 untuk satu koleksi; setiap media menjadi satu canvas dengan layanan IIIF Image API",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Data Koleksi"
+                    "IIIF"
                 ],
-                "summary": "Get Koleksi By ID",
+                "summary": "IIIF Presentation Manifest",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Koleksi",
+                        "description": "ID koleksi",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -582,14 +711,35 @@ const docTemplate = `{
                         }
                     }
                 }
+            }
+        },
+        "/kategori": {
+            "get": {
+                "description": "Mengambil semua data kategori koleksi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Kategori"
+                ],
+                "summary": "Get All Kategori",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui data koleksi museum berdasarkan ID. Semua field bersifat opsional, kecuali \"gudang_id\" wajib diisi. Jika foto diupload, akan mengganti foto lama.",
+                "description": "Menambahkan data kategori museum menggunakan form-data (wajib token)",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -597,159 +747,187 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Data Koleksi"
+                    "Data Kategori"
                 ],
-                "summary": "Update Koleksi",
+                "summary": "Insert Kategori",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Koleksi",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Nama kategori",
+                        "name": "nama_kategori",
+                        "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Nomor Registrasi",
-                        "name": "no_reg",
+                        "description": "Deskripsi kategori",
+                        "name": "deskripsi",
                         "in": "formData"
                     },
                     {
                         "type": "string",
-                        "description": "Nomor Inventaris",
-                        "name": "no_inv",
+                        "description": "ID kategori induk (kosong = kategori utama)",
+                        "name": "parent_id",
                         "in": "formData"
                     },
                     {
                         "type": "string",
-                        "description": "Nama Benda",
-                        "name": "nama_benda",
+                        "description": "Kode singkat kategori untuk penomoran otomatis, contoh: NUM",
+                        "name": "kode",
                         "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Tanggal Perolehan (format: DD-MM-YYYY)",
-                        "name": "tanggal_perolehan",
-                        "in": "formData"
-                    },
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kategori/tree": {
+            "get": {
+                "description": "Mengambil seluruh kategori dalam bentuk pohon (kategori utama beserta sub-kategorinya)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Kategori"
+                ],
+                "summary": "Get Kategori Tree",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kategori/{id}": {
+            "get": {
+                "description": "Mengambil satu data kategori koleksi berdasarkan ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Kategori"
+                ],
+                "summary": "Get Kategori by ID",
+                "parameters": [
                     {
                         "type": "string",
                         "description": "ID Kategori",
-                        "name": "kategori_id",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Bahan Benda",
-                        "name": "bahan",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Panjang Keseluruhan (ukuran)",
-                        "name": "panjang_keseluruhan",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Lebar (ukuran)",
-                        "name": "lebar",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Tebal (ukuran)",
-                        "name": "tebal",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Tinggi (ukuran)",
-                        "name": "tinggi",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Diameter (ukuran)",
-                        "name": "diameter",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Satuan ukuran keseluruhan (cm/m/dll)",
-                        "name": "satuan",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Berat (ukuran)",
-                        "name": "berat",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Satuan berat (kg/g/dll)",
-                        "name": "satuan_berat",
-                        "in": "formData"
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Asal Koleksi",
-                        "name": "asal_koleksi",
-                        "in": "formData"
+                        "description": "ETag dari respons sebelumnya, 304 jika data belum berubah",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versi data saat ini"
+                            }
+                        }
                     },
+                    "304": {
+                        "description": "Data belum berubah",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Tempat Perolehan",
-                        "name": "tempat_perolehan",
-                        "in": "formData"
-                    },
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah data kategori berdasarkan ID. Endpoint ini memerlukan autentikasi JWT Bearer dan menggunakan form-data.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Kategori"
+                ],
+                "summary": "Update Kategori",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Deskripsi Koleksi",
-                        "name": "deskripsi",
-                        "in": "formData"
+                        "description": "ID Kategori",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "ID Gudang",
-                        "name": "gudang_id",
+                        "description": "Nama kategori",
+                        "name": "nama_kategori",
                         "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "ID Rak",
-                        "name": "rak_id",
+                        "description": "Deskripsi kategori",
+                        "name": "deskripsi",
                         "in": "formData"
                     },
                     {
                         "type": "string",
-                        "description": "ID Tahap",
-                        "name": "tahap_id",
+                        "description": "Kode singkat kategori untuk penomoran otomatis (kosong = tidak diubah)",
+                        "name": "kode",
                         "in": "formData"
                     },
                     {
                         "type": "string",
-                        "description": "Kondisi Koleksi",
-                        "name": "kondisi",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Upload foto koleksi",
-                        "name": "foto",
-                        "in": "formData"
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Koleksi berhasil diperbarui",
+                        "description": "Kategori berhasil diperbarui",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
@@ -760,123 +938,159 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus data koleksi berdasarkan ID (wajib autentikasi JWT Bearer)",
+                "description": "Menghapus data kategori berdasarkan ID (wajib autentikasi JWT Bearer)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Data Koleksi"
+                    "Data Kategori"
                 ],
-                "summary": "Delete Koleksi by ID",
+                "summary": "Delete Kategori",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID koleksi",
+                        "description": "ID kategori",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
-                "responses": {}
+                "responses": {
+                    "200": {
+                        "description": "Kategori berhasil dihapus",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
             }
         },
-        "/rak": {
+        "/kategori/{id}/atribut": {
             "get": {
-                "description": "Mengambil seluruh data rak dari database MongoDB.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Mengambil skema atribut tambahan yang berlaku untuk koleksi pada kategori ini (termasuk atribut warisan dari kategori induk). Dipakai front end untuk membuat form dinamis.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Data Tempat Penyimpanan (Rak)"
+                    "Data Kategori"
+                ],
+                "summary": "Get Skema Atribut Kategori",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Kategori",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get All Rak",
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetAllRakResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menambahkan data rak baru ke dalam sistem.",
+                "description": "Mengganti daftar atribut tambahan milik kategori ini. Tipe yang didukung: teks, angka, tanggal (YYYY-MM-DD), boolean, pilihan.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Data Tempat Penyimpanan (Rak)"
+                    "Data Kategori"
                 ],
-                "summary": "Insert Rak",
+                "summary": "Set Skema Atribut Kategori",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Nama Rak",
-                        "name": "nama_rak",
-                        "in": "formData",
+                        "description": "ID Kategori",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Data Rak berhasil ditambahkan",
+                    },
+                    {
+                        "description": "Skema atribut",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.SkemaAtributRequest"
                         }
-                    }
-                }
-            }
-        },
-        "/rak/{id}": {
-            "get": {
-                "description": "Mengambil data rak berdasarkan ID rak",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Data Tempat Penyimpanan (Rak)"
-                ],
-                "summary": "Get Rak by ID",
-                "parameters": [
+                    },
                     {
                         "type": "string",
-                        "description": "ID Rak",
-                        "name": "id",
-                        "in": "path",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data rak berhasil ditemukan",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Rak"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/kategori/{id}/gabung": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui data rak berdasarkan ID rak",
+                "description": "Menggabungkan kategori duplikat ke kategori tujuan: semua koleksi dan sub-kategori dipindahkan ke kategori tujuan, lalu kategori duplikat dihapus.\nAtribut koleksi dan usulan yang menunggu review diperiksa ulang terhadap skema kategori tujuan; jika ada yang tidak sesuai, penggabungan ditolak (409) beserta daftarnya.\nPenggabungan yang gagal di tengah jalan dapat dilanjutkan dengan mengirim ulang permintaan yang sama.",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -884,98 +1098,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Data Tempat Penyimpanan (Rak)"
+                    "Data Kategori"
                 ],
-                "summary": "Update Rak",
+                "summary": "Gabung Kategori",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Rak",
+                        "description": "ID kategori duplikat (akan dihapus)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Nama Rak",
-                        "name": "nama_rak",
+                        "description": "ID kategori tujuan (dipertahankan)",
+                        "name": "target_id",
                         "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag data duplikat dari GET terakhir (tidak diperiksa saat melanjutkan penggabungan)",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data rak berhasil diperbarui",
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Menghapus data rak berdasarkan ID rak",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Data Tempat Penyimpanan (Rak)"
-                ],
-                "summary": "Delete Rak by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID Rak",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Data rak berhasil dihapus",
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
-                    }
-                }
-            }
-        },
-        "/tahap": {
-            "get": {
-                "description": "Mengambil seluruh data tahap penyimpanan dari database MongoDB.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Data Tempat Penyimpanan (Tahap)"
-                ],
-                "summary": "Get All Tahap",
-                "responses": {
-                    "200": {
-                        "description": "Berhasil mengambil data tahap",
+                    },
+                    "412": {
+                        "description": "Data duplikat sudah diubah pengguna lain, berisi versi terbaru",
                         "schema": {
-                            "$ref": "#/definitions/model.GetAllTahapResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/kategori/{id}/pindah": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menambahkan data tahap penyimpanan baru ke dalam sistem.",
+                "description": "Memindahkan kategori (beserta seluruh sub-kategorinya) ke kategori induk lain. Kategori tidak boleh dipindahkan ke dirinya sendiri atau ke sub-kategorinya. Kategori dan path sub-kategori diubah dalam satu transaksi (MongoDB replica set).",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -983,21 +1171,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Data Tempat Penyimpanan (Tahap)"
+                    "Data Kategori"
                 ],
-                "summary": "Insert Tahap",
+                "summary": "Pindah Kategori",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Nama Tahap",
-                        "name": "nama_tahap",
-                        "in": "formData",
+                        "description": "ID Kategori",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID kategori induk baru (kosong = jadikan kategori utama)",
+                        "name": "parent_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Data tahap berhasil ditambahkan",
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1006,44 +1221,113 @@ const docTemplate = `{
                 }
             }
         },
-        "/tahap/{id}": {
+        "/koleksi": {
             "get": {
-                "description": "Mengambil data tahap penyimpanan berdasarkan ID tahap",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mengambil semua data koleksi museum beserta kategori, tempat penyimpanan, dan ukuran",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Data Tempat Penyimpanan (Tahap)"
+                    "Data Koleksi"
                 ],
-                "summary": "Get Tahap by ID",
+                "summary": "Get All Koleksi",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Tahap",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter kategori (termasuk seluruh sub-kategorinya)",
+                        "name": "kategori_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter metode perolehan: hibah, pembelian, temuan, titipan",
+                        "name": "metode_perolehan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter tahun perolehan",
+                        "name": "tahun_perolehan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status lokasi: tersimpan, dipinjamkan",
+                        "name": "status_lokasi",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sertakan koleksi yang sudah dideaksesi (default false)",
+                        "name": "termasuk_deaksesi",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status deaksesi: diajukan, disetujui, selesai",
+                        "name": "deaksesi",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Tinggi minimum (juga tersedia panjang_keseluruhan_, lebar_, tebal_, diameter_ dengan akhiran _min / _max)",
+                        "name": "tinggi_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Tinggi maksimum",
+                        "name": "tinggi_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Satuan untuk filter dimensi: mm, cm, m (default cm)",
+                        "name": "satuan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Berat minimum",
+                        "name": "berat_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Berat maksimum",
+                        "name": "berat_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Satuan untuk filter berat: g, kg (default kg)",
+                        "name": "satuan_berat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Urutkan berdasarkan ukuran: panjang_keseluruhan, lebar, tebal, tinggi, diameter, berat (awali - untuk menurun)",
+                        "name": "urut",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data tahap berhasil ditemukan",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Tahap"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui data tahap penyimpanan berdasarkan ID tahap",
+                "description": "Menambahkan data koleksi museum baru, termasuk kategori, tempat penyimpanan, ukuran, foto, dan lain-lain",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -1051,59 +1335,244 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Data Tempat Penyimpanan (Tahap)"
+                    "Data Koleksi"
                 ],
-                "summary": "Update Tahap",
+                "summary": "Insert Koleksi",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Tahap",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Nomor Registrasi (kosongkan untuk dibuat otomatis sesuai skema penomoran)",
+                        "name": "no_reg",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nomor Inventaris (kosongkan untuk dibuat otomatis sesuai skema penomoran)",
+                        "name": "no_inv",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nama Benda",
+                        "name": "nama_benda",
+                        "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Nama Tahap",
-                        "name": "nama_tahap",
+                        "description": "Deskripsi Koleksi",
+                        "name": "deskripsi",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID Kategori",
+                        "name": "kategori_id",
                         "in": "formData",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Data tahap berhasil diperbarui",
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bahan Benda",
+                        "name": "bahan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tempat Perolehan",
+                        "name": "tempat_perolehan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal Perolehan (format: DD-MM-YYYY)",
+                        "name": "tanggal_perolehan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Panjang Keseluruhan (ukuran)",
+                        "name": "panjang_keseluruhan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Lebar (ukuran)",
+                        "name": "lebar",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Tebal (ukuran)",
+                        "name": "tebal",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Tinggi (ukuran)",
+                        "name": "tinggi",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Diameter (ukuran)",
+                        "name": "diameter",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Satuan ukuran: mm, cm, m",
+                        "name": "satuan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Berat (ukuran)",
+                        "name": "berat",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Satuan berat: g, kg",
+                        "name": "satuan_berat",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID Gudang",
+                        "name": "gudang_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID Rak",
+                        "name": "rak_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID Tahap",
+                        "name": "tahap_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asal Koleksi",
+                        "name": "asal_koleksi",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kondisi Koleksi: baik, cukup, rusak_ringan, rusak_berat, kritis",
+                        "name": "kondisi",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Atribut tambahan sesuai skema kategori (JSON object)",
+                        "name": "atribut",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data perolehan terstruktur (JSON object model.Perolehan)",
+                        "name": "perolehan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Upload foto koleksi",
+                        "name": "foto",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Koleksi berhasil disimpan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "202": {
+                        "description": "Pengguna ber-role contributor: data disimpan sebagai usulan yang menunggu review",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Menghapus data tahap penyimpanan berdasarkan ID tahap",
-                "consumes": [
+            }
+        },
+        "/koleksi/by-inv/{no_inv}": {
+            "get": {
+                "description": "Mengambil satu data koleksi berdasarkan nomor inventaris (misalnya hasil scan barcode label). Karakter \"/\" pada nomor di-encode menjadi %2F.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Data Koleksi"
+                ],
+                "summary": "Get Koleksi By No Inventaris",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nomor Inventaris",
+                        "name": "no_inv",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/by-reg/{no_reg}": {
+            "get": {
+                "description": "Mengambil satu data koleksi berdasarkan nomor registrasi. Karakter \"/\" pada nomor di-encode menjadi %2F.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Data Tempat Penyimpanan (Tahap)"
+                    "Data Koleksi"
                 ],
-                "summary": "Delete Tahap by ID",
+                "summary": "Get Koleksi By No Registrasi",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Tahap",
-                        "name": "id",
+                        "description": "Nomor Registrasi",
+                        "name": "no_reg",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data tahap berhasil dihapus",
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1114,78 +1583,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/koleksi/nomor-ganda": {
             "get": {
-                "description": "Endpoint untuk mengambil seluruh data admin yang tersimpan di sistem",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Menampilkan status unique index no_reg / no_inv dan nomor yang dipakai lebih dari satu koleksi.\nSelama index belum aktif, keunikan nomor dicek manual sebelum koleksi disimpan.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Penomoran"
                 ],
-                "summary": "Get All Users",
+                "summary": "Get Nomor Ganda Koleksi",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.GetAllUsersResponse"
+                            "$ref": "#/definitions/model.LaporanNomorGanda"
                         }
-                    },
-                    "400": {
-                        "description": "Bad Request"
-                    },
-                    "500": {
-                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/users/login": {
+        "/koleksi/nomor-ganda/perbaiki": {
             "post": {
-                "description": "Login user dan dapatkan token JWT untuk autentikasi.",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Membereskan nomor ganda agar unique index no_reg / no_inv bisa dibuat: koleksi yang paling lama dibuat mempertahankan nomornya,\nkoleksi lain diberi akhiran -D2, -D3, dst. (tercatat di riwayat koleksi) untuk dicek ulang terhadap label fisik.\nSetelah itu index dibuat ulang. Gunakan dry_run=true untuk melihat nomor pengganti tanpa menyimpan.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Penomoran"
                 ],
-                "summary": "Login",
+                "summary": "Perbaiki Nomor Ganda Koleksi",
                 "parameters": [
                     {
-                        "description": "Data login user",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.LoginRequest"
-                        }
+                        "type": "boolean",
+                        "description": "Hanya tampilkan rencana perubahan",
+                        "name": "dry_run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.LoginResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Kredensial tidak valid",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "$ref": "#/definitions/model.LaporanNomorGanda"
                         }
                     }
                 }
             }
         },
-        "/users/register": {
+        "/koleksi/ubah-massal": {
             "post": {
-                "description": "Registrasi akun admin.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah kategori, lokasi (gudang / rak / tahap), kondisi, dan atribut tambahan banyak koleksi sekaligus.\nKoleksi dipilih dengan daftar ` + "`" + `ids` + "`" + ` atau ` + "`" + `filter` + "`" + ` (kategori termasuk sub-kategori, gudang, rak, tahap, kondisi; koleksi yang sudah dideaksesi dilewati).\nAtribut digabung dengan atribut lama (nilai null menghapus atribut) lalu divalidasi per koleksi terhadap skema kategori.\nGunakan ` + "`" + `dry_run: true` + "`" + ` untuk melihat jumlah koleksi yang terdampak dan hasil validasi tanpa menyimpan perubahan.\nKoleksi yang gagal validasi tidak menghalangi koleksi lain. Setiap koleksi yang berubah mendapat satu entri riwayat dengan ` + "`" + `massal_id` + "`" + ` yang sama.\nKontributor tidak dapat melakukan ubah massal.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1193,105 +1656,91 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Data Koleksi"
                 ],
-                "summary": "Register",
+                "summary": "Ubah massal koleksi",
                 "parameters": [
                     {
-                        "description": "Payload Body [RAW]",
-                        "name": "request",
+                        "description": "Pilihan koleksi \u0026 perubahan",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.RegisterRequest"
+                            "$ref": "#/definitions/model.UbahMassalRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
+                    "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.RegisterResponse"
+                            "$ref": "#/definitions/model.LaporanUbahMassal"
                         }
                     },
-                    "401": {
-                        "description": "Username already exists",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponseRegister"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/users/username/{username}": {
+        "/koleksi/{id}": {
             "get": {
-                "description": "Mengambil data detail user berdasarkan username tertentu",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mengambil satu data koleksi museum berdasarkan ID MongoDB",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Data Koleksi"
                 ],
-                "summary": "Get User by Username",
+                "summary": "Get Koleksi By ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Username",
-                        "name": "username",
+                        "description": "ID Koleksi",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.GetUserByUsernameResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request"
-                    },
-                    "404": {
-                        "description": "User tidak ditemukan",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error"
-                    }
-                }
-            }
-        },
-        "/users/{id}": {
-            "get": {
-                "description": "Mengambil data user berdasarkan ID MongoDB",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Get User by ID",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "ETag dari respons sebelumnya, 304 jika data belum berubah",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User berhasil ditampilkan",
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versi data saat ini"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Data belum berubah",
+                        "schema": {
+                            "type": "string"
                         }
                     }
                 }
@@ -1302,7 +1751,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui data user berdasarkan ID (wajib autentikasi JWT Bearer)",
+                "description": "Memperbarui data koleksi museum berdasarkan ID. Semua field bersifat opsional, kecuali \"gudang_id\" wajib diisi. Jika foto diupload, foto ditambahkan sebagai media utama (media lama tetap disimpan).",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -1310,37 +1759,196 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Data Koleksi"
                 ],
-                "summary": "Update User",
+                "summary": "Update Koleksi",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID user",
+                        "description": "ID Koleksi",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Username (huruf kecil semua)",
-                        "name": "username",
+                        "description": "Nomor Registrasi",
+                        "name": "no_reg",
                         "in": "formData"
                     },
                     {
                         "type": "string",
-                        "description": "Nomor telepon format 62xxxxxxxx",
-                        "name": "phone_number",
+                        "description": "Nomor Inventaris",
+                        "name": "no_inv",
                         "in": "formData"
                     },
                     {
                         "type": "string",
-                        "description": "Password baru",
-                        "name": "password",
+                        "description": "Nama Benda",
+                        "name": "nama_benda",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal Perolehan (format: DD-MM-YYYY)",
+                        "name": "tanggal_perolehan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID Kategori",
+                        "name": "kategori_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bahan Benda",
+                        "name": "bahan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Panjang Keseluruhan (ukuran)",
+                        "name": "panjang_keseluruhan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Lebar (ukuran)",
+                        "name": "lebar",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Tebal (ukuran)",
+                        "name": "tebal",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Tinggi (ukuran)",
+                        "name": "tinggi",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Diameter (ukuran)",
+                        "name": "diameter",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Satuan ukuran: mm, cm, m",
+                        "name": "satuan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Berat (ukuran)",
+                        "name": "berat",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Satuan berat: g, kg",
+                        "name": "satuan_berat",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asal Koleksi",
+                        "name": "asal_koleksi",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tempat Perolehan",
+                        "name": "tempat_perolehan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deskripsi Koleksi",
+                        "name": "deskripsi",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID Gudang",
+                        "name": "gudang_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID Rak",
+                        "name": "rak_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID Tahap",
+                        "name": "tahap_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kondisi Koleksi: baik, cukup, rusak_ringan, rusak_berat, kritis",
+                        "name": "kondisi",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Atribut tambahan sesuai skema kategori (JSON object)",
+                        "name": "atribut",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Upload foto koleksi",
+                        "name": "foto",
                         "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
-                "responses": {}
+                "responses": {
+                    "200": {
+                        "description": "Koleksi berhasil diperbarui",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "202": {
+                        "description": "Pengguna ber-role contributor: perubahan disimpan sebagai usulan yang menunggu review",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
             },
             "delete": {
                 "security": [
@@ -1348,180 +1956,5809 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus data user berdasarkan ID (wajib autentikasi JWT Bearer)",
+                "description": "Menghapus data koleksi berdasarkan ID (wajib autentikasi JWT Bearer). Koleksi yang punya usulan deaksesi tidak bisa dihapus agar jejak auditnya tetap ada.\nKoleksi yang sedang dipinjamkan, terjadwal di peminjaman keluar yang disetujui, atau di pameran yang sedang / akan berlangsung juga ditolak (409).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Data Koleksi"
                 ],
-                "summary": "Delete User",
+                "summary": "Delete Koleksi by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID user",
+                        "description": "ID koleksi",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "User berhasil dihapus",
+                    "403": {
+                        "description": "Kontributor tidak boleh menghapus koleksi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Koleksi punya usulan deaksesi, sedang dipinjamkan, atau terjadwal",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "model.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah hanya field yang dikirim, field lain tidak tersentuh.\nContent-Type ` + "`" + `application/merge-patch+json` + "`" + ` (atau ` + "`" + `application/json` + "`" + `): JSON Merge Patch, nilai null mengosongkan field opsional, object ` + "`" + `atribut` + "`" + ` \u0026 ` + "`" + `ukuran` + "`" + ` digabung per field.\nContent-Type ` + "`" + `application/json-patch+json` + "`" + `: array operasi JSON Patch (add / remove / replace / move / copy / test) dengan path seperti ` + "`" + `/deskripsi` + "`" + ` atau ` + "`" + `/atribut/nominal` + "`" + `.\nValidasi hanya dijalankan untuk field yang berubah (mis. atribut divalidasi ulang jika atribut atau kategori berubah). Media dikelola lewat /koleksi/{id}/media.\nPengguna ber-role contributor: perubahan disimpan sebagai usulan yang menunggu review.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Koleksi"
+                ],
+                "summary": "Ubah sebagian data koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET /koleksi/{id}",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Field yang diubah (merge patch) atau array model.OperasiJSONPatch (JSON Patch)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PatchKoleksi"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Koleksi berhasil diperbarui",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "202": {
+                        "description": "Usulan perubahan menunggu review",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Operasi test JSON Patch gagal",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Koleksi sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/deaksesi": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengajukan deaksesi (pengeluaran permanen) koleksi dengan alasan dan dokumen pendukung. Alasan: rusak_total, duplikat, tidak_relevan, repatriasi, hilang, lainnya. Usulan membutuhkan persetujuan beberapa admin (DEAKSESI_MIN_PERSETUJUAN, default 2) selain pengusul.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Deaksesi"
+                ],
+                "summary": "Usulkan Deaksesi Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Usulan deaksesi",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UsulanDeaksesiRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/kondisi": {
+            "get": {
+                "description": "Mengambil seluruh riwayat pemeriksaan kondisi koleksi, terbaru di depan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kondisi Koleksi"
+                ],
+                "summary": "Get Laporan Kondisi Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencatat hasil pemeriksaan kondisi koleksi dengan skala baku (baik, cukup, rusak_ringan, rusak_berat, kritis), daftar kerusakan, pemeriksa, dan foto. Laporan dengan tanggal terbaru diringkas ke field kondisi_terakhir pada koleksi.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kondisi Koleksi"
+                ],
+                "summary": "Tambah Laporan Kondisi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Skala kondisi: baik, cukup, rusak_ringan, rusak_berat, kritis",
+                        "name": "kondisi",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nama pemeriksa",
+                        "name": "pemeriksa",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal pemeriksaan (YYYY-MM-DD), default hari ini",
+                        "name": "tanggal",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON array kerusakan, contoh: [{\\",
+                        "name": "kerusakan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rekomendasi tindakan",
+                        "name": "rekomendasi",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Catatan",
+                        "name": "catatan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Perlu perawatan konservasi (default true untuk rusak_ringan ke atas)",
+                        "name": "perlu_perawatan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Foto kondisi / kerusakan (boleh lebih dari satu)",
+                        "name": "foto",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/kondisi/{laporan_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus satu laporan kondisi beserta fotonya; ringkasan kondisi koleksi disusun ulang dari laporan yang tersisa",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kondisi Koleksi"
+                ],
+                "summary": "Delete Laporan Kondisi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID laporan kondisi",
+                        "name": "laporan_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/media": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan satu foto / lampiran (foto kondisi, X-ray, dokumen scan) ke koleksi tanpa mengirim ulang seluruh form koleksi",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media Koleksi"
+                ],
+                "summary": "Tambah Media Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File gambar (JPEG, PNG, GIF, WebP) atau lampiran (PDF, TIFF, BMP, TXT, ZIP). Metadata EXIF gambar dibuang dan rendisi thumbnail / medium / large (JPEG \u0026 WebP) dibuat otomatis; GIF dan lampiran disimpan tanpa diubah.",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipe media: foto (default), kondisi, xray, dokumen, lainnya",
+                        "name": "tipe",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keterangan / caption",
+                        "name": "keterangan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nama fotografer",
+                        "name": "fotografer",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal pengambilan (YYYY-MM-DD)",
+                        "name": "tanggal",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Jadikan media utama",
+                        "name": "utama",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/media/urutan": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengatur ulang urutan media. media_ids wajib berisi seluruh ID media koleksi dengan urutan yang baru.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media Koleksi"
+                ],
+                "summary": "Urutkan Media Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Urutan media",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UrutanMediaRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/media/{media_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus satu media dari koleksi. File di storage ikut dihapus (atau diarsipkan jika MEDIA_ARSIP=true) bila tidak dipakai koleksi lain. Jika media utama dihapus, media pertama menjadi utama.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media Koleksi"
+                ],
+                "summary": "Delete Media Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID media",
+                        "name": "media_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/media/{media_id}/utama": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menjadikan satu media sebagai media utama (thumbnail) koleksi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media Koleksi"
+                ],
+                "summary": "Set Media Utama Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID media",
+                        "name": "media_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/media/{media_id}/verifikasi": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membaca ulang file media dari storage dan membandingkan hash SHA-256-nya dengan hash yang tercatat saat upload",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media Koleksi"
+                ],
+                "summary": "Verifikasi Integritas Media",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID media",
+                        "name": "media_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.VerifikasiMedia"
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/pameran": {
+            "get": {
+                "description": "Daftar pameran yang pernah / sedang / akan menampilkan koleksi ini, terbaru di depan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pameran"
+                ],
+                "summary": "Riwayat Pameran Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/penilaian": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil riwayat penilaian koleksi, terbaru di depan (hanya admin \u0026 finance)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Penilaian Koleksi"
+                ],
+                "summary": "Get Penilaian Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencatat nilai koleksi pada tanggal tertentu (hanya admin \u0026 finance). Metode: pasar, penggantian, ahli, perolehan, lainnya. Tujuan: asuransi, peminjaman, laporan_aset, lainnya.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Penilaian Koleksi"
+                ],
+                "summary": "Tambah Penilaian Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data penilaian",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PenilaianRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/penilaian/{penilaian_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus satu entri penilaian yang salah input (hanya admin \u0026 finance)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Penilaian Koleksi"
+                ],
+                "summary": "Delete Penilaian Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID penilaian",
+                        "name": "penilaian_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/perawatan": {
+            "get": {
+                "description": "Mengambil log perawatan konservasi koleksi, terbaru di depan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kondisi Koleksi"
+                ],
+                "summary": "Get Perawatan Konservasi Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencatat tindakan konservasi pada koleksi. Tanpa tanggal_selesai, perawatan dianggap masih berjalan; tandai selesai lewat endpoint selesai.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kondisi Koleksi"
+                ],
+                "summary": "Tambah Perawatan Konservasi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tindakan konservasi",
+                        "name": "tindakan",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nama konservator",
+                        "name": "konservator",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID laporan kondisi yang ditindaklanjuti",
+                        "name": "laporan_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bahan yang dipakai, pisahkan dengan koma",
+                        "name": "bahan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal mulai (YYYY-MM-DD), default hari ini",
+                        "name": "tanggal_mulai",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal selesai (YYYY-MM-DD)",
+                        "name": "tanggal_selesai",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Catatan",
+                        "name": "catatan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Foto sebelum perawatan (boleh lebih dari satu)",
+                        "name": "foto_sebelum",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Foto sesudah perawatan (boleh lebih dari satu)",
+                        "name": "foto_sesudah",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/perawatan/{perawatan_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus satu catatan perawatan beserta foto sebelum \u0026 sesudahnya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kondisi Koleksi"
+                ],
+                "summary": "Delete Perawatan Konservasi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID perawatan",
+                        "name": "perawatan_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/perawatan/{perawatan_id}/selesai": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menandai perawatan selesai dan menambahkan foto sesudah perawatan. Laporan kondisi yang sudah ditindaklanjuti tidak lagi masuk daftar perlu perawatan.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kondisi Koleksi"
+                ],
+                "summary": "Selesaikan Perawatan Konservasi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID perawatan",
+                        "name": "perawatan_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal selesai (YYYY-MM-DD), default hari ini",
+                        "name": "tanggal_selesai",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Catatan hasil perawatan (menggantikan catatan lama jika diisi)",
+                        "name": "catatan",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Foto sesudah perawatan (boleh lebih dari satu)",
+                        "name": "foto_sesudah",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/perolehan": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengisi / mengganti data perolehan terstruktur: metode (hibah, pembelian, temuan, titipan), tanggal (DD-MM-YYYY, MM-YYYY, YYYY, atau rentang \"1990 s/d 1995\"), sumber, harga \u0026 mata uang, dan dokumen pendukung. Field tanggal_mulai, tanggal_selesai, dan tahun diisi server.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Perolehan Koleksi"
+                ],
+                "summary": "Set Perolehan Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data perolehan",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Perolehan"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/provenans": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan satu pemilik / peristiwa ke riwayat provenans koleksi. Entri baru ditambahkan di akhir (paling baru), gunakan endpoint urutan untuk menyusun ulang.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Perolehan Koleksi"
+                ],
+                "summary": "Tambah Provenans Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Entri provenans",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Provenans"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/provenans/urutan": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menyusun ulang riwayat provenans secara kronologis (paling lama di depan). provenans_ids harus berisi seluruh entri.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Perolehan Koleksi"
+                ],
+                "summary": "Urutkan Provenans Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Urutan ID provenans",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UrutanProvenansRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/provenans/{provenans_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengganti isi satu entri provenans tanpa mengubah urutannya",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Perolehan Koleksi"
+                ],
+                "summary": "Update Provenans Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID entri provenans",
+                        "name": "provenans_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Entri provenans",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Provenans"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus satu entri dari riwayat provenans koleksi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Perolehan Koleksi"
+                ],
+                "summary": "Delete Provenans Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID entri provenans",
+                        "name": "provenans_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/koleksi/{id}/riwayat": {
+            "get": {
+                "description": "Mengambil riwayat tambah / ubah data koleksi, terbaru di depan, termasuk perubahan dari usulan kontributor yang disetujui",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review Koleksi"
+                ],
+                "summary": "Riwayat Perubahan Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kondisi/perlu-perawatan": {
+            "get": {
+                "description": "Daftar koleksi yang pemeriksaan kondisi terakhirnya memerlukan perawatan dan belum ditindaklanjuti, diurutkan dari kondisi terparah lalu pemeriksaan terlama",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kondisi Koleksi"
+                ],
+                "summary": "Get Koleksi Perlu Perawatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hanya kondisi minimal ini, misal rusak_berat",
+                        "name": "kondisi_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true = hanya yang sedang dirawat, false = hanya yang belum dirawat",
+                        "name": "berjalan",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/label/format": {
+            "get": {
+                "description": "Menampilkan format kertas label siap cetak yang didukung endpoint lembar label",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Label"
+                ],
+                "summary": "Get Format Kertas Label",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.FormatLabel"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/label/lembar": {
+            "get": {
+                "description": "Membuat PDF lembar label siap cetak untuk sekumpulan koleksi (misalnya semua koleksi di satu rak) atau tempat penyimpanan. Label disusun sesuai format kertas label standar (lihat /label/format).",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Label"
+                ],
+                "summary": "Get Lembar Label PDF",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Jenis data: koleksi (default), gudang, rak, tahap",
+                        "name": "jenis",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Daftar ID dipisah koma",
+                        "name": "ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter koleksi berdasarkan gudang",
+                        "name": "gudang_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter koleksi berdasarkan rak",
+                        "name": "rak_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter koleksi berdasarkan tahap",
+                        "name": "tahap_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter koleksi berdasarkan kategori (termasuk sub-kategori)",
+                        "name": "kategori_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format kertas label (default a4-21)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kode yang dicetak: qr, barcode, keduanya (default keduanya)",
+                        "name": "kode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Posisi label pertama di lembar (mulai 1), untuk memakai sisa lembar label",
+                        "name": "mulai",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Cetak garis tepi label (untuk kertas biasa)",
+                        "name": "garis",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/label/{jenis}/{id}/{kode}.png": {
+            "get": {
+                "description": "Membuat gambar PNG QR code (berisi URL data) atau barcode Code128 (berisi no_inv untuk koleksi, atau kode lokasi GDG-/RAK-/THP-\u003cid\u003e)",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "Label"
+                ],
+                "summary": "Get Gambar QR Code / Barcode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Jenis data: koleksi, gudang, rak, tahap",
+                        "name": "jenis",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID data",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Jenis kode: qr atau barcode",
+                        "name": "kode",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lebar gambar dalam pixel (default 300)",
+                        "name": "ukuran",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/laporan/nilai-koleksi": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Total nilai koleksi aktif per kategori atau gudang, memakai penilaian terakhir setiap koleksi sampai per_tanggal. Total dipisah per mata uang (hanya admin \u0026 finance).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Penilaian Koleksi"
+                ],
+                "summary": "Laporan Nilai Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kategori (default) atau gudang",
+                        "name": "kelompok",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal acuan (YYYY-MM-DD), default hari ini",
+                        "name": "per_tanggal",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LaporanNilaiKoleksi"
+                        }
+                    }
+                }
+            }
+        },
+        "/laporan/penilaian-kedaluwarsa": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Daftar koleksi aktif yang penilaian terakhirnya lebih tua dari batas umur (default PENILAIAN_MAKS_TAHUN atau 5 tahun), penilaian paling lama di depan (hanya admin \u0026 finance).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Penilaian Koleksi"
+                ],
+                "summary": "Koleksi dengan Penilaian Kedaluwarsa",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Umur maksimal penilaian dalam tahun",
+                        "name": "tahun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sertakan koleksi yang belum pernah dinilai",
+                        "name": "termasuk_belum_dinilai",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter kategori (termasuk sub-kategori)",
+                        "name": "kategori_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter gudang",
+                        "name": "gudang_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LaporanPenilaianKedaluwarsa"
+                        }
+                    }
+                }
+            }
+        },
+        "/laporan/perolehan": {
+            "get": {
+                "description": "Rekap jumlah koleksi dan total harga per metode \u0026 tahun perolehan. Koleksi tanpa data perolehan terstruktur dihitung di belum_terstruktur.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Perolehan Koleksi"
+                ],
+                "summary": "Get Laporan Perolehan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter metode perolehan",
+                        "name": "metode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tahun perolehan awal",
+                        "name": "tahun_dari",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tahun perolehan akhir",
+                        "name": "tahun_sampai",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LaporanPerolehan"
+                        }
+                    }
+                }
+            }
+        },
+        "/lingkungan/ambang": {
+            "get": {
+                "description": "Mengambil seluruh konfigurasi ambang batas suhu \u0026 kelembapan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Monitoring Lingkungan"
+                ],
+                "summary": "Get All Ambang Batas Lingkungan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Gudang",
+                        "name": "gudang_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengatur batas suhu \u0026 kelembapan untuk satu gudang atau satu rak di gudang tersebut. Ambang rak lebih diutamakan daripada ambang gudang.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Monitoring Lingkungan"
+                ],
+                "summary": "Set Ambang Batas Lingkungan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Gudang",
+                        "name": "gudang_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID Rak (kosong = berlaku untuk seluruh gudang)",
+                        "name": "rak_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Suhu minimum (°C)",
+                        "name": "suhu_min",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Suhu maksimum (°C)",
+                        "name": "suhu_max",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Kelembapan minimum (%)",
+                        "name": "kelembapan_min",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Kelembapan maksimum (%)",
+                        "name": "kelembapan_max",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/lingkungan/ambang/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus konfigurasi ambang batas berdasarkan ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Monitoring Lingkungan"
+                ],
+                "summary": "Delete Ambang Batas Lingkungan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID ambang batas",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/lingkungan/pembacaan": {
+            "get": {
+                "description": "Mengambil data mentah suhu \u0026 kelembapan dalam rentang waktu (default 24 jam terakhir)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Monitoring Lingkungan"
+                ],
+                "summary": "Get Pembacaan Sensor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Gudang",
+                        "name": "gudang_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID Rak",
+                        "name": "rak_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waktu awal (RFC3339 / YYYY-MM-DD)",
+                        "name": "dari",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waktu akhir (RFC3339 / YYYY-MM-DD)",
+                        "name": "sampai",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data maksimum (default 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Menerima data suhu \u0026 kelembapan dari sensor (satu objek atau array). Autentikasi memakai header X-API-Key. Pembacaan di luar ambang batas otomatis membuat peringatan; selama peringatan untuk lokasi \u0026 parameter yang sama belum diselesaikan, pembacaan berikutnya hanya memperbarui nilai terakhirnya.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Monitoring Lingkungan"
+                ],
+                "summary": "Ingest Pembacaan Sensor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key sensor",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data pembacaan sensor",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PembacaanSensorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/lingkungan/peringatan": {
+            "get": {
+                "description": "Mengambil peringatan suhu / kelembapan di luar ambang batas, terbaru lebih dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Monitoring Lingkungan"
+                ],
+                "summary": "Get Peringatan Lingkungan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Gudang",
+                        "name": "gudang_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID Rak",
+                        "name": "rak_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Hanya peringatan yang belum diselesaikan",
+                        "name": "aktif",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/lingkungan/peringatan/{id}/selesai": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menandai peringatan lingkungan sudah ditangani",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Monitoring Lingkungan"
+                ],
+                "summary": "Selesaikan Peringatan Lingkungan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID peringatan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/lingkungan/statistik": {
+            "get": {
+                "description": "Menghitung min/max/rata-rata suhu \u0026 kelembapan dalam rentang waktu. Gunakan interval=jam atau interval=hari untuk mengelompokkan hasil.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Monitoring Lingkungan"
+                ],
+                "summary": "Get Statistik Lingkungan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Gudang",
+                        "name": "gudang_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID Rak",
+                        "name": "rak_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waktu awal (RFC3339 / YYYY-MM-DD)",
+                        "name": "dari",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waktu akhir (RFC3339 / YYYY-MM-DD)",
+                        "name": "sampai",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pengelompokan: jam / hari (kosong = satu ringkasan)",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/media/yatim": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan file media di storage yang tidak dipakai koleksi mana pun (dry run garbage collection)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media Koleksi"
+                ],
+                "summary": "Get Media Yatim",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Hanya file yang sudah yatim minimal sekian jam (default 24)",
+                        "name": "umur_jam",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LaporanGCMedia"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus file media yang tidak dipakai koleksi mana pun dari storage (atau memindahkannya ke folder arsip jika MEDIA_ARSIP=true). Khusus admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media Koleksi"
+                ],
+                "summary": "Hapus Media Yatim",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Hanya file yang sudah yatim minimal sekian jam (default 24, harus \u003e 0)",
+                        "name": "umur_jam",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LaporanGCMedia"
+                        }
+                    }
+                }
+            }
+        },
+        "/okupansi": {
+            "get": {
+                "description": "Menghitung jumlah koleksi dan total berat (kg) per gudang, rak, dan tahap, lalu menandai lokasi yang penuh atau melebihi kapasitas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Okupansi"
+                ],
+                "summary": "Get Okupansi Tempat Penyimpanan",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Hanya tampilkan lokasi yang penuh / melebihi kapasitas",
+                        "name": "hanya_penuh",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OkupansiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pameran": {
+            "get": {
+                "description": "Mengambil daftar pameran, terbaru di depan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pameran"
+                ],
+                "summary": "Get All Pameran",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "true = hanya pameran yang sedang berlangsung hari ini",
+                        "name": "aktif",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hanya pameran yang berlangsung pada tanggal ini (YYYY-MM-DD)",
+                        "name": "tanggal",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat pameran baru. Kosongkan tanggal_selesai untuk pameran tetap. Koleksi ditambahkan lewat endpoint /pameran/{id}/koleksi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pameran"
+                ],
+                "summary": "Insert Pameran",
+                "parameters": [
+                    {
+                        "description": "Data pameran",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PameranRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pameran/{id}": {
+            "get": {
+                "description": "Mengambil satu pameran beserta daftar koleksinya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pameran"
+                ],
+                "summary": "Get Pameran By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID pameran",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah data utama pameran. Jika periode berubah, seluruh koleksi pameran diperiksa ulang terhadap pameran \u0026 peminjaman lain.\nDitolak (409) jika daftar koleksi pameran berubah selama pemeriksaan; muat ulang lalu kirim lagi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pameran"
+                ],
+                "summary": "Update Pameran",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID pameran",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data pameran",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PameranRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus pameran beserta daftar penempatan koleksinya (data koleksi tidak ikut terhapus)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pameran"
+                ],
+                "summary": "Delete Pameran",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID pameran",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pameran/{id}/checklist": {
+            "get": {
+                "description": "Daftar koleksi pameran beserta lokasi penyimpanannya, diurutkan per gudang, rak, dan tahap untuk memudahkan pengambilan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pameran"
+                ],
+                "summary": "Checklist Pameran",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID pameran",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChecklistPameran"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pameran/{id}/koleksi": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menempatkan satu atau beberapa koleksi di pameran. Ditolak (409) jika koleksi sudah terjadwal di pameran lain atau peminjaman keluar (disetujui / dikirim) pada periode yang beririsan; daftar bentrok dikembalikan di field bentrok.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pameran"
+                ],
+                "summary": "Tambah Koleksi ke Pameran",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID pameran",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Koleksi yang ditambahkan",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TambahKoleksiPameranRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/pameran/{id}/koleksi/{koleksi_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengeluarkan satu koleksi dari daftar pameran",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pameran"
+                ],
+                "summary": "Hapus Koleksi dari Pameran",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID pameran",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID koleksi",
+                        "name": "koleksi_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/peminjaman": {
+            "get": {
+                "description": "Mengambil daftar peminjaman, terbaru di depan. Field terlambat bernilai true jika benda sudah dikirim dan tanggal_selesai sudah lewat.\nNilai asuransi hanya ditampilkan untuk admin / finance (kirim token Bearer).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Peminjaman"
+                ],
+                "summary": "Get All Peminjaman",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "keluar / masuk",
+                        "name": "arah",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "diajukan, disetujui, dikirim, dikembalikan, dibatalkan",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hanya peminjaman yang memuat koleksi ini",
+                        "name": "koleksi_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true = hanya peminjaman yang terlambat dikembalikan",
+                        "name": "terlambat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat perjanjian pinjam baru dengan status diajukan. Arah keluar = koleksi museum dipinjamkan ke institusi lain (koleksi_id wajib), arah masuk = museum meminjam benda dari institusi lain. Untuk peminjaman keluar, nilai asuransi per benda yang kosong diisi dari penilaian terakhir koleksi (mata uang yang sama). Nilai asuransi total dijumlahkan dari nilai per benda jika tidak diisi. Peminjaman keluar ditolak (409) jika koleksi sudah terjadwal di pameran atau peminjaman keluar lain pada periode yang sama; jadwal dicek ulang saat disetujui / dikirim.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Peminjaman"
+                ],
+                "summary": "Insert Peminjaman",
+                "parameters": [
+                    {
+                        "description": "Data peminjaman",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PeminjamanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/peminjaman/{id}": {
+            "get": {
+                "description": "Mengambil satu perjanjian pinjam beserta riwayat statusnya. Nilai asuransi hanya ditampilkan untuk admin / finance (kirim token Bearer).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Peminjaman"
+                ],
+                "summary": "Get Peminjaman By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID peminjaman",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah isi perjanjian pinjam. Hanya bisa selama status masih diajukan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Peminjaman"
+                ],
+                "summary": "Update Peminjaman",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID peminjaman",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data peminjaman",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PeminjamanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus peminjaman yang masih diajukan atau sudah dibatalkan. Peminjaman yang pernah disetujui / dikirim disimpan sebagai arsip.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Peminjaman"
+                ],
+                "summary": "Delete Peminjaman",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID peminjaman",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/peminjaman/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memindahkan status peminjaman: diajukan → disetujui → dikirim → dikembalikan (atau dibatalkan sebelum dikirim). Saat peminjaman keluar dikirim, status_lokasi koleksi menjadi dipinjamkan; saat dikembalikan, status lokasi dikosongkan lagi. Saat disetujui / dikirim, koleksi tidak boleh terjadwal di peminjaman keluar lain atau pameran pada periode yang sama, dan tidak boleh dalam proses deaksesi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Peminjaman"
+                ],
+                "summary": "Ubah Status Peminjaman",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID peminjaman",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status baru",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StatusPeminjamanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/penomoran": {
+            "get": {
+                "description": "Menampilkan pola penomoran otomatis no_reg dan no_inv yang sedang dipakai",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Penomoran"
+                ],
+                "summary": "Get Skema Penomoran",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SkemaPenomoran"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/penomoran/{field}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengatur pola penomoran otomatis untuk no_reg / no_inv. Token: {kategori_code} (kode kategori), {year}, {month}, {seq} atau {seq:04}. Nomor yang diisi manual saat insert/update koleksi harus sesuai pola ini.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Penomoran"
+                ],
+                "summary": "Set Skema Penomoran",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "no_reg atau no_inv",
+                        "name": "field",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pola penomoran",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SkemaPenomoranRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SkemaPenomoran"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mematikan penomoran otomatis untuk no_reg / no_inv (nomor kembali wajib diisi manual). Counter tidak dihapus.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Penomoran"
+                ],
+                "summary": "Delete Skema Penomoran",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "no_reg atau no_inv",
+                        "name": "field",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/penomoran/{field}/berikutnya": {
+            "get": {
+                "description": "Menampilkan no_reg / no_inv yang akan dipakai jika field dikosongkan saat insert koleksi. Nomor belum dipesan, sehingga bisa berubah jika ada koleksi lain yang disimpan lebih dulu.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Penomoran"
+                ],
+                "summary": "Preview Nomor Berikutnya",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "no_reg atau no_inv",
+                        "name": "field",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID kategori (wajib jika pola memakai {kategori_code})",
+                        "name": "kategori_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PreviewNomor"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/perubahan": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil daftar usulan tambah / ubah koleksi dari kontributor, terbaru di depan. Kontributor hanya melihat usulannya sendiri.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review Koleksi"
+                ],
+                "summary": "Get All Usulan Perubahan Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "menunggu, diproses, disetujui, ditolak",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "tambah, ubah",
+                        "name": "jenis",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter koleksi",
+                        "name": "koleksi_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/perubahan/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil satu usulan perubahan beserta data sebelum \u0026 sesudah serta daftar field yang berubah",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review Koleksi"
+                ],
+                "summary": "Get Usulan Perubahan Koleksi By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID usulan perubahan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reviewer memperbaiki usulan sebelum disetujui. Form sama dengan PUT /koleksi/{id}. Usulan ubah disusun ulang dari data koleksi terbaru; foto baru menggantikan foto usulan.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review Koleksi"
+                ],
+                "summary": "Edit Usulan Perubahan Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID usulan perubahan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nomor Registrasi",
+                        "name": "no_reg",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nomor Inventaris",
+                        "name": "no_inv",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nama Benda",
+                        "name": "nama_benda",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID Kategori",
+                        "name": "kategori_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID Gudang",
+                        "name": "gudang_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Foto pengganti",
+                        "name": "foto",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/perubahan/{id}/setujui": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menerapkan usulan ke data koleksi lalu mencatatnya di riwayat koleksi. Usulan ubah ditolak (409) jika koleksi sudah berubah sejak usulan dibuat; edit usulan untuk menyesuaikan dengan data terbaru.\nUsulan yang tertahan berstatus diproses lebih dari 5 menit (persetujuan sebelumnya terputus) bisa disetujui ulang; perubahan yang ternyata sudah diterapkan tidak diterapkan dua kali.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review Koleksi"
+                ],
+                "summary": "Setujui Usulan Perubahan Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID usulan perubahan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/perubahan/{id}/tolak": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menolak usulan dengan komentar untuk kontributor. Foto usulan ikut dilepas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review Koleksi"
+                ],
+                "summary": "Tolak Usulan Perubahan Koleksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID usulan perubahan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Komentar reviewer",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TolakPerubahanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/rak": {
+            "get": {
+                "description": "Mengambil seluruh data rak dari database MongoDB.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Tempat Penyimpanan (Rak)"
+                ],
+                "summary": "Get All Rak",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetAllRakResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan data rak baru ke dalam sistem.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Tempat Penyimpanan (Rak)"
+                ],
+                "summary": "Insert Rak",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nama Rak",
+                        "name": "nama_rak",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah maksimum koleksi (0 = tidak dibatasi)",
+                        "name": "kapasitas_slot",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Beban maksimum dalam kg (0 = tidak dibatasi)",
+                        "name": "berat_maks",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Data Rak berhasil ditambahkan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/rak/{id}": {
+            "get": {
+                "description": "Mengambil data rak berdasarkan ID rak",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Tempat Penyimpanan (Rak)"
+                ],
+                "summary": "Get Rak by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Rak",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari respons sebelumnya, 304 jika data belum berubah",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data rak berhasil ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.Rak"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versi data saat ini"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Data belum berubah",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memperbarui data rak berdasarkan ID rak",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Tempat Penyimpanan (Rak)"
+                ],
+                "summary": "Update Rak",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Rak",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nama Rak",
+                        "name": "nama_rak",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah maksimum koleksi (0 = tidak dibatasi)",
+                        "name": "kapasitas_slot",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Beban maksimum dalam kg (0 = tidak dibatasi)",
+                        "name": "berat_maks",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data rak berhasil diperbarui",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Menghapus data rak berdasarkan ID rak",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Tempat Penyimpanan (Rak)"
+                ],
+                "summary": "Delete Rak by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Rak",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data rak berhasil dihapus",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/rak/{id}/gabung": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menggabungkan rak duplikat ke rak tujuan: semua koleksi, data sensor, dan ambang batas dipindahkan ke rak tujuan, lalu rak duplikat dihapus",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Tempat Penyimpanan (Rak)"
+                ],
+                "summary": "Gabung Rak",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID rak duplikat (akan dihapus)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID rak tujuan (dipertahankan)",
+                        "name": "target_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag data duplikat dari GET terakhir (tidak diperiksa saat melanjutkan penggabungan)",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Data duplikat sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/resolve/{code}": {
+            "get": {
+                "description": "Mencari koleksi atau tempat penyimpanan dari kode hasil scan label: URL di QR code, kode lokasi (GDG-/RAK-/THP-\u003cid\u003e), no_inv, no_reg, atau ID koleksi. Kode di-encode sebagai path (contoh \"/\" menjadi %2F).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Label"
+                ],
+                "summary": "Resolve Kode Label",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kode hasil scan",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.HasilResolve"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tahap": {
+            "get": {
+                "description": "Mengambil seluruh data tahap penyimpanan dari database MongoDB.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Tempat Penyimpanan (Tahap)"
+                ],
+                "summary": "Get All Tahap",
+                "responses": {
+                    "200": {
+                        "description": "Berhasil mengambil data tahap",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetAllTahapResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan data tahap penyimpanan baru ke dalam sistem.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Tempat Penyimpanan (Tahap)"
+                ],
+                "summary": "Insert Tahap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nama Tahap",
+                        "name": "nama_tahap",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah maksimum koleksi (0 = tidak dibatasi)",
+                        "name": "kapasitas_slot",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Beban maksimum dalam kg (0 = tidak dibatasi)",
+                        "name": "berat_maks",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Data tahap berhasil ditambahkan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/tahap/{id}": {
+            "get": {
+                "description": "Mengambil data tahap penyimpanan berdasarkan ID tahap",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Tempat Penyimpanan (Tahap)"
+                ],
+                "summary": "Get Tahap by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Tahap",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari respons sebelumnya, 304 jika data belum berubah",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data tahap berhasil ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.Tahap"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versi data saat ini"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Data belum berubah",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memperbarui data tahap penyimpanan berdasarkan ID tahap",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Tempat Penyimpanan (Tahap)"
+                ],
+                "summary": "Update Tahap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Tahap",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nama Tahap",
+                        "name": "nama_tahap",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah maksimum koleksi (0 = tidak dibatasi)",
+                        "name": "kapasitas_slot",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Beban maksimum dalam kg (0 = tidak dibatasi)",
+                        "name": "berat_maks",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data tahap berhasil diperbarui",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Menghapus data tahap penyimpanan berdasarkan ID tahap",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Tempat Penyimpanan (Tahap)"
+                ],
+                "summary": "Delete Tahap by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Tahap",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET terakhir",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data tahap berhasil dihapus",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Data sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/tahap/{id}/gabung": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menggabungkan tahap duplikat ke tahap tujuan: semua koleksi dipindahkan ke tahap tujuan, lalu tahap duplikat dihapus",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Data Tempat Penyimpanan (Tahap)"
+                ],
+                "summary": "Gabung Tahap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID tahap duplikat (akan dihapus)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID tahap tujuan (dipertahankan)",
+                        "name": "target_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag data duplikat dari GET terakhir (tidak diperiksa saat melanjutkan penggabungan)",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Data duplikat sudah diubah pengguna lain, berisi versi terbaru",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Header If-Match belum diisi",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Endpoint untuk mengambil seluruh data admin yang tersimpan di sistem",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get All Users",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetAllUsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/login": {
+            "post": {
+                "description": "Login user dan dapatkan token JWT untuk autentikasi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login",
+                "parameters": [
+                    {
+                        "description": "Data login user",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LoginResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Kredensial tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/register": {
+            "post": {
+                "description": "Registrasi akun baru. Akun baru selalu ber-role contributor; role lain diberikan admin lewat PUT /users/{id}/role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Register",
+                "parameters": [
+                    {
+                        "description": "Payload Body [RAW]",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RegisterResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Username already exists",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponseRegister"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/username/{username}": {
+            "get": {
+                "description": "Mengambil data detail user berdasarkan username tertentu",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get User by Username",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GetUserByUsernameResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "404": {
+                        "description": "User tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Mengambil data user berdasarkan ID MongoDB",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get User by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User berhasil ditampilkan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memperbarui data user berdasarkan ID (wajib autentikasi JWT Bearer)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID user",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Username (huruf kecil semua)",
+                        "name": "username",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nomor telepon format 62xxxxxxxx",
+                        "name": "phone_number",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Password baru",
+                        "name": "password",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {}
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus data user berdasarkan ID (wajib autentikasi JWT Bearer)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID user",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User berhasil dihapus",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah role user (khusus admin). Role yang didukung: admin, contributor, finance.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Ubah Role User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID user",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role baru (admin, contributor, finance)",
+                        "name": "role",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role user berhasil diubah",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Role tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Bukan admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "User tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.AtributKategori": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "example": "Nominal"
+                },
+                "nama": {
+                    "description": "key di koleksi.atribut",
+                    "type": "string",
+                    "example": "nominal"
+                },
+                "pilihan": {
+                    "description": "nilai yang diizinkan untuk tipe pilihan",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "satuan": {
+                    "type": "string",
+                    "example": "gulden"
+                },
+                "sumber": {
+                    "description": "nama kategori asal (untuk atribut warisan)",
+                    "type": "string"
+                },
+                "tipe": {
+                    "description": "teks / angka / tanggal / boolean / pilihan",
+                    "type": "string",
+                    "example": "angka"
+                },
+                "wajib": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.CatatanRequest": {
+            "type": "object",
+            "properties": {
+                "catatan": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ChecklistPameran": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "urut per gudang, rak, tahap",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ItemChecklistPameran"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Berhasil menyusun checklist pameran"
+                },
+                "pameran": {
+                    "$ref": "#/definitions/model.Pameran"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.DokumenPerolehan": {
+            "type": "object",
+            "properties": {
+                "jenis": {
+                    "type": "string",
+                    "example": "akta hibah"
+                },
+                "media_id": {
+                    "description": "scan dokumen di media koleksi",
+                    "type": "string"
+                },
+                "nomor": {
+                    "type": "string"
+                },
+                "tanggal": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Invalid credentials"
+                }
+            }
+        },
+        "model.ErrorResponseRegister": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Username already exists"
+                }
+            }
+        },
+        "model.FilterUbahMassal": {
+            "type": "object",
+            "properties": {
+                "gudang_id": {
+                    "type": "string"
+                },
+                "kategori_id": {
+                    "description": "termasuk sub-kategori",
+                    "type": "string",
+                    "example": "665f1c2a9b1e8a0012345678"
+                },
+                "kondisi": {
+                    "type": "string"
+                },
+                "rak_id": {
+                    "type": "string"
+                },
+                "tahap_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FormatLabel": {
+            "type": "object",
+            "properties": {
+                "baris": {
+                    "type": "integer"
+                },
+                "jarak_baris": {
+                    "description": "jarak awal label ke awal label berikutnya (vertikal)",
+                    "type": "number"
+                },
+                "jarak_kolom": {
+                    "description": "jarak awal label ke awal label berikutnya (horizontal)",
+                    "type": "number"
+                },
+                "kertas": {
+                    "description": "A4 / Letter",
+                    "type": "string"
+                },
+                "keterangan": {
+                    "type": "string"
+                },
+                "kolom": {
+                    "type": "integer"
+                },
+                "lebar": {
+                    "description": "lebar satu label",
+                    "type": "number"
+                },
+                "margin_atas": {
+                    "description": "jarak tepi atas kertas ke label pertama",
+                    "type": "number"
+                },
+                "margin_kiri": {
+                    "description": "jarak tepi kiri kertas ke label pertama",
+                    "type": "number"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "tinggi": {
+                    "description": "tinggi satu label",
+                    "type": "number"
+                }
+            }
+        },
+        "model.GetAllRakResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RakResponseItem"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Berhasil mengambil semua data rak"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "model.GetAllTahapResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TahapResponseItem"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Berhasil mengambil semua data tahap"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "model.GetAllUsersResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Users"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Berhasil mengambil semua data users"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "model.GetUserByUsernameResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.Users"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "User ditemukan"
+                }
+            }
+        },
+        "model.Gudang": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "nama_gudang": {
+                    "type": "string"
+                },
+                "versi": {
+                    "description": "nomor versi untuk ETag / If-Match",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.HasilResolve": {
+            "type": "object",
+            "properties": {
+                "cocok": {
+                    "description": "no_inv / no_reg / kode_lokasi / url / id",
+                    "type": "string",
+                    "example": "no_inv"
+                },
+                "data": {},
+                "id": {
+                    "type": "string",
+                    "example": "65f1c0a1b2c3d4e5f6a7b8c9"
+                },
+                "jenis": {
+                    "description": "koleksi / gudang / rak / tahap",
+                    "type": "string",
+                    "example": "koleksi"
+                },
+                "kode": {
+                    "type": "string",
+                    "example": "INV.2024.0012"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Kode ditemukan"
+                }
+            }
+        },
+        "model.HasilUbahMassal": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "field": {
+                    "description": "field yang berubah",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "koleksi_id": {
+                    "type": "string"
+                },
+                "nama_benda": {
+                    "type": "string"
+                },
+                "no_inv": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "siap / berhasil / tidak_berubah / gagal",
+                    "type": "string",
+                    "example": "siap"
+                }
+            }
+        },
+        "model.InstitusiPeminjaman": {
+            "type": "object",
+            "properties": {
+                "alamat": {
+                    "type": "string"
+                },
+                "kontak": {
+                    "description": "email / telepon",
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string",
+                    "example": "Museum Nasional Indonesia"
+                },
+                "nama_kontak": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ItemChecklistPameran": {
+            "type": "object",
+            "properties": {
+                "catatan": {
+                    "type": "string"
+                },
+                "ditemukan": {
+                    "description": "false jika koleksi sudah dihapus",
+                    "type": "boolean"
+                },
+                "gudang": {
+                    "type": "string"
+                },
+                "koleksi_id": {
+                    "type": "string"
+                },
+                "kondisi": {
+                    "type": "string"
+                },
+                "nama_benda": {
+                    "type": "string"
+                },
+                "no_inv": {
+                    "type": "string"
+                },
+                "no_reg": {
+                    "type": "string"
+                },
+                "posisi": {
+                    "description": "tujuan di ruang pamer",
+                    "type": "string"
+                },
+                "rak": {
+                    "type": "string"
+                },
+                "status_lokasi": {
+                    "type": "string"
+                },
+                "tahap": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ItemPameran": {
+            "type": "object",
+            "properties": {
+                "catatan": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "koleksi_id": {
+                    "type": "string"
+                },
+                "nama_benda": {
+                    "type": "string"
+                },
+                "no_inv": {
+                    "type": "string"
+                },
+                "posisi": {
+                    "description": "vitrin / zona di ruang pamer",
+                    "type": "string",
+                    "example": "Vitrin 3"
+                }
+            }
+        },
+        "model.ItemPameranInput": {
+            "type": "object",
+            "properties": {
+                "catatan": {
+                    "type": "string"
+                },
+                "koleksi_id": {
+                    "type": "string",
+                    "example": "665f1c2a9b1e8a0012345678"
+                },
+                "posisi": {
+                    "type": "string",
+                    "example": "Vitrin 3"
+                }
+            }
+        },
+        "model.ItemPeminjamanInput": {
+            "type": "object",
+            "properties": {
+                "catatan": {
+                    "type": "string"
+                },
+                "koleksi_id": {
+                    "type": "string",
+                    "example": "665f1c2a9b1e8a0012345678"
+                },
+                "nama_benda": {
+                    "description": "wajib jika koleksi_id kosong (arah masuk)",
+                    "type": "string"
+                },
+                "nilai_asuransi": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.KoleksiGanda": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "nama_benda": {
+                    "type": "string"
+                },
+                "nomor_baru": {
+                    "description": "nomor pengganti saat diperbaiki",
+                    "type": "string"
+                }
+            }
+        },
+        "model.LaporanGCMedia": {
+            "type": "object",
+            "properties": {
+                "dihapus": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "gagal": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "hapus": {
+                    "description": "false = hanya daftar (dry run)",
+                    "type": "boolean"
+                },
+                "jumlah_dipakai": {
+                    "type": "integer"
+                },
+                "jumlah_file": {
+                    "type": "integer"
+                },
+                "total_ukuran": {
+                    "description": "total byte media yatim yang tercatat",
+                    "type": "integer"
+                },
+                "yatim": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MediaYatim"
+                    }
+                }
+            }
+        },
+        "model.LaporanNilaiKoleksi": {
+            "type": "object",
+            "properties": {
+                "belum_dinilai": {
+                    "description": "koleksi aktif tanpa penilaian sampai per_tanggal",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RekapNilaiKoleksi"
+                    }
+                },
+                "jumlah_dinilai": {
+                    "type": "integer"
+                },
+                "kelompok": {
+                    "type": "string",
+                    "example": "kategori"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Berhasil menyusun laporan nilai koleksi"
+                },
+                "per_tanggal": {
+                    "description": "nilai terakhir sampai tanggal ini",
+                    "type": "string"
+                },
+                "total_nilai": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                }
+            }
+        },
+        "model.LaporanNomorGanda": {
+            "type": "object",
+            "properties": {
+                "diperbaiki": {
+                    "description": "jumlah koleksi yang nomornya diganti",
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "ganda": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.NomorGanda"
+                    }
+                },
+                "index_aktif": {
+                    "description": "no_reg_unik / no_inv_unik",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "index_gagal": {
+                    "description": "pesan error pembuatan index",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.LaporanPenilaianKedaluwarsa": {
+            "type": "object",
+            "properties": {
+                "batas": {
+                    "description": "penilaian sebelum tanggal ini dianggap kedaluwarsa",
+                    "type": "string"
+                },
+                "data": {
+                    "description": "penilaian paling lama di depan",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PenilaianKedaluwarsa"
+                    }
+                },
+                "maks_umur_tahun": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Berhasil mengambil koleksi dengan penilaian kedaluwarsa"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.LaporanPerolehan": {
+            "type": "object",
+            "properties": {
+                "belum_terstruktur": {
+                    "description": "koleksi tanpa metode perolehan",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RekapPerolehan"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Berhasil mengambil laporan perolehan"
+                },
+                "total_koleksi": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.LaporanUbahMassal": {
+            "type": "object",
+            "properties": {
+                "diubah": {
+                    "description": "siap (dry run) atau berhasil",
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "gagal": {
+                    "type": "integer"
+                },
+                "hasil": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HasilUbahMassal"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Pratinjau ubah massal"
+                },
+                "tidak_berubah": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "admin12345"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "ghaida"
+                }
+            }
+        },
+        "model.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "expires": {
+                    "type": "string",
+                    "example": "2026-01-22T15:11:51.917322007Z"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Login successful"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "model.MediaYatim": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "tercatat": {
+                    "description": "false = file lama yang baru dicatat di objek_media pada pemindaian ini",
+                    "type": "boolean"
+                },
+                "ukuran": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "yatim_sejak": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.NomorGanda": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "no_inv"
+                },
+                "koleksi": {
+                    "description": "terurut dari yang paling lama dibuat (dipertahankan saat diperbaiki)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.KoleksiGanda"
+                    }
+                },
+                "nomor": {
+                    "type": "string",
+                    "example": "INV.2024.0012"
+                }
+            }
+        },
+        "model.OkupansiLokasi": {
+            "type": "object",
+            "properties": {
+                "berat_maks": {
+                    "description": "0 = tidak dibatasi",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jumlah_koleksi": {
+                    "type": "integer"
+                },
+                "kapasitas_slot": {
+                    "description": "0 = tidak dibatasi",
+                    "type": "integer"
+                },
+                "melebihi_kapasitas": {
+                    "description": "slot atau berat sudah melewati batas",
+                    "type": "boolean"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "penuh": {
+                    "description": "slot atau berat sudah mencapai batas",
+                    "type": "boolean"
+                },
+                "sisa_berat": {
+                    "description": "nil jika berat maksimum tidak diatur",
+                    "type": "number"
+                },
+                "sisa_slot": {
+                    "description": "nil jika kapasitas slot tidak diatur",
+                    "type": "integer"
+                },
+                "tanpa_berat": {
+                    "description": "koleksi yang beratnya kosong / tidak terbaca",
+                    "type": "integer"
+                },
+                "total_berat": {
+                    "description": "dalam kg",
+                    "type": "number"
+                }
+            }
+        },
+        "model.OkupansiResponse": {
+            "type": "object",
+            "properties": {
+                "gudang": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OkupansiLokasi"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Berhasil mengambil data okupansi"
+                },
+                "rak": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OkupansiLokasi"
+                    }
+                },
+                "tahap": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OkupansiLokasi"
+                    }
+                }
+            }
+        },
+        "model.Pameran": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "deskripsi": {
+                    "type": "string"
+                },
+                "judul": {
+                    "type": "string",
+                    "example": "Keramik Nusantara"
+                },
+                "koleksi": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ItemPameran"
+                    }
+                },
+                "kurator": {
+                    "type": "string",
+                    "example": "Dewi Lestari"
+                },
+                "tanggal_mulai": {
+                    "type": "string"
+                },
+                "tanggal_selesai": {
+                    "description": "kosong = pameran tetap",
+                    "type": "string"
+                },
+                "tempat": {
+                    "type": "string",
+                    "example": "Ruang Pamer Temporer Lt. 2"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PameranRequest": {
+            "type": "object",
+            "properties": {
+                "deskripsi": {
+                    "type": "string"
+                },
+                "judul": {
+                    "type": "string",
+                    "example": "Keramik Nusantara"
+                },
+                "kurator": {
+                    "type": "string"
+                },
+                "tanggal_mulai": {
+                    "type": "string",
+                    "example": "2025-08-01"
+                },
+                "tanggal_selesai": {
+                    "description": "kosongkan untuk pameran tetap",
+                    "type": "string",
+                    "example": "2025-10-31"
+                },
+                "tempat": {
+                    "type": "string",
+                    "example": "Ruang Pamer Temporer Lt. 2"
+                }
+            }
+        },
+        "model.PatchKoleksi": {
+            "type": "object",
+            "properties": {
+                "asal_koleksi": {
+                    "type": "string"
+                },
+                "atribut": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "bahan": {
+                    "type": "string"
+                },
+                "catatan": {
+                    "description": "catatan tempat penyimpanan",
+                    "type": "string"
+                },
+                "deskripsi": {
+                    "type": "string"
+                },
+                "gudang_id": {
+                    "type": "string"
+                },
+                "kategori_id": {
+                    "type": "string"
+                },
+                "kondisi": {
+                    "type": "string"
+                },
+                "nama_benda": {
+                    "type": "string"
+                },
+                "no_inv": {
+                    "type": "string"
+                },
+                "no_reg": {
+                    "type": "string"
+                },
+                "rak_id": {
+                    "type": "string"
+                },
+                "tahap_id": {
+                    "type": "string"
+                },
+                "tanggal_perolehan": {
+                    "type": "string"
+                },
+                "tempat_perolehan": {
+                    "type": "string"
+                },
+                "ukuran": {
+                    "$ref": "#/definitions/model.PatchUkuran"
+                }
+            }
+        },
+        "model.PatchUkuran": {
+            "type": "object",
+            "properties": {
+                "berat": {
+                    "type": "number"
+                },
+                "catatan": {
+                    "type": "string"
+                },
+                "diameter": {
+                    "type": "number"
+                },
+                "lebar": {
+                    "type": "number"
+                },
+                "panjang_keseluruhan": {
+                    "type": "number"
+                },
+                "satuan": {
+                    "type": "string"
+                },
+                "satuan_berat": {
+                    "type": "string"
+                },
+                "tebal": {
+                    "type": "number"
+                },
+                "tinggi": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.PelepasanDeaksesiRequest": {
+            "type": "object",
+            "properties": {
+                "catatan": {
+                    "type": "string"
+                },
+                "metode": {
+                    "type": "string",
+                    "example": "hibah"
+                },
+                "penerima": {
+                    "type": "string"
+                },
+                "tanggal": {
+                    "type": "string",
+                    "example": "2025-09-15"
+                }
+            }
+        },
+        "model.PembacaanSensorRequest": {
+            "type": "object",
+            "properties": {
+                "gudang_id": {
+                    "type": "string",
+                    "example": "693a3a7a416cd8d592b5058e"
+                },
+                "kelembapan": {
+                    "type": "number",
+                    "example": 55
+                },
+                "rak_id": {
+                    "type": "string",
+                    "example": "693a3a7a416cd8d592b5058f"
+                },
+                "sensor_id": {
+                    "type": "string",
+                    "example": "sensor-gudang-a-01"
+                },
+                "suhu": {
+                    "type": "number",
+                    "example": 22.5
+                },
+                "waktu": {
+                    "description": "RFC3339, kosong = waktu server",
+                    "type": "string",
+                    "example": "2026-01-22T15:11:51Z"
+                }
+            }
+        },
+        "model.PeminjamanRequest": {
+            "type": "object",
+            "properties": {
+                "arah": {
+                    "type": "string",
+                    "example": "keluar"
+                },
+                "catatan": {
+                    "type": "string"
+                },
+                "institusi": {
+                    "$ref": "#/definitions/model.InstitusiPeminjaman"
+                },
+                "keperluan": {
+                    "type": "string"
+                },
+                "koleksi": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ItemPeminjamanInput"
+                    }
+                },
+                "mata_uang": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "nilai_asuransi": {
+                    "type": "number"
+                },
+                "no_perjanjian": {
+                    "type": "string"
+                },
+                "tanggal_mulai": {
+                    "type": "string",
+                    "example": "2025-07-01"
+                },
+                "tanggal_selesai": {
+                    "type": "string",
+                    "example": "2025-12-31"
+                }
+            }
+        },
+        "model.PenilaianKedaluwarsa": {
+            "type": "object",
+            "properties": {
+                "gudang": {
+                    "type": "string"
+                },
+                "kategori": {
+                    "type": "string"
+                },
+                "koleksi_id": {
+                    "type": "string"
+                },
+                "nama_benda": {
+                    "type": "string"
+                },
+                "no_inv": {
+                    "type": "string"
+                },
+                "no_reg": {
+                    "type": "string"
+                },
+                "penilaian_terakhir": {
+                    "description": "kosong = belum pernah dinilai",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.PenilaianKoleksi"
+                        }
+                    ]
+                }
+            }
+        },
+        "model.PenilaianKoleksi": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "catatan": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "koleksi_id": {
+                    "type": "string"
+                },
+                "mata_uang": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "metode": {
+                    "type": "string",
+                    "example": "ahli"
+                },
+                "nilai": {
+                    "type": "number",
+                    "example": 150000000
+                },
+                "penilai": {
                     "type": "string",
-                    "example": "Invalid credentials"
-                }
-            }
-        },
-        "model.ErrorResponseRegister": {
-            "type": "object",
-            "properties": {
-                "error": {
+                    "example": "KJPP Nusantara"
+                },
+                "tanggal": {
+                    "type": "string"
+                },
+                "tujuan": {
                     "type": "string",
-                    "example": "Username already exists"
+                    "example": "asuransi"
                 }
             }
         },
-        "model.GetAllRakResponse": {
+        "model.PenilaianRequest": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.RakResponseItem"
-                    }
+                "catatan": {
+                    "type": "string"
                 },
-                "message": {
+                "mata_uang": {
+                    "description": "default IDR",
                     "type": "string",
-                    "example": "Berhasil mengambil semua data rak"
+                    "example": "IDR"
                 },
-                "total": {
-                    "type": "integer",
-                    "example": 12
+                "metode": {
+                    "type": "string",
+                    "example": "ahli"
+                },
+                "nilai": {
+                    "type": "number",
+                    "example": 150000000
+                },
+                "penilai": {
+                    "type": "string",
+                    "example": "KJPP Nusantara"
+                },
+                "tanggal": {
+                    "description": "default hari ini",
+                    "type": "string",
+                    "example": "2025-01-15"
+                },
+                "tujuan": {
+                    "type": "string",
+                    "example": "asuransi"
                 }
             }
         },
-        "model.GetAllTahapResponse": {
+        "model.Perolehan": {
             "type": "object",
             "properties": {
-                "data": {
+                "catatan": {
+                    "type": "string"
+                },
+                "dokumen": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.TahapResponseItem"
+                        "$ref": "#/definitions/model.DokumenPerolehan"
                     }
                 },
-                "message": {
+                "harga": {
+                    "type": "number",
+                    "example": 15000000
+                },
+                "mata_uang": {
                     "type": "string",
-                    "example": "Berhasil mengambil semua data tahap"
+                    "example": "IDR"
                 },
-                "total": {
-                    "type": "integer",
-                    "example": 12
+                "metode": {
+                    "description": "hibah / pembelian / temuan / titipan",
+                    "type": "string",
+                    "example": "hibah"
+                },
+                "sumber": {
+                    "$ref": "#/definitions/model.PihakPerolehan"
+                },
+                "tahun": {
+                    "description": "tahun tanggal_mulai, untuk filter \u0026 laporan",
+                    "type": "integer"
+                },
+                "tanggal": {
+                    "type": "string",
+                    "example": "12-03-1998"
+                },
+                "tanggal_mulai": {
+                    "description": "diisi server dari tanggal",
+                    "type": "string"
+                },
+                "tanggal_selesai": {
+                    "description": "diisi server dari tanggal",
+                    "type": "string"
+                },
+                "tempat": {
+                    "type": "string",
+                    "example": "Cirebon"
                 }
             }
         },
-        "model.GetAllUsersResponse": {
+        "model.PersetujuanDeaksesiRequest": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Users"
-                    }
+                "catatan": {
+                    "type": "string"
                 },
-                "message": {
+                "keputusan": {
                     "type": "string",
-                    "example": "Berhasil mengambil semua data users"
-                },
-                "total": {
-                    "type": "integer",
-                    "example": 1
+                    "example": "setuju"
                 }
             }
         },
-        "model.GetUserByUsernameResponse": {
+        "model.PerubahanMassal": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/model.Users"
+                "atribut": {
+                    "description": "digabung dengan atribut lama, nilai null menghapus atribut",
+                    "type": "object",
+                    "additionalProperties": true
                 },
-                "message": {
-                    "type": "string",
-                    "example": "User ditemukan"
+                "buang_atribut_lain": {
+                    "description": "buang atribut yang tidak ada di skema kategori baru",
+                    "type": "boolean"
+                },
+                "gudang_id": {
+                    "type": "string"
+                },
+                "kategori_id": {
+                    "type": "string"
+                },
+                "kondisi": {
+                    "type": "string"
+                },
+                "rak_id": {
+                    "description": "\"\" = kosongkan rak",
+                    "type": "string"
+                },
+                "tahap_id": {
+                    "description": "\"\" = kosongkan tahap",
+                    "type": "string"
                 }
             }
         },
-        "model.Gudang": {
+        "model.PihakPerolehan": {
             "type": "object",
             "properties": {
-                "id": {
+                "alamat": {
                     "type": "string"
                 },
-                "nama_gudang": {
+                "jenis": {
+                    "description": "perorangan / instansi / komunitas / lainnya",
+                    "type": "string",
+                    "example": "perorangan"
+                },
+                "kontak": {
                     "type": "string"
+                },
+                "nama": {
+                    "type": "string",
+                    "example": "Keluarga R. Soedarmo"
                 }
             }
         },
-        "model.LoginRequest": {
+        "model.PreviewNomor": {
             "type": "object",
             "properties": {
-                "password": {
+                "field": {
                     "type": "string",
-                    "example": "admin12345"
+                    "example": "no_inv"
                 },
-                "username": {
+                "nomor": {
                     "type": "string",
-                    "example": "ghaida"
+                    "example": "NUM.2026.0013"
+                },
+                "pola": {
+                    "type": "string",
+                    "example": "{kategori_code}.{year}.{seq:04}"
                 }
             }
         },
-        "model.LoginResponse": {
+        "model.Provenans": {
             "type": "object",
             "properties": {
-                "expires": {
-                    "type": "string",
-                    "example": "2026-01-22T15:11:51.917322007Z"
+                "_id": {
+                    "type": "string"
                 },
-                "message": {
+                "keterangan": {
+                    "type": "string"
+                },
+                "pemilik": {
                     "type": "string",
-                    "example": "Login successful"
+                    "example": "R. Soedarmo"
                 },
-                "role": {
+                "peristiwa": {
+                    "description": "pembelian, warisan, lelang, penemuan, dll",
                     "type": "string",
-                    "example": "admin"
+                    "example": "warisan"
                 },
-                "status": {
-                    "type": "integer",
-                    "example": 200
+                "referensi": {
+                    "description": "sumber informasi (arsip, wawancara, publikasi)",
+                    "type": "string"
                 },
-                "token": {
+                "tanggal": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "1950 s/d 1975"
+                },
+                "tanggal_mulai": {
+                    "type": "string"
+                },
+                "tanggal_selesai": {
+                    "type": "string"
+                },
+                "tempat": {
+                    "type": "string"
                 }
             }
         },
         "model.Rak": {
             "type": "object",
             "properties": {
+                "berat_maks": {
+                    "description": "beban maksimum dalam kg",
+                    "type": "number"
+                },
                 "id": {
                     "type": "string"
                 },
+                "kapasitas_slot": {
+                    "description": "jumlah maksimum koleksi",
+                    "type": "integer"
+                },
                 "nama_rak": {
                     "type": "string"
+                },
+                "versi": {
+                    "description": "nomor versi untuk ETag / If-Match",
+                    "type": "integer"
                 }
             }
         },
@@ -1581,14 +7818,126 @@ const docTemplate = `{
                 }
             }
         },
+        "model.RekapNilaiKoleksi": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "jumlah": {
+                    "description": "koleksi yang sudah dinilai",
+                    "type": "integer",
+                    "example": 12
+                },
+                "nama": {
+                    "type": "string",
+                    "example": "Keramik"
+                },
+                "total_nilai": {
+                    "description": "per mata uang",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                }
+            }
+        },
+        "model.RekapPerolehan": {
+            "type": "object",
+            "properties": {
+                "jumlah": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "metode": {
+                    "type": "string",
+                    "example": "hibah"
+                },
+                "tahun": {
+                    "description": "0 = tahun tidak diketahui",
+                    "type": "integer",
+                    "example": 2024
+                },
+                "total_harga": {
+                    "description": "per mata uang",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                }
+            }
+        },
+        "model.SkemaAtributRequest": {
+            "type": "object",
+            "properties": {
+                "atribut": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AtributKategori"
+                    }
+                }
+            }
+        },
+        "model.SkemaPenomoran": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "no_reg / no_inv",
+                    "type": "string",
+                    "example": "no_inv"
+                },
+                "pola": {
+                    "type": "string",
+                    "example": "{kategori_code}.{year}.{seq:04}"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SkemaPenomoranRequest": {
+            "type": "object",
+            "properties": {
+                "pola": {
+                    "type": "string",
+                    "example": "{kategori_code}.{year}.{seq:04}"
+                }
+            }
+        },
+        "model.StatusPeminjamanRequest": {
+            "type": "object",
+            "properties": {
+                "catatan": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "disetujui"
+                }
+            }
+        },
         "model.Tahap": {
             "type": "object",
             "properties": {
+                "berat_maks": {
+                    "description": "beban maksimum dalam kg",
+                    "type": "number"
+                },
                 "id": {
                     "type": "string"
                 },
+                "kapasitas_slot": {
+                    "description": "jumlah maksimum koleksi",
+                    "type": "integer"
+                },
                 "nama_tahap": {
                     "type": "string"
+                },
+                "versi": {
+                    "description": "nomor versi untuk ETag / If-Match",
+                    "type": "integer"
                 }
             }
         },
@@ -1605,6 +7954,73 @@ const docTemplate = `{
                 }
             }
         },
+        "model.TambahKoleksiPameranRequest": {
+            "type": "object",
+            "properties": {
+                "koleksi": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ItemPameranInput"
+                    }
+                }
+            }
+        },
+        "model.TolakPerubahanRequest": {
+            "type": "object",
+            "properties": {
+                "catatan": {
+                    "type": "string",
+                    "example": "No inventaris tidak sesuai label fisik, mohon dicek ulang"
+                }
+            }
+        },
+        "model.UbahMassalRequest": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "description": "true = hanya pratinjau, tidak ada data yang diubah",
+                    "type": "boolean"
+                },
+                "filter": {
+                    "$ref": "#/definitions/model.FilterUbahMassal"
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "perubahan": {
+                    "$ref": "#/definitions/model.PerubahanMassal"
+                }
+            }
+        },
+        "model.UrutanMediaRequest": {
+            "type": "object",
+            "properties": {
+                "media_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "665f1c2a9b1e8a0012345678",
+                        "665f1c2a9b1e8a0012345679"
+                    ]
+                }
+            }
+        },
+        "model.UrutanProvenansRequest": {
+            "type": "object",
+            "properties": {
+                "provenans_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "model.Users": {
             "type": "object",
             "properties": {
@@ -1625,6 +8041,44 @@ const docTemplate = `{
                     "example": "ghaida"
                 }
             }
+        },
+        "model.UsulanDeaksesiRequest": {
+            "type": "object",
+            "properties": {
+                "alasan": {
+                    "type": "string",
+                    "example": "duplikat"
+                },
+                "dokumen": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DokumenPerolehan"
+                    }
+                },
+                "keterangan": {
+                    "type": "string",
+                    "example": "Duplikat dari koleksi no. inv 01/KRM/2019, kondisi lebih buruk"
+                }
+            }
+        },
+        "model.VerifikasiMedia": {
+            "type": "object",
+            "properties": {
+                "cocok": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "description": "hash yang tercatat",
+                    "type": "string"
+                },
+                "sha256_aktual": {
+                    "description": "hash file di storage saat ini",
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -4,6 +4,7 @@ import (
 	"be-internship/config"
 	_ "be-internship/docs"
 	route "be-internship/routes"
+	"be-internship/storage"
 	"log"
 	"os"
 
@@ -43,7 +44,19 @@ func main() {
 		log.Println("⚠️  Tidak dapat memuat .env, menggunakan environment variable sistem...")
 	}
 
-	app := fiber.New()
+	// Siapkan collection khusus & index database
+	config.SetupDatabase()
+
+	// Pilih storage foto (STORAGE_DRIVER = github / local / s3)
+	if err := storage.Init(); err != nil {
+		log.Fatalf("❌ Gagal menyiapkan storage: %v", err)
+	}
+	log.Printf("🗂️  Storage foto: %s", storage.Aktif().Nama())
+
+	app := fiber.New(fiber.Config{
+		// Batas body untuk upload foto koleksi; batas per file diatur lewat MEDIA_MAX_MB
+		BodyLimit: 50 * 1024 * 1024,
+	})
 
 	app.Use(logger.New())
 	app.Use(cors.New(config.Cors))
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Status usulan deaksesi (pengeluaran koleksi secara permanen)
+const (
+	StatusDeaksesiDiajukan   = "diajukan"
+	StatusDeaksesiDisetujui  = "disetujui" // persetujuan admin sudah cukup, menunggu pelepasan fisik
+	StatusDeaksesiDitolak    = "ditolak"
+	StatusDeaksesiDibatalkan = "dibatalkan"
+	StatusDeaksesiSelesai    = "selesai" // koleksi sudah keluar, data koleksi menjadi hanya-baca
+)
+
+// StatusDeaksesiAktif adalah status usulan yang masih berjalan
+var StatusDeaksesiAktif = []string{StatusDeaksesiDiajukan, StatusDeaksesiDisetujui}
+
+// AlasanDeaksesiValid berisi alasan deaksesi yang diterima API
+var AlasanDeaksesiValid = map[string]bool{
+	"rusak_total":   true, // rusak dan tidak bisa dikonservasi
+	"duplikat":      true,
+	"tidak_relevan": true, // di luar kebijakan koleksi museum
+	"repatriasi":    true, // dikembalikan ke pemilik / komunitas asal
+	"hilang":        true,
+	"lainnya":       true,
+}
+
+// MetodePelepasanValid berisi cara koleksi dilepas setelah deaksesi disetujui
+var MetodePelepasanValid = map[string]bool{
+	"hibah":        true, // diserahkan ke institusi lain
+	"pengembalian": true, // dikembalikan ke pemilik / komunitas asal
+	"pertukaran":   true,
+	"penjualan":    true,
+	"pemusnahan":   true,
+	"dihapuskan":   true, // hanya penghapusan catatan (misal benda hilang)
+}
+
+// Keputusan persetujuan deaksesi
+const (
+	KeputusanSetuju = "setuju"
+	KeputusanTolak  = "tolak"
+)
+
+// PersetujuanDeaksesi adalah keputusan satu admin atas usulan deaksesi
+type PersetujuanDeaksesi struct {
+	Oleh      string    `json:"oleh" bson:"oleh"`
+	Keputusan string    `json:"keputusan" bson:"keputusan"` // setuju / tolak
+	Catatan   string    `json:"catatan,omitempty" bson:"catatan,omitempty"`
+	Tanggal   time.Time `json:"tanggal" bson:"tanggal"`
+}
+
+// PelepasanDeaksesi mencatat bagaimana & kapan koleksi keluar dari museum
+type PelepasanDeaksesi struct {
+	Metode   string    `json:"metode" bson:"metode" example:"hibah"`
+	Tanggal  time.Time `json:"tanggal" bson:"tanggal"`
+	Penerima string    `json:"penerima,omitempty" bson:"penerima,omitempty" example:"Museum Negeri Jawa Barat"`
+	Catatan  string    `json:"catatan,omitempty" bson:"catatan,omitempty"`
+	Oleh     string    `json:"oleh,omitempty" bson:"oleh,omitempty"`
+}
+
+// RiwayatDeaksesi mencatat setiap perubahan status usulan deaksesi (jejak audit)
+type RiwayatDeaksesi struct {
+	Status  string    `json:"status" bson:"status"`
+	Tanggal time.Time `json:"tanggal" bson:"tanggal"`
+	Oleh    string    `json:"oleh,omitempty" bson:"oleh,omitempty"`
+	Catatan string    `json:"catatan,omitempty" bson:"catatan,omitempty"`
+}
+
+// UsulanDeaksesi adalah satu proses deaksesi koleksi, dari usulan sampai pelepasan
+type UsulanDeaksesi struct {
+	ID             primitive.ObjectID    `json:"_id" bson:"_id"`
+	KoleksiID      primitive.ObjectID    `json:"koleksi_id" bson:"koleksi_id"`
+	NamaBenda      string                `json:"nama_benda" bson:"nama_benda"`
+	NoRegistrasi   string                `json:"no_reg,omitempty" bson:"no_reg,omitempty"`
+	NoInventaris   string                `json:"no_inv,omitempty" bson:"no_inv,omitempty"`
+	Alasan         string                `json:"alasan" bson:"alasan" example:"duplikat"`
+	Keterangan     string                `json:"keterangan" bson:"keterangan"`
+	Dokumen        []DokumenPerolehan    `json:"dokumen,omitempty" bson:"dokumen,omitempty"` // dokumen pendukung, media_id merujuk media koleksi
+	Status         string                `json:"status" bson:"status"`
+	MinPersetujuan int                   `json:"min_persetujuan" bson:"min_persetujuan"`
+	Persetujuan    []PersetujuanDeaksesi `json:"persetujuan,omitempty" bson:"persetujuan,omitempty"`
+	Pelepasan      *PelepasanDeaksesi    `json:"pelepasan,omitempty" bson:"pelepasan,omitempty"`
+	Riwayat        []RiwayatDeaksesi     `json:"riwayat" bson:"riwayat"`
+	DiajukanOleh   string                `json:"diajukan_oleh,omitempty" bson:"diajukan_oleh,omitempty"`
+	CreatedAt      time.Time             `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at" bson:"updated_at"`
+}
+
+// RingkasanDeaksesi disimpan di dokumen koleksi selama / setelah proses deaksesi
+type RingkasanDeaksesi struct {
+	UsulanID primitive.ObjectID `json:"usulan_id" bson:"usulan_id"`
+	Status   string             `json:"status" bson:"status"`
+	Alasan   string             `json:"alasan" bson:"alasan"`
+	Metode   string             `json:"metode,omitempty" bson:"metode,omitempty"`
+	Tanggal  *time.Time         `json:"tanggal,omitempty" bson:"tanggal,omitempty"` // tanggal pelepasan
+}
+
+// UsulanDeaksesiRequest untuk mengajukan deaksesi koleksi
+type UsulanDeaksesiRequest struct {
+	Alasan     string             `json:"alasan" example:"duplikat"`
+	Keterangan string             `json:"keterangan" example:"Duplikat dari koleksi no. inv 01/KRM/2019, kondisi lebih buruk"`
+	Dokumen    []DokumenPerolehan `json:"dokumen"`
+}
+
+// PersetujuanDeaksesiRequest untuk menyetujui / menolak usulan deaksesi
+type PersetujuanDeaksesiRequest struct {
+	Keputusan string `json:"keputusan" example:"setuju"`
+	Catatan   string `json:"catatan"`
+}
+
+// PelepasanDeaksesiRequest untuk mencatat pelepasan koleksi. Tanggal dalam format YYYY-MM-DD.
+type PelepasanDeaksesiRequest struct {
+	Metode   string `json:"metode" example:"hibah"`
+	Tanggal  string `json:"tanggal" example:"2025-09-15"`
+	Penerima string `json:"penerima"`
+	Catatan  string `json:"catatan"`
+}
+
+// CatatanRequest berisi catatan opsional untuk aksi tanpa data lain (misal pembatalan)
+type CatatanRequest struct {
+	Catatan string `json:"catatan"`
+}
@@ -3,7 +3,44 @@ package model
 import "go.mongodb.org/mongo-driver/bson/primitive"
 
 type Kategori struct {
-	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	NamaKategori string             `bson:"nama_kategori" json:"nama_kategori"`
-	Deskripsi    string             `bson:"deskripsi,omitempty" json:"deskripsi,omitempty"`
+	ID           primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	NamaKategori string              `bson:"nama_kategori" json:"nama_kategori"`
+	NamaNormal   string              `bson:"nama_normal,omitempty" json:"-"` // nama yang dinormalisasi untuk index unik
+	Deskripsi    string              `bson:"deskripsi,omitempty" json:"deskripsi,omitempty"`
+	Kode         string              `bson:"kode,omitempty" json:"kode,omitempty"` // kode singkat untuk penomoran otomatis, contoh: NUM
+	ParentID     *primitive.ObjectID `bson:"parent_id,omitempty" json:"parent_id,omitempty"`
+	Path         string              `bson:"path,omitempty" json:"path,omitempty"` // materialized path: "/<id leluhur>/.../"
+	Atribut      []AtributKategori   `bson:"atribut,omitempty" json:"atribut,omitempty"`
+	Versi        int64               `bson:"versi,omitempty" json:"versi,omitempty"` // nomor versi untuk ETag / If-Match, naik setiap perubahan
+}
+
+// Tipe data atribut tambahan koleksi
+const (
+	TipeAtributTeks    = "teks"
+	TipeAtributAngka   = "angka"
+	TipeAtributTanggal = "tanggal" // format YYYY-MM-DD
+	TipeAtributBoolean = "boolean"
+	TipeAtributPilihan = "pilihan"
+)
+
+// AtributKategori adalah definisi satu atribut tambahan untuk koleksi pada kategori tertentu
+type AtributKategori struct {
+	Nama    string   `bson:"nama" json:"nama" example:"nominal"` // key di koleksi.atribut
+	Label   string   `bson:"label,omitempty" json:"label,omitempty" example:"Nominal"`
+	Tipe    string   `bson:"tipe" json:"tipe" example:"angka"` // teks / angka / tanggal / boolean / pilihan
+	Wajib   bool     `bson:"wajib,omitempty" json:"wajib,omitempty"`
+	Pilihan []string `bson:"pilihan,omitempty" json:"pilihan,omitempty"` // nilai yang diizinkan untuk tipe pilihan
+	Satuan  string   `bson:"satuan,omitempty" json:"satuan,omitempty" example:"gulden"`
+	Sumber  string   `bson:"-" json:"sumber,omitempty"` // nama kategori asal (untuk atribut warisan)
+}
+
+// SkemaAtributRequest untuk request mengatur skema atribut kategori
+type SkemaAtributRequest struct {
+	Atribut []AtributKategori `json:"atribut"`
+}
+
+// KategoriTree adalah node kategori beserta sub-kategorinya
+type KategoriTree struct {
+	Kategori
+	SubKategori []*KategoriTree `json:"sub_kategori"`
 }
@@ -8,33 +8,58 @@ import (
 
 // Struct utama untuk data koleksi museum
 type Koleksi struct {
-	ID                primitive.ObjectID `json:"_id,omitempty" bson:"_id,omitempty"`
-	Kategori          Kategori           `json:"kategori,omitempty" bson:"kategori,omitempty"`
-	NoRegistrasi      string             `json:"no_reg,omitempty" bson:"no_reg,omitempty"`
-	NoInventaris      string             `json:"no_inv,omitempty" bson:"no_inv,omitempty"`
-	NamaBenda         string             `json:"nama_benda,omitempty" bson:"nama_benda,omitempty"`
-	AsalKoleksi       string             `json:"asal_koleksi,omitempty" bson:"asal_koleksi,omitempty"`
-	Bahan             string             `json:"bahan,omitempty" bson:"bahan,omitempty"`
-	Ukuran            *Ukuran            `json:"ukuran,omitempty" bson:"ukuran,omitempty"`
-	TempatPerolehan   string             `json:"tempat_perolehan,omitempty" bson:"tempat_perolehan,omitempty"`
-	TanggalPerolehan  string             `json:"tanggal_perolehan,omitempty" bson:"tanggal_perolehan,omitempty"`
-	Deskripsi         string             `json:"deskripsi,omitempty" bson:"deskripsi,omitempty"`
-	TempatPenyimpanan TempatPenyimpanan  `json:"tempat_penyimpanan,omitempty" bson:"tempat_penyimpanan,omitempty"`
-	Kondisi           string             `json:"kondisi,omitempty" bson:"kondisi,omitempty"`
-	Foto              string             `json:"foto,omitempty" bson:"foto,omitempty"`
-	CreatedAt         time.Time          `json:"created_at,omitempty" bson:"created_at,omitempty"`
+	ID                primitive.ObjectID     `json:"_id,omitempty" bson:"_id,omitempty"`
+	Kategori          Kategori               `json:"kategori,omitempty" bson:"kategori,omitempty"`
+	NoRegistrasi      string                 `json:"no_reg,omitempty" bson:"no_reg,omitempty"`
+	NoInventaris      string                 `json:"no_inv,omitempty" bson:"no_inv,omitempty"`
+	NamaBenda         string                 `json:"nama_benda,omitempty" bson:"nama_benda,omitempty"`
+	AsalKoleksi       string                 `json:"asal_koleksi,omitempty" bson:"asal_koleksi,omitempty"`
+	Bahan             string                 `json:"bahan,omitempty" bson:"bahan,omitempty"`
+	Ukuran            *Ukuran                `json:"ukuran,omitempty" bson:"ukuran,omitempty"`
+	TempatPerolehan   string                 `json:"tempat_perolehan,omitempty" bson:"tempat_perolehan,omitempty"`
+	TanggalPerolehan  string                 `json:"tanggal_perolehan,omitempty" bson:"tanggal_perolehan,omitempty"`
+	Perolehan         *Perolehan             `json:"perolehan,omitempty" bson:"perolehan,omitempty"` // data perolehan terstruktur
+	Provenans         []Provenans            `json:"provenans,omitempty" bson:"provenans,omitempty"` // riwayat pemilik sebelumnya, terurut kronologis
+	Deskripsi         string                 `json:"deskripsi,omitempty" bson:"deskripsi,omitempty"`
+	TempatPenyimpanan TempatPenyimpanan      `json:"tempat_penyimpanan,omitempty" bson:"tempat_penyimpanan,omitempty"`
+	Kondisi           string                 `json:"kondisi,omitempty" bson:"kondisi,omitempty"`
+	KondisiTerakhir   *RingkasanKondisi      `json:"kondisi_terakhir,omitempty" bson:"kondisi_terakhir,omitempty"` // ringkasan laporan kondisi terbaru
+	StatusLokasi      string                 `json:"status_lokasi,omitempty" bson:"status_lokasi,omitempty"`       // kosong = di tempat penyimpanan, dipinjamkan = sedang dipinjam institusi lain
+	PinjamanAktif     *PinjamanAktif         `json:"pinjaman_aktif,omitempty" bson:"pinjaman_aktif,omitempty"`     // peminjaman keluar yang sedang berjalan
+	Deaksesi          *RingkasanDeaksesi     `json:"deaksesi,omitempty" bson:"deaksesi,omitempty"`                 // status = selesai berarti koleksi sudah keluar & hanya-baca
+	Media             []Media                `json:"media,omitempty" bson:"media,omitempty"`                       // foto & lampiran, terurut sesuai urutan tampil
+	Atribut           map[string]interface{} `json:"atribut,omitempty" bson:"atribut,omitempty"`                   // atribut tambahan sesuai skema kategori
+	CreatedAt         time.Time              `json:"created_at,omitempty" bson:"created_at,omitempty"`
+	UpdatedAt         time.Time              `json:"updated_at,omitempty" bson:"updated_at,omitempty"`
+	Versi             int64                  `json:"versi,omitempty" bson:"versi,omitempty"` // naik setiap kali data diubah, dipakai sebagai ETag
+	PerubahanID       *primitive.ObjectID    `json:"-" bson:"perubahan_id,omitempty"`        // usulan kontributor terakhir yang diterapkan, untuk melanjutkan persetujuan yang terputus
 }
 
+// Ukuran menyimpan dimensi & berat sebagai angka desimal dengan satuan yang divalidasi.
+// Nilai dalam satuan SI (meter & kilogram) disimpan di Normal untuk pengurutan & filter.
 type Ukuran struct {
 	ID                 primitive.ObjectID `json:"id" bson:"_id"`
-	Lebar              string             `json:"lebar,omitempty" bson:"lebar,omitempty"`
-	Tebal              string             `json:"tebal,omitempty" bson:"tebal,omitempty"`
-	Tinggi             string             `json:"tinggi,omitempty" bson:"tinggi,omitempty"`
-	Diameter           string             `json:"diameter,omitempty" bson:"diameter,omitempty"`
-	Berat              string             `json:"berat,omitempty" bson:"berat,omitempty"`
-	PanjangKeseluruhan string             `json:"panjang_keseluruhan,omitempty" bson:"panjang_keseluruhan,omitempty"`
-	Satuan             string             `json:"satuan,omitempty" bson:"satuan,omitempty"`
-	SatuanBerat        string             `json:"satuan_berat,omitempty" bson:"satuan_berat,omitempty"`
+	Lebar              *float64           `json:"lebar,omitempty" bson:"lebar,omitempty"`
+	Tebal              *float64           `json:"tebal,omitempty" bson:"tebal,omitempty"`
+	Tinggi             *float64           `json:"tinggi,omitempty" bson:"tinggi,omitempty"`
+	Diameter           *float64           `json:"diameter,omitempty" bson:"diameter,omitempty"`
+	Berat              *float64           `json:"berat,omitempty" bson:"berat,omitempty"`
+	PanjangKeseluruhan *float64           `json:"panjang_keseluruhan,omitempty" bson:"panjang_keseluruhan,omitempty"`
+	Satuan             string             `json:"satuan,omitempty" bson:"satuan,omitempty"`             // mm / cm / m
+	SatuanBerat        string             `json:"satuan_berat,omitempty" bson:"satuan_berat,omitempty"` // g / kg
+	Catatan            string             `json:"catatan,omitempty" bson:"catatan,omitempty"`           // teks ukuran lama yang tidak bisa dibaca sebagai angka
+	Normal             *UkuranNormal      `json:"normal,omitempty" bson:"normal,omitempty"`
+}
+
+// UkuranNormal adalah ukuran dalam satuan SI: panjang dalam meter, berat dalam kilogram
+type UkuranNormal struct {
+	Lebar              *float64 `json:"lebar,omitempty" bson:"lebar,omitempty"`
+	Tebal              *float64 `json:"tebal,omitempty" bson:"tebal,omitempty"`
+	Tinggi             *float64 `json:"tinggi,omitempty" bson:"tinggi,omitempty"`
+	Diameter           *float64 `json:"diameter,omitempty" bson:"diameter,omitempty"`
+	Berat              *float64 `json:"berat,omitempty" bson:"berat,omitempty"`
+	PanjangKeseluruhan *float64 `json:"panjang_keseluruhan,omitempty" bson:"panjang_keseluruhan,omitempty"`
+	Bulat              bool     `json:"-" bson:"bulat,omitempty"` // dihitung dengan BulatkanUkuran; data lama tanpa penanda dihitung ulang saat startup
 }
 
 type TempatPenyimpanan struct {
@@ -47,14 +72,24 @@ type TempatPenyimpanan struct {
 type Gudang struct {
 	ID         primitive.ObjectID `json:"id" bson:"_id"`
 	NamaGudang string             `json:"nama_gudang,omitempty" bson:"nama_gudang,omitempty"`
+	NamaNormal string             `json:"-" bson:"nama_normal,omitempty"`         // nama yang dinormalisasi untuk index unik
+	Versi      int64              `json:"versi,omitempty" bson:"versi,omitempty"` // nomor versi untuk ETag / If-Match
 }
 
 type Rak struct {
-	ID      primitive.ObjectID `json:"id" bson:"_id"`
-	NamaRak string             `json:"nama_rak,omitempty" bson:"nama_rak,omitempty"`
+	ID            primitive.ObjectID `json:"id" bson:"_id"`
+	NamaRak       string             `json:"nama_rak,omitempty" bson:"nama_rak,omitempty"`
+	NamaNormal    string             `json:"-" bson:"nama_normal,omitempty"`                           // nama yang dinormalisasi untuk index unik
+	KapasitasSlot int                `json:"kapasitas_slot,omitempty" bson:"kapasitas_slot,omitempty"` // jumlah maksimum koleksi
+	BeratMaks     float64            `json:"berat_maks,omitempty" bson:"berat_maks,omitempty"`         // beban maksimum dalam kg
+	Versi         int64              `json:"versi,omitempty" bson:"versi,omitempty"`                   // nomor versi untuk ETag / If-Match
 }
 
 type Tahap struct {
-	ID        primitive.ObjectID `json:"id" bson:"_id"`
-	NamaTahap string             `json:"nama_tahap,omitempty" bson:"nama_tahap,omitempty"`
+	ID            primitive.ObjectID `json:"id" bson:"_id"`
+	NamaTahap     string             `json:"nama_tahap,omitempty" bson:"nama_tahap,omitempty"`
+	NamaNormal    string             `json:"-" bson:"nama_normal,omitempty"`                           // nama yang dinormalisasi untuk index unik
+	KapasitasSlot int                `json:"kapasitas_slot,omitempty" bson:"kapasitas_slot,omitempty"` // jumlah maksimum koleksi
+	BeratMaks     float64            `json:"berat_maks,omitempty" bson:"berat_maks,omitempty"`         // beban maksimum dalam kg
+	Versi         int64              `json:"versi,omitempty" bson:"versi,omitempty"`                   // nomor versi untuk ETag / If-Match
 }
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Skala kondisi koleksi, dari yang paling baik
+const (
+	KondisiBaik        = "baik"
+	KondisiCukup       = "cukup"
+	KondisiRusakRingan = "rusak_ringan"
+	KondisiRusakBerat  = "rusak_berat"
+	KondisiKritis      = "kritis"
+)
+
+// TingkatKondisi memetakan skala kondisi ke tingkat kerusakan (1 = baik, 5 = kritis)
+var TingkatKondisi = map[string]int{
+	KondisiBaik:        1,
+	KondisiCukup:       2,
+	KondisiRusakRingan: 3,
+	KondisiRusakBerat:  4,
+	KondisiKritis:      5,
+}
+
+// JenisKerusakanValid berisi jenis kerusakan yang diterima API
+var JenisKerusakanValid = map[string]bool{
+	"retak":         true,
+	"pecah":         true,
+	"patah":         true,
+	"sobek":         true,
+	"korosi":        true,
+	"jamur":         true,
+	"serangga":      true,
+	"noda":          true,
+	"pudar":         true,
+	"deformasi":     true,
+	"bagian_hilang": true,
+	"lepas":         true,
+	"lainnya":       true,
+}
+
+// Kerusakan adalah satu temuan kerusakan pada pemeriksaan kondisi
+type Kerusakan struct {
+	Jenis      string `json:"jenis" bson:"jenis" example:"retak"`
+	Area       string `json:"area,omitempty" bson:"area,omitempty" example:"bibir wadah sisi kiri"` // bagian objek yang terdampak
+	Keterangan string `json:"keterangan,omitempty" bson:"keterangan,omitempty"`
+}
+
+// LaporanKondisi adalah hasil satu kali pemeriksaan kondisi koleksi
+type LaporanKondisi struct {
+	ID             primitive.ObjectID `json:"_id" bson:"_id"`
+	KoleksiID      primitive.ObjectID `json:"koleksi_id" bson:"koleksi_id"`
+	Tanggal        time.Time          `json:"tanggal" bson:"tanggal"`
+	Kondisi        string             `json:"kondisi" bson:"kondisi" example:"rusak_ringan"` // baik / cukup / rusak_ringan / rusak_berat / kritis
+	Kerusakan      []Kerusakan        `json:"kerusakan,omitempty" bson:"kerusakan,omitempty"`
+	Pemeriksa      string             `json:"pemeriksa" bson:"pemeriksa" example:"Siti Aminah"`
+	Rekomendasi    string             `json:"rekomendasi,omitempty" bson:"rekomendasi,omitempty"`
+	Catatan        string             `json:"catatan,omitempty" bson:"catatan,omitempty"`
+	PerluPerawatan bool               `json:"perlu_perawatan" bson:"perlu_perawatan"`
+	Media          []Media            `json:"media,omitempty" bson:"media,omitempty"` // foto kondisi / kerusakan
+	CreatedBy      string             `json:"created_by,omitempty" bson:"created_by,omitempty"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// PerawatanKonservasi adalah catatan satu tindakan konservasi pada koleksi
+type PerawatanKonservasi struct {
+	ID             primitive.ObjectID  `json:"_id" bson:"_id"`
+	KoleksiID      primitive.ObjectID  `json:"koleksi_id" bson:"koleksi_id"`
+	LaporanID      *primitive.ObjectID `json:"laporan_id,omitempty" bson:"laporan_id,omitempty"` // laporan kondisi yang ditindaklanjuti
+	Tindakan       string              `json:"tindakan" bson:"tindakan" example:"pembersihan & konsolidasi retakan"`
+	Bahan          []string            `json:"bahan,omitempty" bson:"bahan,omitempty" example:"Paraloid B-72,aseton"`
+	Konservator    string              `json:"konservator" bson:"konservator" example:"Budi Santoso"`
+	TanggalMulai   time.Time           `json:"tanggal_mulai" bson:"tanggal_mulai"`
+	TanggalSelesai *time.Time          `json:"tanggal_selesai,omitempty" bson:"tanggal_selesai,omitempty"` // kosong = masih berjalan
+	Catatan        string              `json:"catatan,omitempty" bson:"catatan,omitempty"`
+	Sebelum        []Media             `json:"sebelum,omitempty" bson:"sebelum,omitempty"` // foto sebelum perawatan
+	Sesudah        []Media             `json:"sesudah,omitempty" bson:"sesudah,omitempty"` // foto sesudah perawatan
+	CreatedBy      string              `json:"created_by,omitempty" bson:"created_by,omitempty"`
+	CreatedAt      time.Time           `json:"created_at" bson:"created_at"`
+}
+
+// SemuaMedia mengembalikan seluruh foto sebelum & sesudah perawatan
+func (p PerawatanKonservasi) SemuaMedia() []Media {
+	return append(append([]Media{}, p.Sebelum...), p.Sesudah...)
+}
+
+// RingkasanKondisi adalah ringkasan pemeriksaan terakhir yang disimpan di dokumen koleksi
+type RingkasanKondisi struct {
+	LaporanID         primitive.ObjectID `json:"laporan_id" bson:"laporan_id"`
+	Tanggal           time.Time          `json:"tanggal" bson:"tanggal"`
+	Kondisi           string             `json:"kondisi" bson:"kondisi"`
+	Tingkat           int                `json:"tingkat" bson:"tingkat"` // 1 = baik, 5 = kritis
+	JenisKerusakan    []string           `json:"jenis_kerusakan,omitempty" bson:"jenis_kerusakan,omitempty"`
+	Pemeriksa         string             `json:"pemeriksa" bson:"pemeriksa"`
+	PerluPerawatan    bool               `json:"perlu_perawatan" bson:"perlu_perawatan"` // false jika sudah ada perawatan selesai setelah pemeriksaan
+	PerawatanBerjalan bool               `json:"perawatan_berjalan" bson:"perawatan_berjalan"`
+	PerawatanTerakhir *time.Time         `json:"perawatan_terakhir,omitempty" bson:"perawatan_terakhir,omitempty"`
+}
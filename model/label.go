@@ -0,0 +1,46 @@
+package model
+
+// FormatLabel adalah ukuran kertas label siap cetak (semua satuan dalam mm)
+type FormatLabel struct {
+	Nama       string  `json:"nama"`
+	Keterangan string  `json:"keterangan"`
+	Kertas     string  `json:"kertas"` // A4 / Letter
+	Kolom      int     `json:"kolom"`
+	Baris      int     `json:"baris"`
+	Lebar      float64 `json:"lebar"`       // lebar satu label
+	Tinggi     float64 `json:"tinggi"`      // tinggi satu label
+	MarginAtas float64 `json:"margin_atas"` // jarak tepi atas kertas ke label pertama
+	MarginKiri float64 `json:"margin_kiri"` // jarak tepi kiri kertas ke label pertama
+	JarakKolom float64 `json:"jarak_kolom"` // jarak awal label ke awal label berikutnya (horizontal)
+	JarakBaris float64 `json:"jarak_baris"` // jarak awal label ke awal label berikutnya (vertikal)
+}
+
+// FormatLabelTersedia berisi format kertas label standar yang didukung
+var FormatLabelTersedia = map[string]FormatLabel{
+	"a4-21": {Nama: "a4-21", Keterangan: "A4, 3 x 7 label 63,5 x 38,1 mm (Avery L7160)", Kertas: "A4",
+		Kolom: 3, Baris: 7, Lebar: 63.5, Tinggi: 38.1, MarginAtas: 15.15, MarginKiri: 7.2, JarakKolom: 66.0, JarakBaris: 38.1},
+	"a4-24": {Nama: "a4-24", Keterangan: "A4, 3 x 8 label 63,5 x 33,9 mm (Avery L7159)", Kertas: "A4",
+		Kolom: 3, Baris: 8, Lebar: 63.5, Tinggi: 33.9, MarginAtas: 12.9, MarginKiri: 6.5, JarakKolom: 66.0, JarakBaris: 33.9},
+	"a4-65": {Nama: "a4-65", Keterangan: "A4, 5 x 13 label 38,1 x 21,2 mm (Avery L7651)", Kertas: "A4",
+		Kolom: 5, Baris: 13, Lebar: 38.1, Tinggi: 21.2, MarginAtas: 10.7, MarginKiri: 4.7, JarakKolom: 40.6, JarakBaris: 21.2},
+	"letter-30": {Nama: "letter-30", Keterangan: "Letter, 3 x 10 label 66,7 x 25,4 mm (Avery 5160)", Kertas: "Letter",
+		Kolom: 3, Baris: 10, Lebar: 66.675, Tinggi: 25.4, MarginAtas: 12.7, MarginKiri: 4.7625, JarakKolom: 69.85, JarakBaris: 25.4},
+}
+
+// ItemLabel adalah isi satu label: kode yang di-encode beserta teks yang dicetak
+type ItemLabel struct {
+	Kode  string   // isi Code128 (no_inv atau kode lokasi)
+	URL   string   // isi QR code
+	Judul string   // teks utama (no_inv / nama lokasi)
+	Baris []string // teks tambahan di bawah judul
+}
+
+// HasilResolve adalah data yang ditemukan dari kode hasil scan label
+type HasilResolve struct {
+	Message string      `json:"message" example:"Kode ditemukan"`
+	Kode    string      `json:"kode" example:"INV.2024.0012"`
+	Jenis   string      `json:"jenis" example:"koleksi"` // koleksi / gudang / rak / tahap
+	Cocok   string      `json:"cocok" example:"no_inv"`  // no_inv / no_reg / kode_lokasi / url / id
+	ID      string      `json:"id" example:"65f1c0a1b2c3d4e5f6a7b8c9"`
+	Data    interface{} `json:"data"`
+}
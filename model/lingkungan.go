@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LokasiSensor adalah metadata time-series: lokasi tempat sensor dipasang
+type LokasiSensor struct {
+	GudangID primitive.ObjectID `json:"gudang_id" bson:"gudang_id"`
+	RakID    primitive.ObjectID `json:"rak_id,omitempty" bson:"rak_id,omitempty"`
+	SensorID string             `json:"sensor_id,omitempty" bson:"sensor_id,omitempty"`
+}
+
+// PembacaanSensor adalah satu data suhu / kelembapan dari sensor (collection time-series)
+type PembacaanSensor struct {
+	ID         primitive.ObjectID `json:"_id,omitempty" bson:"_id,omitempty"`
+	Waktu      time.Time          `json:"waktu" bson:"waktu"`
+	Lokasi     LokasiSensor       `json:"lokasi" bson:"lokasi"`
+	Suhu       *float64           `json:"suhu,omitempty" bson:"suhu,omitempty"`             // derajat Celsius
+	Kelembapan *float64           `json:"kelembapan,omitempty" bson:"kelembapan,omitempty"` // persen RH
+}
+
+// PembacaanSensorRequest untuk request ingest data sensor
+type PembacaanSensorRequest struct {
+	GudangID   string   `json:"gudang_id" example:"693a3a7a416cd8d592b5058e"`
+	RakID      string   `json:"rak_id,omitempty" example:"693a3a7a416cd8d592b5058f"`
+	SensorID   string   `json:"sensor_id,omitempty" example:"sensor-gudang-a-01"`
+	Waktu      string   `json:"waktu,omitempty" example:"2026-01-22T15:11:51Z"` // RFC3339, kosong = waktu server
+	Suhu       *float64 `json:"suhu,omitempty" example:"22.5"`
+	Kelembapan *float64 `json:"kelembapan,omitempty" example:"55"`
+}
+
+// AmbangLingkungan adalah batas suhu / kelembapan yang diizinkan untuk satu gudang atau rak
+type AmbangLingkungan struct {
+	ID            primitive.ObjectID `json:"_id,omitempty" bson:"_id,omitempty"`
+	GudangID      primitive.ObjectID `json:"gudang_id" bson:"gudang_id"`
+	RakID         primitive.ObjectID `json:"rak_id,omitempty" bson:"rak_id,omitempty"`
+	SuhuMin       *float64           `json:"suhu_min,omitempty" bson:"suhu_min,omitempty"`
+	SuhuMax       *float64           `json:"suhu_max,omitempty" bson:"suhu_max,omitempty"`
+	KelembapanMin *float64           `json:"kelembapan_min,omitempty" bson:"kelembapan_min,omitempty"`
+	KelembapanMax *float64           `json:"kelembapan_max,omitempty" bson:"kelembapan_max,omitempty"`
+	UpdatedAt     time.Time          `json:"updated_at,omitempty" bson:"updated_at,omitempty"`
+}
+
+// PeringatanLingkungan dibuat ketika pembacaan sensor berada di luar ambang batas
+type PeringatanLingkungan struct {
+	ID        primitive.ObjectID `json:"_id,omitempty" bson:"_id,omitempty"`
+	Waktu     time.Time          `json:"waktu" bson:"waktu"`
+	Lokasi    LokasiSensor       `json:"lokasi" bson:"lokasi"`
+	Parameter string             `json:"parameter" bson:"parameter"` // suhu / kelembapan
+	Nilai     float64            `json:"nilai" bson:"nilai"`
+	BatasMin  *float64           `json:"batas_min,omitempty" bson:"batas_min,omitempty"`
+	BatasMax  *float64           `json:"batas_max,omitempty" bson:"batas_max,omitempty"`
+	Pesan     string             `json:"pesan" bson:"pesan"`
+	Selesai   bool               `json:"selesai" bson:"selesai"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+
+	// Pembacaan berikutnya yang masih di luar ambang memperbarui peringatan yang belum selesai, bukan membuat peringatan baru
+	NilaiTerakhir   float64    `json:"nilai_terakhir,omitempty" bson:"nilai_terakhir,omitempty"`
+	WaktuTerakhir   *time.Time `json:"waktu_terakhir,omitempty" bson:"waktu_terakhir,omitempty"`
+	JumlahPembacaan int        `json:"jumlah_pembacaan,omitempty" bson:"jumlah_pembacaan,omitempty"` // jumlah pembacaan di luar ambang sejak peringatan dibuat
+}
+
+// StatistikLingkungan berisi ringkasan min/max/rata-rata dalam satu jendela waktu
+type StatistikLingkungan struct {
+	Waktu         *time.Time `json:"waktu,omitempty" bson:"_id,omitempty"` // awal interval jika dikelompokkan
+	Jumlah        int        `json:"jumlah" bson:"jumlah"`
+	SuhuMin       *float64   `json:"suhu_min" bson:"suhu_min"`
+	SuhuMax       *float64   `json:"suhu_max" bson:"suhu_max"`
+	SuhuAvg       *float64   `json:"suhu_avg" bson:"suhu_avg"`
+	KelembapanMin *float64   `json:"kelembapan_min" bson:"kelembapan_min"`
+	KelembapanMax *float64   `json:"kelembapan_max" bson:"kelembapan_max"`
+	KelembapanAvg *float64   `json:"kelembapan_avg" bson:"kelembapan_avg"`
+}
@@ -0,0 +1,58 @@
+package model
+
+// Status hasil ubah massal per koleksi
+const (
+	StatusMassalSiap         = "siap" // dry run: perubahan valid dan akan diterapkan
+	StatusMassalBerhasil     = "berhasil"
+	StatusMassalTidakBerubah = "tidak_berubah"
+	StatusMassalGagal        = "gagal"
+)
+
+// FilterUbahMassal memilih koleksi yang diubah massal. Kriteria yang diisi digabung dengan AND.
+type FilterUbahMassal struct {
+	KategoriID string `json:"kategori_id" example:"665f1c2a9b1e8a0012345678"` // termasuk sub-kategori
+	GudangID   string `json:"gudang_id"`
+	RakID      string `json:"rak_id"`
+	TahapID    string `json:"tahap_id"`
+	Kondisi    string `json:"kondisi"`
+}
+
+// PerubahanMassal berisi field yang diubah pada setiap koleksi; field yang tidak dikirim tidak diubah
+type PerubahanMassal struct {
+	KategoriID       string                 `json:"kategori_id"`
+	GudangID         string                 `json:"gudang_id"`
+	RakID            *string                `json:"rak_id"`   // "" = kosongkan rak
+	TahapID          *string                `json:"tahap_id"` // "" = kosongkan tahap
+	Kondisi          *string                `json:"kondisi"`
+	Atribut          map[string]interface{} `json:"atribut"`            // digabung dengan atribut lama, nilai null menghapus atribut
+	BuangAtributLain bool                   `json:"buang_atribut_lain"` // buang atribut yang tidak ada di skema kategori baru
+}
+
+// UbahMassalRequest untuk mengubah banyak koleksi sekaligus berdasarkan daftar ID atau filter
+type UbahMassalRequest struct {
+	IDs       []string          `json:"ids"`
+	Filter    *FilterUbahMassal `json:"filter"`
+	Perubahan PerubahanMassal   `json:"perubahan"`
+	DryRun    bool              `json:"dry_run"` // true = hanya pratinjau, tidak ada data yang diubah
+}
+
+// HasilUbahMassal adalah hasil validasi / penerapan perubahan untuk satu koleksi
+type HasilUbahMassal struct {
+	KoleksiID    string   `json:"koleksi_id"`
+	NoInventaris string   `json:"no_inv,omitempty"`
+	NamaBenda    string   `json:"nama_benda,omitempty"`
+	Status       string   `json:"status" example:"siap"` // siap / berhasil / tidak_berubah / gagal
+	Field        []string `json:"field,omitempty"`       // field yang berubah
+	Error        string   `json:"error,omitempty"`
+}
+
+// LaporanUbahMassal adalah response ubah massal koleksi
+type LaporanUbahMassal struct {
+	Message      string            `json:"message" example:"Pratinjau ubah massal"`
+	DryRun       bool              `json:"dry_run"`
+	Total        int               `json:"total"`
+	Diubah       int               `json:"diubah"` // siap (dry run) atau berhasil
+	TidakBerubah int               `json:"tidak_berubah"`
+	Gagal        int               `json:"gagal"`
+	Hasil        []HasilUbahMassal `json:"hasil"`
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Jenis media yang bisa dilampirkan pada koleksi
+const (
+	TipeMediaFoto    = "foto"    // foto objek dari berbagai sudut
+	TipeMediaKondisi = "kondisi" // foto kondisi / kerusakan
+	TipeMediaXRay    = "xray"    // hasil X-ray / pemindaian
+	TipeMediaDokumen = "dokumen" // dokumen hasil scan (surat, sertifikat, dll)
+	TipeMediaLainnya = "lainnya"
+)
+
+// TipeMediaValid berisi tipe media yang diterima API
+var TipeMediaValid = map[string]bool{
+	TipeMediaFoto:    true,
+	TipeMediaKondisi: true,
+	TipeMediaXRay:    true,
+	TipeMediaDokumen: true,
+	TipeMediaLainnya: true,
+}
+
+// Media adalah satu file yang dilampirkan pada koleksi. Urutan array = urutan tampil.
+type Media struct {
+	ID         primitive.ObjectID      `json:"_id" bson:"_id"`
+	URL        string                  `json:"url" bson:"url"`
+	Key        string                  `json:"key,omitempty" bson:"key,omitempty"` // key di storage, dipakai saat menghapus file
+	MIME       string                  `json:"mime,omitempty" bson:"mime,omitempty"`
+	Lebar      int                     `json:"lebar,omitempty" bson:"lebar,omitempty"`     // lebar gambar asli (px)
+	Tinggi     int                     `json:"tinggi,omitempty" bson:"tinggi,omitempty"`   // tinggi gambar asli (px)
+	Ukuran     int64                   `json:"ukuran,omitempty" bson:"ukuran,omitempty"`   // ukuran file asli (byte)
+	SHA256     string                  `json:"sha256,omitempty" bson:"sha256,omitempty"`   // hash isi file asli untuk cek integritas
+	Rendisi    map[string]RendisiMedia `json:"rendisi,omitempty" bson:"rendisi,omitempty"` // thumbnail / medium / large
+	Tipe       string                  `json:"tipe" bson:"tipe"`
+	Keterangan string                  `json:"keterangan,omitempty" bson:"keterangan,omitempty"` // caption
+	Fotografer string                  `json:"fotografer,omitempty" bson:"fotografer,omitempty"`
+	Tanggal    string                  `json:"tanggal,omitempty" bson:"tanggal,omitempty"` // tanggal pengambilan (YYYY-MM-DD)
+	Utama      bool                    `json:"utama" bson:"utama"`                         // media utama / thumbnail koleksi
+	Urutan     int                     `json:"urutan" bson:"urutan"`
+	CreatedAt  time.Time               `json:"created_at,omitempty" bson:"created_at,omitempty"`
+}
+
+// Nama rendisi gambar yang dibuat saat upload
+const (
+	RendisiThumbnail = "thumbnail"
+	RendisiMedium    = "medium"
+	RendisiLarge     = "large"
+)
+
+// RendisiMedia adalah versi gambar yang sudah diperkecil, dalam format JPEG dan WebP
+type RendisiMedia struct {
+	Lebar   int    `json:"lebar" bson:"lebar"`
+	Tinggi  int    `json:"tinggi" bson:"tinggi"`
+	URL     string `json:"url" bson:"url"` // JPEG
+	Key     string `json:"key,omitempty" bson:"key,omitempty"`
+	WebPURL string `json:"webp_url,omitempty" bson:"webp_url,omitempty"`
+	WebPKey string `json:"webp_key,omitempty" bson:"webp_key,omitempty"`
+}
+
+// SemuaKey mengembalikan seluruh key storage milik media (file asli & semua rendisi)
+func (m Media) SemuaKey() []string {
+	var keys []string
+	if m.Key != "" {
+		keys = append(keys, m.Key)
+	}
+	for _, r := range m.Rendisi {
+		if r.Key != "" {
+			keys = append(keys, r.Key)
+		}
+		if r.WebPKey != "" {
+			keys = append(keys, r.WebPKey)
+		}
+	}
+	return keys
+}
+
+// UrutanMediaRequest berisi seluruh ID media koleksi dengan urutan yang baru
+type UrutanMediaRequest struct {
+	MediaIDs []string `json:"media_ids" example:"665f1c2a9b1e8a0012345678,665f1c2a9b1e8a0012345679"`
+}
+
+// ObjekMedia mencatat satu file di storage beserta jumlah media koleksi yang memakainya
+type ObjekMedia struct {
+	Key         string     `json:"key" bson:"_id"`
+	URL         string     `json:"url" bson:"url"`
+	ContentType string     `json:"content_type,omitempty" bson:"content_type,omitempty"`
+	Ukuran      int64      `json:"ukuran" bson:"ukuran"`                     // byte
+	SHA256      string     `json:"sha256,omitempty" bson:"sha256,omitempty"` // hash isi file, dipakai untuk deduplikasi
+	RefCount    int        `json:"ref_count" bson:"ref_count"`
+	YatimSejak  *time.Time `json:"yatim_sejak,omitempty" bson:"yatim_sejak,omitempty"` // sejak kapan tidak dipakai koleksi mana pun
+	Dihapus     *time.Time `json:"dihapus,omitempty" bson:"dihapus,omitempty"`         // sedang dihapus dari storage, tidak boleh dipakai ulang
+	CreatedAt   time.Time  `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" bson:"updated_at"`
+}
+
+// MediaYatim adalah file di storage yang tidak lagi direferensikan koleksi mana pun
+type MediaYatim struct {
+	Key        string     `json:"key"`
+	URL        string     `json:"url"`
+	Ukuran     int64      `json:"ukuran,omitempty"`
+	Tercatat   bool       `json:"tercatat"` // false = file lama yang baru dicatat di objek_media pada pemindaian ini
+	YatimSejak *time.Time `json:"yatim_sejak,omitempty"`
+}
+
+// LaporanGCMedia adalah hasil garbage collection media
+type LaporanGCMedia struct {
+	Hapus         bool         `json:"hapus"` // false = hanya daftar (dry run)
+	JumlahFile    int          `json:"jumlah_file"`
+	JumlahDipakai int          `json:"jumlah_dipakai"`
+	Yatim         []MediaYatim `json:"yatim"`
+	Dihapus       []string     `json:"dihapus,omitempty"`
+	Gagal         []string     `json:"gagal,omitempty"`
+	TotalUkuran   int64        `json:"total_ukuran"` // total byte media yatim yang tercatat
+}
+
+// VerifikasiMedia adalah hasil pengecekan integritas file media terhadap hash yang tercatat
+type VerifikasiMedia struct {
+	Key          string `json:"key"`
+	SHA256       string `json:"sha256"`        // hash yang tercatat
+	SHA256Aktual string `json:"sha256_aktual"` // hash file di storage saat ini
+	Cocok        bool   `json:"cocok"`
+}
@@ -0,0 +1,10 @@
+package model
+
+import "strings"
+
+// NormalisasiNama menyeragamkan nama master data untuk pengecekan duplikat:
+// huruf kecil, tanpa spasi di awal/akhir, dan spasi ganda dijadikan satu.
+// Contoh: "  Keramik " dan "keramik" sama-sama menjadi "keramik".
+func NormalisasiNama(nama string) string {
+	return strings.ToLower(strings.Join(strings.Fields(nama), " "))
+}
@@ -0,0 +1,23 @@
+package model
+
+import "testing"
+
+func TestNormalisasiNama(t *testing.T) {
+	tests := []struct {
+		nama string
+		want string
+	}{
+		{"Keramik", "keramik"},
+		{"  Keramik ", "keramik"},
+		{"Alat   Musik\tTradisional", "alat musik tradisional"},
+		{"GUDANG  A\n", "gudang a"},
+		{"", ""},
+		{"   ", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalisasiNama(tt.nama); got != tt.want {
+			t.Errorf("NormalisasiNama(%q) = %q, want %q", tt.nama, got, tt.want)
+		}
+	}
+}
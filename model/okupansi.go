@@ -0,0 +1,26 @@
+package model
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// OkupansiLokasi berisi ringkasan isi satu lokasi penyimpanan (gudang, rak, atau tahap)
+type OkupansiLokasi struct {
+	ID                primitive.ObjectID `json:"id"`
+	Nama              string             `json:"nama"`
+	JumlahKoleksi     int                `json:"jumlah_koleksi"`
+	TotalBerat        float64            `json:"total_berat"`              // dalam kg
+	TanpaBerat        int                `json:"tanpa_berat"`              // koleksi yang beratnya kosong / tidak terbaca
+	KapasitasSlot     int                `json:"kapasitas_slot,omitempty"` // 0 = tidak dibatasi
+	BeratMaks         float64            `json:"berat_maks,omitempty"`     // 0 = tidak dibatasi
+	SisaSlot          *int               `json:"sisa_slot,omitempty"`      // nil jika kapasitas slot tidak diatur
+	SisaBerat         *float64           `json:"sisa_berat,omitempty"`     // nil jika berat maksimum tidak diatur
+	Penuh             bool               `json:"penuh"`                    // slot atau berat sudah mencapai batas
+	MelebihiKapasitas bool               `json:"melebihi_kapasitas"`       // slot atau berat sudah melewati batas
+}
+
+// OkupansiResponse untuk response laporan okupansi tempat penyimpanan
+type OkupansiResponse struct {
+	Message string           `json:"message" example:"Berhasil mengambil data okupansi"`
+	Gudang  []OkupansiLokasi `json:"gudang"`
+	Rak     []OkupansiLokasi `json:"rak"`
+	Tahap   []OkupansiLokasi `json:"tahap"`
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Pameran adalah satu pameran (tetap maupun temporer) beserta koleksi yang dipamerkan
+type Pameran struct {
+	ID             primitive.ObjectID `json:"_id" bson:"_id"`
+	Judul          string             `json:"judul" bson:"judul" example:"Keramik Nusantara"`
+	Tempat         string             `json:"tempat" bson:"tempat" example:"Ruang Pamer Temporer Lt. 2"`
+	TanggalMulai   time.Time          `json:"tanggal_mulai" bson:"tanggal_mulai"`
+	TanggalSelesai *time.Time         `json:"tanggal_selesai,omitempty" bson:"tanggal_selesai,omitempty"` // kosong = pameran tetap
+	Kurator        string             `json:"kurator,omitempty" bson:"kurator,omitempty" example:"Dewi Lestari"`
+	Deskripsi      string             `json:"deskripsi,omitempty" bson:"deskripsi,omitempty"`
+	Koleksi        []ItemPameran      `json:"koleksi" bson:"koleksi"`
+	CreatedBy      string             `json:"created_by,omitempty" bson:"created_by,omitempty"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// ItemPameran adalah satu koleksi yang ditempatkan di pameran
+type ItemPameran struct {
+	KoleksiID    primitive.ObjectID `json:"koleksi_id" bson:"koleksi_id"`
+	NamaBenda    string             `json:"nama_benda" bson:"nama_benda"`
+	NoInventaris string             `json:"no_inv,omitempty" bson:"no_inv,omitempty"`
+	Posisi       string             `json:"posisi,omitempty" bson:"posisi,omitempty" example:"Vitrin 3"` // vitrin / zona di ruang pamer
+	Catatan      string             `json:"catatan,omitempty" bson:"catatan,omitempty"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// PameranRequest untuk membuat / mengubah data pameran. Tanggal dalam format YYYY-MM-DD.
+type PameranRequest struct {
+	Judul          string `json:"judul" example:"Keramik Nusantara"`
+	Tempat         string `json:"tempat" example:"Ruang Pamer Temporer Lt. 2"`
+	TanggalMulai   string `json:"tanggal_mulai" example:"2025-08-01"`
+	TanggalSelesai string `json:"tanggal_selesai" example:"2025-10-31"` // kosongkan untuk pameran tetap
+	Kurator        string `json:"kurator"`
+	Deskripsi      string `json:"deskripsi"`
+}
+
+// TambahKoleksiPameranRequest berisi koleksi yang ditambahkan ke pameran
+type TambahKoleksiPameranRequest struct {
+	Koleksi []ItemPameranInput `json:"koleksi"`
+}
+
+// ItemPameranInput adalah satu koleksi pada request penambahan koleksi pameran
+type ItemPameranInput struct {
+	KoleksiID string `json:"koleksi_id" example:"665f1c2a9b1e8a0012345678"`
+	Posisi    string `json:"posisi" example:"Vitrin 3"`
+	Catatan   string `json:"catatan"`
+}
+
+// BentrokJadwal adalah jadwal lain (pameran / peminjaman keluar) yang memakai koleksi yang sama
+type BentrokJadwal struct {
+	KoleksiID      primitive.ObjectID `json:"koleksi_id"`
+	NamaBenda      string             `json:"nama_benda"`
+	Jenis          string             `json:"jenis" example:"pameran"` // pameran / peminjaman
+	ID             primitive.ObjectID `json:"id"`
+	Nama           string             `json:"nama"` // judul pameran / nama institusi peminjam
+	TanggalMulai   time.Time          `json:"tanggal_mulai"`
+	TanggalSelesai *time.Time         `json:"tanggal_selesai,omitempty"`
+}
+
+// ItemChecklistPameran adalah satu baris checklist pengambilan koleksi dari tempat penyimpanan
+type ItemChecklistPameran struct {
+	KoleksiID    primitive.ObjectID `json:"koleksi_id"`
+	NoRegistrasi string             `json:"no_reg,omitempty"`
+	NoInventaris string             `json:"no_inv,omitempty"`
+	NamaBenda    string             `json:"nama_benda"`
+	Gudang       string             `json:"gudang,omitempty"`
+	Rak          string             `json:"rak,omitempty"`
+	Tahap        string             `json:"tahap,omitempty"`
+	StatusLokasi string             `json:"status_lokasi,omitempty"`
+	Kondisi      string             `json:"kondisi,omitempty"`
+	Posisi       string             `json:"posisi,omitempty"` // tujuan di ruang pamer
+	Catatan      string             `json:"catatan,omitempty"`
+	Ditemukan    bool               `json:"ditemukan"` // false jika koleksi sudah dihapus
+}
+
+// ChecklistPameran adalah response checklist pengambilan koleksi untuk satu pameran
+type ChecklistPameran struct {
+	Message string                 `json:"message" example:"Berhasil menyusun checklist pameran"`
+	Pameran Pameran                `json:"pameran"`
+	Total   int                    `json:"total"`
+	Data    []ItemChecklistPameran `json:"data"` // urut per gudang, rak, tahap
+}
@@ -0,0 +1,44 @@
+package model
+
+// PatchKoleksi adalah bentuk dokumen koleksi yang bisa diubah lewat PATCH /koleksi/{id}.
+// Dengan JSON Merge Patch hanya field yang dikirim yang diubah; null mengosongkan field opsional.
+// Media tidak termasuk, kelola lewat /koleksi/{id}/media.
+type PatchKoleksi struct {
+	KategoriID       string                 `json:"kategori_id,omitempty"`
+	NoRegistrasi     string                 `json:"no_reg,omitempty"`
+	NoInventaris     string                 `json:"no_inv,omitempty"`
+	NamaBenda        string                 `json:"nama_benda,omitempty"`
+	GudangID         string                 `json:"gudang_id,omitempty"`
+	RakID            string                 `json:"rak_id,omitempty"`
+	TahapID          string                 `json:"tahap_id,omitempty"`
+	Catatan          string                 `json:"catatan,omitempty"` // catatan tempat penyimpanan
+	AsalKoleksi      string                 `json:"asal_koleksi,omitempty"`
+	Bahan            string                 `json:"bahan,omitempty"`
+	TempatPerolehan  string                 `json:"tempat_perolehan,omitempty"`
+	TanggalPerolehan string                 `json:"tanggal_perolehan,omitempty"`
+	Deskripsi        string                 `json:"deskripsi,omitempty"`
+	Kondisi          string                 `json:"kondisi,omitempty"`
+	Ukuran           *PatchUkuran           `json:"ukuran,omitempty"`
+	Atribut          map[string]interface{} `json:"atribut,omitempty"`
+}
+
+// PatchUkuran adalah ukuran koleksi di dalam PatchKoleksi
+type PatchUkuran struct {
+	PanjangKeseluruhan *float64 `json:"panjang_keseluruhan,omitempty"`
+	Lebar              *float64 `json:"lebar,omitempty"`
+	Tebal              *float64 `json:"tebal,omitempty"`
+	Tinggi             *float64 `json:"tinggi,omitempty"`
+	Diameter           *float64 `json:"diameter,omitempty"`
+	Satuan             string   `json:"satuan,omitempty"`
+	Berat              *float64 `json:"berat,omitempty"`
+	SatuanBerat        string   `json:"satuan_berat,omitempty"`
+	Catatan            string   `json:"catatan,omitempty"`
+}
+
+// OperasiJSONPatch adalah satu operasi JSON Patch (RFC 6902) terhadap PatchKoleksi
+type OperasiJSONPatch struct {
+	Op    string      `json:"op" example:"replace"` // add / remove / replace / move / copy / test
+	Path  string      `json:"path" example:"/deskripsi"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
@@ -0,0 +1,121 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Arah peminjaman dilihat dari museum
+const (
+	ArahPeminjamanKeluar = "keluar" // koleksi museum dipinjamkan ke institusi lain
+	ArahPeminjamanMasuk  = "masuk"  // museum meminjam benda dari institusi lain
+)
+
+// Status alur peminjaman
+const (
+	StatusPinjamDiajukan     = "diajukan"
+	StatusPinjamDisetujui    = "disetujui"
+	StatusPinjamDikirim      = "dikirim"
+	StatusPinjamDikembalikan = "dikembalikan"
+	StatusPinjamDibatalkan   = "dibatalkan"
+)
+
+// TransisiStatusPinjam berisi status tujuan yang boleh dipilih dari setiap status
+var TransisiStatusPinjam = map[string][]string{
+	StatusPinjamDiajukan:     {StatusPinjamDisetujui, StatusPinjamDibatalkan},
+	StatusPinjamDisetujui:    {StatusPinjamDikirim, StatusPinjamDibatalkan},
+	StatusPinjamDikirim:      {StatusPinjamDikembalikan},
+	StatusPinjamDikembalikan: {},
+	StatusPinjamDibatalkan:   {},
+}
+
+// Status lokasi koleksi selain tersimpan di tempat penyimpanan
+const (
+	StatusLokasiDipinjamkan = "dipinjamkan"
+)
+
+// InstitusiPeminjaman adalah museum / lembaga peminjam (arah keluar) atau pemberi pinjaman (arah masuk)
+type InstitusiPeminjaman struct {
+	Nama       string `json:"nama" bson:"nama" example:"Museum Nasional Indonesia"`
+	Alamat     string `json:"alamat,omitempty" bson:"alamat,omitempty"`
+	NamaKontak string `json:"nama_kontak,omitempty" bson:"nama_kontak,omitempty"`
+	Kontak     string `json:"kontak,omitempty" bson:"kontak,omitempty"` // email / telepon
+}
+
+// ItemPeminjaman adalah satu benda dalam perjanjian pinjam.
+// Untuk arah keluar koleksi_id wajib; untuk arah masuk boleh hanya nama benda.
+type ItemPeminjaman struct {
+	KoleksiID     *primitive.ObjectID `json:"koleksi_id,omitempty" bson:"koleksi_id,omitempty"`
+	NamaBenda     string              `json:"nama_benda" bson:"nama_benda"`
+	NoInventaris  string              `json:"no_inv,omitempty" bson:"no_inv,omitempty"`
+	NilaiAsuransi *float64            `json:"nilai_asuransi,omitempty" bson:"nilai_asuransi,omitempty"`
+	Catatan       string              `json:"catatan,omitempty" bson:"catatan,omitempty"`
+}
+
+// RiwayatStatusPinjam mencatat setiap perubahan status peminjaman
+type RiwayatStatusPinjam struct {
+	Status  string    `json:"status" bson:"status"`
+	Tanggal time.Time `json:"tanggal" bson:"tanggal"`
+	Oleh    string    `json:"oleh,omitempty" bson:"oleh,omitempty"`
+	Catatan string    `json:"catatan,omitempty" bson:"catatan,omitempty"`
+}
+
+// Peminjaman adalah satu perjanjian pinjam koleksi
+type Peminjaman struct {
+	ID             primitive.ObjectID    `json:"_id" bson:"_id"`
+	NoPerjanjian   string                `json:"no_perjanjian,omitempty" bson:"no_perjanjian,omitempty" example:"PJM/2025/004"`
+	Arah           string                `json:"arah" bson:"arah" example:"keluar"` // keluar / masuk
+	Institusi      InstitusiPeminjaman   `json:"institusi" bson:"institusi"`
+	Keperluan      string                `json:"keperluan,omitempty" bson:"keperluan,omitempty" example:"Pameran Keramik Nusantara"`
+	TanggalMulai   time.Time             `json:"tanggal_mulai" bson:"tanggal_mulai"`
+	TanggalSelesai time.Time             `json:"tanggal_selesai" bson:"tanggal_selesai"` // batas pengembalian
+	NilaiAsuransi  *float64              `json:"nilai_asuransi,omitempty" bson:"nilai_asuransi,omitempty"`
+	MataUang       string                `json:"mata_uang,omitempty" bson:"mata_uang,omitempty" example:"IDR"`
+	Koleksi        []ItemPeminjaman      `json:"koleksi" bson:"koleksi"`
+	Status         string                `json:"status" bson:"status"`
+	Riwayat        []RiwayatStatusPinjam `json:"riwayat,omitempty" bson:"riwayat,omitempty"`
+	Catatan        string                `json:"catatan,omitempty" bson:"catatan,omitempty"`
+	Terlambat      bool                  `json:"terlambat" bson:"-"`                // diisi server: sudah lewat tanggal_selesai tapi belum dikembalikan
+	HariTerlambat  int                   `json:"hari_terlambat,omitempty" bson:"-"` // diisi server
+	CreatedBy      string                `json:"created_by,omitempty" bson:"created_by,omitempty"`
+	CreatedAt      time.Time             `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at" bson:"updated_at"`
+}
+
+// PeminjamanRequest untuk membuat / mengubah perjanjian pinjam. Tanggal dalam format YYYY-MM-DD.
+type PeminjamanRequest struct {
+	NoPerjanjian   string                `json:"no_perjanjian"`
+	Arah           string                `json:"arah" example:"keluar"`
+	Institusi      InstitusiPeminjaman   `json:"institusi"`
+	Keperluan      string                `json:"keperluan"`
+	TanggalMulai   string                `json:"tanggal_mulai" example:"2025-07-01"`
+	TanggalSelesai string                `json:"tanggal_selesai" example:"2025-12-31"`
+	NilaiAsuransi  *float64              `json:"nilai_asuransi"`
+	MataUang       string                `json:"mata_uang" example:"IDR"`
+	Koleksi        []ItemPeminjamanInput `json:"koleksi"`
+	Catatan        string                `json:"catatan"`
+}
+
+// ItemPeminjamanInput adalah satu benda pada request peminjaman
+type ItemPeminjamanInput struct {
+	KoleksiID     string   `json:"koleksi_id" example:"665f1c2a9b1e8a0012345678"`
+	NamaBenda     string   `json:"nama_benda"` // wajib jika koleksi_id kosong (arah masuk)
+	NilaiAsuransi *float64 `json:"nilai_asuransi"`
+	Catatan       string   `json:"catatan"`
+}
+
+// StatusPeminjamanRequest untuk memindahkan status peminjaman
+type StatusPeminjamanRequest struct {
+	Status  string `json:"status" example:"disetujui"`
+	Catatan string `json:"catatan"`
+}
+
+// PinjamanAktif adalah ringkasan peminjaman keluar yang sedang berjalan, disimpan di dokumen koleksi
+type PinjamanAktif struct {
+	PeminjamanID   primitive.ObjectID `json:"peminjaman_id" bson:"peminjaman_id"`
+	NoPerjanjian   string             `json:"no_perjanjian,omitempty" bson:"no_perjanjian,omitempty"`
+	Institusi      string             `json:"institusi" bson:"institusi"`
+	TanggalKirim   time.Time          `json:"tanggal_kirim" bson:"tanggal_kirim"`
+	TanggalSelesai time.Time          `json:"tanggal_selesai" bson:"tanggal_selesai"`
+}
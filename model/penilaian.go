@@ -0,0 +1,90 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MetodePenilaianValid berisi metode penilaian (valuasi) koleksi yang diterima API
+var MetodePenilaianValid = map[string]bool{
+	"pasar":       true, // perbandingan harga pasar / lelang
+	"penggantian": true, // biaya penggantian dengan benda sejenis
+	"ahli":        true, // taksiran ahli / appraiser
+	"perolehan":   true, // mengikuti harga perolehan
+	"lainnya":     true,
+}
+
+// TujuanPenilaianValid berisi keperluan penilaian koleksi
+var TujuanPenilaianValid = map[string]bool{
+	"asuransi":     true,
+	"peminjaman":   true, // nilai pertanggungan pada perjanjian peminjaman
+	"laporan_aset": true, // laporan aset tahunan
+	"lainnya":      true,
+}
+
+// PenilaianKoleksi adalah satu entri nilai (valuasi) koleksi pada tanggal tertentu.
+// Disimpan terpisah dari dokumen koleksi karena hanya boleh dilihat admin & keuangan.
+type PenilaianKoleksi struct {
+	ID        primitive.ObjectID `json:"_id" bson:"_id"`
+	KoleksiID primitive.ObjectID `json:"koleksi_id" bson:"koleksi_id"`
+	Tanggal   time.Time          `json:"tanggal" bson:"tanggal"`
+	Nilai     float64            `json:"nilai" bson:"nilai" example:"150000000"`
+	MataUang  string             `json:"mata_uang" bson:"mata_uang" example:"IDR"`
+	Penilai   string             `json:"penilai" bson:"penilai" example:"KJPP Nusantara"`
+	Metode    string             `json:"metode" bson:"metode" example:"ahli"`
+	Tujuan    string             `json:"tujuan" bson:"tujuan" example:"asuransi"`
+	Catatan   string             `json:"catatan,omitempty" bson:"catatan,omitempty"`
+	CreatedBy string             `json:"created_by,omitempty" bson:"created_by,omitempty"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// PenilaianRequest untuk menambah entri penilaian koleksi. Tanggal dalam format YYYY-MM-DD.
+type PenilaianRequest struct {
+	Tanggal  string  `json:"tanggal" example:"2025-01-15"` // default hari ini
+	Nilai    float64 `json:"nilai" example:"150000000"`
+	MataUang string  `json:"mata_uang" example:"IDR"` // default IDR
+	Penilai  string  `json:"penilai" example:"KJPP Nusantara"`
+	Metode   string  `json:"metode" example:"ahli"`
+	Tujuan   string  `json:"tujuan" example:"asuransi"`
+	Catatan  string  `json:"catatan"`
+}
+
+// RekapNilaiKoleksi adalah total nilai koleksi dalam satu kategori / gudang
+type RekapNilaiKoleksi struct {
+	ID         primitive.ObjectID `json:"id"`
+	Nama       string             `json:"nama" example:"Keramik"`
+	Jumlah     int                `json:"jumlah" example:"12"` // koleksi yang sudah dinilai
+	TotalNilai map[string]float64 `json:"total_nilai"`         // per mata uang
+}
+
+// LaporanNilaiKoleksi adalah response total nilai koleksi per kategori / gudang
+type LaporanNilaiKoleksi struct {
+	Message       string              `json:"message" example:"Berhasil menyusun laporan nilai koleksi"`
+	Kelompok      string              `json:"kelompok" example:"kategori"`
+	PerTanggal    time.Time           `json:"per_tanggal"` // nilai terakhir sampai tanggal ini
+	Data          []RekapNilaiKoleksi `json:"data"`
+	TotalNilai    map[string]float64  `json:"total_nilai"`
+	JumlahDinilai int                 `json:"jumlah_dinilai"`
+	BelumDinilai  int                 `json:"belum_dinilai"` // koleksi aktif tanpa penilaian sampai per_tanggal
+}
+
+// PenilaianKedaluwarsa adalah koleksi yang penilaian terakhirnya lebih tua dari batas
+type PenilaianKedaluwarsa struct {
+	KoleksiID         primitive.ObjectID `json:"koleksi_id" bson:"_id"`
+	NoRegistrasi      string             `json:"no_reg,omitempty" bson:"no_reg,omitempty"`
+	NoInventaris      string             `json:"no_inv,omitempty" bson:"no_inv,omitempty"`
+	NamaBenda         string             `json:"nama_benda" bson:"nama_benda"`
+	Kategori          string             `json:"kategori,omitempty" bson:"kategori,omitempty"`
+	Gudang            string             `json:"gudang,omitempty" bson:"gudang,omitempty"`
+	PenilaianTerakhir *PenilaianKoleksi  `json:"penilaian_terakhir,omitempty" bson:"penilaian_terakhir,omitempty"` // kosong = belum pernah dinilai
+}
+
+// LaporanPenilaianKedaluwarsa adalah response daftar koleksi yang perlu dinilai ulang
+type LaporanPenilaianKedaluwarsa struct {
+	Message  string                 `json:"message" example:"Berhasil mengambil koleksi dengan penilaian kedaluwarsa"`
+	MaksUmur int                    `json:"maks_umur_tahun" example:"5"`
+	Batas    time.Time              `json:"batas"` // penilaian sebelum tanggal ini dianggap kedaluwarsa
+	Total    int                    `json:"total"`
+	Data     []PenilaianKedaluwarsa `json:"data"` // penilaian paling lama di depan
+}
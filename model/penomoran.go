@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SkemaPenomoran adalah pola pembuatan no_reg / no_inv otomatis.
+// Token yang didukung: {kategori_code}, {year}, {month}, {seq} atau {seq:04} (diisi nol di depan).
+type SkemaPenomoran struct {
+	Field     string    `bson:"_id" json:"field" example:"no_inv"` // no_reg / no_inv
+	Pola      string    `bson:"pola" json:"pola" example:"{kategori_code}.{year}.{seq:04}"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// SkemaPenomoranRequest untuk request mengatur pola penomoran
+type SkemaPenomoranRequest struct {
+	Pola string `json:"pola" example:"{kategori_code}.{year}.{seq:04}"`
+}
+
+// PreviewNomor adalah perkiraan nomor berikutnya (belum dipesan, bisa berubah jika ada koleksi baru)
+type PreviewNomor struct {
+	Field string `json:"field" example:"no_inv"`
+	Pola  string `json:"pola" example:"{kategori_code}.{year}.{seq:04}"`
+	Nomor string `json:"nomor" example:"NUM.2026.0013"`
+}
+
+// NomorGanda adalah satu nomor registrasi / inventaris yang dipakai lebih dari satu koleksi
+type NomorGanda struct {
+	Field   string         `json:"field" example:"no_inv"`
+	Nomor   string         `json:"nomor" example:"INV.2024.0012"`
+	Koleksi []KoleksiGanda `json:"koleksi"` // terurut dari yang paling lama dibuat (dipertahankan saat diperbaiki)
+}
+
+// KoleksiGanda adalah ringkasan koleksi pemakai nomor ganda
+type KoleksiGanda struct {
+	ID        primitive.ObjectID `json:"_id" bson:"_id"`
+	NamaBenda string             `json:"nama_benda" bson:"nama_benda"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	NomorBaru string             `json:"nomor_baru,omitempty" bson:"-"` // nomor pengganti saat diperbaiki
+}
+
+// LaporanNomorGanda berisi status unique index nomor koleksi dan daftar nomor ganda yang menghalanginya
+type LaporanNomorGanda struct {
+	IndexAktif map[string]bool   `json:"index_aktif"`           // no_reg_unik / no_inv_unik
+	IndexGagal map[string]string `json:"index_gagal,omitempty"` // pesan error pembuatan index
+	Ganda      []NomorGanda      `json:"ganda"`
+	DryRun     bool              `json:"dry_run,omitempty"`
+	Diperbaiki int               `json:"diperbaiki,omitempty"` // jumlah koleksi yang nomornya diganti
+}
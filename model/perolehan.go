@@ -0,0 +1,147 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Cara koleksi diperoleh museum
+const (
+	MetodePerolehanHibah     = "hibah"
+	MetodePerolehanPembelian = "pembelian"
+	MetodePerolehanTemuan    = "temuan"
+	MetodePerolehanTitipan   = "titipan"
+)
+
+// MetodePerolehanValid berisi metode perolehan yang diterima API
+var MetodePerolehanValid = map[string]bool{
+	MetodePerolehanHibah:     true,
+	MetodePerolehanPembelian: true,
+	MetodePerolehanTemuan:    true,
+	MetodePerolehanTitipan:   true,
+}
+
+// JenisPihakValid berisi jenis pihak sumber perolehan / pemilik
+var JenisPihakValid = map[string]bool{
+	"perorangan": true,
+	"instansi":   true,
+	"komunitas":  true,
+	"lainnya":    true,
+}
+
+// Perolehan adalah catatan terstruktur bagaimana koleksi masuk ke museum
+type Perolehan struct {
+	Metode         string             `json:"metode" bson:"metode,omitempty" example:"hibah"` // hibah / pembelian / temuan / titipan
+	Tanggal        string             `json:"tanggal,omitempty" bson:"tanggal,omitempty" example:"12-03-1998"`
+	TanggalMulai   *time.Time         `json:"tanggal_mulai,omitempty" bson:"tanggal_mulai,omitempty"`     // diisi server dari tanggal
+	TanggalSelesai *time.Time         `json:"tanggal_selesai,omitempty" bson:"tanggal_selesai,omitempty"` // diisi server dari tanggal
+	Tahun          int                `json:"tahun,omitempty" bson:"tahun,omitempty"`                     // tahun tanggal_mulai, untuk filter & laporan
+	Sumber         *PihakPerolehan    `json:"sumber,omitempty" bson:"sumber,omitempty"`
+	Tempat         string             `json:"tempat,omitempty" bson:"tempat,omitempty" example:"Cirebon"`
+	Harga          *float64           `json:"harga,omitempty" bson:"harga,omitempty" example:"15000000"`
+	MataUang       string             `json:"mata_uang,omitempty" bson:"mata_uang,omitempty" example:"IDR"`
+	Dokumen        []DokumenPerolehan `json:"dokumen,omitempty" bson:"dokumen,omitempty"`
+	Catatan        string             `json:"catatan,omitempty" bson:"catatan,omitempty"`
+}
+
+// PihakPerolehan adalah pihak pemberi / penjual / penemu / penitip koleksi
+type PihakPerolehan struct {
+	Nama   string `json:"nama" bson:"nama" example:"Keluarga R. Soedarmo"`
+	Jenis  string `json:"jenis,omitempty" bson:"jenis,omitempty" example:"perorangan"` // perorangan / instansi / komunitas / lainnya
+	Alamat string `json:"alamat,omitempty" bson:"alamat,omitempty"`
+	Kontak string `json:"kontak,omitempty" bson:"kontak,omitempty"`
+}
+
+// DokumenPerolehan adalah dokumen pendukung perolehan (akta hibah, kuitansi, berita acara temuan, dll)
+type DokumenPerolehan struct {
+	Jenis   string              `json:"jenis" bson:"jenis" example:"akta hibah"`
+	Nomor   string              `json:"nomor,omitempty" bson:"nomor,omitempty"`
+	Tanggal string              `json:"tanggal,omitempty" bson:"tanggal,omitempty"`
+	MediaID *primitive.ObjectID `json:"media_id,omitempty" bson:"media_id,omitempty"` // scan dokumen di media koleksi
+}
+
+// Provenans adalah satu pemilik / peristiwa dalam riwayat koleksi sebelum masuk museum.
+// Urutan array = urutan kronologis (paling lama di depan).
+type Provenans struct {
+	ID             primitive.ObjectID `json:"_id" bson:"_id"`
+	Pemilik        string             `json:"pemilik,omitempty" bson:"pemilik,omitempty" example:"R. Soedarmo"`
+	Peristiwa      string             `json:"peristiwa" bson:"peristiwa" example:"warisan"` // pembelian, warisan, lelang, penemuan, dll
+	Tanggal        string             `json:"tanggal,omitempty" bson:"tanggal,omitempty" example:"1950 s/d 1975"`
+	TanggalMulai   *time.Time         `json:"tanggal_mulai,omitempty" bson:"tanggal_mulai,omitempty"`
+	TanggalSelesai *time.Time         `json:"tanggal_selesai,omitempty" bson:"tanggal_selesai,omitempty"`
+	Tempat         string             `json:"tempat,omitempty" bson:"tempat,omitempty"`
+	Keterangan     string             `json:"keterangan,omitempty" bson:"keterangan,omitempty"`
+	Referensi      string             `json:"referensi,omitempty" bson:"referensi,omitempty"` // sumber informasi (arsip, wawancara, publikasi)
+}
+
+// UrutanProvenansRequest untuk request mengurutkan riwayat provenans
+type UrutanProvenansRequest struct {
+	ProvenansIDs []string `json:"provenans_ids"`
+}
+
+// RekapPerolehan adalah jumlah koleksi per metode & tahun perolehan
+type RekapPerolehan struct {
+	Metode     string             `json:"metode" bson:"metode" example:"hibah"`
+	Tahun      int                `json:"tahun" bson:"tahun" example:"2024"` // 0 = tahun tidak diketahui
+	Jumlah     int                `json:"jumlah" bson:"jumlah" example:"12"`
+	TotalHarga map[string]float64 `json:"total_harga,omitempty" bson:"-"` // per mata uang
+}
+
+// LaporanPerolehan adalah response laporan perolehan koleksi
+type LaporanPerolehan struct {
+	Message          string           `json:"message" example:"Berhasil mengambil laporan perolehan"`
+	Data             []RekapPerolehan `json:"data"`
+	TotalKoleksi     int              `json:"total_koleksi"`
+	BelumTerstruktur int              `json:"belum_terstruktur"` // koleksi tanpa metode perolehan
+}
+
+var (
+	pemisahRentangTanggal  = regexp.MustCompile(`(?i)\s*(?:s/d|sampai|\.\.)\s*|\s+-\s+`)
+	formatTanggalPerolehan = []string{"02-01-2006", "2006-01-02", "02/01/2006"}
+	formatBulanPerolehan   = []string{"01-2006", "2006-01", "01/2006"}
+)
+
+// ParseTanggalPerolehan membaca tanggal perolehan / provenans berupa tanggal (DD-MM-YYYY atau YYYY-MM-DD),
+// bulan (MM-YYYY), tahun (YYYY), atau rentang "<awal> s/d <akhir>".
+// Mengembalikan awal dan akhir periode.
+func ParseTanggalPerolehan(teks string) (time.Time, time.Time, error) {
+	teks = strings.TrimSpace(teks)
+	if bagian := pemisahRentangTanggal.Split(teks, -1); len(bagian) == 2 {
+		mulai, _, err := parsePeriode(bagian[0])
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		_, selesai, err := parsePeriode(bagian[1])
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if selesai.Before(mulai) {
+			return time.Time{}, time.Time{}, fmt.Errorf("akhir rentang tanggal %q lebih awal dari awalnya", teks)
+		}
+		return mulai, selesai, nil
+	}
+	return parsePeriode(teks)
+}
+
+// parsePeriode membaca satu tanggal, bulan, atau tahun
+func parsePeriode(teks string) (time.Time, time.Time, error) {
+	teks = strings.TrimSpace(teks)
+	for _, f := range formatTanggalPerolehan {
+		if t, err := time.Parse(f, teks); err == nil {
+			return t, t, nil
+		}
+	}
+	for _, f := range formatBulanPerolehan {
+		if t, err := time.Parse(f, teks); err == nil {
+			return t, t.AddDate(0, 1, -1), nil
+		}
+	}
+	if t, err := time.Parse("2006", teks); err == nil {
+		return t, t.AddDate(1, 0, -1), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("format tanggal %q tidak dikenali, gunakan DD-MM-YYYY, MM-YYYY, YYYY, atau rentang \"<awal> s/d <akhir>\"", teks)
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTanggalPerolehan(t *testing.T) {
+	tgl := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		teks    string
+		mulai   time.Time
+		selesai time.Time
+		gagal   bool
+	}{
+		// tanggal
+		{teks: "12-03-1998", mulai: tgl(1998, 3, 12), selesai: tgl(1998, 3, 12)},
+		{teks: "1998-03-12", mulai: tgl(1998, 3, 12), selesai: tgl(1998, 3, 12)},
+		{teks: "12/03/1998", mulai: tgl(1998, 3, 12), selesai: tgl(1998, 3, 12)},
+		{teks: "  12-03-1998 ", mulai: tgl(1998, 3, 12), selesai: tgl(1998, 3, 12)},
+		// bulan: sampai hari terakhir bulan itu
+		{teks: "03-1998", mulai: tgl(1998, 3, 1), selesai: tgl(1998, 3, 31)},
+		{teks: "1998-04", mulai: tgl(1998, 4, 1), selesai: tgl(1998, 4, 30)},
+		{teks: "02/2024", mulai: tgl(2024, 2, 1), selesai: tgl(2024, 2, 29)},
+		{teks: "02-2023", mulai: tgl(2023, 2, 1), selesai: tgl(2023, 2, 28)},
+		// tahun
+		{teks: "1998", mulai: tgl(1998, 1, 1), selesai: tgl(1998, 12, 31)},
+		// rentang: awal periode pertama sampai akhir periode kedua
+		{teks: "1990 s/d 1995", mulai: tgl(1990, 1, 1), selesai: tgl(1995, 12, 31)},
+		{teks: "1990 S/D 1995", mulai: tgl(1990, 1, 1), selesai: tgl(1995, 12, 31)},
+		{teks: "03-1990 sampai 12-03-1991", mulai: tgl(1990, 3, 1), selesai: tgl(1991, 3, 12)},
+		{teks: "1990..1995", mulai: tgl(1990, 1, 1), selesai: tgl(1995, 12, 31)},
+		{teks: "1990 - 1995", mulai: tgl(1990, 1, 1), selesai: tgl(1995, 12, 31)},
+		{teks: "1998 s/d 1998", mulai: tgl(1998, 1, 1), selesai: tgl(1998, 12, 31)},
+		// tidak valid
+		{teks: "", gagal: true},
+		{teks: "kemarin", gagal: true},
+		{teks: "31-02-1998", gagal: true},
+		{teks: "13-1998", gagal: true},
+		{teks: "98", gagal: true},
+		{teks: "1990-1995", gagal: true}, // rentang harus dipisah spasi
+		{teks: "1995 s/d 1990", gagal: true},
+		{teks: "1990 s/d", gagal: true},
+		{teks: "1990 s/d 1995 s/d 2000", gagal: true},
+	}
+
+	for _, tt := range tests {
+		mulai, selesai, err := ParseTanggalPerolehan(tt.teks)
+		if tt.gagal {
+			if err == nil {
+				t.Errorf("ParseTanggalPerolehan(%q) = %v, %v, want error", tt.teks, mulai, selesai)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseTanggalPerolehan(%q) error: %v", tt.teks, err)
+			continue
+		}
+		if !mulai.Equal(tt.mulai) || !selesai.Equal(tt.selesai) {
+			t.Errorf("ParseTanggalPerolehan(%q) = %v, %v, want %v, %v", tt.teks, mulai, selesai, tt.mulai, tt.selesai)
+		}
+	}
+}
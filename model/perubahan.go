@@ -0,0 +1,69 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Jenis usulan perubahan / riwayat koleksi
+const (
+	JenisPerubahanTambah = "tambah"
+	JenisPerubahanUbah   = "ubah"
+	JenisPerubahanMassal = "ubah_massal" // hanya untuk riwayat koleksi
+)
+
+// Status usulan perubahan koleksi dari kontributor
+const (
+	StatusPerubahanMenunggu  = "menunggu" // menunggu review
+	StatusPerubahanDiproses  = "diproses" // sedang diterapkan oleh reviewer, tidak bisa diedit / ditolak
+	StatusPerubahanDisetujui = "disetujui"
+	StatusPerubahanDitolak   = "ditolak"
+)
+
+// PerubahanKoleksi adalah usulan tambah / ubah koleksi dari kontributor yang harus direview sebelum berlaku
+type PerubahanKoleksi struct {
+	ID            primitive.ObjectID `json:"_id" bson:"_id"`
+	Jenis         string             `json:"jenis" bson:"jenis" example:"ubah"` // tambah / ubah
+	KoleksiID     primitive.ObjectID `json:"koleksi_id" bson:"koleksi_id"`      // untuk usulan tambah, ID koleksi yang akan dibuat
+	NamaBenda     string             `json:"nama_benda" bson:"nama_benda"`
+	Data          Koleksi            `json:"data" bson:"data"`                           // data koleksi setelah perubahan
+	Sebelum       *Koleksi           `json:"sebelum,omitempty" bson:"sebelum,omitempty"` // data koleksi saat usulan dibuat (usulan ubah)
+	Field         []string           `json:"field,omitempty" bson:"field,omitempty"`     // field yang berubah dibanding data sebelum
+	FotoBaru      *Media             `json:"foto_baru,omitempty" bson:"foto_baru,omitempty"`
+	Otomatis      []string           `json:"-" bson:"otomatis,omitempty"` // nomor yang dibuat dari skema penomoran (usulan tambah)
+	Status        string             `json:"status" bson:"status"`
+	DiprosesSejak *time.Time         `json:"diproses_sejak,omitempty" bson:"diproses_sejak,omitempty"` // waktu klaim saat disetujui; klaim yang terlalu lama bisa diambil alih
+	DiajukanOleh  string             `json:"diajukan_oleh,omitempty" bson:"diajukan_oleh,omitempty"`
+	Reviewer      string             `json:"reviewer,omitempty" bson:"reviewer,omitempty"`
+	Catatan       string             `json:"catatan,omitempty" bson:"catatan,omitempty"` // komentar reviewer
+	Riwayat       []RiwayatPerubahan `json:"riwayat" bson:"riwayat"`
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// RiwayatPerubahan mencatat setiap aksi pada usulan perubahan (diajukan, diedit, disetujui, ditolak)
+type RiwayatPerubahan struct {
+	Aksi    string    `json:"aksi" bson:"aksi"`
+	Oleh    string    `json:"oleh,omitempty" bson:"oleh,omitempty"`
+	Tanggal time.Time `json:"tanggal" bson:"tanggal"`
+	Catatan string    `json:"catatan,omitempty" bson:"catatan,omitempty"`
+}
+
+// RiwayatKoleksi adalah satu entri riwayat perubahan data koleksi
+type RiwayatKoleksi struct {
+	ID            primitive.ObjectID  `json:"_id" bson:"_id"`
+	KoleksiID     primitive.ObjectID  `json:"koleksi_id" bson:"koleksi_id"`
+	Aksi          string              `json:"aksi" bson:"aksi" example:"ubah"` // tambah / ubah / ubah_massal
+	Field         []string            `json:"field,omitempty" bson:"field,omitempty"`
+	Oleh          string              `json:"oleh,omitempty" bson:"oleh,omitempty"`
+	DisetujuiOleh string              `json:"disetujui_oleh,omitempty" bson:"disetujui_oleh,omitempty"` // reviewer, jika berasal dari usulan kontributor
+	PerubahanID   *primitive.ObjectID `json:"perubahan_id,omitempty" bson:"perubahan_id,omitempty"`
+	MassalID      *primitive.ObjectID `json:"massal_id,omitempty" bson:"massal_id,omitempty"` // sama untuk semua koleksi dalam satu ubah massal
+	Tanggal       time.Time           `json:"tanggal" bson:"tanggal"`
+}
+
+// TolakPerubahanRequest berisi komentar reviewer saat menolak usulan
+type TolakPerubahanRequest struct {
+	Catatan string `json:"catatan" example:"No inventaris tidak sesuai label fisik, mohon dicek ulang"`
+}
@@ -0,0 +1,138 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SatuanPanjang berisi satuan dimensi yang diterima beserta pembagi konversi ke meter.
+// Pembagi berupa bilangan bulat supaya hasil konversi tidak membawa galat faktor desimal (0.01, 0.001).
+var SatuanPanjang = map[string]float64{
+	"mm": 1000,
+	"cm": 100,
+	"m":  1,
+}
+
+// SatuanBerat berisi satuan berat yang diterima beserta pembagi konversi ke kilogram
+var SatuanBerat = map[string]float64{
+	"g":  1000,
+	"kg": 1,
+}
+
+// presisiUkuran adalah jumlah digit desimal nilai ukuran normal (meter / kilogram)
+const presisiUkuran = 1e9
+
+// BulatkanUkuran membulatkan nilai ukuran normal ke 9 digit desimal, sehingga nilai yang sama
+// (misal 12,3 cm dari form dan 12,3 cm dari query filter) selalu menghasilkan angka yang sama persis
+func BulatkanUkuran(n float64) float64 {
+	return math.Round(n*presisiUkuran) / presisiUkuran
+}
+
+// KonversiUkuran mengubah nilai ke satuan SI (meter / kilogram) dengan pembagi dari SatuanPanjang / SatuanBerat
+func KonversiUkuran(nilai, pembagi float64) float64 {
+	return BulatkanUkuran(nilai / pembagi)
+}
+
+// DimensiUkuran adalah nama field dimensi (sama dengan nama field form & bson)
+var DimensiUkuran = []string{"panjang_keseluruhan", "lebar", "tebal", "tinggi", "diameter"}
+
+// ParseAngkaUkuran membaca angka ukuran; koma desimal ("12,5") juga diterima
+func ParseAngkaUkuran(teks string) (*float64, error) {
+	teks = strings.TrimSpace(teks)
+	if teks == "" {
+		return nil, nil
+	}
+	n, err := strconv.ParseFloat(strings.ReplaceAll(teks, ",", "."), 64)
+	if err != nil {
+		return nil, fmt.Errorf("%q bukan angka", teks)
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("%q tidak boleh negatif", teks)
+	}
+	return &n, nil
+}
+
+// Dimensi mengembalikan pointer field dimensi berdasarkan nama field
+func (u *Ukuran) Dimensi(nama string) **float64 {
+	switch nama {
+	case "panjang_keseluruhan":
+		return &u.PanjangKeseluruhan
+	case "lebar":
+		return &u.Lebar
+	case "tebal":
+		return &u.Tebal
+	case "tinggi":
+		return &u.Tinggi
+	case "diameter":
+		return &u.Diameter
+	}
+	return nil
+}
+
+// AdaDimensi bernilai true jika salah satu dimensi diisi
+func (u *Ukuran) AdaDimensi() bool {
+	for _, d := range DimensiUkuran {
+		if *u.Dimensi(d) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Kosong bernilai true jika tidak ada dimensi, berat, maupun catatan
+func (u *Ukuran) Kosong() bool {
+	return !u.AdaDimensi() && u.Berat == nil && u.Catatan == ""
+}
+
+// HitungNormal mengisi Normal dari nilai & satuan. Satuan harus sudah divalidasi.
+func (u *Ukuran) HitungNormal() {
+	konversi := func(v *float64, pembagi float64) *float64 {
+		if v == nil || pembagi == 0 {
+			return nil
+		}
+		n := KonversiUkuran(*v, pembagi)
+		return &n
+	}
+
+	pembagiPanjang := SatuanPanjang[u.Satuan]
+	n := UkuranNormal{
+		PanjangKeseluruhan: konversi(u.PanjangKeseluruhan, pembagiPanjang),
+		Lebar:              konversi(u.Lebar, pembagiPanjang),
+		Tebal:              konversi(u.Tebal, pembagiPanjang),
+		Tinggi:             konversi(u.Tinggi, pembagiPanjang),
+		Diameter:           konversi(u.Diameter, pembagiPanjang),
+		Berat:              konversi(u.Berat, SatuanBerat[u.SatuanBerat]),
+		Bulat:              true,
+	}
+	u.Normal = &n
+}
+
+// satuanLama memetakan penulisan satuan pada data lama ke satuan baku beserta pengali nilainya
+var satuanLama = map[string]struct {
+	satuan  string
+	pengali float64
+}{
+	"mm": {"mm", 1}, "milimeter": {"mm", 1},
+	"cm": {"cm", 1}, "centimeter": {"cm", 1}, "sentimeter": {"cm", 1}, "senti": {"cm", 1},
+	"m": {"m", 1}, "meter": {"m", 1},
+	"mg": {"g", 0.001}, "miligram": {"g", 0.001},
+	"g": {"g", 1}, "gr": {"g", 1}, "gram": {"g", 1},
+	"ons": {"g", 100},
+	"kg":  {"kg", 1}, "kilogram": {"kg", 1}, "kilo": {"kg", 1},
+	"ton": {"kg", 1000},
+}
+
+// KonversiSatuanLama mengubah satuan teks bebas pada data lama menjadi satuan baku.
+// Nilai false dikembalikan jika satuan tidak dikenali.
+func KonversiSatuanLama(satuan string) (string, float64, bool) {
+	s, ok := satuanLama[strings.ToLower(strings.TrimSpace(satuan))]
+	return s.satuan, s.pengali, ok
+}
+
+// KaliSatuanLama mengalikan nilai data lama dengan pengali dari KonversiSatuanLama.
+// Hasilnya dibulatkan ke 9 digit desimal supaya pengali desimal (mg → g) tidak meninggalkan galat.
+func KaliSatuanLama(nilai, pengali float64) float64 {
+	return BulatkanUkuran(nilai * pengali)
+}
@@ -0,0 +1,133 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestParseAngkaUkuran(t *testing.T) {
+	tests := []struct {
+		teks   string
+		want   float64
+		kosong bool
+		gagal  bool
+	}{
+		{teks: "12", want: 12},
+		{teks: "12.5", want: 12.5},
+		{teks: "12,5", want: 12.5},
+		{teks: " 0,75 ", want: 0.75},
+		{teks: "0", want: 0},
+		{teks: "", kosong: true},
+		{teks: "   ", kosong: true},
+		{teks: "-3", gagal: true},
+		{teks: "12 cm", gagal: true},
+		{teks: "1.200,5", gagal: true}, // pemisah ribuan tidak didukung
+		{teks: "abc", gagal: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAngkaUkuran(tt.teks)
+		switch {
+		case tt.gagal:
+			if err == nil {
+				t.Errorf("ParseAngkaUkuran(%q) = %v, want error", tt.teks, got)
+			}
+		case err != nil:
+			t.Errorf("ParseAngkaUkuran(%q) error: %v", tt.teks, err)
+		case tt.kosong:
+			if got != nil {
+				t.Errorf("ParseAngkaUkuran(%q) = %v, want nil", tt.teks, *got)
+			}
+		case got == nil || *got != tt.want:
+			t.Errorf("ParseAngkaUkuran(%q) = %v, want %v", tt.teks, got, tt.want)
+		}
+	}
+}
+
+func TestHitungNormal(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		nama   string
+		ukuran Ukuran
+		tinggi *float64
+		lebar  *float64
+		berat  *float64
+	}{
+		// 12.3 * 0.01 = 0.12300000000000001, hasil normal harus tepat 0.123
+		{nama: "cm", ukuran: Ukuran{Tinggi: f(12.3), Lebar: f(0.1), Satuan: "cm"}, tinggi: f(0.123), lebar: f(0.001)},
+		{nama: "mm", ukuran: Ukuran{Tinggi: f(7), Lebar: f(0.3), Satuan: "mm"}, tinggi: f(0.007), lebar: f(0.0003)},
+		{nama: "m", ukuran: Ukuran{Tinggi: f(1.75), Satuan: "m"}, tinggi: f(1.75)},
+		{nama: "gram", ukuran: Ukuran{Berat: f(1.1), SatuanBerat: "g"}, berat: f(0.0011)},
+		{nama: "kg", ukuran: Ukuran{Berat: f(2.5), SatuanBerat: "kg"}, berat: f(2.5)},
+		{nama: "kosong", ukuran: Ukuran{Satuan: "cm"}},
+		// satuan tidak dikenal tidak menghasilkan nilai normal
+		{nama: "satuan salah", ukuran: Ukuran{Tinggi: f(10), Satuan: "inch"}},
+	}
+
+	sama := func(a, b *float64) bool {
+		if a == nil || b == nil {
+			return a == b
+		}
+		return *a == *b
+	}
+	teks := func(v *float64) interface{} {
+		if v == nil {
+			return nil
+		}
+		return *v
+	}
+
+	for _, tt := range tests {
+		u := tt.ukuran
+		u.HitungNormal()
+		if u.Normal == nil || !u.Normal.Bulat {
+			t.Errorf("%s: Normal = %+v, want terisi & ditandai bulat", tt.nama, u.Normal)
+			continue
+		}
+		if !sama(u.Normal.Tinggi, tt.tinggi) || !sama(u.Normal.Lebar, tt.lebar) || !sama(u.Normal.Berat, tt.berat) {
+			t.Errorf("%s: tinggi=%v lebar=%v berat=%v, want %v %v %v", tt.nama,
+				teks(u.Normal.Tinggi), teks(u.Normal.Lebar), teks(u.Normal.Berat),
+				teks(tt.tinggi), teks(tt.lebar), teks(tt.berat))
+		}
+	}
+}
+
+// Batas filter yang diketik sama dengan nilai tersimpan harus menghasilkan angka yang sama persis
+func TestKonversiUkuranKonsisten(t *testing.T) {
+	for satuan, pembagi := range SatuanPanjang {
+		for _, v := range []float64{0.1, 0.3, 1.1, 12.3, 33.33, 99.99, 150.7} {
+			u := Ukuran{Tinggi: &v, Satuan: satuan}
+			u.HitungNormal()
+			if got := KonversiUkuran(v, pembagi); got != *u.Normal.Tinggi {
+				t.Errorf("%v %s: filter %v != tersimpan %v", v, satuan, got, *u.Normal.Tinggi)
+			}
+		}
+	}
+}
+
+func TestKaliSatuanLama(t *testing.T) {
+	tests := []struct {
+		satuan string
+		nilai  float64
+		want   float64
+		baku   string
+	}{
+		{satuan: "mg", nilai: 3, want: 0.003, baku: "g"},
+		{satuan: "ons", nilai: 2, want: 200, baku: "g"},
+		{satuan: "Ton", nilai: 1.2, want: 1200, baku: "kg"},
+		{satuan: "senti", nilai: 12.3, want: 12.3, baku: "cm"},
+	}
+	for _, tt := range tests {
+		baku, pengali, ok := KonversiSatuanLama(tt.satuan)
+		if !ok || baku != tt.baku {
+			t.Errorf("KonversiSatuanLama(%q) = %q, %v, want %q", tt.satuan, baku, ok, tt.baku)
+			continue
+		}
+		if got := KaliSatuanLama(tt.nilai, pengali); got != tt.want {
+			t.Errorf("KaliSatuanLama(%v %s) = %v, want %v", tt.nilai, tt.satuan, got, tt.want)
+		}
+	}
+	if _, _, ok := KonversiSatuanLama("jengkal"); ok {
+		t.Error("satuan tidak dikenal harus ditolak")
+	}
+}
@@ -4,6 +4,13 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Role pengguna
+const (
+	RoleAdmin       = "admin"
+	RoleContributor = "contributor" // perubahan koleksi harus direview sebelum berlaku
+	RoleFinance     = "finance"     // boleh melihat & mencatat nilai koleksi
+)
+
 type Users struct {
 	ID          primitive.ObjectID `json:"_id,omitempty" bson:"_id,omitempty" example:"12345678"`
 	Role        string             `json:"role,omitempty" bson:"role,omitempty" example:"admin"`
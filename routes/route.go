@@ -1,13 +1,16 @@
 package routes
 
 import (
+	"be-internship/config"
 	"be-internship/controller"
 	// ← fiberSwagger
 	_ "be-internship/docs" //
+	"be-internship/storage"
 	// ← swaggerFiles
 	// swagger handler
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
 	fiberSwagger "github.com/swaggo/fiber-swagger"
 
 )
@@ -17,6 +20,11 @@ func SetupRoutes(app *fiber.App) {
 	// ===== Swagger route =====
 	app.Get("/swagger/*", fiberSwagger.WrapHandler) // ← versi terbaru fiber-swagger
 
+	// ===== Media lokal (hanya jika STORAGE_DRIVER=local) =====
+	if local, ok := storage.Aktif().(*storage.Local); ok {
+		app.Static(local.URLPrefix, local.Dir)
+	}
+
 	// Group API routes
 	api := app.Group("/api")
 
@@ -28,23 +36,58 @@ func SetupRoutes(app *fiber.App) {
 	userRoutes.Get("/:id", controller.GetUserByID)                   // Route untuk mengambil data pengguna berdasarkan ID
 	userRoutes.Get("/username/:username", controller.GetUserByUsername) // Route untuk mengambil data pengguna berdasarkan username
 	userRoutes.Put("/:id", controller.JWTAuth, controller.UpdateUserByID)    // Route untuk mengupdate data pengguna berdasarkan ID
+	userRoutes.Put("/:id/role", controller.JWTAuth, controller.RequireRole("admin"), controller.UbahRoleUser) // Route untuk mengubah role pengguna (khusus admin)
 	userRoutes.Delete("/:id", controller.JWTAuth, controller.DeleteUserByID) // Route untuk menghapus data pengguna berdasarkan ID
 
 	// Koleksi routes
 	koleksiRoutes := api.Group("/koleksi")
 	koleksiRoutes.Post("/", controller.JWTAuth, controller.InsertKoleksi)
 	koleksiRoutes.Get("/", controller.GetAllKoleksi)
+	koleksiRoutes.Get("/by-inv/:no_inv", controller.GetKoleksiByNoInv) // Route untuk lookup hasil scan no inventaris
+	koleksiRoutes.Get("/by-reg/:no_reg", controller.GetKoleksiByNoReg) // Route untuk lookup no registrasi
+	koleksiRoutes.Post("/ubah-massal", controller.JWTAuth, controller.UbahMassalKoleksi) // Route untuk ubah massal koleksi (kategori, lokasi, kondisi, atribut)
+	koleksiRoutes.Get("/nomor-ganda", controller.JWTAuth, controller.RequireRole("admin"), controller.GetNomorGandaKoleksi)                 // Route untuk cek nomor ganda & status unique index
+	koleksiRoutes.Post("/nomor-ganda/perbaiki", controller.JWTAuth, controller.RequireRole("admin"), controller.PerbaikiNomorGandaKoleksi) // Route untuk membereskan nomor ganda
 	koleksiRoutes.Get("/:id", controller.GetKoleksiByID)
-	koleksiRoutes.Put("/:id", controller.JWTAuth, controller.UpdateKoleksi)
-	koleksiRoutes.Delete("/:id", controller.JWTAuth, controller.DeleteKoleksiByID)
+	koleksiRoutes.Put("/:id", controller.JWTAuth, controller.KoleksiBelumDideaksesi, controller.UpdateKoleksi)
+	koleksiRoutes.Patch("/:id", controller.JWTAuth, controller.KoleksiBelumDideaksesi, controller.PatchKoleksi) // Route untuk ubah sebagian field (merge patch / JSON patch)
+	koleksiRoutes.Delete("/:id", controller.JWTAuth, controller.KoleksiBelumDideaksesi, controller.DeleteKoleksiByID)
+	koleksiRoutes.Post("/:id/media", controller.JWTAuth, controller.TolakKontributor, controller.KoleksiBelumDideaksesi, controller.TambahMediaKoleksi)                     // Route untuk menambah foto / lampiran
+	koleksiRoutes.Put("/:id/media/urutan", controller.JWTAuth, controller.TolakKontributor, controller.KoleksiBelumDideaksesi, controller.UrutkanMediaKoleksi)              // Route untuk mengurutkan media
+	koleksiRoutes.Put("/:id/media/:media_id/utama", controller.JWTAuth, controller.TolakKontributor, controller.KoleksiBelumDideaksesi, controller.SetMediaUtamaKoleksi)    // Route untuk memilih media utama
+	koleksiRoutes.Delete("/:id/media/:media_id", controller.JWTAuth, controller.TolakKontributor, controller.KoleksiBelumDideaksesi, controller.DeleteMediaKoleksi)         // Route untuk menghapus satu media
+	koleksiRoutes.Get("/:id/media/:media_id/verifikasi", controller.JWTAuth, controller.VerifikasiMediaKoleksi) // Route untuk cek integritas file media
+	koleksiRoutes.Put("/:id/perolehan", controller.JWTAuth, controller.TolakKontributor, controller.KoleksiBelumDideaksesi, controller.SetPerolehanKoleksi)                    // Route untuk data perolehan terstruktur
+	koleksiRoutes.Post("/:id/provenans", controller.JWTAuth, controller.TolakKontributor, controller.KoleksiBelumDideaksesi, controller.TambahProvenansKoleksi)                // Route untuk menambah riwayat pemilik
+	koleksiRoutes.Put("/:id/provenans/urutan", controller.JWTAuth, controller.TolakKontributor, controller.KoleksiBelumDideaksesi, controller.UrutkanProvenansKoleksi)         // Route untuk mengurutkan provenans, harus sebelum /:provenans_id
+	koleksiRoutes.Put("/:id/provenans/:provenans_id", controller.JWTAuth, controller.TolakKontributor, controller.KoleksiBelumDideaksesi, controller.UpdateProvenansKoleksi)
+	koleksiRoutes.Delete("/:id/provenans/:provenans_id", controller.JWTAuth, controller.TolakKontributor, controller.KoleksiBelumDideaksesi, controller.DeleteProvenansKoleksi)
+	koleksiRoutes.Post("/:id/kondisi", controller.JWTAuth, controller.TolakKontributor, controller.KoleksiBelumDideaksesi, controller.TambahLaporanKondisi)                    // Route untuk laporan pemeriksaan kondisi
+	koleksiRoutes.Get("/:id/kondisi", controller.GetLaporanKondisiKoleksi)
+	koleksiRoutes.Delete("/:id/kondisi/:laporan_id", controller.JWTAuth, controller.TolakKontributor, controller.KoleksiBelumDideaksesi, controller.DeleteLaporanKondisi)
+	koleksiRoutes.Post("/:id/perawatan", controller.JWTAuth, controller.TolakKontributor, controller.KoleksiBelumDideaksesi, controller.TambahPerawatanKoleksi)                // Route untuk log perawatan konservasi
+	koleksiRoutes.Get("/:id/perawatan", controller.GetPerawatanKoleksi)
+	koleksiRoutes.Put("/:id/perawatan/:perawatan_id/selesai", controller.JWTAuth, controller.TolakKontributor, controller.KoleksiBelumDideaksesi, controller.SelesaikanPerawatanKoleksi)
+	koleksiRoutes.Delete("/:id/perawatan/:perawatan_id", controller.JWTAuth, controller.TolakKontributor, controller.KoleksiBelumDideaksesi, controller.DeletePerawatanKoleksi)
+	koleksiRoutes.Get("/:id/pameran", controller.GetRiwayatPameranKoleksi) // Route untuk riwayat pameran koleksi
+	koleksiRoutes.Post("/:id/penilaian", controller.JWTAuth, controller.RequireRole("admin", "finance"), controller.KoleksiBelumDideaksesi, controller.TambahPenilaianKoleksi) // Route untuk nilai asuransi / aset koleksi
+	koleksiRoutes.Get("/:id/penilaian", controller.JWTAuth, controller.RequireRole("admin", "finance"), controller.GetPenilaianKoleksi)
+	koleksiRoutes.Delete("/:id/penilaian/:penilaian_id", controller.JWTAuth, controller.RequireRole("admin", "finance"), controller.KoleksiBelumDideaksesi, controller.DeletePenilaianKoleksi)
+	koleksiRoutes.Get("/:id/riwayat", controller.GetRiwayatKoleksi) // Route untuk riwayat perubahan data koleksi
+	koleksiRoutes.Post("/:id/deaksesi", controller.JWTAuth, controller.KoleksiBelumDideaksesi, controller.UsulkanDeaksesi) // Route untuk mengusulkan deaksesi koleksi
 
 	// Kategori routes
 	kategoriRoutes := api.Group("/kategori")
 	kategoriRoutes.Post("/", controller.JWTAuth, controller.InsertKategori)
 	kategoriRoutes.Get("/", controller.GetAllCategory)
+	kategoriRoutes.Get("/tree", controller.GetKategoriTree) // Route untuk pohon kategori, harus sebelum /:id
 	kategoriRoutes.Get("/:id", controller.GetCategoryByID)
 	kategoriRoutes.Put("/:id", controller.JWTAuth, controller.UpdateKategori)
-	kategoriRoutes.Delete("/:id", controller.JWTAuth, controller.DeleteKategoriByID)
+	kategoriRoutes.Put("/:id/pindah", controller.JWTAuth, controller.RequireRole("admin"), controller.PindahKategori) // Route untuk memindahkan kategori ke induk lain
+	kategoriRoutes.Post("/:id/gabung", controller.JWTAuth, controller.RequireRole("admin"), controller.GabungKategori) // Route untuk menggabungkan kategori duplikat
+	kategoriRoutes.Get("/:id/atribut", controller.GetSkemaAtributKategori)                   // Route untuk skema atribut (form dinamis)
+	kategoriRoutes.Put("/:id/atribut", controller.JWTAuth, controller.RequireRole("admin"), controller.SetSkemaAtributKategori) // Route untuk mengatur skema atribut
+	kategoriRoutes.Delete("/:id", controller.JWTAuth, controller.RequireRole("admin"), controller.DeleteKategoriByID)
 	
 	// Gudang routes
 	GudangRoutes := api.Group("/gudang")
@@ -52,7 +95,8 @@ func SetupRoutes(app *fiber.App) {
 	GudangRoutes.Put("/:id", controller.JWTAuth, controller.UpdateGudangByID)
 	GudangRoutes.Get("/", controller.GetAllGudang)
 	GudangRoutes.Get("/:id", controller.GetGudangByID)
-	GudangRoutes.Delete("/:id", controller.JWTAuth, controller.DeleteGudangByID)
+	GudangRoutes.Delete("/:id", controller.JWTAuth, controller.RequireRole("admin"), controller.DeleteGudangByID)
+	GudangRoutes.Post("/:id/gabung", controller.JWTAuth, controller.RequireRole("admin"), controller.GabungGudang)
 	
 	// Rak routes
 	RakRoutes := api.Group("/rak")
@@ -60,7 +104,8 @@ func SetupRoutes(app *fiber.App) {
 	RakRoutes.Put("/:id", controller.JWTAuth, controller.UpdateRakByID)
 	RakRoutes.Get("/", controller.GetAllRak)
 	RakRoutes.Get("/:id", controller.GetRakByID)
-	RakRoutes.Delete("/:id", controller.JWTAuth, controller.DeleteRakByID)
+	RakRoutes.Delete("/:id", controller.JWTAuth, controller.RequireRole("admin"), controller.DeleteRakByID)
+	RakRoutes.Post("/:id/gabung", controller.JWTAuth, controller.RequireRole("admin"), controller.GabungRak)
 
 	// Tahap routes
 	TahapRoutes := api.Group("/tahap")
@@ -68,5 +113,93 @@ func SetupRoutes(app *fiber.App) {
 	TahapRoutes.Put("/:id", controller.JWTAuth, controller.UpdateTahapByID)
 	TahapRoutes.Get("/", controller.GetAllTahap)
 	TahapRoutes.Get("/:id", controller.GetTahapByID)
-	TahapRoutes.Delete("/:id", controller.JWTAuth, controller.DeleteTahapByID)
+	TahapRoutes.Delete("/:id", controller.JWTAuth, controller.RequireRole("admin"), controller.DeleteTahapByID)
+	TahapRoutes.Post("/:id/gabung", controller.JWTAuth, controller.RequireRole("admin"), controller.GabungTahap)
+
+	// Laporan routes
+	api.Get("/laporan/perolehan", controller.GetLaporanPerolehan) // Route untuk rekap perolehan per metode & tahun
+	api.Get("/laporan/nilai-koleksi", controller.JWTAuth, controller.RequireRole("admin", "finance"), controller.GetLaporanNilaiKoleksi)          // Route untuk total nilai koleksi per kategori / gudang
+	api.Get("/laporan/penilaian-kedaluwarsa", controller.JWTAuth, controller.RequireRole("admin", "finance"), controller.GetPenilaianKedaluwarsa) // Route untuk koleksi yang perlu dinilai ulang
+
+	// Peminjaman routes (keluar ke / masuk dari institusi lain)
+	peminjamanRoutes := api.Group("/peminjaman")
+	peminjamanRoutes.Post("/", controller.JWTAuth, controller.InsertPeminjaman)
+	peminjamanRoutes.Get("/", controller.JWTOpsional, controller.GetAllPeminjaman) // Token opsional, nilai asuransi hanya untuk admin / finance
+	peminjamanRoutes.Get("/:id", controller.JWTOpsional, controller.GetPeminjamanByID)
+	peminjamanRoutes.Put("/:id", controller.JWTAuth, controller.UpdatePeminjaman)
+	peminjamanRoutes.Put("/:id/status", controller.JWTAuth, controller.UbahStatusPeminjaman) // Route untuk alur diajukan → disetujui → dikirim → dikembalikan
+	peminjamanRoutes.Delete("/:id", controller.JWTAuth, controller.DeletePeminjaman)
+
+	// Pameran routes
+	pameranRoutes := api.Group("/pameran")
+	pameranRoutes.Post("/", controller.JWTAuth, controller.InsertPameran)
+	pameranRoutes.Get("/", controller.GetAllPameran)
+	pameranRoutes.Get("/:id", controller.GetPameranByID)
+	pameranRoutes.Put("/:id", controller.JWTAuth, controller.UpdatePameran)
+	pameranRoutes.Delete("/:id", controller.JWTAuth, controller.DeletePameran)
+	pameranRoutes.Get("/:id/checklist", controller.GetChecklistPameran) // Route untuk checklist pengambilan koleksi dari gudang
+	pameranRoutes.Post("/:id/koleksi", controller.JWTAuth, controller.TambahKoleksiPameran)
+	pameranRoutes.Delete("/:id/koleksi/:koleksi_id", controller.JWTAuth, controller.HapusKoleksiPameran)
+
+	// Review perubahan koleksi dari kontributor
+	perubahanRoutes := api.Group("/perubahan")
+	perubahanRoutes.Get("/", controller.JWTAuth, controller.GetAllPerubahanKoleksi)
+	perubahanRoutes.Get("/:id", controller.JWTAuth, controller.GetPerubahanKoleksiByID)
+	perubahanRoutes.Put("/:id", controller.JWTAuth, controller.RequireRole("admin"), controller.EditPerubahanKoleksi) // Route untuk reviewer memperbaiki usulan
+	perubahanRoutes.Put("/:id/setujui", controller.JWTAuth, controller.RequireRole("admin"), controller.SetujuiPerubahanKoleksi)
+	perubahanRoutes.Put("/:id/tolak", controller.JWTAuth, controller.RequireRole("admin"), controller.TolakPerubahanKoleksi)
+
+	// Deaksesi routes
+	deaksesiRoutes := api.Group("/deaksesi")
+	deaksesiRoutes.Get("/", controller.GetAllDeaksesi)
+	deaksesiRoutes.Get("/:id", controller.GetDeaksesiByID)
+	deaksesiRoutes.Put("/:id/persetujuan", controller.JWTAuth, controller.RequireRole("admin"), controller.PutusanDeaksesi) // Route untuk persetujuan / penolakan admin
+	deaksesiRoutes.Put("/:id/selesai", controller.JWTAuth, controller.RequireRole("admin"), controller.SelesaikanDeaksesi)
+	deaksesiRoutes.Put("/:id/batal", controller.JWTAuth, controller.BatalkanDeaksesi)
+
+	// Kondisi routes
+	api.Get("/kondisi/perlu-perawatan", controller.GetKoleksiPerluPerawatan) // Route untuk daftar koleksi yang perlu perawatan konservasi
+
+	// Okupansi routes
+	api.Get("/okupansi", controller.GetOkupansi) // Route untuk laporan isi & kapasitas tempat penyimpanan
+
+	// Duplikat master data routes
+	api.Get("/duplikat", controller.GetDuplikatMasterData) // Route untuk mencari kategori/gudang/rak/tahap dengan nama kembar
+
+	// Monitoring lingkungan routes
+	lingkunganRoutes := api.Group("/lingkungan")
+	lingkunganRoutes.Post("/pembacaan", controller.SensorAPIKeyAuth, controller.InsertPembacaanSensor) // Route untuk sensor mengirim data (X-API-Key)
+	lingkunganRoutes.Get("/pembacaan", controller.GetPembacaanSensor)
+	lingkunganRoutes.Get("/statistik", controller.GetStatistikLingkungan)
+	lingkunganRoutes.Get("/ambang", controller.GetAllAmbangLingkungan)
+	lingkunganRoutes.Put("/ambang", controller.JWTAuth, controller.SetAmbangLingkungan)
+	lingkunganRoutes.Delete("/ambang/:id", controller.JWTAuth, controller.DeleteAmbangLingkungan)
+	lingkunganRoutes.Get("/peringatan", controller.GetPeringatanLingkungan)
+	lingkunganRoutes.Put("/peringatan/:id/selesai", controller.JWTAuth, controller.SelesaikanPeringatanLingkungan)
+
+	// Media routes (garbage collection file media yang tidak dipakai)
+	mediaRoutes := api.Group("/media")
+	mediaRoutes.Get("/yatim", controller.JWTAuth, controller.RequireRole("admin"), controller.GetMediaYatim)
+	mediaRoutes.Delete("/yatim", controller.JWTAuth, controller.RequireRole("admin"), controller.HapusMediaYatim)
+
+	// IIIF routes (Image API & Presentation API untuk viewer seperti Mirador / Universal Viewer)
+	iiifRoutes := api.Group("/iiif", cors.New(config.CorsPublik))
+	iiifRoutes.Get("/image/:id", controller.RedirectIIIFInfo)
+	iiifRoutes.Get("/image/:id/info.json", controller.GetIIIFInfo)
+	iiifRoutes.Get("/image/:id/:region/:size/:rotation/:quality.:format", controller.GetIIIFImage)
+	iiifRoutes.Get("/koleksi/:id/manifest", controller.GetIIIFManifest)
+
+	// Label routes (QR code / barcode & lembar label PDF siap cetak)
+	labelRoutes := api.Group("/label")
+	labelRoutes.Get("/format", controller.GetFormatLabel)
+	labelRoutes.Get("/lembar", controller.GetLembarLabel)
+	labelRoutes.Get("/:jenis/:id/:kode.png", controller.GetGambarLabel)
+	api.Get("/resolve/:code", controller.ResolveKode) // Route untuk mencari koleksi / lokasi dari kode hasil scan
+
+	// Penomoran otomatis no_reg / no_inv
+	penomoranRoutes := api.Group("/penomoran")
+	penomoranRoutes.Get("/", controller.GetSkemaPenomoran)
+	penomoranRoutes.Get("/:field/berikutnya", controller.PreviewNomorBerikutnya)
+	penomoranRoutes.Put("/:field", controller.JWTAuth, controller.RequireRole("admin"), controller.SetSkemaPenomoran)
+	penomoranRoutes.Delete("/:field", controller.JWTAuth, controller.RequireRole("admin"), controller.DeleteSkemaPenomoran)
 }
@@ -0,0 +1,223 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// GitHub menyimpan file ke repository GitHub lewat contents API
+type GitHub struct {
+	Token  string
+	Owner  string
+	Repo   string
+	Branch string
+}
+
+// NewGitHubFromEnv membuat driver GitHub dari env GH_ACCESS_TOKEN, GH_REPO_OWNER, GH_REPO_NAME, GH_BRANCH
+func NewGitHubFromEnv() *GitHub {
+	return &GitHub{
+		Token:  os.Getenv("GH_ACCESS_TOKEN"),
+		Owner:  envOrDefault("GH_REPO_OWNER", "ghaidafasya24"),
+		Repo:   envOrDefault("GH_REPO_NAME", "images-koleksi-museum"),
+		Branch: envOrDefault("GH_BRANCH", "main"),
+	}
+}
+
+func (g *GitHub) Nama() string { return "github" }
+
+func (g *GitHub) apiURL(key string) string {
+	return fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s", g.Owner, g.Repo, key)
+}
+
+func (g *GitHub) URL(key string) string {
+	return fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s", g.Owner, g.Repo, g.Branch, key)
+}
+
+func (g *GitHub) request(ctx context.Context, method, url string, payload interface{}) (*http.Response, error) {
+	if g.Token == "" {
+		return nil, fmt.Errorf("GH_ACCESS_TOKEN belum diatur di environment variable")
+	}
+
+	var body io.Reader
+	if payload != nil {
+		payloadBytes, _ := json.Marshal(payload)
+		body = bytes.NewReader(payloadBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+g.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gagal request ke GitHub API: %w", err)
+	}
+	return resp, nil
+}
+
+// sha mengambil SHA file yang dibutuhkan GitHub untuk menimpa / menghapus file
+func (g *GitHub) sha(ctx context.Context, key string) (string, error) {
+	resp, err := g.request(ctx, http.MethodGet, g.apiURL(key)+"?ref="+g.Branch, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", ErrNotFound
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GitHub API error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		SHA string `json:"sha"`
+	}
+	json.Unmarshal(body, &result)
+	return result.SHA, nil
+}
+
+func (g *GitHub) Put(ctx context.Context, key string, data []byte, contentType string) (string, error) {
+	payload := map[string]string{
+		"message": fmt.Sprintf("Upload %s", key),
+		"content": base64.StdEncoding.EncodeToString(data),
+		"branch":  g.Branch,
+	}
+
+	// file yang sudah ada hanya bisa ditimpa jika SHA-nya ikut dikirim (tanpa SHA GitHub membalas 422)
+	sha, err := g.sha(ctx, key)
+	switch {
+	case err == nil:
+		payload["message"] = fmt.Sprintf("Update %s", key)
+		payload["sha"] = sha
+	case err != ErrNotFound:
+		return "", err
+	}
+
+	resp, err := g.request(ctx, http.MethodPut, g.apiURL(key), payload)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GitHub API error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Content struct {
+			DownloadURL string `json:"download_url"`
+		} `json:"content"`
+	}
+	json.Unmarshal(body, &result)
+	if result.Content.DownloadURL == "" {
+		return "", fmt.Errorf("tidak menemukan download_url dari GitHub response")
+	}
+
+	return result.Content.DownloadURL, nil
+}
+
+func (g *GitHub) Get(ctx context.Context, key string) ([]byte, error) {
+	if g.Token == "" {
+		return nil, fmt.Errorf("GH_ACCESS_TOKEN belum diatur di environment variable")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.apiURL(key)+"?ref="+g.Branch, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+g.Token)
+	req.Header.Set("Accept", "application/vnd.github.raw")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gagal request ke GitHub API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("GitHub API error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func (g *GitHub) Delete(ctx context.Context, key string) error {
+	sha, err := g.sha(ctx, key)
+	if err == ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]string{
+		"message": fmt.Sprintf("Delete %s", key),
+		"sha":     sha,
+		"branch":  g.Branch,
+	}
+
+	resp, err := g.request(ctx, http.MethodDelete, g.apiURL(key), payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("GitHub API error (%d): %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+func (g *GitHub) List(ctx context.Context, prefix string) ([]string, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/git/trees/%s?recursive=1", g.Owner, g.Repo, g.Branch)
+	resp, err := g.request(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitHub API error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Tree []struct {
+			Path string `json:"path"`
+			Type string `json:"type"`
+		} `json:"tree"`
+		Truncated bool `json:"truncated"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("gagal membaca response GitHub: %w", err)
+	}
+	if result.Truncated {
+		return nil, fmt.Errorf("daftar file GitHub terpotong, repository terlalu besar untuk di-list")
+	}
+
+	var keys []string
+	for _, t := range result.Tree {
+		if t.Type == "blob" && strings.HasPrefix(t.Path, prefix) {
+			keys = append(keys, t.Path)
+		}
+	}
+	return keys, nil
+}
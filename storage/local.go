@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local menyimpan file di disk server. File disajikan oleh aplikasi pada URLPrefix.
+type Local struct {
+	Dir       string // folder penyimpanan
+	URLPrefix string // path route static, misalnya /media
+	BaseURL   string // URL publik, misalnya https://museum.example.com/media
+}
+
+// NewLocalFromEnv membuat driver lokal dari env STORAGE_LOCAL_DIR (default ./uploads)
+// dan STORAGE_PUBLIC_URL (default /media)
+func NewLocalFromEnv() (*Local, error) {
+	l := &Local{
+		Dir:       envOrDefault("STORAGE_LOCAL_DIR", "./uploads"),
+		URLPrefix: "/media",
+		BaseURL:   strings.TrimRight(envOrDefault("STORAGE_PUBLIC_URL", "/media"), "/"),
+	}
+
+	if err := os.MkdirAll(l.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("gagal membuat folder storage lokal: %w", err)
+	}
+	return l, nil
+}
+
+func (l *Local) Nama() string { return "local" }
+
+// path mengubah key menjadi path file dan menolak key yang keluar dari folder storage
+func (l *Local) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if clean == "/" {
+		return "", fmt.Errorf("key storage tidak valid: %q", key)
+	}
+	return filepath.Join(l.Dir, filepath.FromSlash(clean)), nil
+}
+
+func (l *Local) URL(key string) string {
+	return l.BaseURL + "/" + strings.TrimLeft(key, "/")
+}
+
+func (l *Local) Put(ctx context.Context, key string, data []byte, contentType string) (string, error) {
+	p, err := l.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return "", fmt.Errorf("gagal membuat folder: %w", err)
+	}
+
+	// tulis ke file sementara lalu rename supaya file tidak pernah terbaca setengah jadi
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return "", fmt.Errorf("gagal menyimpan file: %w", err)
+	}
+	if err := os.Rename(tmp, p); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("gagal menyimpan file: %w", err)
+	}
+
+	return l.URL(key), nil
+}
+
+func (l *Local) Get(ctx context.Context, key string) ([]byte, error) {
+	p, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error {
+	p, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (l *Local) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.WalkDir(l.Dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasSuffix(p, ".tmp") {
+			return nil
+		}
+		rel, err := filepath.Rel(l.Dir, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return keys, err
+}
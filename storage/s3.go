@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3 menyimpan file di object storage S3-compatible (AWS S3, MinIO, dll)
+type S3 struct {
+	client  *minio.Client
+	Bucket  string
+	BaseURL string // URL publik bucket, misalnya http://localhost:9000/koleksi
+}
+
+// NewS3FromEnv membuat driver S3 dari env:
+// S3_ENDPOINT (contoh: localhost:9000), S3_ACCESS_KEY, S3_SECRET_KEY, S3_BUCKET,
+// S3_REGION (opsional), S3_USE_SSL (default true), S3_PUBLIC_URL (opsional).
+// Bucket dibuat otomatis jika belum ada.
+func NewS3FromEnv() (*S3, error) {
+	endpoint := os.Getenv("S3_ENDPOINT")
+	bucket := os.Getenv("S3_BUCKET")
+	if endpoint == "" || bucket == "" {
+		return nil, fmt.Errorf("S3_ENDPOINT dan S3_BUCKET wajib diatur untuk STORAGE_DRIVER=s3")
+	}
+
+	useSSL, err := strconv.ParseBool(envOrDefault("S3_USE_SSL", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("S3_USE_SSL harus true atau false")
+	}
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(os.Getenv("S3_ACCESS_KEY"), os.Getenv("S3_SECRET_KEY"), ""),
+		Secure: useSSL,
+		Region: os.Getenv("S3_REGION"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat client S3: %w", err)
+	}
+
+	scheme := "https"
+	if !useSSL {
+		scheme = "http"
+	}
+
+	s := &S3{
+		client:  client,
+		Bucket:  bucket,
+		BaseURL: strings.TrimRight(envOrDefault("S3_PUBLIC_URL", fmt.Sprintf("%s://%s/%s", scheme, endpoint, bucket)), "/"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	exists, err := client.BucketExists(ctx, bucket)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengecek bucket S3: %w", err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{Region: os.Getenv("S3_REGION")}); err != nil {
+			return nil, fmt.Errorf("gagal membuat bucket S3: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *S3) Nama() string { return "s3" }
+
+func (s *S3) URL(key string) string {
+	return s.BaseURL + "/" + strings.TrimLeft(key, "/")
+}
+
+func (s *S3) Put(ctx context.Context, key string, data []byte, contentType string) (string, error) {
+	_, err := s.client.PutObject(ctx, s.Bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return "", fmt.Errorf("gagal upload ke S3: %w", err)
+	}
+	return s.URL(key), nil
+}
+
+func (s *S3) Get(ctx context.Context, key string) ([]byte, error) {
+	obj, err := s.client.GetObject(ctx, s.Bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	return s.client.RemoveObject(ctx, s.Bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (s *S3) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	for obj := range s.client.ListObjects(ctx, s.Bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			return nil, obj.Err
+		}
+		keys = append(keys, obj.Key)
+	}
+	return keys, nil
+}
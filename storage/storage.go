@@ -0,0 +1,69 @@
+// Package storage menyediakan tempat penyimpanan file (foto koleksi) yang bisa diganti
+// lewat konfigurasi: GitHub repository, disk lokal, atau object storage S3-compatible.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrNotFound dikembalikan jika objek tidak ada di storage
+var ErrNotFound = errors.New("objek tidak ditemukan di storage")
+
+// Storage adalah driver penyimpanan file
+type Storage interface {
+	// Nama driver (github / local / s3)
+	Nama() string
+	// Put menyimpan data pada key (path relatif, misalnya "koleksi/abc.jpg") dan mengembalikan URL publiknya
+	Put(ctx context.Context, key string, data []byte, contentType string) (string, error)
+	// Get membaca isi objek
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete menghapus objek. Objek yang tidak ada tidak dianggap error.
+	Delete(ctx context.Context, key string) error
+	// URL mengembalikan URL publik untuk key
+	URL(key string) string
+	// List mengembalikan seluruh key yang diawali prefix (rekursif)
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+var aktif Storage
+
+// Init memilih driver berdasarkan env STORAGE_DRIVER (github / local / s3, default github).
+// Dipanggil dari main setelah file .env dimuat.
+func Init() error {
+	driver := strings.ToLower(strings.TrimSpace(os.Getenv("STORAGE_DRIVER")))
+
+	var err error
+	switch driver {
+	case "", "github":
+		aktif = NewGitHubFromEnv()
+	case "local":
+		aktif, err = NewLocalFromEnv()
+	case "s3":
+		aktif, err = NewS3FromEnv()
+	default:
+		err = fmt.Errorf("STORAGE_DRIVER tidak dikenal: %s (gunakan github, local, atau s3)", driver)
+	}
+
+	return err
+}
+
+// Aktif mengembalikan driver storage yang sedang dipakai
+func Aktif() Storage {
+	if aktif == nil {
+		// fallback jika Init belum dipanggil, sama seperti perilaku lama
+		aktif = NewGitHubFromEnv()
+	}
+	return aktif
+}
+
+// envOrDefault membaca env, atau nilai default jika kosong
+func envOrDefault(key, def string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	return def
+}